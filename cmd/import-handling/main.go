@@ -0,0 +1,180 @@
+// Command import-handling imports handling reports from CSV and IFTSTA files delivered by partner terminals.
+//
+// Usage:
+//
+//	import-handling FILE...                 import the given files once
+//	import-handling -watch DIR [-interval]  import files dropped into DIR, moving them to DIR/done or DIR/failed
+//
+// Files whose entries were not all registered make the command exit with status 1, or are moved to
+// DIR/failed with an error report. Entries the lenient sequence policy quarantines are kept in the
+// database for review; with in-memory storage they count as not registered, since the quarantine is
+// not kept once the command exits.
+//
+// The command uses the same environment configuration as the server and should share its storage.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"go_hex/cmd/internal/wiring"
+	"go_hex/internal/adapters/driving/fileimport"
+	"go_hex/internal/adapters/integration"
+	"go_hex/internal/booking/bookingapplication"
+	"go_hex/internal/handling/handlingapplication"
+	"go_hex/internal/handling/handlingdomain"
+	"go_hex/internal/routing/routingapplication"
+	"go_hex/internal/support/config"
+	"go_hex/internal/support/logging"
+	"go_hex/internal/support/outbox"
+	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+func main() {
+	os.Exit(run())
+}
+
+// run imports the files or watches the inbox named on the command line and returns the exit code
+func run() int {
+	watchDir := flag.String("watch", "", "inbox directory to watch for handling report files")
+	interval := flag.Duration("interval", 10*time.Second, "how often the inbox is scanned")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-watch DIR [-interval DURATION]] [FILE...]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if (*watchDir == "" && flag.NArg() == 0) || *interval <= 0 {
+		flag.Usage()
+		return 2
+	}
+
+	cfg, err := config.New()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	logging.Initialize(cfg)
+	logger := logging.Get()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	importer, shutdown := wireImporter(cfg, logger)
+	exitCode := 0
+
+	if *watchDir != "" {
+		fileimport.NewDirectoryWatcher(importer, *watchDir, *interval, logger).Run(ctx)
+	} else {
+		for _, path := range flag.Args() {
+			result, err := importer.ImportFile(ctx, path)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+				exitCode = 1
+				continue
+			}
+
+			fmt.Printf("%s: %d accepted, %d quarantined, %d duplicate, %d not registered\n",
+				path, result.Accepted, result.Quarantined, result.Duplicates, len(result.Problems))
+			for _, problem := range result.Problems {
+				fmt.Printf("  %s: %s\n", problem.Source, problem.Reason)
+			}
+			if result.Failed() {
+				exitCode = 1
+			}
+		}
+	}
+
+	// Let recorded handling events reach the booking context before exiting
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := shutdown(shutdownCtx); err != nil {
+		logger.Error("Failed to deliver pending events", "error", err)
+		exitCode = 1
+	}
+
+	return exitCode
+}
+
+// wireImporter creates the file importer on top of the handling context, with the booking and routing
+// contexts it checks reports against, and returns the hook that relays the outbox and drains the event bus
+func wireImporter(cfg *config.Config, logger *slog.Logger) (*fileimport.Importer, func(context.Context) error) {
+	eventBus := wiring.NewEventBus(cfg, logger)
+
+	repos, err := wiring.NewRepositories(cfg, logger)
+	if err != nil {
+		logger.Error("Failed to create repositories", "error", err, "storage", cfg.Storage.Driver)
+		log.Panic("Failed to create repositories:", err)
+	}
+	if !repos.Durable {
+		logger.Warn("Importing into in-memory storage, which holds no booked cargo; set STORAGE to share the server's database")
+	}
+
+	routingService := routingapplication.NewRoutingApplicationService(
+		repos.Voyage,
+		repos.Location,
+		cfg.Routing.MaxLegs,
+		logger,
+	)
+
+	handlingQueryService := handlingapplication.NewHandlingEventQueryService(repos.HandlingEvent, logger)
+
+	bookingService := bookingapplication.NewBookingApplicationService(
+		repos.Cargo,
+		integration.NewRoutingServiceAdapter(routingService),
+		integration.NewVoyageScheduleAdapter(routingService),
+		integration.NewHandlingHistoryAdapter(handlingQueryService),
+		logger,
+	)
+
+	handlingReportService := handlingapplication.NewHandlingReportService(
+		repos.HandlingEvent,
+		repos.Quarantine,
+		integration.NewCargoBookingAdapter(bookingService),
+		integration.NewShippingNetworkAdapter(routingService),
+		handlingapplication.SequencePolicy(cfg.Handling.SequencePolicy),
+		wiring.HandlingTimeWindow(cfg),
+		logger,
+	)
+
+	// Keep cargo delivery up to date with the imported handling events
+	handlingToBookingHandler := integration.NewHandlingToBookingEventHandler(bookingService, logger)
+	eventBus.Subscribe(
+		handlingdomain.HandlingEventRegisteredEvent{}.EventName(),
+		handlingToBookingHandler.HandleCargoWasHandled,
+	)
+
+	// Relay the handling events the importer records in the outbox while it runs; the cargo events they cause are
+	// left to the server's relay, whose subscribers handle them
+	registry := outbox.NewRegistry()
+	outbox.Register[handlingdomain.HandlingEventRegisteredEvent](registry)
+	relay := outbox.NewRelay(repos.Outbox, eventBus, registry, outbox.DefaultRelayConfig(), logger)
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	relayDone := make(chan struct{})
+	go func() {
+		defer close(relayDone)
+		relay.Run(relayCtx)
+	}()
+
+	shutdown := func(ctx context.Context) error {
+		stopRelay()
+		select {
+		case <-relayDone:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		// Deliver what was recorded since the relay's last pass; anything left is delivered by the server's relay
+		for ctx.Err() == nil && relay.DispatchPending() > 0 {
+		}
+		return eventBus.Shutdown(ctx)
+	}
+
+	// Without a database the quarantine lives only as long as this process, so quarantined entries are reported as not registered
+	return fileimport.NewImporter(handlingReportService, repos.Durable, logger), shutdown
+}
@@ -0,0 +1,141 @@
+// Package wiring creates the infrastructure shared by the server and the command line tools from configuration
+package wiring
+
+import (
+	"go_hex/internal/adapters/driven/event_bus"
+	"go_hex/internal/adapters/driven/in_memory_cargo_repo"
+	"go_hex/internal/adapters/driven/in_memory_handling_quarantine"
+	"go_hex/internal/adapters/driven/in_memory_handling_repo"
+	"go_hex/internal/adapters/driven/in_memory_location_repo"
+	"go_hex/internal/adapters/driven/in_memory_outbox"
+	"go_hex/internal/adapters/driven/in_memory_reroute_worklist"
+	"go_hex/internal/adapters/driven/in_memory_voyage_repo"
+	"go_hex/internal/adapters/driven/postgres_cargo_repo"
+	"go_hex/internal/adapters/driven/postgres_db"
+	"go_hex/internal/adapters/driven/postgres_handling_quarantine"
+	"go_hex/internal/adapters/driven/postgres_handling_repo"
+	"go_hex/internal/adapters/driven/postgres_location_repo"
+	"go_hex/internal/adapters/driven/postgres_outbox"
+	"go_hex/internal/adapters/driven/postgres_reroute_worklist"
+	"go_hex/internal/adapters/driven/postgres_voyage_repo"
+	"go_hex/internal/adapters/driven/sqlite_cargo_repo"
+	"go_hex/internal/adapters/driven/sqlite_db"
+	"go_hex/internal/adapters/driven/sqlite_handling_quarantine"
+	"go_hex/internal/adapters/driven/sqlite_handling_repo"
+	"go_hex/internal/adapters/driven/sqlite_location_repo"
+	"go_hex/internal/adapters/driven/sqlite_outbox"
+	"go_hex/internal/adapters/driven/sqlite_reroute_worklist"
+	"go_hex/internal/adapters/driven/sqlite_voyage_repo"
+	"go_hex/internal/booking/bookingdomain"
+	"go_hex/internal/booking/ports/bookingsecondary"
+	"go_hex/internal/handling/handlingdomain"
+	"go_hex/internal/handling/ports/handlingsecondary"
+	"go_hex/internal/routing/ports/routingsecondary"
+	"go_hex/internal/support/config"
+	"go_hex/internal/support/outbox"
+	"log/slog"
+)
+
+// NewEventBus creates the event bus for the configured delivery mode
+func NewEventBus(cfg *config.Config, logger *slog.Logger) event_bus.EventBus {
+	if !cfg.UsesAsyncEventBus() {
+		logger.Info("Using synchronous event bus")
+		return event_bus.NewInMemoryEventBus(logger)
+	}
+
+	logger.Info("Using asynchronous event bus", "queue_size", cfg.EventBus.QueueSize)
+
+	asyncConfig := event_bus.AsyncConfig{
+		QueueSize: cfg.EventBus.QueueSize,
+	}
+	return event_bus.NewAsyncEventBus(asyncConfig, logger)
+}
+
+// HandlingTimeWindow returns the window of completion times handling reports are accepted for
+func HandlingTimeWindow(cfg *config.Config) handlingdomain.TimeWindowPolicy {
+	return handlingdomain.TimeWindowPolicy{
+		MaxAge:        cfg.Handling.MaxEventAge,
+		MaxFutureSkew: cfg.Handling.MaxFutureSkew,
+	}
+}
+
+// Repositories groups the persistence adapters selected by configuration
+type Repositories struct {
+	Cargo         bookingsecondary.CargoRepository
+	Voyage        routingsecondary.VoyageRepository
+	Location      routingsecondary.LocationRepository
+	HandlingEvent handlingsecondary.HandlingEventRepository
+	Quarantine    handlingsecondary.HandlingQuarantine
+	Worklist      bookingsecondary.RerouteWorklist
+	Outbox        outbox.Store
+
+	// Durable is set when the repositories keep their data beyond the process, in a database
+	Durable bool
+}
+
+// NewRepositories creates the repositories for the configured storage driver
+func NewRepositories(cfg *config.Config, logger *slog.Logger) (Repositories, error) {
+	if cfg.UsesPostgres() {
+		db, err := postgres_db.Open(cfg.Storage.PostgresDSN)
+		if err != nil {
+			return Repositories{}, err
+		}
+		logger.Info("Using PostgreSQL repositories")
+
+		return Repositories{
+			Cargo:         postgres_cargo_repo.NewPostgresCargoRepository(db),
+			Voyage:        postgres_voyage_repo.NewPostgresVoyageRepository(db),
+			Location:      postgres_location_repo.NewPostgresLocationRepository(db),
+			HandlingEvent: postgres_handling_repo.NewPostgresHandlingEventRepository(db),
+			Quarantine:    postgres_handling_quarantine.NewPostgresHandlingQuarantine(db),
+			Worklist:      postgres_reroute_worklist.NewPostgresRerouteWorklist(db),
+			Outbox:        postgres_outbox.NewPostgresOutbox(db),
+			Durable:       true,
+		}, nil
+	}
+
+	if cfg.UsesSQLite() {
+		db, err := sqlite_db.Open(cfg.Storage.SQLitePath)
+		if err != nil {
+			return Repositories{}, err
+		}
+		logger.Info("Using SQLite repositories", "path", cfg.Storage.SQLitePath)
+
+		return Repositories{
+			Cargo:         sqlite_cargo_repo.NewSQLiteCargoRepository(db),
+			Voyage:        sqlite_voyage_repo.NewSQLiteVoyageRepository(db),
+			Location:      sqlite_location_repo.NewSQLiteLocationRepository(db),
+			HandlingEvent: sqlite_handling_repo.NewSQLiteHandlingEventRepository(db),
+			Quarantine:    sqlite_handling_quarantine.NewSQLiteHandlingQuarantine(db),
+			Worklist:      sqlite_reroute_worklist.NewSQLiteRerouteWorklist(db),
+			Outbox:        sqlite_outbox.NewSQLiteOutbox(db),
+			Durable:       true,
+		}, nil
+	}
+
+	logger.Info("Using in-memory repositories")
+
+	eventOutbox := in_memory_outbox.NewInMemoryOutbox()
+	cargoRepo := in_memory_cargo_repo.NewInMemoryCargoRepository(eventOutbox)
+
+	return Repositories{
+		Cargo:         cargoRepo,
+		Voyage:        in_memory_voyage_repo.NewInMemoryVoyageRepository(cargoRepo),
+		Location:      in_memory_location_repo.NewInMemoryLocationRepository(),
+		HandlingEvent: in_memory_handling_repo.NewInMemoryHandlingEventRepository(eventOutbox),
+		Quarantine:    in_memory_handling_quarantine.NewInMemoryHandlingQuarantine(),
+		Worklist:      in_memory_reroute_worklist.NewInMemoryRerouteWorklist(),
+		Outbox:        eventOutbox,
+	}, nil
+}
+
+// NewEventRegistry registers the domain events the server subscribes to and so delivers through the outbox. Events
+// nothing subscribes to, such as CargoBooked, stay recorded in the outbox undelivered.
+func NewEventRegistry() *outbox.Registry {
+	registry := outbox.NewRegistry()
+	outbox.Register[bookingdomain.CargoRoutedEvent](registry)
+	outbox.Register[bookingdomain.CargoDeliveryUpdatedEvent](registry)
+	outbox.Register[bookingdomain.CargoCancelledEvent](registry)
+	outbox.Register[handlingdomain.HandlingEventRegisteredEvent](registry)
+	return registry
+}
@@ -2,22 +2,22 @@ package main
 
 import (
 	"context"
-	"go_hex/internal/adapters/driven/event_bus"
-	"go_hex/internal/adapters/driven/in_memory_cargo_repo"
-	"go_hex/internal/adapters/driven/in_memory_handling_repo"
-	"go_hex/internal/adapters/driven/in_memory_location_repo"
-	"go_hex/internal/adapters/driven/in_memory_voyage_repo"
+	"go_hex/cmd/internal/wiring"
 	httpadapter "go_hex/internal/adapters/driving/httpadapter"
 	"go_hex/internal/adapters/driving/httpadapter/httpmiddleware"
 	"go_hex/internal/adapters/integration"
 
 	"go_hex/internal/booking/bookingapplication"
+	"go_hex/internal/booking/bookingdomain"
 	"go_hex/internal/booking/ports/bookingprimary"
 	"go_hex/internal/handling/handlingapplication"
 	"go_hex/internal/handling/handlingdomain"
 	"go_hex/internal/handling/ports/handlingprimary"
 	"go_hex/internal/routing/ports/routingprimary"
 	"go_hex/internal/routing/routingapplication"
+	"go_hex/internal/tracking/ports/trackingprimary"
+	"go_hex/internal/tracking/trackingapplication"
+	"go_hex/internal/tracking/trackingmock"
 
 	"go_hex/internal/booking/bookingmock"
 	"go_hex/internal/handling/handlingmock"
@@ -26,6 +26,7 @@ import (
 	"go_hex/internal/support/auth"
 	"go_hex/internal/support/config"
 	"go_hex/internal/support/logging"
+	"go_hex/internal/support/outbox"
 	"go_hex/internal/support/server"
 	"log"
 	"log/slog"
@@ -40,9 +41,9 @@ func main() {
 	logging.Initialize(cfg)
 	logger := logging.Get()
 
-	httpHandler := wireAppDependencies(cfg, logger)
+	httpHandler, shutdownHooks := wireAppDependencies(cfg, logger)
 
-	httpServer := server.New(cfg, httpHandler, nil)
+	httpServer := server.New(cfg, httpHandler, shutdownHooks...)
 	if err := httpServer.Start(); err != nil {
 		logger.Error("Server startup failed", "error", err)
 	}
@@ -50,15 +51,30 @@ func main() {
 	log.Println("Server exited")
 }
 
-func wireAppDependencies(cfg *config.Config, logger *slog.Logger) *httpadapter.Handler {
+func wireAppDependencies(cfg *config.Config, logger *slog.Logger) (*httpadapter.Handler, []server.ShutdownHook) {
 	// Create event bus for inter-context communication
-	eventBus := event_bus.NewInMemoryEventBus(logger)
+	eventBus := wiring.NewEventBus(cfg, logger)
 
 	// Create repositories
-	cargoRepo := in_memory_cargo_repo.NewInMemoryCargoRepository()
-	voyageRepo := in_memory_voyage_repo.NewInMemoryVoyageRepository()
-	locationRepo := in_memory_location_repo.NewInMemoryLocationRepository()
-	handlingEventRepo := in_memory_handling_repo.NewInMemoryHandlingEventRepository()
+	repos, err := wiring.NewRepositories(cfg, logger)
+	if err != nil {
+		logger.Error("Failed to create repositories", "error", err, "storage", cfg.Storage.Driver)
+		log.Panic("Failed to create repositories:", err)
+	}
+	cargoRepo := repos.Cargo
+	voyageRepo := repos.Voyage
+	locationRepo := repos.Location
+	handlingEventRepo := repos.HandlingEvent
+
+	// Out-of-sequence handling reports are kept here when the lenient sequence policy is configured
+	handlingQuarantine := repos.Quarantine
+	sequencePolicy := handlingapplication.SequencePolicy(cfg.Handling.SequencePolicy)
+	timeWindow := wiring.HandlingTimeWindow(cfg)
+
+	handlingQueryService := handlingapplication.NewHandlingEventQueryService(handlingEventRepo, logger)
+
+	// Create adapter for Booking->Handling history queries (synchronous, ACL)
+	handlingHistoryAdapter := integration.NewHandlingHistoryAdapter(handlingQueryService)
 
 	var bookingService bookingprimary.BookingService
 	var handlingReportService handlingprimary.HandlingReportService
@@ -71,6 +87,7 @@ func wireAppDependencies(cfg *config.Config, logger *slog.Logger) *httpadapter.H
 		mockRoutingService := routingmock.NewMockRoutingApplication(
 			voyageRepo,
 			locationRepo,
+			cfg.Routing.MaxLegs,
 			logger,
 			1017, // Use seed or reproducibility
 		)
@@ -83,7 +100,8 @@ func wireAppDependencies(cfg *config.Config, logger *slog.Logger) *httpadapter.H
 		mockBookingService := bookingmock.NewMockBookingApplication(
 			cargoRepo,
 			routingAdapter, // Synchronous integration with routing
-			eventBus,       // Event publisher
+			integration.NewVoyageScheduleAdapter(routingService), // Synchronous read of current voyage schedules
+			handlingHistoryAdapter,                               // Synchronous integration with handling
 			logger,
 			1017, // Use seed for reproducibility
 		)
@@ -91,7 +109,11 @@ func wireAppDependencies(cfg *config.Config, logger *slog.Logger) *httpadapter.H
 
 		handlingReportService = handlingmock.NewMockHandlingApplication(
 			handlingEventRepo,
-			eventBus, // Event publisher for handling events
+			handlingQuarantine,
+			integration.NewCargoBookingAdapter(bookingService),    // Synchronous check of handled cargo
+			integration.NewShippingNetworkAdapter(routingService), // Synchronous check of locations and voyages
+			sequencePolicy,
+			timeWindow,
 			logger,
 			1017, // Use seed for reproducibility
 		)
@@ -135,11 +157,13 @@ func wireAppDependencies(cfg *config.Config, logger *slog.Logger) *httpadapter.H
 		logger.Info("Running in live mode", "mode", cfg.Mode, "isMockMode", cfg.IsMockMode(), "isLiveMode", cfg.IsLiveMode())
 
 		// Create Routing context application service
-		routingService = routingapplication.NewRoutingApplicationService(
+		routingApplicationService := routingapplication.NewRoutingApplicationService(
 			voyageRepo,
 			locationRepo,
+			cfg.Routing.MaxLegs,
 			logger,
 		)
+		routingService = routingApplicationService
 
 		// Create adapter for Booking->Routing integration (synchronous, customer-supplier)
 		routingAdapter := integration.NewRoutingServiceAdapter(routingService)
@@ -148,20 +172,30 @@ func wireAppDependencies(cfg *config.Config, logger *slog.Logger) *httpadapter.H
 		bookingService = bookingapplication.NewBookingApplicationService(
 			cargoRepo,
 			routingAdapter, // Synchronous integration with routing
-			eventBus,       // Event publisher
+			integration.NewVoyageScheduleAdapter(routingService), // Synchronous read of current voyage schedules
+			handlingHistoryAdapter,                               // Synchronous integration with handling
 			logger,
 		)
 
 		// Create Handling context application services
 		handlingReportService = handlingapplication.NewHandlingReportService(
 			handlingEventRepo,
-			eventBus, // Event publisher for handling events
+			handlingQuarantine,
+			integration.NewCargoBookingAdapter(bookingService),    // Synchronous check of handled cargo
+			integration.NewShippingNetworkAdapter(routingService), // Synchronous check of locations and voyages
+			sequencePolicy,
+			timeWindow,
 			logger,
 		)
 
 	}
 
-	handlingQueryService := handlingapplication.NewHandlingEventQueryService(handlingEventRepo, logger)
+	// Create Routing context schedule service, refusing schedule changes that would strand routed cargo (synchronous, ACL)
+	voyageScheduler := routingapplication.NewVoyageSchedulingService(
+		voyageRepo,
+		integration.NewCargoItineraryAdapter(bookingService),
+		logger,
+	)
 
 	// Set up event-driven integration: Handling->Booking (asynchronous, ACL)
 	handlingToBookingHandler := integration.NewHandlingToBookingEventHandler(bookingService, logger)
@@ -172,6 +206,56 @@ func wireAppDependencies(cfg *config.Config, logger *slog.Logger) *httpadapter.H
 		handlingToBookingHandler.HandleCargoWasHandled,
 	)
 
+	// Reroute misdirected cargo, or put it on the reroute worklist, as handling reveals it
+	rerouteService := bookingapplication.NewRerouteApplicationService(
+		cargoRepo,
+		integration.NewRoutingServiceAdapter(routingService), // Synchronous integration with routing
+		repos.Worklist,
+		bookingapplication.ReroutePolicy(cfg.Booking.ReroutePolicy),
+		bookingdomain.RankingCriteria{Strategy: cfg.Booking.RerouteStrategy},
+		logger,
+	)
+	misdirectedCargoHandler := integration.NewMisdirectedCargoEventHandler(rerouteService, logger)
+
+	// Subscribe to booking events
+	eventBus.Subscribe(
+		bookingdomain.CargoDeliveryUpdatedEvent{}.EventName(),
+		misdirectedCargoHandler.HandleCargoDeliveryUpdated,
+	)
+	eventBus.Subscribe(
+		bookingdomain.CargoRoutedEvent{}.EventName(),
+		misdirectedCargoHandler.HandleCargoRouted,
+	)
+	eventBus.Subscribe(
+		bookingdomain.CargoCancelledEvent{}.EventName(),
+		misdirectedCargoHandler.HandleCargoCancelled,
+	)
+
+	// Relay cargo and handling events recorded in the outbox to the event bus, once every subscriber is in place
+	relay := outbox.NewRelay(repos.Outbox, eventBus, wiring.NewEventRegistry(), outbox.DefaultRelayConfig(), logger)
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	relayDone := make(chan struct{})
+	go func() {
+		defer close(relayDone)
+		relay.Run(relayCtx)
+	}()
+
+	// Create Tracking context query service, reading booking and handling through ACL adapters
+	var timelineService trackingprimary.CargoTimelineService
+	if cfg.IsMockMode() {
+		timelineService = trackingmock.NewMockTrackingApplication(
+			integration.NewTrackingBookingAdapter(bookingService),
+			integration.NewTrackingHandlingAdapter(handlingQueryService),
+			logger,
+		)
+	} else {
+		timelineService = trackingapplication.NewCargoTimelineService(
+			integration.NewTrackingBookingAdapter(bookingService),
+			integration.NewTrackingHandlingAdapter(handlingQueryService),
+			logger,
+		)
+	}
+
 	// Wire up authentication middleware
 	authMiddleware := httpmiddleware.NewAuthMiddleware(cfg.JWT.SecretKey, cfg.JWT.Issuer, cfg.JWT.Audience)
 
@@ -180,14 +264,37 @@ func wireAppDependencies(cfg *config.Config, logger *slog.Logger) *httpadapter.H
 		authMiddleware,
 		bookingService,
 		routingService,
+		voyageScheduler,
 		handlingReportService,
 		handlingQueryService,
+		timelineService,
+		rerouteService,
 	)
 
 	logger.Info("Application dependencies wired successfully",
 		"cargo_subscribers", eventBus.GetSubscriberCount(handlingdomain.HandlingEventRegisteredEvent{}.EventName()),
 		"mode", cfg.Mode,
+		"storage", cfg.Storage.Driver,
+		"event_bus", cfg.EventBus.Mode,
+		"handling_sequence_policy", cfg.Handling.SequencePolicy,
+		"handling_max_event_age", cfg.Handling.MaxEventAge,
+		"handling_max_future_skew", cfg.Handling.MaxFutureSkew,
+		"booking_reroute_policy", cfg.Booking.ReroutePolicy,
 	)
 
-	return httpHandler
+	// Stop feeding the bus before draining it, so relayed events are not rejected mid-drain
+	shutdownHooks := []server.ShutdownHook{
+		func(ctx context.Context) error {
+			stopRelay()
+			select {
+			case <-relayDone:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+		eventBus.Shutdown,
+	}
+
+	return httpHandler, shutdownHooks
 }
@@ -0,0 +1,187 @@
+package event_bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"go_hex/internal/support/basedomain"
+)
+
+// ErrBusClosed is returned by Publish once Shutdown has been called
+var ErrBusClosed = errors.New("event bus is shut down")
+
+// AsyncConfig controls queueing behaviour of the asynchronous event bus
+type AsyncConfig struct {
+	QueueSize int
+}
+
+// DefaultAsyncConfig returns the asynchronous bus settings used when none are configured
+func DefaultAsyncConfig() AsyncConfig {
+	return AsyncConfig{
+		QueueSize: 256,
+	}
+}
+
+// delivery is an event queued for a subscriber, with the channel its publisher waits on for the outcome
+type delivery struct {
+	event  basedomain.DomainEvent
+	result chan<- error
+}
+
+// subscriber is a handler with its own bounded queue and worker goroutine
+type subscriber struct {
+	name    string
+	handler EventHandler
+	queue   chan delivery
+}
+
+// AsyncEventBus delivers events to each subscriber on a dedicated worker, so the subscribers of an event handle it
+// concurrently while each handles its events in publication order. Publish waits for every subscriber and returns
+// their failures: the bus does not retry, redelivery is left to the outbox relay.
+type AsyncEventBus struct {
+	mu          sync.RWMutex
+	subscribers map[string][]*subscriber
+	closed      bool
+	workers     sync.WaitGroup
+	ctx         context.Context
+	cancel      context.CancelFunc
+	config      AsyncConfig
+	logger      *slog.Logger
+}
+
+// NewAsyncEventBus creates an asynchronous event bus
+func NewAsyncEventBus(config AsyncConfig, logger *slog.Logger) *AsyncEventBus {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &AsyncEventBus{
+		subscribers: make(map[string][]*subscriber),
+		ctx:         ctx,
+		cancel:      cancel,
+		config:      config,
+		logger:      logger,
+	}
+}
+
+// Subscribe registers a handler and starts its worker
+func (b *AsyncEventBus) Subscribe(eventName string, handler EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub := &subscriber{
+		name:    fmt.Sprintf("%s#%d", eventName, len(b.subscribers[eventName])),
+		handler: handler,
+		queue:   make(chan delivery, b.config.QueueSize),
+	}
+	b.subscribers[eventName] = append(b.subscribers[eventName], sub)
+
+	b.workers.Add(1)
+	go b.work(sub)
+
+	b.logger.Info("Event handler subscribed",
+		"event_name", eventName,
+		"subscriber", sub.name,
+		"handler_count", len(b.subscribers[eventName]))
+}
+
+// Publish queues the event for every subscriber and waits until each has handled it
+func (b *AsyncEventBus) Publish(event basedomain.DomainEvent) error {
+	results, count, err := b.enqueue(event)
+	if err != nil {
+		return err
+	}
+
+	errs := make([]error, 0, count)
+	for i := 0; i < count; i++ {
+		errs = append(errs, <-results)
+	}
+	return errors.Join(errs...)
+}
+
+// enqueue queues the event for every subscriber, returning the channel their results arrive on. The queues are only
+// closed under the write lock, so sending under the read lock never hits a closed queue; a full queue makes the
+// publisher wait for room, as the worker drains it without the lock.
+func (b *AsyncEventBus) enqueue(event basedomain.DomainEvent) (<-chan error, int, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return nil, 0, ErrBusClosed
+	}
+
+	subscribers := b.subscribers[event.EventName()]
+	if len(subscribers) == 0 {
+		return nil, 0, fmt.Errorf("%w %s", ErrNoSubscribers, event.EventName())
+	}
+
+	results := make(chan error, len(subscribers))
+	for _, sub := range subscribers {
+		sub.queue <- delivery{event: event, result: results}
+	}
+	return results, len(subscribers), nil
+}
+
+// GetSubscriberCount returns the number of handlers subscribed to eventName
+func (b *AsyncEventBus) GetSubscriberCount(eventName string) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subscribers[eventName])
+}
+
+// Shutdown stops accepting events and waits for queued events to be handled. If ctx expires first, running handlers
+// are cancelled and events still queued are reported to their publishers as undelivered.
+func (b *AsyncEventBus) Shutdown(ctx context.Context) error {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		for _, subscribers := range b.subscribers {
+			for _, sub := range subscribers {
+				close(sub.queue)
+			}
+		}
+	}
+	b.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		b.workers.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		b.cancel()
+		return nil
+	case <-ctx.Done():
+		b.cancel()
+		return fmt.Errorf("event bus drain interrupted: %w", ctx.Err())
+	}
+}
+
+// work hands queued events to a subscriber until its queue is closed and empty
+func (b *AsyncEventBus) work(sub *subscriber) {
+	defer b.workers.Done()
+
+	for delivery := range sub.queue {
+		delivery.result <- b.deliver(sub, delivery.event)
+	}
+}
+
+// deliver runs the handler once, unless the drain was interrupted
+func (b *AsyncEventBus) deliver(sub *subscriber, event basedomain.DomainEvent) error {
+	if b.ctx.Err() != nil {
+		return fmt.Errorf("subscriber %s: %w", sub.name, ErrBusClosed)
+	}
+
+	if err := sub.handler(b.ctx, event); err != nil {
+		b.logger.Error("Event handler failed",
+			"event_name", event.EventName(),
+			"subscriber", sub.name,
+			"error", err)
+		return fmt.Errorf("subscriber %s: %w", sub.name, err)
+	}
+	return nil
+}
+
+var _ EventBus = (*AsyncEventBus)(nil)
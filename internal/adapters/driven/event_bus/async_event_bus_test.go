@@ -0,0 +1,147 @@
+package event_bus
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go_hex/internal/support/basedomain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testEvent struct {
+	name string
+}
+
+func (e testEvent) EventName() string     { return e.name }
+func (e testEvent) OccurredAt() time.Time { return time.Time{} }
+
+func TestAsyncEventBus(t *testing.T) {
+	setup := func(config AsyncConfig) *AsyncEventBus {
+		return NewAsyncEventBus(config, slog.Default())
+	}
+
+	config := AsyncConfig{QueueSize: 4}
+
+	t.Run("should return once every subscriber handled the event", func(t *testing.T) {
+		// Setup
+		bus := setup(config)
+		var handled atomic.Int32
+		for i := 0; i < 2; i++ {
+			bus.Subscribe("Test", func(ctx context.Context, event basedomain.DomainEvent) error {
+				time.Sleep(10 * time.Millisecond)
+				handled.Add(1)
+				return nil
+			})
+		}
+
+		// Execute
+		err := bus.Publish(testEvent{name: "Test"})
+
+		// Verify
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), handled.Load())
+		require.NoError(t, bus.Shutdown(context.Background()))
+	})
+
+	t.Run("should return handler failure without retrying", func(t *testing.T) {
+		// Setup
+		bus := setup(config)
+		var calls atomic.Int32
+		bus.Subscribe("Test", func(ctx context.Context, event basedomain.DomainEvent) error {
+			calls.Add(1)
+			return errors.New("booking unavailable")
+		})
+
+		// Execute
+		err := bus.Publish(testEvent{name: "Test"})
+
+		// Verify
+		assert.ErrorContains(t, err, "subscriber Test#0: booking unavailable")
+		require.NoError(t, bus.Shutdown(context.Background()))
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("should isolate subscribers from each other", func(t *testing.T) {
+		// Setup
+		bus := setup(config)
+		var healthyCalls atomic.Int32
+		bus.Subscribe("Test", func(ctx context.Context, event basedomain.DomainEvent) error {
+			return errors.New("always failing")
+		})
+		bus.Subscribe("Test", func(ctx context.Context, event basedomain.DomainEvent) error {
+			healthyCalls.Add(1)
+			return nil
+		})
+
+		// Execute
+		err := bus.Publish(testEvent{name: "Test"})
+
+		// Verify
+		assert.ErrorContains(t, err, "subscriber Test#0")
+		assert.NotContains(t, err.Error(), "subscriber Test#1")
+		assert.Equal(t, int32(1), healthyCalls.Load())
+		require.NoError(t, bus.Shutdown(context.Background()))
+	})
+
+	t.Run("should reject events after shutdown", func(t *testing.T) {
+		// Setup
+		bus := setup(config)
+		require.NoError(t, bus.Shutdown(context.Background()))
+
+		// Execute
+		err := bus.Publish(testEvent{name: "Test"})
+
+		// Verify
+		assert.ErrorIs(t, err, ErrBusClosed)
+	})
+
+	t.Run("should reject events nobody subscribed to", func(t *testing.T) {
+		// Setup
+		bus := setup(config)
+		bus.Subscribe("Other", func(ctx context.Context, event basedomain.DomainEvent) error { return nil })
+
+		// Execute
+		err := bus.Publish(testEvent{name: "Test"})
+
+		// Verify
+		assert.ErrorIs(t, err, ErrNoSubscribers)
+		require.NoError(t, bus.Shutdown(context.Background()))
+	})
+
+	t.Run("should report queued events as undelivered when drain times out", func(t *testing.T) {
+		// Setup
+		bus := setup(config)
+		started := make(chan struct{}, 1)
+		bus.Subscribe("Test", func(ctx context.Context, event basedomain.DomainEvent) error {
+			started <- struct{}{}
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		results := make(chan error, 2)
+		go func() { results <- bus.Publish(testEvent{name: "Test"}) }()
+		<-started
+		go func() { results <- bus.Publish(testEvent{name: "Test"}) }()
+		require.Eventually(t, func() bool { return len(bus.subscribers["Test"][0].queue) == 1 }, time.Second, time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		// Execute
+		err := bus.Shutdown(ctx)
+
+		// Verify
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		first, second := <-results, <-results
+		if errors.Is(first, ErrBusClosed) {
+			first, second = second, first
+		}
+		assert.ErrorIs(t, first, context.Canceled, "the running handler is cancelled")
+		assert.ErrorIs(t, second, ErrBusClosed, "the queued event is not handled")
+	})
+}
@@ -2,6 +2,7 @@ package event_bus
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"sync"
@@ -9,9 +10,23 @@ import (
 	"go_hex/internal/support/basedomain"
 )
 
+// ErrNoSubscribers is returned by Publish when no handler is subscribed to the event, so it was not delivered
+var ErrNoSubscribers = errors.New("no subscribers for event")
+
 // EventHandler defines a function that handles events
 type EventHandler func(ctx context.Context, event basedomain.DomainEvent) error
 
+// EventBus is implemented by the synchronous and asynchronous buses so they can be swapped by configuration
+type EventBus interface {
+	basedomain.EventPublisher
+
+	Subscribe(eventName string, handler EventHandler)
+	GetSubscriberCount(eventName string) int
+
+	// Shutdown stops accepting events and waits for queued deliveries to finish or ctx to expire
+	Shutdown(ctx context.Context) error
+}
+
 // InMemoryEventBus is a simple in-memory event bus for inter-module communication
 type InMemoryEventBus struct {
 	mu       sync.RWMutex
@@ -42,8 +57,7 @@ func (b *InMemoryEventBus) Publish(event basedomain.DomainEvent) error {
 	b.mu.RUnlock()
 
 	if !exists {
-		b.logger.Debug("No handlers registered for event", "event_name", event.EventName())
-		return nil
+		return fmt.Errorf("%w %s", ErrNoSubscribers, event.EventName())
 	}
 
 	b.logger.Info("Publishing event",
@@ -76,4 +90,9 @@ func (b *InMemoryEventBus) GetSubscriberCount(eventName string) int {
 	return len(b.handlers[eventName])
 }
 
-var _ basedomain.EventPublisher = (*InMemoryEventBus)(nil)
+// Shutdown is a no-op because handlers have already run by the time Publish returns
+func (b *InMemoryEventBus) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+var _ EventBus = (*InMemoryEventBus)(nil)
@@ -1,34 +1,38 @@
 package in_memory_cargo_repo
 
 import (
-	"fmt"
+	"sort"
 	"sync"
 
+	"go_hex/internal/adapters/driven/in_memory_outbox"
 	"go_hex/internal/booking/bookingdomain"
 	"go_hex/internal/booking/ports/bookingsecondary"
+	"go_hex/internal/support/outbox"
 )
 
 // InMemoryCargoRepository provides an in-memory implementation of the CargoRepository
 type InMemoryCargoRepository struct {
 	cargos map[string]bookingdomain.Cargo
+	outbox *in_memory_outbox.InMemoryOutbox
 	mutex  sync.RWMutex
 }
 
-// NewInMemoryCargoRepository creates a new in-memory cargo repository
-func NewInMemoryCargoRepository() bookingsecondary.CargoRepository {
+// NewInMemoryCargoRepository creates a new in-memory cargo repository recording cargo events in the given outbox
+func NewInMemoryCargoRepository(eventOutbox *in_memory_outbox.InMemoryOutbox) bookingsecondary.CargoRepository {
 	return &InMemoryCargoRepository{
 		cargos: make(map[string]bookingdomain.Cargo),
+		outbox: eventOutbox,
 	}
 }
 
-// Store saves a cargo to the repository
+// Store saves a new cargo to the repository together with its pending events
 func (r *InMemoryCargoRepository) Store(cargo bookingdomain.Cargo) error {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
-
-	trackingId := cargo.GetTrackingId().String()
-	r.cargos[trackingId] = cargo
-	return nil
+	return r.save(cargo, func(stored bookingdomain.Cargo, exists bool) error {
+		if exists {
+			return bookingdomain.NewConcurrencyConflictError(cargo.GetTrackingId(), cargo.GetVersion())
+		}
+		return nil
+	})
 }
 
 // FindByTrackingId retrieves a cargo by its tracking ID
@@ -38,7 +42,7 @@ func (r *InMemoryCargoRepository) FindByTrackingId(trackingId bookingdomain.Trac
 
 	cargo, exists := r.cargos[trackingId.String()]
 	if !exists {
-		return bookingdomain.Cargo{}, fmt.Errorf("cargo with tracking ID %s not found", trackingId.String())
+		return bookingdomain.Cargo{}, bookingdomain.NewCargoNotFoundError(trackingId)
 	}
 	return cargo, nil
 }
@@ -55,26 +59,85 @@ func (r *InMemoryCargoRepository) FindAll() ([]bookingdomain.Cargo, error) {
 	return cargos, nil
 }
 
-// FindUnrouted retrieves all cargos that don't have an assigned itinerary
+// FindByQuery retrieves one page of the cargo matching the query, in the query's sort order
+func (r *InMemoryCargoRepository) FindByQuery(query bookingdomain.CargoQuery) (bookingdomain.CargoPage, error) {
+	query, err := query.Normalize()
+	if err != nil {
+		return bookingdomain.CargoPage{}, err
+	}
+	cursor, hasCursor, err := query.DecodeCursor()
+	if err != nil {
+		return bookingdomain.CargoPage{}, err
+	}
+
+	r.mutex.RLock()
+	matching := make([]bookingdomain.Cargo, 0)
+	for _, cargo := range r.cargos {
+		if query.Matches(cargo) && (!hasCursor || query.IsAfter(cargo, cursor)) {
+			matching = append(matching, cargo)
+		}
+	}
+	r.mutex.RUnlock()
+
+	sort.Slice(matching, func(i, j int) bool {
+		return query.Less(matching[i], matching[j])
+	})
+
+	page := bookingdomain.CargoPage{Cargos: matching}
+	if len(matching) > query.Limit {
+		page.Cargos = matching[:query.Limit]
+		page.NextCursor = query.CursorAfter(page.Cargos[query.Limit-1])
+	}
+	return page, nil
+}
+
+// FindUnrouted retrieves all active cargos that don't have an assigned itinerary
 func (r *InMemoryCargoRepository) FindUnrouted() ([]bookingdomain.Cargo, error) {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
 
 	var unroutedCargos []bookingdomain.Cargo
 	for _, cargo := range r.cargos {
-		if cargo.GetItinerary() == nil {
+		if cargo.GetItinerary() == nil && !cargo.GetDelivery().IsCancelled() {
 			unroutedCargos = append(unroutedCargos, cargo)
 		}
 	}
 	return unroutedCargos, nil
 }
 
-// Update updates an existing cargo
+// Update updates an existing cargo together with its pending events, unless it was changed since it was loaded
 func (r *InMemoryCargoRepository) Update(cargo bookingdomain.Cargo) error {
+	return r.save(cargo, func(stored bookingdomain.Cargo, exists bool) error {
+		if !exists {
+			return bookingdomain.NewCargoNotFoundError(cargo.GetTrackingId())
+		}
+		if stored.GetVersion() != cargo.GetVersion() {
+			return bookingdomain.NewConcurrencyConflictError(cargo.GetTrackingId(), cargo.GetVersion())
+		}
+		return nil
+	})
+}
+
+// save checks the stored state, then writes the next version of the cargo and appends its pending events
+// to the outbox under the same lock
+func (r *InMemoryCargoRepository) save(cargo bookingdomain.Cargo, check func(stored bookingdomain.Cargo, exists bool) error) error {
+	messages, err := outbox.NewMessages(cargo.GetEvents())
+	if err != nil {
+		return err
+	}
+
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	trackingId := cargo.GetTrackingId().String()
-	r.cargos[trackingId] = cargo
+	stored, exists := r.cargos[cargo.GetTrackingId().String()]
+	if err := check(stored, exists); err != nil {
+		return err
+	}
+
+	// Recorded events must not be recorded again when the stored cargo is loaded and saved later
+	cargo.ClearEvents()
+	cargo.SetVersion(cargo.GetVersion() + 1)
+	r.cargos[cargo.GetTrackingId().String()] = cargo
+	r.outbox.Append(messages)
 	return nil
 }
@@ -0,0 +1,23 @@
+package in_memory_cargo_repo
+
+import (
+	"testing"
+
+	"go_hex/internal/adapters/driven/in_memory_outbox"
+	"go_hex/internal/adapters/driven/repository_contract"
+	"go_hex/internal/booking/ports/bookingsecondary"
+	"go_hex/internal/support/outbox"
+)
+
+func TestInMemoryCargoRepository(t *testing.T) {
+	repository_contract.CargoRepositoryContract(t, func(t *testing.T) bookingsecondary.CargoRepository {
+		return NewInMemoryCargoRepository(in_memory_outbox.NewInMemoryOutbox())
+	})
+}
+
+func TestInMemoryCargoOutbox(t *testing.T) {
+	repository_contract.CargoOutboxContract(t, func(t *testing.T) (bookingsecondary.CargoRepository, outbox.Store) {
+		eventOutbox := in_memory_outbox.NewInMemoryOutbox()
+		return NewInMemoryCargoRepository(eventOutbox), eventOutbox
+	})
+}
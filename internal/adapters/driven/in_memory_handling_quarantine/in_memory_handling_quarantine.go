@@ -0,0 +1,74 @@
+package in_memory_handling_quarantine
+
+import (
+	"slices"
+	"sync"
+
+	"go_hex/internal/handling/handlingdomain"
+	"go_hex/internal/handling/ports/handlingsecondary"
+)
+
+// InMemoryHandlingQuarantine provides an in-memory implementation of the HandlingQuarantine
+type InMemoryHandlingQuarantine struct {
+	reports []handlingdomain.QuarantinedReport
+	mutex   sync.RWMutex
+}
+
+// NewInMemoryHandlingQuarantine creates a new in-memory handling quarantine
+func NewInMemoryHandlingQuarantine() handlingsecondary.HandlingQuarantine {
+	return &InMemoryHandlingQuarantine{}
+}
+
+// Add keeps a quarantined report
+func (q *InMemoryHandlingQuarantine) Add(report handlingdomain.QuarantinedReport) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.reports = append(q.reports, report)
+	return nil
+}
+
+// FindAll retrieves all quarantined reports, oldest first
+func (q *InMemoryHandlingQuarantine) FindAll() ([]handlingdomain.QuarantinedReport, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	reports := make([]handlingdomain.QuarantinedReport, len(q.reports))
+	copy(reports, q.reports)
+	slices.SortStableFunc(reports, func(a, b handlingdomain.QuarantinedReport) int {
+		return a.QuarantinedAt.Compare(b.QuarantinedAt)
+	})
+	return reports, nil
+}
+
+// FindById retrieves a quarantined report by its ID
+func (q *InMemoryHandlingQuarantine) FindById(id string) (handlingdomain.QuarantinedReport, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	i := q.indexOf(id)
+	if i < 0 {
+		return handlingdomain.QuarantinedReport{}, handlingdomain.NewQuarantinedReportNotFoundError(id)
+	}
+	return q.reports[i], nil
+}
+
+// Remove takes a report out of the quarantine
+func (q *InMemoryHandlingQuarantine) Remove(id string) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	i := q.indexOf(id)
+	if i < 0 {
+		return handlingdomain.NewQuarantinedReportNotFoundError(id)
+	}
+	q.reports = slices.Delete(q.reports, i, i+1)
+	return nil
+}
+
+// indexOf returns the position of the report with the ID, or -1; callers must hold the lock
+func (q *InMemoryHandlingQuarantine) indexOf(id string) int {
+	return slices.IndexFunc(q.reports, func(report handlingdomain.QuarantinedReport) bool {
+		return report.Id == id
+	})
+}
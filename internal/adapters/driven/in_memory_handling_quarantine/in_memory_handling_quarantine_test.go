@@ -0,0 +1,14 @@
+package in_memory_handling_quarantine
+
+import (
+	"testing"
+
+	"go_hex/internal/adapters/driven/repository_contract"
+	"go_hex/internal/handling/ports/handlingsecondary"
+)
+
+func TestInMemoryHandlingQuarantine(t *testing.T) {
+	repository_contract.HandlingQuarantineContract(t, func(t *testing.T) handlingsecondary.HandlingQuarantine {
+		return NewInMemoryHandlingQuarantine()
+	})
+}
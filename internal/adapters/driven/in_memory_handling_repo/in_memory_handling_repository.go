@@ -2,35 +2,146 @@ package in_memory_handling_repo
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 
+	"go_hex/internal/adapters/driven/in_memory_outbox"
 	"go_hex/internal/handling/handlingdomain"
 	"go_hex/internal/handling/ports/handlingsecondary"
+	"go_hex/internal/support/outbox"
 )
 
 // InMemoryHandlingEventRepository provides an in-memory implementation of the HandlingEventRepository
 type InMemoryHandlingEventRepository struct {
 	events map[string]handlingdomain.HandlingEvent
+
+	// byTrackingId indexes the IDs of the events of each cargo
+	byTrackingId map[string]map[string]struct{}
+
+	// byIdempotencyKey indexes the ID of the event submitted with each idempotency key
+	byIdempotencyKey map[string]string
+
+	outbox *in_memory_outbox.InMemoryOutbox
 	mutex  sync.RWMutex
 }
 
-// NewInMemoryHandlingEventRepository creates a new in-memory handling event repository
-func NewInMemoryHandlingEventRepository() handlingsecondary.HandlingEventRepository {
+// NewInMemoryHandlingEventRepository creates a new in-memory handling event repository recording handling events
+// in the given outbox
+func NewInMemoryHandlingEventRepository(eventOutbox *in_memory_outbox.InMemoryOutbox) handlingsecondary.HandlingEventRepository {
 	return &InMemoryHandlingEventRepository{
-		events: make(map[string]handlingdomain.HandlingEvent),
+		events:           make(map[string]handlingdomain.HandlingEvent),
+		byTrackingId:     make(map[string]map[string]struct{}),
+		byIdempotencyKey: make(map[string]string),
+		outbox:           eventOutbox,
 	}
 }
 
-// Store saves a handling event to the repository
+// Store saves a handling event and appends its pending events to the outbox under the same lock. It fails if another
+// event uses the same idempotency key or records the same handling.
 func (r *InMemoryHandlingEventRepository) Store(event handlingdomain.HandlingEvent) error {
+	return r.storeAll([]handlingdomain.HandlingEvent{event}, nil)
+}
+
+// Append saves a new handling event like Store, provided the cargo still has historyLength stored events
+func (r *InMemoryHandlingEventRepository) Append(event handlingdomain.HandlingEvent, historyLength int) error {
+	return r.AppendAll([]handlingdomain.HandlingEvent{event}, map[string]int{event.GetTrackingId(): historyLength})
+}
+
+// AppendAll saves new handling events like Append, all of them or none
+func (r *InMemoryHandlingEventRepository) AppendAll(events []handlingdomain.HandlingEvent, historyLengths map[string]int) error {
+	return r.storeAll(events, historyLengths)
+}
+
+// storeAll saves handling events and appends their outbox messages under one lock, provided each cargo of
+// historyLengths still has that many stored events. Nothing is saved unless every event can be.
+func (r *InMemoryHandlingEventRepository) storeAll(events []handlingdomain.HandlingEvent, historyLengths map[string]int) error {
+	var messages []outbox.Message
+	for _, event := range events {
+		eventMessages, err := outbox.NewMessages(event.GetEvents())
+		if err != nil {
+			return err
+		}
+		messages = append(messages, eventMessages...)
+	}
+
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
+	for trackingId, historyLength := range historyLengths {
+		if len(r.byTrackingId[trackingId]) != historyLength {
+			return handlingdomain.NewHandlingHistoryChangedError(trackingId)
+		}
+	}
+	for i, event := range events {
+		if err := r.checkUnique(event, events[:i]); err != nil {
+			return err
+		}
+	}
+
+	for _, event := range events {
+		r.put(event)
+	}
+	r.outbox.Append(messages)
+	return nil
+}
+
+// checkUnique fails if a stored event, or one of the events saved before it in the same batch, uses the event's
+// idempotency key or records the same handling; the caller must hold the lock
+func (r *InMemoryHandlingEventRepository) checkUnique(event handlingdomain.HandlingEvent, before []handlingdomain.HandlingEvent) error {
 	eventId := event.GetEventId().String()
-	r.events[eventId] = event
+	key := event.GetIdempotencyKey()
+	if key != "" {
+		if id, used := r.byIdempotencyKey[key]; used && id != eventId {
+			return fmt.Errorf("idempotency key %s is already used by handling event %s", key, id)
+		}
+	}
+	for _, earlier := range before {
+		if key != "" && earlier.GetIdempotencyKey() == key {
+			return fmt.Errorf("idempotency key %s is already used by handling event %s", key, earlier.GetEventId().String())
+		}
+	}
+
+	for _, stored := range append(r.eventsOf(event.GetTrackingId()), before...) {
+		if stored.GetEventId() != event.GetEventId() && stored.IsSameHandlingAs(event) {
+			return fmt.Errorf("handling event %s already records the same handling", stored.GetEventId().String())
+		}
+	}
 	return nil
 }
 
+// put saves a handling event and indexes it; the caller must hold the write lock
+func (r *InMemoryHandlingEventRepository) put(event handlingdomain.HandlingEvent) {
+	eventId := event.GetEventId().String()
+	if previous, exists := r.events[eventId]; exists {
+		r.unindex(previous, eventId)
+	}
+
+	// Recorded events must not be recorded again when the stored event is loaded and saved later
+	event.ClearEvents()
+	r.events[eventId] = event
+
+	trackingId := event.GetTrackingId()
+	if r.byTrackingId[trackingId] == nil {
+		r.byTrackingId[trackingId] = make(map[string]struct{})
+	}
+	r.byTrackingId[trackingId][eventId] = struct{}{}
+	if key := event.GetIdempotencyKey(); key != "" {
+		r.byIdempotencyKey[key] = eventId
+	}
+}
+
+// unindex removes a stored event from the tracking ID and idempotency key indexes
+func (r *InMemoryHandlingEventRepository) unindex(event handlingdomain.HandlingEvent, eventId string) {
+	trackingId := event.GetTrackingId()
+	delete(r.byTrackingId[trackingId], eventId)
+	if len(r.byTrackingId[trackingId]) == 0 {
+		delete(r.byTrackingId, trackingId)
+	}
+	if key := event.GetIdempotencyKey(); key != "" && r.byIdempotencyKey[key] == eventId {
+		delete(r.byIdempotencyKey, key)
+	}
+}
+
 // FindById retrieves a handling event by its ID
 func (r *InMemoryHandlingEventRepository) FindById(eventId handlingdomain.HandlingEventId) (handlingdomain.HandlingEvent, error) {
 	r.mutex.RLock()
@@ -43,20 +154,38 @@ func (r *InMemoryHandlingEventRepository) FindById(eventId handlingdomain.Handli
 	return event, nil
 }
 
-// FindByTrackingId retrieves all handling events for a specific cargo
-func (r *InMemoryHandlingEventRepository) FindByTrackingId(trackingId string) ([]handlingdomain.HandlingEvent, error) {
+// FindByIdempotencyKey retrieves the handling event submitted with an idempotency key
+func (r *InMemoryHandlingEventRepository) FindByIdempotencyKey(key string) (handlingdomain.HandlingEvent, bool, error) {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
 
-	var events []handlingdomain.HandlingEvent
-	for _, event := range r.events {
-		if event.GetTrackingId() == trackingId {
-			events = append(events, event)
-		}
+	id, exists := r.byIdempotencyKey[key]
+	if !exists {
+		return handlingdomain.HandlingEvent{}, false, nil
 	}
+	return r.events[id], true, nil
+}
+
+// FindByTrackingId retrieves all handling events for a specific cargo, by completion time, registration time and event ID
+func (r *InMemoryHandlingEventRepository) FindByTrackingId(trackingId string) ([]handlingdomain.HandlingEvent, error) {
+	r.mutex.RLock()
+	events := r.eventsOf(trackingId)
+	r.mutex.RUnlock()
+
+	handlingdomain.SortHandlingEvents(events)
 	return events, nil
 }
 
+// eventsOf looks up the events of a cargo in the tracking ID index; the caller must hold the lock
+func (r *InMemoryHandlingEventRepository) eventsOf(trackingId string) []handlingdomain.HandlingEvent {
+	ids := r.byTrackingId[trackingId]
+	events := make([]handlingdomain.HandlingEvent, 0, len(ids))
+	for id := range ids {
+		events = append(events, r.events[id])
+	}
+	return events
+}
+
 // FindAll retrieves all handling events in the repository
 func (r *InMemoryHandlingEventRepository) FindAll() ([]handlingdomain.HandlingEvent, error) {
 	r.mutex.RLock()
@@ -68,3 +197,44 @@ func (r *InMemoryHandlingEventRepository) FindAll() ([]handlingdomain.HandlingEv
 	}
 	return events, nil
 }
+
+// FindByQuery retrieves one page of the handling events matching the query, by completion time and then event ID
+func (r *InMemoryHandlingEventRepository) FindByQuery(query handlingdomain.HandlingEventQuery) (handlingdomain.HandlingEventPage, error) {
+	query, err := query.Normalize()
+	if err != nil {
+		return handlingdomain.HandlingEventPage{}, err
+	}
+	cursor, hasCursor, err := query.DecodeCursor()
+	if err != nil {
+		return handlingdomain.HandlingEventPage{}, err
+	}
+
+	r.mutex.RLock()
+	var candidates []handlingdomain.HandlingEvent
+	if query.TrackingId != "" {
+		candidates = r.eventsOf(query.TrackingId)
+	} else {
+		candidates = make([]handlingdomain.HandlingEvent, 0, len(r.events))
+		for _, event := range r.events {
+			candidates = append(candidates, event)
+		}
+	}
+	matching := make([]handlingdomain.HandlingEvent, 0)
+	for _, event := range candidates {
+		if query.Matches(event) && (!hasCursor || query.IsAfter(event, cursor)) {
+			matching = append(matching, event)
+		}
+	}
+	r.mutex.RUnlock()
+
+	sort.Slice(matching, func(i, j int) bool {
+		return query.Less(matching[i], matching[j])
+	})
+
+	page := handlingdomain.HandlingEventPage{Events: matching}
+	if len(matching) > query.Limit {
+		page.Events = matching[:query.Limit]
+		page.NextCursor = query.CursorAfter(page.Events[query.Limit-1])
+	}
+	return page, nil
+}
@@ -0,0 +1,23 @@
+package in_memory_handling_repo
+
+import (
+	"testing"
+
+	"go_hex/internal/adapters/driven/in_memory_outbox"
+	"go_hex/internal/adapters/driven/repository_contract"
+	"go_hex/internal/handling/ports/handlingsecondary"
+	"go_hex/internal/support/outbox"
+)
+
+func TestInMemoryHandlingEventRepository(t *testing.T) {
+	repository_contract.HandlingEventRepositoryContract(t, func(t *testing.T) handlingsecondary.HandlingEventRepository {
+		return NewInMemoryHandlingEventRepository(in_memory_outbox.NewInMemoryOutbox())
+	})
+}
+
+func TestInMemoryHandlingEventOutbox(t *testing.T) {
+	repository_contract.HandlingEventOutboxContract(t, func(t *testing.T) (handlingsecondary.HandlingEventRepository, outbox.Store) {
+		eventOutbox := in_memory_outbox.NewInMemoryOutbox()
+		return NewInMemoryHandlingEventRepository(eventOutbox), eventOutbox
+	})
+}
@@ -1,7 +1,6 @@
 package in_memory_location_repo
 
 import (
-	"fmt"
 	"sync"
 
 	"go_hex/internal/routing/ports/routingsecondary"
@@ -38,7 +37,7 @@ func (r *InMemoryLocationRepository) FindByUnLocode(unLocode routingdomain.UnLoc
 
 	location, exists := r.locations[unLocode.String()]
 	if !exists {
-		return routingdomain.Location{}, fmt.Errorf("location with UN/LOCODE %s not found", unLocode.String())
+		return routingdomain.Location{}, routingdomain.NewLocationNotFoundError(unLocode)
 	}
 	return location, nil
 }
@@ -0,0 +1,14 @@
+package in_memory_location_repo
+
+import (
+	"testing"
+
+	"go_hex/internal/adapters/driven/repository_contract"
+	"go_hex/internal/routing/ports/routingsecondary"
+)
+
+func TestInMemoryLocationRepository(t *testing.T) {
+	repository_contract.LocationRepositoryContract(t, func(t *testing.T) routingsecondary.LocationRepository {
+		return NewInMemoryLocationRepository()
+	})
+}
@@ -0,0 +1,118 @@
+package in_memory_outbox
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"go_hex/internal/support/outbox"
+)
+
+// outboxEntry tracks the delivery state of a recorded message
+type outboxEntry struct {
+	message outbox.Message
+}
+
+// InMemoryOutbox provides an in-memory implementation of the outbox Store, filled by the in-memory repositories.
+// Only undelivered messages are kept: dispatched messages are deleted, so memory follows the backlog, not the
+// history. Messages that ran out of attempts are kept with their last error, as the SQL stores keep their rows, but
+// move aside the next time pending messages are read, so polling cost follows the messages still being delivered.
+type InMemoryOutbox struct {
+	pending []*outboxEntry
+	failed  []*outboxEntry
+	byId    map[string]*outboxEntry
+	mutex   sync.Mutex
+}
+
+// NewInMemoryOutbox creates a new in-memory outbox
+func NewInMemoryOutbox() *InMemoryOutbox {
+	return &InMemoryOutbox{
+		byId: make(map[string]*outboxEntry),
+	}
+}
+
+// Append records messages for delivery, preserving their order
+func (o *InMemoryOutbox) Append(messages []outbox.Message) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	for _, message := range messages {
+		entry := &outboxEntry{message: message}
+		o.pending = append(o.pending, entry)
+		o.byId[message.Id] = entry
+	}
+}
+
+// FindPending retrieves undispatched messages of the named events with fewer than maxAttempts failed deliveries,
+// oldest first
+func (o *InMemoryOutbox) FindPending(eventNames []string, maxAttempts, limit int) ([]outbox.Message, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	var messages []outbox.Message
+	kept := o.pending[:0]
+	for _, entry := range o.pending {
+		if entry.message.Attempts >= maxAttempts {
+			// The relay has given up on this message
+			o.failed = append(o.failed, entry)
+			continue
+		}
+		if len(messages) < limit && slices.Contains(eventNames, entry.message.EventName) {
+			messages = append(messages, entry.message)
+		}
+		kept = append(kept, entry)
+	}
+	clear(o.pending[len(kept):])
+	o.pending = kept
+
+	return messages, nil
+}
+
+// Failed retrieves the messages the relay gave up on, in the order it gave up on them
+func (o *InMemoryOutbox) Failed() []outbox.Message {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	messages := make([]outbox.Message, 0, len(o.failed))
+	for _, entry := range o.failed {
+		messages = append(messages, entry.message)
+	}
+	return messages
+}
+
+// MarkDispatched records that a message was delivered, deleting it from the outbox
+func (o *InMemoryOutbox) MarkDispatched(id string) error {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	entry, exists := o.byId[id]
+	if !exists {
+		return fmt.Errorf("outbox message %s not found", id)
+	}
+	delete(o.byId, id)
+
+	// The relay dispatches oldest first, so the message is almost always at the front
+	for i, candidate := range o.pending {
+		if candidate == entry {
+			o.pending = slices.Delete(o.pending, i, i+1)
+			break
+		}
+	}
+	return nil
+}
+
+// MarkFailed records a failed delivery attempt for a message
+func (o *InMemoryOutbox) MarkFailed(id string, reason string) error {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	entry, exists := o.byId[id]
+	if !exists {
+		return fmt.Errorf("outbox message %s not found", id)
+	}
+	entry.message.Attempts++
+	entry.message.LastError = reason
+	return nil
+}
+
+var _ outbox.Store = (*InMemoryOutbox)(nil)
@@ -0,0 +1,46 @@
+package in_memory_outbox
+
+import (
+	"testing"
+
+	"go_hex/internal/support/outbox"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryOutbox(t *testing.T) {
+	messages := []outbox.Message{{Id: "first", EventName: "Test"}, {Id: "second", EventName: "Test"}, {Id: "third", EventName: "Test"}}
+
+	t.Run("should delete dispatched messages", func(t *testing.T) {
+		// Setup
+		store := NewInMemoryOutbox()
+		store.Append(messages)
+
+		// Execute
+		require.NoError(t, store.MarkDispatched("first"))
+		require.NoError(t, store.MarkDispatched("third"))
+
+		// Verify
+		pending, err := store.FindPending([]string{"Test"}, 10, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []outbox.Message{messages[1]}, pending)
+		assert.Error(t, store.MarkDispatched("first"))
+	})
+
+	t.Run("should set aside messages that ran out of attempts", func(t *testing.T) {
+		// Setup
+		store := NewInMemoryOutbox()
+		store.Append(messages)
+		require.NoError(t, store.MarkFailed("first", "booking unavailable"))
+		require.NoError(t, store.MarkFailed("first", "booking unavailable"))
+
+		// Execute
+		pending, err := store.FindPending([]string{"Test"}, 2, 1)
+
+		// Verify
+		require.NoError(t, err)
+		assert.Equal(t, []outbox.Message{messages[1]}, pending)
+		assert.Equal(t, []outbox.Message{{Id: "first", EventName: "Test", Attempts: 2, LastError: "booking unavailable"}}, store.Failed())
+	})
+}
@@ -0,0 +1,58 @@
+package in_memory_reroute_worklist
+
+import (
+	"slices"
+	"sync"
+
+	"go_hex/internal/booking/bookingdomain"
+	"go_hex/internal/booking/ports/bookingsecondary"
+)
+
+// InMemoryRerouteWorklist provides an in-memory implementation of the RerouteWorklist
+type InMemoryRerouteWorklist struct {
+	tasks []bookingdomain.RerouteTask
+	mutex sync.RWMutex
+}
+
+// NewInMemoryRerouteWorklist creates a new in-memory reroute worklist
+func NewInMemoryRerouteWorklist() bookingsecondary.RerouteWorklist {
+	return &InMemoryRerouteWorklist{}
+}
+
+// Put adds a task, replacing any earlier task of the same cargo
+func (w *InMemoryRerouteWorklist) Put(task bookingdomain.RerouteTask) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.tasks = append(w.without(task.TrackingId), task)
+	return nil
+}
+
+// Remove takes the cargo off the worklist
+func (w *InMemoryRerouteWorklist) Remove(trackingId bookingdomain.TrackingId) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.tasks = w.without(trackingId)
+	return nil
+}
+
+// FindAll retrieves all tasks, oldest first
+func (w *InMemoryRerouteWorklist) FindAll() ([]bookingdomain.RerouteTask, error) {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	tasks := make([]bookingdomain.RerouteTask, len(w.tasks))
+	copy(tasks, w.tasks)
+	slices.SortStableFunc(tasks, func(a, b bookingdomain.RerouteTask) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return tasks, nil
+}
+
+// without returns the tasks of every other cargo
+func (w *InMemoryRerouteWorklist) without(trackingId bookingdomain.TrackingId) []bookingdomain.RerouteTask {
+	return slices.DeleteFunc(w.tasks, func(task bookingdomain.RerouteTask) bool {
+		return task.TrackingId == trackingId
+	})
+}
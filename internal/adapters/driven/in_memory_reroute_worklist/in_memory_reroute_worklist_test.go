@@ -0,0 +1,14 @@
+package in_memory_reroute_worklist
+
+import (
+	"testing"
+
+	"go_hex/internal/adapters/driven/repository_contract"
+	"go_hex/internal/booking/ports/bookingsecondary"
+)
+
+func TestInMemoryRerouteWorklist(t *testing.T) {
+	repository_contract.RerouteWorklistContract(t, func(t *testing.T) bookingsecondary.RerouteWorklist {
+		return NewInMemoryRerouteWorklist()
+	})
+}
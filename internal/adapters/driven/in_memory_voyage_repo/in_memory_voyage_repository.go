@@ -1,23 +1,27 @@
 package in_memory_voyage_repo
 
 import (
-	"fmt"
 	"sync"
 
+	"go_hex/internal/booking/bookingdomain"
+	"go_hex/internal/booking/ports/bookingsecondary"
 	"go_hex/internal/routing/ports/routingsecondary"
 	"go_hex/internal/routing/routingdomain"
 )
 
 // InMemoryVoyageRepository provides an in-memory implementation of the VoyageRepository
+// Like the SQL repositories sharing a database with cargo, it checks the cargo routed on a voyage while deleting it.
 type InMemoryVoyageRepository struct {
-	voyages map[string]routingdomain.Voyage
-	mutex   sync.RWMutex
+	voyages   map[string]routingdomain.Voyage
+	cargoRepo bookingsecondary.CargoRepository
+	mutex     sync.RWMutex
 }
 
-// NewInMemoryVoyageRepository creates a new in-memory voyage repository
-func NewInMemoryVoyageRepository() routingsecondary.VoyageRepository {
+// NewInMemoryVoyageRepository creates a new in-memory voyage repository guarding deletions with the given cargo repository
+func NewInMemoryVoyageRepository(cargoRepo bookingsecondary.CargoRepository) routingsecondary.VoyageRepository {
 	return &InMemoryVoyageRepository{
-		voyages: make(map[string]routingdomain.Voyage),
+		voyages:   make(map[string]routingdomain.Voyage),
+		cargoRepo: cargoRepo,
 	}
 }
 
@@ -38,11 +42,32 @@ func (r *InMemoryVoyageRepository) FindByVoyageNumber(voyageNumber routingdomain
 
 	voyage, exists := r.voyages[voyageNumber.String()]
 	if !exists {
-		return routingdomain.Voyage{}, fmt.Errorf("voyage with number %s not found", voyageNumber.String())
+		return routingdomain.Voyage{}, routingdomain.NewVoyageNotFoundError(voyageNumber)
 	}
 	return voyage, nil
 }
 
+// Delete removes a voyage from the repository unless cargo is routed on it
+func (r *InMemoryVoyageRepository) Delete(voyageNumber routingdomain.VoyageNumber) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.voyages[voyageNumber.String()]; !exists {
+		return routingdomain.NewVoyageNotFoundError(voyageNumber)
+	}
+
+	routed, err := r.cargoRepo.FindByQuery(bookingdomain.CargoQuery{OnVoyage: voyageNumber.String(), Limit: 1})
+	if err != nil {
+		return err
+	}
+	if len(routed.Cargos) > 0 {
+		return routingdomain.NewVoyageInUseError(voyageNumber)
+	}
+
+	delete(r.voyages, voyageNumber.String())
+	return nil
+}
+
 // FindAll retrieves all voyages in the repository
 func (r *InMemoryVoyageRepository) FindAll() ([]routingdomain.Voyage, error) {
 	r.mutex.RLock()
@@ -0,0 +1,18 @@
+package in_memory_voyage_repo
+
+import (
+	"testing"
+
+	"go_hex/internal/adapters/driven/in_memory_cargo_repo"
+	"go_hex/internal/adapters/driven/in_memory_outbox"
+	"go_hex/internal/adapters/driven/repository_contract"
+	"go_hex/internal/booking/ports/bookingsecondary"
+	"go_hex/internal/routing/ports/routingsecondary"
+)
+
+func TestInMemoryVoyageRepository(t *testing.T) {
+	repository_contract.VoyageRepositoryContract(t, func(t *testing.T) (routingsecondary.VoyageRepository, bookingsecondary.CargoRepository) {
+		cargoRepo := in_memory_cargo_repo.NewInMemoryCargoRepository(in_memory_outbox.NewInMemoryOutbox())
+		return NewInMemoryVoyageRepository(cargoRepo), cargoRepo
+	})
+}
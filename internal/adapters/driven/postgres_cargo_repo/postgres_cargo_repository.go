@@ -0,0 +1,28 @@
+package postgres_cargo_repo
+
+import (
+	"database/sql"
+
+	"go_hex/internal/adapters/driven/postgres_db"
+	"go_hex/internal/adapters/driven/sql_cargo_repo"
+	"go_hex/internal/booking/ports/bookingsecondary"
+)
+
+// dialect spells the cargo statements for PostgreSQL
+var dialect = sql_cargo_repo.Dialect{
+	Dialect: postgres_db.Dialect,
+	HasLegOnVoyage: func(voyage string) string {
+		return "itinerary -> 'legs' @> jsonb_build_array(jsonb_build_object('voyage_number', " + voyage + "::text))"
+	},
+}
+
+// RoutedOnVoyage returns the condition that a row of the cargos table is neither delivered nor cancelled and has a leg
+// on the voyage in the placeholder, for the voyage repository to guard deletions with
+func RoutedOnVoyage(voyage string) string {
+	return dialect.RoutedOnVoyage(voyage)
+}
+
+// NewPostgresCargoRepository creates a new PostgreSQL cargo repository
+func NewPostgresCargoRepository(db *sql.DB) bookingsecondary.CargoRepository {
+	return sql_cargo_repo.NewSQLCargoRepository(db, dialect)
+}
@@ -0,0 +1,33 @@
+package postgres_cargo_repo
+
+import (
+	"testing"
+
+	"go_hex/internal/adapters/driven/postgres_db"
+	"go_hex/internal/adapters/driven/postgres_outbox"
+	"go_hex/internal/adapters/driven/repository_contract"
+	"go_hex/internal/booking/ports/bookingsecondary"
+	"go_hex/internal/support/outbox"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresCargoRepository(t *testing.T) {
+	db := postgres_db.OpenTestDatabase(t)
+
+	repository_contract.CargoRepositoryContract(t, func(t *testing.T) bookingsecondary.CargoRepository {
+		_, err := db.Exec("TRUNCATE cargos, outbox_messages")
+		require.NoError(t, err)
+		return NewPostgresCargoRepository(db)
+	})
+}
+
+func TestPostgresCargoOutbox(t *testing.T) {
+	db := postgres_db.OpenTestDatabase(t)
+
+	repository_contract.CargoOutboxContract(t, func(t *testing.T) (bookingsecondary.CargoRepository, outbox.Store) {
+		_, err := db.Exec("TRUNCATE cargos, outbox_messages")
+		require.NoError(t, err)
+		return NewPostgresCargoRepository(db), postgres_outbox.NewPostgresOutbox(db)
+	})
+}
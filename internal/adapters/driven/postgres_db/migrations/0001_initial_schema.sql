@@ -0,0 +1,49 @@
+CREATE TABLE IF NOT EXISTS locations (
+    unlocode VARCHAR(5)   PRIMARY KEY,
+    name     VARCHAR(100) NOT NULL,
+    country  CHAR(2)      NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS voyages (
+    voyage_number UUID PRIMARY KEY
+);
+
+CREATE TABLE IF NOT EXISTS carrier_movements (
+    voyage_number      UUID        NOT NULL REFERENCES voyages (voyage_number) ON DELETE CASCADE,
+    sequence           INTEGER     NOT NULL,
+    departure_location VARCHAR(5)  NOT NULL,
+    arrival_location   VARCHAR(5)  NOT NULL,
+    departure_time     TIMESTAMPTZ NOT NULL,
+    arrival_time       TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (voyage_number, sequence)
+);
+
+CREATE INDEX IF NOT EXISTS idx_carrier_movements_route
+    ON carrier_movements (departure_location, arrival_location);
+
+CREATE TABLE IF NOT EXISTS cargos (
+    tracking_id            UUID        PRIMARY KEY,
+    origin                 VARCHAR(5)  NOT NULL,
+    destination            VARCHAR(5)  NOT NULL,
+    arrival_deadline       TIMESTAMPTZ NOT NULL,
+    itinerary              JSONB,
+    transport_status       VARCHAR(32) NOT NULL,
+    routing_status         VARCHAR(32) NOT NULL,
+    last_known_location    VARCHAR(5)  NOT NULL DEFAULT '',
+    current_voyage         VARCHAR(64) NOT NULL DEFAULT '',
+    is_unloaded_at_dest    BOOLEAN     NOT NULL DEFAULT FALSE,
+    delivery_calculated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS handling_events (
+    event_id          UUID        PRIMARY KEY,
+    tracking_id       VARCHAR(64) NOT NULL,
+    event_type        VARCHAR(16) NOT NULL,
+    location          VARCHAR(5)  NOT NULL,
+    voyage_number     VARCHAR(64) NOT NULL DEFAULT '',
+    completion_time   TIMESTAMPTZ NOT NULL,
+    registration_time TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_handling_events_tracking_id
+    ON handling_events (tracking_id);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS outbox_messages (
+    position      BIGSERIAL    PRIMARY KEY,
+    id            UUID         NOT NULL UNIQUE,
+    event_name    VARCHAR(128) NOT NULL,
+    payload       JSONB        NOT NULL,
+    occurred_at   TIMESTAMPTZ  NOT NULL,
+    attempts      INTEGER      NOT NULL DEFAULT 0,
+    last_error    TEXT         NOT NULL DEFAULT '',
+    dispatched_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_pending
+    ON outbox_messages (position) WHERE dispatched_at IS NULL;
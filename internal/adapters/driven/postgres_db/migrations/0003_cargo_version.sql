@@ -0,0 +1 @@
+ALTER TABLE cargos ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,5 @@
+CREATE INDEX IF NOT EXISTS idx_cargos_arrival_deadline
+    ON cargos (arrival_deadline, tracking_id);
+
+CREATE INDEX IF NOT EXISTS idx_cargos_status
+    ON cargos (routing_status, transport_status);
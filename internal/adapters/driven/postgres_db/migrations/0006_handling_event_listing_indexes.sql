@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS idx_handling_events_tracking_id;
+
+CREATE INDEX IF NOT EXISTS idx_handling_events_tracking_id_completion
+    ON handling_events (tracking_id, completion_time, event_id);
+
+CREATE INDEX IF NOT EXISTS idx_handling_events_location_completion
+    ON handling_events (location, completion_time, event_id);
+
+CREATE INDEX IF NOT EXISTS idx_handling_events_voyage_completion
+    ON handling_events (voyage_number, completion_time, event_id);
+
+CREATE INDEX IF NOT EXISTS idx_handling_events_completion
+    ON handling_events (completion_time, event_id);
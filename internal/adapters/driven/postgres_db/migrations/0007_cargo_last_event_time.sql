@@ -0,0 +1 @@
+ALTER TABLE cargos ADD COLUMN last_event_time TIMESTAMPTZ;
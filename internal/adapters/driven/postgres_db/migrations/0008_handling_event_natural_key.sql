@@ -0,0 +1,13 @@
+-- A handling event is recorded once per physical handling. Keep the first registration of any handling recorded
+-- twice before this was enforced, so concurrent reports of the same handling can no longer both be stored.
+DELETE FROM handling_events AS later
+USING handling_events AS earlier
+WHERE earlier.tracking_id = later.tracking_id
+  AND earlier.event_type = later.event_type
+  AND earlier.location = later.location
+  AND earlier.voyage_number = later.voyage_number
+  AND earlier.completion_time = later.completion_time
+  AND (earlier.registration_time, earlier.event_id) < (later.registration_time, later.event_id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_handling_events_natural_key
+    ON handling_events (tracking_id, event_type, location, voyage_number, completion_time);
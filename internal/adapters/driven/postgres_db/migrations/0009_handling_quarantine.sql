@@ -0,0 +1,15 @@
+-- Handling reports the lenient sequence policy sets aside for review, kept until they are released
+CREATE TABLE IF NOT EXISTS handling_quarantine (
+    id              UUID         PRIMARY KEY,
+    tracking_id     VARCHAR(64)  NOT NULL,
+    event_type      VARCHAR(16)  NOT NULL,
+    location        VARCHAR(5)   NOT NULL,
+    voyage_number   VARCHAR(64)  NOT NULL DEFAULT '',
+    completion_time VARCHAR(64)  NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL DEFAULT '',
+    reason          TEXT         NOT NULL,
+    quarantined_at  TIMESTAMPTZ  NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_handling_quarantine_quarantined_at
+    ON handling_quarantine (quarantined_at);
@@ -0,0 +1,10 @@
+-- Misdirected cargo waiting for a planner to choose a new route, one task per cargo
+CREATE TABLE IF NOT EXISTS reroute_worklist (
+    tracking_id         VARCHAR(64) PRIMARY KEY,
+    route_specification JSONB       NOT NULL,
+    candidates          JSONB       NOT NULL,
+    created_at          TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_reroute_worklist_created_at
+    ON reroute_worklist (created_at);
@@ -0,0 +1,3 @@
+-- When the cargo was booked and when its current itinerary was assigned; NULL where not known
+ALTER TABLE cargos ADD COLUMN booked_at TIMESTAMPTZ;
+ALTER TABLE cargos ADD COLUMN routed_at TIMESTAMPTZ;
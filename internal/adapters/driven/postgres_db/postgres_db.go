@@ -0,0 +1,61 @@
+package postgres_db
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+
+	"go_hex/internal/adapters/driven/sql_db"
+
+	_ "github.com/jackc/pgx/v5/stdlib" // Registers the "pgx" database/sql driver
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockId serializes migrations across application instances sharing a database
+const migrationLockId = 7_246_012
+
+// Dialect spells the shared SQL statements for PostgreSQL
+var Dialect = sql_db.Dialect{
+	Placeholder: func(n int) string {
+		return fmt.Sprintf("$%d", n)
+	},
+	Bytewise: func(column string) string {
+		return column + ` COLLATE "C"`
+	},
+	LockMigrations: func(tx *sql.Tx) error {
+		_, err := tx.Exec("SELECT pg_advisory_xact_lock($1)", migrationLockId)
+		return err
+	},
+	// The two-key form keeps these locks apart from the migration lock
+	Lock: func(tx *sql.Tx, key string) error {
+		_, err := tx.Exec("SELECT pg_advisory_xact_lock(0, hashtext($1))", key)
+		return err
+	},
+}
+
+// Open connects to PostgreSQL and applies any pending schema migrations
+func Open(dsn string) (*sql.DB, error) {
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open postgres connection: %w", err)
+	}
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to postgres: %w", err)
+	}
+
+	if err := Migrate(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// Migrate applies the embedded migrations that have not been applied yet, in file name order
+func Migrate(db *sql.DB) error {
+	return sql_db.Migrate(db, Dialect, migrationFiles)
+}
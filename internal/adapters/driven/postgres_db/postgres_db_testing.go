@@ -0,0 +1,36 @@
+package postgres_db
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+)
+
+// TestDSNEnvVar names the environment variable pointing the SQL adapter tests at a disposable PostgreSQL database
+const TestDSNEnvVar = "POSTGRES_TEST_DSN"
+
+// OpenTestDatabase connects to the test database and migrates it. Without a configured database, tests built with
+// the embeddedpostgres tag start a throwaway server of their own. Otherwise the test is skipped locally, but fails
+// in CI (when the CI environment variable is set) so the adapters cannot go untested.
+func OpenTestDatabase(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dsn := os.Getenv(TestDSNEnvVar)
+	if dsn == "" {
+		dsn = startTestServer(t)
+	}
+	if dsn == "" {
+		if os.Getenv("CI") != "" {
+			t.Fatalf("%s must be set, or the tests built with -tags embeddedpostgres, to run the PostgreSQL repository tests in CI", TestDSNEnvVar)
+		}
+		t.Skipf("%s not set; skipping PostgreSQL repository tests", TestDSNEnvVar)
+	}
+
+	db, err := Open(dsn)
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
@@ -0,0 +1,50 @@
+//go:build embeddedpostgres
+
+package postgres_db
+
+import (
+	"io"
+	"net"
+	"path/filepath"
+	"testing"
+
+	embeddedpostgres "github.com/fergusstrange/embedded-postgres"
+)
+
+// startTestServer starts a throwaway PostgreSQL server for the test, stopped when the test finishes, and returns its
+// DSN. Each server gets a port and directory of its own, as the test binaries of several packages run at once.
+func startTestServer(t *testing.T) string {
+	t.Helper()
+
+	config := embeddedpostgres.DefaultConfig().
+		Version(embeddedpostgres.V16).
+		Port(freePort(t)).
+		Database("gohex_test").
+		RuntimePath(filepath.Join(t.TempDir(), "postgres")).
+		Logger(io.Discard)
+
+	server := embeddedpostgres.NewDatabase(config)
+	if err := server.Start(); err != nil {
+		t.Fatalf("failed to start embedded PostgreSQL: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := server.Stop(); err != nil {
+			t.Errorf("failed to stop embedded PostgreSQL: %v", err)
+		}
+	})
+
+	return config.GetConnectionURL() + "?sslmode=disable"
+}
+
+// freePort returns a TCP port nothing listens on right now
+func freePort(t *testing.T) uint32 {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("failed to find a free port: %v", err)
+	}
+	defer listener.Close()
+
+	return uint32(listener.Addr().(*net.TCPAddr).Port)
+}
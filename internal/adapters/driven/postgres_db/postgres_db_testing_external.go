@@ -0,0 +1,10 @@
+//go:build !embeddedpostgres
+
+package postgres_db
+
+import "testing"
+
+// startTestServer returns no DSN: without the embeddedpostgres tag the tests use POSTGRES_TEST_DSN only
+func startTestServer(t *testing.T) string {
+	return ""
+}
@@ -0,0 +1,14 @@
+package postgres_handling_quarantine
+
+import (
+	"database/sql"
+
+	"go_hex/internal/adapters/driven/postgres_db"
+	"go_hex/internal/adapters/driven/sql_handling_quarantine"
+	"go_hex/internal/handling/ports/handlingsecondary"
+)
+
+// NewPostgresHandlingQuarantine creates a new PostgreSQL handling quarantine
+func NewPostgresHandlingQuarantine(db *sql.DB) handlingsecondary.HandlingQuarantine {
+	return sql_handling_quarantine.NewSQLHandlingQuarantine(db, postgres_db.Dialect)
+}
@@ -0,0 +1,21 @@
+package postgres_handling_quarantine
+
+import (
+	"testing"
+
+	"go_hex/internal/adapters/driven/postgres_db"
+	"go_hex/internal/adapters/driven/repository_contract"
+	"go_hex/internal/handling/ports/handlingsecondary"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresHandlingQuarantine(t *testing.T) {
+	db := postgres_db.OpenTestDatabase(t)
+
+	repository_contract.HandlingQuarantineContract(t, func(t *testing.T) handlingsecondary.HandlingQuarantine {
+		_, err := db.Exec("TRUNCATE handling_quarantine")
+		require.NoError(t, err)
+		return NewPostgresHandlingQuarantine(db)
+	})
+}
@@ -0,0 +1,14 @@
+package postgres_handling_repo
+
+import (
+	"database/sql"
+
+	"go_hex/internal/adapters/driven/postgres_db"
+	"go_hex/internal/adapters/driven/sql_handling_repo"
+	"go_hex/internal/handling/ports/handlingsecondary"
+)
+
+// NewPostgresHandlingEventRepository creates a new PostgreSQL handling event repository
+func NewPostgresHandlingEventRepository(db *sql.DB) handlingsecondary.HandlingEventRepository {
+	return sql_handling_repo.NewSQLHandlingEventRepository(db, postgres_db.Dialect)
+}
@@ -0,0 +1,33 @@
+package postgres_handling_repo
+
+import (
+	"testing"
+
+	"go_hex/internal/adapters/driven/postgres_db"
+	"go_hex/internal/adapters/driven/postgres_outbox"
+	"go_hex/internal/adapters/driven/repository_contract"
+	"go_hex/internal/handling/ports/handlingsecondary"
+	"go_hex/internal/support/outbox"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresHandlingEventRepository(t *testing.T) {
+	db := postgres_db.OpenTestDatabase(t)
+
+	repository_contract.HandlingEventRepositoryContract(t, func(t *testing.T) handlingsecondary.HandlingEventRepository {
+		_, err := db.Exec("TRUNCATE handling_events, outbox_messages")
+		require.NoError(t, err)
+		return NewPostgresHandlingEventRepository(db)
+	})
+}
+
+func TestPostgresHandlingEventOutbox(t *testing.T) {
+	db := postgres_db.OpenTestDatabase(t)
+
+	repository_contract.HandlingEventOutboxContract(t, func(t *testing.T) (handlingsecondary.HandlingEventRepository, outbox.Store) {
+		_, err := db.Exec("TRUNCATE handling_events, outbox_messages")
+		require.NoError(t, err)
+		return NewPostgresHandlingEventRepository(db), postgres_outbox.NewPostgresOutbox(db)
+	})
+}
@@ -0,0 +1,14 @@
+package postgres_location_repo
+
+import (
+	"database/sql"
+
+	"go_hex/internal/adapters/driven/postgres_db"
+	"go_hex/internal/adapters/driven/sql_location_repo"
+	"go_hex/internal/routing/ports/routingsecondary"
+)
+
+// NewPostgresLocationRepository creates a new PostgreSQL location repository
+func NewPostgresLocationRepository(db *sql.DB) routingsecondary.LocationRepository {
+	return sql_location_repo.NewSQLLocationRepository(db, postgres_db.Dialect)
+}
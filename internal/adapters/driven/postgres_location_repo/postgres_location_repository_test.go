@@ -0,0 +1,21 @@
+package postgres_location_repo
+
+import (
+	"testing"
+
+	"go_hex/internal/adapters/driven/postgres_db"
+	"go_hex/internal/adapters/driven/repository_contract"
+	"go_hex/internal/routing/ports/routingsecondary"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresLocationRepository(t *testing.T) {
+	db := postgres_db.OpenTestDatabase(t)
+
+	repository_contract.LocationRepositoryContract(t, func(t *testing.T) routingsecondary.LocationRepository {
+		_, err := db.Exec("TRUNCATE locations")
+		require.NoError(t, err)
+		return NewPostgresLocationRepository(db)
+	})
+}
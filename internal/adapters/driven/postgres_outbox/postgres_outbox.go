@@ -0,0 +1,14 @@
+package postgres_outbox
+
+import (
+	"database/sql"
+
+	"go_hex/internal/adapters/driven/postgres_db"
+	"go_hex/internal/adapters/driven/sql_outbox"
+	"go_hex/internal/support/outbox"
+)
+
+// NewPostgresOutbox creates a new PostgreSQL outbox
+func NewPostgresOutbox(db *sql.DB) outbox.Store {
+	return sql_outbox.NewSQLOutbox(db, postgres_db.Dialect)
+}
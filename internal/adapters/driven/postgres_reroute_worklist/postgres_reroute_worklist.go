@@ -0,0 +1,14 @@
+package postgres_reroute_worklist
+
+import (
+	"database/sql"
+
+	"go_hex/internal/adapters/driven/postgres_db"
+	"go_hex/internal/adapters/driven/sql_reroute_worklist"
+	"go_hex/internal/booking/ports/bookingsecondary"
+)
+
+// NewPostgresRerouteWorklist creates a new PostgreSQL reroute worklist
+func NewPostgresRerouteWorklist(db *sql.DB) bookingsecondary.RerouteWorklist {
+	return sql_reroute_worklist.NewSQLRerouteWorklist(db, postgres_db.Dialect)
+}
@@ -0,0 +1,21 @@
+package postgres_reroute_worklist
+
+import (
+	"testing"
+
+	"go_hex/internal/adapters/driven/postgres_db"
+	"go_hex/internal/adapters/driven/repository_contract"
+	"go_hex/internal/booking/ports/bookingsecondary"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresRerouteWorklist(t *testing.T) {
+	db := postgres_db.OpenTestDatabase(t)
+
+	repository_contract.RerouteWorklistContract(t, func(t *testing.T) bookingsecondary.RerouteWorklist {
+		_, err := db.Exec("TRUNCATE reroute_worklist")
+		require.NoError(t, err)
+		return NewPostgresRerouteWorklist(db)
+	})
+}
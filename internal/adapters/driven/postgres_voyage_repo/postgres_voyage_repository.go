@@ -0,0 +1,21 @@
+package postgres_voyage_repo
+
+import (
+	"database/sql"
+
+	"go_hex/internal/adapters/driven/postgres_cargo_repo"
+	"go_hex/internal/adapters/driven/postgres_db"
+	"go_hex/internal/adapters/driven/sql_voyage_repo"
+	"go_hex/internal/routing/ports/routingsecondary"
+)
+
+// dialect spells the voyage statements for PostgreSQL, guarding deletions with the cargo repository's condition
+var dialect = sql_voyage_repo.Dialect{
+	Dialect:        postgres_db.Dialect,
+	RoutedOnVoyage: postgres_cargo_repo.RoutedOnVoyage,
+}
+
+// NewPostgresVoyageRepository creates a new PostgreSQL voyage repository
+func NewPostgresVoyageRepository(db *sql.DB) routingsecondary.VoyageRepository {
+	return sql_voyage_repo.NewSQLVoyageRepository(db, dialect)
+}
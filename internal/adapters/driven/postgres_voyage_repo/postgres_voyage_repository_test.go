@@ -0,0 +1,23 @@
+package postgres_voyage_repo
+
+import (
+	"testing"
+
+	"go_hex/internal/adapters/driven/postgres_cargo_repo"
+	"go_hex/internal/adapters/driven/postgres_db"
+	"go_hex/internal/adapters/driven/repository_contract"
+	"go_hex/internal/booking/ports/bookingsecondary"
+	"go_hex/internal/routing/ports/routingsecondary"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresVoyageRepository(t *testing.T) {
+	db := postgres_db.OpenTestDatabase(t)
+
+	repository_contract.VoyageRepositoryContract(t, func(t *testing.T) (routingsecondary.VoyageRepository, bookingsecondary.CargoRepository) {
+		_, err := db.Exec("TRUNCATE voyages, cargos, outbox_messages CASCADE")
+		require.NoError(t, err)
+		return NewPostgresVoyageRepository(db), postgres_cargo_repo.NewPostgresCargoRepository(db)
+	})
+}
@@ -0,0 +1,1647 @@
+// Package repository_contract holds the behavioural tests every repository adapter must pass,
+// so the in-memory and SQL implementations stay interchangeable.
+package repository_contract
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"go_hex/internal/booking/bookingdomain"
+	"go_hex/internal/booking/ports/bookingsecondary"
+	"go_hex/internal/handling/handlingdomain"
+	"go_hex/internal/handling/ports/handlingsecondary"
+	"go_hex/internal/routing/ports/routingsecondary"
+	"go_hex/internal/routing/routingdomain"
+	"go_hex/internal/support/outbox"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// CargoRepositoryContract verifies a CargoRepository implementation; newRepo must return an empty repository
+func CargoRepositoryContract(t *testing.T, newRepo func(t *testing.T) bookingsecondary.CargoRepository) {
+	t.Run("should store and find cargo by tracking ID", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		cargo := newRoutedCargo(t)
+
+		// Execute
+		require.NoError(t, repo.Store(cargo))
+		found, err := repo.FindByTrackingId(cargo.GetTrackingId())
+
+		// Verify
+		require.NoError(t, err)
+		assertSameCargo(t, cargo, found)
+	})
+
+	t.Run("should restore the handling progress of stored cargo", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		cargo := newRoutedCargo(t)
+		firstLeg := cargo.GetItinerary().Legs[0]
+		require.NoError(t, cargo.DeriveDeliveryProgress([]bookingdomain.HandlingEventSummary{
+			{Type: "LOAD", Location: "USNYC", VoyageNumber: "V001", Timestamp: firstLeg.LoadTime.Add(12 * time.Hour)},
+		}))
+
+		// Execute
+		require.NoError(t, repo.Store(cargo))
+		found, err := repo.FindByTrackingId(cargo.GetTrackingId())
+
+		// Verify
+		require.NoError(t, err)
+		assertSameCargo(t, cargo, found)
+		require.NotNil(t, found.GetEstimatedTimeOfArrival())
+		assert.True(t, cargo.GetEstimatedTimeOfArrival().Equal(*found.GetEstimatedTimeOfArrival()))
+		assert.Equal(t, cargo.GetDelivery().NextExpectedActivity, found.GetDelivery().NextExpectedActivity)
+	})
+
+	t.Run("should return error for unknown tracking ID", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+
+		// Execute
+		_, err := repo.FindByTrackingId(bookingdomain.NewTrackingId())
+
+		// Verify
+		var notFound bookingdomain.CargoNotFoundError
+		assert.ErrorAs(t, err, &notFound)
+		assert.Contains(t, err.Error(), "not found")
+	})
+
+	t.Run("should replace stored state on update", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		cargo := newCargo(t, "USNYC", "DEHAM")
+		require.NoError(t, repo.Store(cargo))
+
+		loaded, err := repo.FindByTrackingId(cargo.GetTrackingId())
+		require.NoError(t, err)
+		require.NoError(t, loaded.Cancel("customer withdrew the booking"))
+
+		// Execute
+		require.NoError(t, repo.Update(loaded))
+		found, err := repo.FindByTrackingId(cargo.GetTrackingId())
+
+		// Verify
+		require.NoError(t, err)
+		assert.True(t, found.GetDelivery().IsCancelled())
+		assertSameCargo(t, loaded, found)
+	})
+
+	t.Run("should store new cargo as version 1 and increment version on update", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		cargo := newCargo(t, "USNYC", "DEHAM")
+		require.NoError(t, repo.Store(cargo))
+		stored, err := repo.FindByTrackingId(cargo.GetTrackingId())
+		require.NoError(t, err)
+
+		// Execute
+		require.NoError(t, repo.Update(stored))
+		updated, err := repo.FindByTrackingId(cargo.GetTrackingId())
+
+		// Verify
+		require.NoError(t, err)
+		assert.Equal(t, 1, stored.GetVersion())
+		assert.Equal(t, 2, updated.GetVersion())
+	})
+
+	t.Run("should reject update of a stale version", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		cargo := newCargo(t, "USNYC", "DEHAM")
+		require.NoError(t, repo.Store(cargo))
+
+		first, err := repo.FindByTrackingId(cargo.GetTrackingId())
+		require.NoError(t, err)
+		second, err := repo.FindByTrackingId(cargo.GetTrackingId())
+		require.NoError(t, err)
+
+		require.NoError(t, first.Cancel("customer withdrew the booking"))
+		require.NoError(t, repo.Update(first))
+
+		// Execute
+		err = repo.Update(second)
+
+		// Verify
+		var conflict bookingdomain.ConcurrencyConflictError
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, cargo.GetTrackingId(), conflict.TrackingId)
+		found, err := repo.FindByTrackingId(cargo.GetTrackingId())
+		require.NoError(t, err)
+		assert.True(t, found.GetDelivery().IsCancelled())
+		assert.Equal(t, 2, found.GetVersion())
+	})
+
+	t.Run("should reject storing a tracking ID twice", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		cargo := newCargo(t, "USNYC", "DEHAM")
+		require.NoError(t, repo.Store(cargo))
+
+		// Execute
+		err := repo.Store(cargo)
+
+		// Verify
+		var conflict bookingdomain.ConcurrencyConflictError
+		assert.ErrorAs(t, err, &conflict)
+	})
+
+	t.Run("should return error when updating unknown cargo", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+
+		// Execute
+		err := repo.Update(newCargo(t, "USNYC", "DEHAM"))
+
+		// Verify
+		var notFound bookingdomain.CargoNotFoundError
+		assert.ErrorAs(t, err, &notFound)
+	})
+
+	t.Run("should find all cargos", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		first := newCargo(t, "USNYC", "DEHAM")
+		second := newRoutedCargo(t)
+		require.NoError(t, repo.Store(first))
+		require.NoError(t, repo.Store(second))
+
+		// Execute
+		cargos, err := repo.FindAll()
+
+		// Verify
+		require.NoError(t, err)
+		assert.ElementsMatch(t,
+			[]string{first.GetTrackingId().String(), second.GetTrackingId().String()},
+			cargoTrackingIds(cargos),
+		)
+	})
+
+	t.Run("should find only active unrouted cargos", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		unrouted := newCargo(t, "USNYC", "DEHAM")
+		routed := newRoutedCargo(t)
+		cancelled := newCargo(t, "USNYC", "CNSHA")
+		require.NoError(t, cancelled.Cancel("duplicate booking"))
+
+		for _, cargo := range []bookingdomain.Cargo{unrouted, routed, cancelled} {
+			require.NoError(t, repo.Store(cargo))
+		}
+
+		// Execute
+		cargos, err := repo.FindUnrouted()
+
+		// Verify
+		require.NoError(t, err)
+		assert.Equal(t, []string{unrouted.GetTrackingId().String()}, cargoTrackingIds(cargos))
+	})
+
+	t.Run("should find cargos matching every filter of a query", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		unrouted := newCargo(t, "USNYC", "DEHAM")
+		routed := newRoutedCargo(t)
+		elsewhere := newCargo(t, "CNSHA", "DEHAM")
+		cancelled := newCargo(t, "USNYC", "DEHAM")
+		require.NoError(t, cancelled.Cancel("duplicate booking"))
+		misdirected := newMisdirectedCargo(t)
+
+		for _, cargo := range []bookingdomain.Cargo{unrouted, routed, elsewhere, cancelled, misdirected} {
+			require.NoError(t, repo.Store(cargo))
+		}
+
+		deadline := unrouted.GetRouteSpecification().ArrivalDeadline
+		tests := []struct {
+			name     string
+			query    bookingdomain.CargoQuery
+			expected []bookingdomain.Cargo
+		}{
+			{"routing status", bookingdomain.CargoQuery{RoutingStatus: bookingdomain.RoutingStatusRouted}, []bookingdomain.Cargo{routed}},
+			{"transport status", bookingdomain.CargoQuery{TransportStatus: bookingdomain.TransportStatusCancelled}, []bookingdomain.Cargo{cancelled}},
+			{"origin", bookingdomain.CargoQuery{Origin: "CNSHA"}, []bookingdomain.Cargo{elsewhere}},
+			{"destination", bookingdomain.CargoQuery{Destination: "CNSHA"}, []bookingdomain.Cargo{misdirected}},
+			{"misdirected", bookingdomain.CargoQuery{RoutingStatus: bookingdomain.RoutingStatusMisdirected}, []bookingdomain.Cargo{misdirected}},
+			{"deadline range", bookingdomain.CargoQuery{DeadlineFrom: deadline.Add(time.Hour), DeadlineTo: deadline.Add(20 * 24 * time.Hour)}, []bookingdomain.Cargo{misdirected}},
+			{"combined", bookingdomain.CargoQuery{Origin: "USNYC", RoutingStatus: bookingdomain.RoutingStatusNotRouted, TransportStatus: bookingdomain.TransportStatusNotReceived}, []bookingdomain.Cargo{unrouted}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// Execute
+				page, err := repo.FindByQuery(tt.query)
+
+				// Verify
+				require.NoError(t, err)
+				assert.ElementsMatch(t, cargoTrackingIds(tt.expected), cargoTrackingIds(page.Cargos))
+				assert.Empty(t, page.NextCursor)
+			})
+		}
+	})
+
+	t.Run("should find overdue cargos that are neither delivered nor cancelled", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		active := newRoutedCargo(t)
+		cancelled := newCargo(t, "USNYC", "DEHAM")
+		require.NoError(t, cancelled.Cancel("duplicate booking"))
+		later := newCargo(t, "USNYC", "DEHAM")
+		require.NoError(t, later.ChangeArrivalDeadline(baseTime().Add(90*24*time.Hour)))
+
+		for _, cargo := range []bookingdomain.Cargo{active, cancelled, later} {
+			require.NoError(t, repo.Store(cargo))
+		}
+
+		// Execute
+		page, err := repo.FindByQuery(bookingdomain.CargoQuery{Overdue: true, AsOf: baseTime().Add(60 * 24 * time.Hour)})
+
+		// Verify
+		require.NoError(t, err)
+		assert.Equal(t, []string{active.GetTrackingId().String()}, cargoTrackingIds(page.Cargos))
+	})
+
+	t.Run("should find cargos still to be delivered along a voyage", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		routed := newRoutedCargo(t)
+		cancelled := newRoutedCargo(t)
+		require.NoError(t, cancelled.Cancel("duplicate booking"))
+		unrouted := newCargo(t, "USNYC", "DEHAM")
+
+		for _, cargo := range []bookingdomain.Cargo{routed, cancelled, unrouted} {
+			require.NoError(t, repo.Store(cargo))
+		}
+
+		// Execute
+		onVoyage, err := repo.FindByQuery(bookingdomain.CargoQuery{OnVoyage: "V002"})
+		require.NoError(t, err)
+		onOtherVoyage, err := repo.FindByQuery(bookingdomain.CargoQuery{OnVoyage: "V003"})
+		require.NoError(t, err)
+
+		// Verify
+		assert.Equal(t, []string{routed.GetTrackingId().String()}, cargoTrackingIds(onVoyage.Cargos))
+		assert.Empty(t, onOtherVoyage.Cargos)
+	})
+
+	t.Run("should page through cargos in sort order without skipping or repeating", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		var expected []bookingdomain.Cargo
+		for i := 0; i < 5; i++ {
+			cargo := newCargo(t, "USNYC", "DEHAM")
+			// Two cargos share a deadline, so the tracking ID has to break the tie
+			require.NoError(t, cargo.ChangeArrivalDeadline(baseTime().Add(time.Duration(10+i/2*10)*24*time.Hour)))
+			require.NoError(t, repo.Store(cargo))
+			expected = append(expected, cargo)
+		}
+		query := bookingdomain.CargoQuery{SortBy: bookingdomain.CargoSortByArrivalDeadline, Order: bookingdomain.SortDescending, Limit: 2}
+		sort.Slice(expected, func(i, j int) bool { return query.Less(expected[i], expected[j]) })
+
+		// Execute
+		var listed []bookingdomain.Cargo
+		pages := 0
+		for {
+			page, err := repo.FindByQuery(query)
+			require.NoError(t, err)
+			listed = append(listed, page.Cargos...)
+			pages++
+			if page.NextCursor == "" {
+				break
+			}
+			query.Cursor = page.NextCursor
+		}
+
+		// Verify
+		assert.Equal(t, 3, pages)
+		assert.Equal(t, cargoTrackingIds(expected), cargoTrackingIds(listed))
+		for i := 1; i < len(listed); i++ {
+			assert.False(t, listed[i].GetRouteSpecification().ArrivalDeadline.After(listed[i-1].GetRouteSpecification().ArrivalDeadline))
+		}
+	})
+
+	t.Run("should compare deadlines given with different offsets as instants", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		deadline := baseTime().Add(10 * 24 * time.Hour)
+		// Read on the clock of their offsets, the later deadline comes first
+		earlier := newCargo(t, "USNYC", "DEHAM")
+		require.NoError(t, earlier.ChangeArrivalDeadline(deadline.In(time.FixedZone("UTC+9", 9*60*60))))
+		require.NoError(t, repo.Store(earlier))
+		later := newCargo(t, "USNYC", "DEHAM")
+		require.NoError(t, later.ChangeArrivalDeadline(deadline.Add(time.Hour).In(time.FixedZone("UTC-5", -5*60*60))))
+		require.NoError(t, repo.Store(later))
+
+		// Execute
+		sorted, err := repo.FindByQuery(bookingdomain.CargoQuery{SortBy: bookingdomain.CargoSortByArrivalDeadline})
+		require.NoError(t, err)
+		filtered, err := repo.FindByQuery(bookingdomain.CargoQuery{DeadlineTo: deadline.Add(30 * time.Minute).In(time.FixedZone("UTC+2", 2*60*60))})
+		require.NoError(t, err)
+
+		// Verify
+		assert.Equal(t, cargoTrackingIds([]bookingdomain.Cargo{earlier, later}), cargoTrackingIds(sorted.Cargos))
+		assert.Equal(t, cargoTrackingIds([]bookingdomain.Cargo{earlier}), cargoTrackingIds(filtered.Cargos))
+	})
+
+	t.Run("should page through cargos by tracking ID by default", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		var ids []string
+		for i := 0; i < 3; i++ {
+			cargo := newCargo(t, "USNYC", "DEHAM")
+			require.NoError(t, repo.Store(cargo))
+			ids = append(ids, cargo.GetTrackingId().String())
+		}
+		sort.Strings(ids)
+
+		// Execute
+		first, err := repo.FindByQuery(bookingdomain.CargoQuery{Limit: 2})
+		require.NoError(t, err)
+		second, err := repo.FindByQuery(bookingdomain.CargoQuery{Limit: 2, Cursor: first.NextCursor})
+		require.NoError(t, err)
+
+		// Verify
+		assert.Equal(t, ids[:2], cargoTrackingIds(first.Cargos))
+		assert.Equal(t, ids[2:], cargoTrackingIds(second.Cargos))
+		assert.Empty(t, second.NextCursor)
+	})
+
+	t.Run("should reject a cursor of a listing in another sort order", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		for i := 0; i < 2; i++ {
+			require.NoError(t, repo.Store(newCargo(t, "USNYC", "DEHAM")))
+		}
+		page, err := repo.FindByQuery(bookingdomain.CargoQuery{Limit: 1})
+		require.NoError(t, err)
+
+		// Execute
+		_, err = repo.FindByQuery(bookingdomain.CargoQuery{SortBy: bookingdomain.CargoSortByOrigin, Cursor: page.NextCursor})
+
+		// Verify
+		var validationErr bookingdomain.DomainValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+}
+
+// CargoOutboxContract verifies that a CargoRepository records cargo events in its outbox; newRepo must return an empty
+// repository together with the outbox it writes to
+func CargoOutboxContract(t *testing.T, newRepo func(t *testing.T) (bookingsecondary.CargoRepository, outbox.Store)) {
+	t.Run("should record cargo events in order on store", func(t *testing.T) {
+		// Setup
+		repo, store := newRepo(t)
+		cargo := newRoutedCargo(t)
+
+		// Execute
+		require.NoError(t, repo.Store(cargo))
+		pending, err := store.FindPending(outboxEventNames, 10, 100)
+
+		// Verify
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			bookingdomain.CargoBookedEvent{}.EventName(),
+			bookingdomain.CargoRoutedEvent{}.EventName(),
+		}, eventNames(pending))
+		for _, message := range pending {
+			assert.NotEmpty(t, message.Id)
+			assert.NotEmpty(t, message.Payload)
+			assert.Zero(t, message.Attempts)
+		}
+	})
+
+	t.Run("should record only new events on update of a reloaded cargo", func(t *testing.T) {
+		// Setup
+		repo, store := newRepo(t)
+		cargo := newCargo(t, "USNYC", "DEHAM")
+		require.NoError(t, repo.Store(cargo))
+
+		reloaded, err := repo.FindByTrackingId(cargo.GetTrackingId())
+		require.NoError(t, err)
+		require.NoError(t, reloaded.Cancel("customer withdrew the booking"))
+
+		// Execute
+		require.NoError(t, repo.Update(reloaded))
+		pending, err := store.FindPending(outboxEventNames, 10, 100)
+
+		// Verify
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			bookingdomain.CargoBookedEvent{}.EventName(),
+			bookingdomain.CargoCancelledEvent{}.EventName(),
+		}, eventNames(pending))
+	})
+
+	t.Run("should not record events of a rejected update", func(t *testing.T) {
+		// Setup
+		repo, store := newRepo(t)
+		cargo := newCargo(t, "USNYC", "DEHAM")
+		require.NoError(t, repo.Store(cargo))
+
+		stale, err := repo.FindByTrackingId(cargo.GetTrackingId())
+		require.NoError(t, err)
+		current, err := repo.FindByTrackingId(cargo.GetTrackingId())
+		require.NoError(t, err)
+		require.NoError(t, repo.Update(current))
+
+		require.NoError(t, stale.Cancel("customer withdrew the booking"))
+
+		// Execute
+		err = repo.Update(stale)
+		pending, findErr := store.FindPending(outboxEventNames, 10, 100)
+
+		// Verify
+		require.Error(t, err)
+		require.NoError(t, findErr)
+		assert.Equal(t, []string{bookingdomain.CargoBookedEvent{}.EventName()}, eventNames(pending))
+	})
+
+	t.Run("should not return dispatched messages", func(t *testing.T) {
+		// Setup
+		repo, store := newRepo(t)
+		require.NoError(t, repo.Store(newRoutedCargo(t)))
+		pending, err := store.FindPending(outboxEventNames, 10, 100)
+		require.NoError(t, err)
+		require.Len(t, pending, 2)
+
+		// Execute
+		require.NoError(t, store.MarkDispatched(pending[0].Id))
+		remaining, err := store.FindPending(outboxEventNames, 10, 100)
+
+		// Verify
+		require.NoError(t, err)
+		assert.Equal(t, []string{pending[1].Id}, messageIds(remaining))
+	})
+
+	t.Run("should count failed attempts and stop returning exhausted messages", func(t *testing.T) {
+		// Setup
+		repo, store := newRepo(t)
+		require.NoError(t, repo.Store(newCargo(t, "USNYC", "DEHAM")))
+		pending, err := store.FindPending(outboxEventNames, 2, 100)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		id := pending[0].Id
+
+		// Execute
+		require.NoError(t, store.MarkFailed(id, "subscriber unavailable"))
+		afterFirst, err := store.FindPending(outboxEventNames, 2, 100)
+		require.NoError(t, err)
+		require.NoError(t, store.MarkFailed(id, "subscriber still unavailable"))
+		afterSecond, err := store.FindPending(outboxEventNames, 2, 100)
+		require.NoError(t, err)
+
+		// Verify
+		require.Len(t, afterFirst, 1)
+		assert.Equal(t, 1, afterFirst[0].Attempts)
+		assert.Equal(t, "subscriber unavailable", afterFirst[0].LastError)
+		assert.Empty(t, afterSecond)
+	})
+
+	t.Run("should limit the number of pending messages returned", func(t *testing.T) {
+		// Setup
+		repo, store := newRepo(t)
+		require.NoError(t, repo.Store(newRoutedCargo(t)))
+
+		// Execute
+		pending, err := store.FindPending(outboxEventNames, 10, 1)
+
+		// Verify
+		require.NoError(t, err)
+		assert.Equal(t, []string{bookingdomain.CargoBookedEvent{}.EventName()}, eventNames(pending))
+	})
+
+	t.Run("should return only messages of the given events", func(t *testing.T) {
+		// Setup
+		repo, store := newRepo(t)
+		require.NoError(t, repo.Store(newRoutedCargo(t)))
+
+		// Execute
+		pending, err := store.FindPending([]string{bookingdomain.CargoRoutedEvent{}.EventName()}, 10, 1)
+
+		// Verify
+		require.NoError(t, err)
+		assert.Equal(t, []string{bookingdomain.CargoRoutedEvent{}.EventName()}, eventNames(pending))
+	})
+
+	t.Run("should return error when settling unknown message", func(t *testing.T) {
+		// Setup
+		_, store := newRepo(t)
+
+		// Execute
+		dispatchErr := store.MarkDispatched("00000000-0000-0000-0000-000000000000")
+		failErr := store.MarkFailed("00000000-0000-0000-0000-000000000000", "unknown")
+
+		// Verify
+		assert.ErrorContains(t, dispatchErr, "not found")
+		assert.ErrorContains(t, failErr, "not found")
+	})
+}
+
+// RerouteWorklistContract verifies a RerouteWorklist implementation; newWorklist must return an empty worklist
+func RerouteWorklistContract(t *testing.T, newWorklist func(t *testing.T) bookingsecondary.RerouteWorklist) {
+	t.Run("should keep tasks oldest first", func(t *testing.T) {
+		// Setup
+		worklist := newWorklist(t)
+		later := newRerouteTask(t, baseTime().Add(time.Hour))
+		earlier := newRerouteTask(t, baseTime())
+
+		// Execute
+		require.NoError(t, worklist.Put(later))
+		require.NoError(t, worklist.Put(earlier))
+		tasks, err := worklist.FindAll()
+
+		// Verify
+		require.NoError(t, err)
+		require.Len(t, tasks, 2)
+		assertSameRerouteTask(t, earlier, tasks[0])
+		assertSameRerouteTask(t, later, tasks[1])
+	})
+
+	t.Run("should replace the earlier task of the same cargo", func(t *testing.T) {
+		// Setup
+		worklist := newWorklist(t)
+		earlier := newRerouteTask(t, baseTime())
+		require.NoError(t, worklist.Put(earlier))
+		replacement := newRerouteTask(t, baseTime().Add(time.Hour))
+		replacement.TrackingId = earlier.TrackingId
+		replacement.Candidates = nil
+
+		// Execute
+		err := worklist.Put(replacement)
+
+		// Verify
+		require.NoError(t, err)
+		tasks, err := worklist.FindAll()
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		assertSameRerouteTask(t, replacement, tasks[0])
+	})
+
+	t.Run("should remove task", func(t *testing.T) {
+		// Setup
+		worklist := newWorklist(t)
+		removed := newRerouteTask(t, baseTime())
+		kept := newRerouteTask(t, baseTime().Add(time.Hour))
+		require.NoError(t, worklist.Put(removed))
+		require.NoError(t, worklist.Put(kept))
+
+		// Execute
+		err := worklist.Remove(removed.TrackingId)
+
+		// Verify
+		require.NoError(t, err)
+		tasks, err := worklist.FindAll()
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		assert.Equal(t, kept.TrackingId, tasks[0].TrackingId)
+	})
+
+	t.Run("should ignore removal of cargo that is not on the worklist", func(t *testing.T) {
+		// Setup
+		worklist := newWorklist(t)
+
+		// Execute
+		err := worklist.Remove(bookingdomain.NewTrackingId())
+
+		// Verify
+		assert.NoError(t, err)
+	})
+}
+
+// HandlingEventRepositoryContract verifies a HandlingEventRepository implementation; newRepo must return an empty repository
+func HandlingEventRepositoryContract(t *testing.T, newRepo func(t *testing.T) handlingsecondary.HandlingEventRepository) {
+	t.Run("should store and find handling event by ID", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		event := newHandlingEvent(t, "cargo-1", handlingdomain.HandlingEventTypeLoad, "V001", 2*time.Hour)
+
+		// Execute
+		require.NoError(t, repo.Store(event))
+		found, err := repo.FindById(event.GetEventId())
+
+		// Verify
+		require.NoError(t, err)
+		assertSameHandlingEvent(t, event, found)
+	})
+
+	t.Run("should return error for unknown event ID", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+
+		// Execute
+		_, err := repo.FindById(handlingdomain.NewHandlingEventId())
+
+		// Verify
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+
+	t.Run("should find handling events by tracking ID", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		received := newHandlingEvent(t, "cargo-1", handlingdomain.HandlingEventTypeReceive, "", 3*time.Hour)
+		loaded := newHandlingEvent(t, "cargo-1", handlingdomain.HandlingEventTypeLoad, "V001", 2*time.Hour)
+		unloaded := newHandlingEvent(t, "cargo-1", handlingdomain.HandlingEventTypeUnload, "V001", time.Hour)
+		other := newHandlingEvent(t, "cargo-2", handlingdomain.HandlingEventTypeReceive, "", time.Hour)
+
+		for _, event := range []handlingdomain.HandlingEvent{loaded, other, unloaded, received} {
+			require.NoError(t, repo.Store(event))
+		}
+
+		// Execute
+		events, err := repo.FindByTrackingId("cargo-1")
+
+		// Verify
+		require.NoError(t, err)
+		assert.Equal(t,
+			[]string{received.GetEventId().String(), loaded.GetEventId().String(), unloaded.GetEventId().String()},
+			handlingEventIds(events),
+		)
+	})
+
+	t.Run("should order events completed at the same time by registration time", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		completed := baseTime().Add(-2 * time.Hour)
+		customs, err := handlingdomain.NewHandlingEventFromExisting(handlingdomain.NewHandlingEventId(), "cargo-1",
+			handlingdomain.HandlingEventTypeCustoms, "USNYC", "", completed, completed.Add(time.Minute), "")
+		require.NoError(t, err)
+		loaded, err := handlingdomain.NewHandlingEventFromExisting(handlingdomain.NewHandlingEventId(), "cargo-1",
+			handlingdomain.HandlingEventTypeLoad, "USNYC", "V001", completed, completed.Add(2*time.Minute), "")
+		require.NoError(t, err)
+		require.NoError(t, repo.Store(loaded))
+		require.NoError(t, repo.Store(customs))
+
+		// Execute
+		events, err := repo.FindByTrackingId("cargo-1")
+
+		// Verify
+		require.NoError(t, err)
+		assert.Equal(t, []string{customs.GetEventId().String(), loaded.GetEventId().String()}, handlingEventIds(events))
+	})
+
+	t.Run("should find event under its tracking ID after it is stored again for another cargo", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		event := newHandlingEvent(t, "cargo-1", handlingdomain.HandlingEventTypeReceive, "", time.Hour)
+		require.NoError(t, repo.Store(event))
+		moved, err := handlingdomain.NewHandlingEventFromExisting(event.GetEventId(), "cargo-2", event.GetEventType(),
+			event.GetLocation(), event.GetVoyageNumber(), event.GetCompletionTime(), event.GetRegistrationTime(), event.GetIdempotencyKey())
+		require.NoError(t, err)
+
+		// Execute
+		require.NoError(t, repo.Store(moved))
+
+		// Verify
+		previous, err := repo.FindByTrackingId("cargo-1")
+		require.NoError(t, err)
+		assert.Empty(t, previous)
+		current, err := repo.FindByTrackingId("cargo-2")
+		require.NoError(t, err)
+		assert.Equal(t, []string{event.GetEventId().String()}, handlingEventIds(current))
+	})
+
+	t.Run("should return no events for unknown tracking ID", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+
+		// Execute
+		events, err := repo.FindByTrackingId("unknown-cargo")
+
+		// Verify
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("should find handling event by idempotency key", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		event := newHandlingEvent(t, "cargo-1", handlingdomain.HandlingEventTypeLoad, "V001", 2*time.Hour).WithIdempotencyKey("scan-42")
+		require.NoError(t, repo.Store(newHandlingEvent(t, "cargo-1", handlingdomain.HandlingEventTypeReceive, "", 3*time.Hour)))
+		require.NoError(t, repo.Store(event))
+
+		// Execute
+		found, ok, err := repo.FindByIdempotencyKey("scan-42")
+
+		// Verify
+		require.NoError(t, err)
+		require.True(t, ok)
+		assertSameHandlingEvent(t, event, found)
+		assert.Equal(t, "scan-42", found.GetIdempotencyKey())
+	})
+
+	t.Run("should not find unknown idempotency key", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		require.NoError(t, repo.Store(newHandlingEvent(t, "cargo-1", handlingdomain.HandlingEventTypeReceive, "", time.Hour)))
+
+		// Execute
+		_, ok, err := repo.FindByIdempotencyKey("unknown-key")
+
+		// Verify
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("should reject idempotency key of another event", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		first := newHandlingEvent(t, "cargo-1", handlingdomain.HandlingEventTypeReceive, "", 2*time.Hour).WithIdempotencyKey("scan-42")
+		second := newHandlingEvent(t, "cargo-2", handlingdomain.HandlingEventTypeReceive, "", time.Hour).WithIdempotencyKey("scan-42")
+		require.NoError(t, repo.Store(first))
+
+		// Execute
+		err := repo.Store(second)
+
+		// Verify
+		assert.Error(t, err)
+		found, ok, err := repo.FindByIdempotencyKey("scan-42")
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, first.GetEventId(), found.GetEventId())
+	})
+
+	t.Run("should reject another event recording the same handling", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		first := newHandlingEvent(t, "cargo-1", handlingdomain.HandlingEventTypeLoad, "V001", time.Hour)
+		second := newHandlingEvent(t, "cargo-1", handlingdomain.HandlingEventTypeLoad, "V001", time.Hour)
+		require.NoError(t, repo.Store(first))
+
+		// Execute
+		err := repo.Store(second)
+
+		// Verify
+		assert.Error(t, err)
+		events, err := repo.FindByTrackingId("cargo-1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{first.GetEventId().String()}, handlingEventIds(events))
+	})
+
+	t.Run("should append event to an unchanged history", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		first := newHandlingEvent(t, "cargo-1", handlingdomain.HandlingEventTypeReceive, "", 2*time.Hour)
+		second := newHandlingEvent(t, "cargo-1", handlingdomain.HandlingEventTypeLoad, "V001", time.Hour)
+		require.NoError(t, repo.Append(first, 0))
+
+		// Execute
+		err := repo.Append(second, 1)
+
+		// Verify
+		require.NoError(t, err)
+		events, err := repo.FindByTrackingId("cargo-1")
+		require.NoError(t, err)
+		assert.Equal(t, handlingEventIds([]handlingdomain.HandlingEvent{first, second}), handlingEventIds(events))
+	})
+
+	t.Run("should reject append to a history that changed", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		first := newHandlingEvent(t, "cargo-1", handlingdomain.HandlingEventTypeReceive, "", 2*time.Hour)
+		second := newHandlingEvent(t, "cargo-1", handlingdomain.HandlingEventTypeLoad, "V001", time.Hour)
+		require.NoError(t, repo.Store(newHandlingEvent(t, "cargo-2", handlingdomain.HandlingEventTypeReceive, "", time.Hour)))
+		require.NoError(t, repo.Append(first, 0))
+
+		// Execute
+		err := repo.Append(second, 0)
+
+		// Verify
+		var changed handlingdomain.HandlingHistoryChangedError
+		require.ErrorAs(t, err, &changed)
+		assert.Equal(t, "cargo-1", changed.TrackingId)
+		events, err := repo.FindByTrackingId("cargo-1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{first.GetEventId().String()}, handlingEventIds(events))
+	})
+
+	t.Run("should let one of concurrent appends to the same history through", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		var wg sync.WaitGroup
+		errs := make(chan error, 5)
+
+		// Execute
+		for i := 1; i <= cap(errs); i++ {
+			event := newHandlingEvent(t, "cargo-1", handlingdomain.HandlingEventTypeReceive, "", time.Duration(i)*time.Hour)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- repo.Append(event, 0)
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		// Verify
+		stored := 0
+		for err := range errs {
+			var changed handlingdomain.HandlingHistoryChangedError
+			if err == nil {
+				stored++
+			} else {
+				assert.ErrorAs(t, err, &changed)
+			}
+		}
+		assert.Equal(t, 1, stored)
+		events, err := repo.FindByTrackingId("cargo-1")
+		require.NoError(t, err)
+		assert.Len(t, events, 1)
+	})
+
+	t.Run("should append events of several cargos at once", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		received := newHandlingEvent(t, "cargo-1", handlingdomain.HandlingEventTypeReceive, "", 2*time.Hour)
+		loaded := newHandlingEvent(t, "cargo-1", handlingdomain.HandlingEventTypeLoad, "V001", time.Hour)
+		other := newHandlingEvent(t, "cargo-2", handlingdomain.HandlingEventTypeReceive, "", time.Hour)
+		require.NoError(t, repo.Store(received))
+
+		// Execute
+		err := repo.AppendAll([]handlingdomain.HandlingEvent{loaded, other}, map[string]int{"cargo-1": 1, "cargo-2": 0})
+
+		// Verify
+		require.NoError(t, err)
+		events, err := repo.FindByTrackingId("cargo-1")
+		require.NoError(t, err)
+		assert.Equal(t, handlingEventIds([]handlingdomain.HandlingEvent{received, loaded}), handlingEventIds(events))
+		events, err = repo.FindByTrackingId("cargo-2")
+		require.NoError(t, err)
+		assert.Equal(t, []string{other.GetEventId().String()}, handlingEventIds(events))
+	})
+
+	t.Run("should append none of the events if a history changed", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		first := newHandlingEvent(t, "cargo-1", handlingdomain.HandlingEventTypeReceive, "", 2*time.Hour)
+		second := newHandlingEvent(t, "cargo-2", handlingdomain.HandlingEventTypeReceive, "", time.Hour)
+		require.NoError(t, repo.Store(newHandlingEvent(t, "cargo-2", handlingdomain.HandlingEventTypeClaim, "", time.Minute)))
+
+		// Execute
+		err := repo.AppendAll([]handlingdomain.HandlingEvent{first, second}, map[string]int{"cargo-1": 0, "cargo-2": 0})
+
+		// Verify
+		var changed handlingdomain.HandlingHistoryChangedError
+		require.ErrorAs(t, err, &changed)
+		assert.Equal(t, "cargo-2", changed.TrackingId)
+		events, err := repo.FindByTrackingId("cargo-1")
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("should append none of the events if two record the same handling", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		received := newHandlingEvent(t, "cargo-1", handlingdomain.HandlingEventTypeReceive, "", 2*time.Hour)
+		first := newHandlingEvent(t, "cargo-2", handlingdomain.HandlingEventTypeLoad, "V001", time.Hour)
+		second := newHandlingEvent(t, "cargo-2", handlingdomain.HandlingEventTypeLoad, "V001", time.Hour)
+
+		// Execute
+		err := repo.AppendAll([]handlingdomain.HandlingEvent{received, first, second}, map[string]int{"cargo-1": 0, "cargo-2": 0})
+
+		// Verify
+		assert.Error(t, err)
+		events, err := repo.FindAll()
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("should find all handling events", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		first := newHandlingEvent(t, "cargo-1", handlingdomain.HandlingEventTypeReceive, "", 2*time.Hour)
+		second := newHandlingEvent(t, "cargo-2", handlingdomain.HandlingEventTypeReceive, "", time.Hour)
+		require.NoError(t, repo.Store(first))
+		require.NoError(t, repo.Store(second))
+
+		// Execute
+		events, err := repo.FindAll()
+
+		// Verify
+		require.NoError(t, err)
+		assert.ElementsMatch(t,
+			[]string{first.GetEventId().String(), second.GetEventId().String()},
+			handlingEventIds(events),
+		)
+	})
+
+	t.Run("should find handling events matching every filter of a query", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		received := newHandlingEvent(t, "cargo-1", handlingdomain.HandlingEventTypeReceive, "", 3*time.Hour)
+		loaded := newHandlingEvent(t, "cargo-1", handlingdomain.HandlingEventTypeLoad, "V001", 2*time.Hour)
+		unloaded, err := handlingdomain.NewHandlingEvent("cargo-1", handlingdomain.HandlingEventTypeUnload, "NLRTM", "V001", baseTime().Add(-time.Hour))
+		require.NoError(t, err)
+		other := newHandlingEvent(t, "cargo-2", handlingdomain.HandlingEventTypeLoad, "V002", 2*time.Hour)
+
+		for _, event := range []handlingdomain.HandlingEvent{received, loaded, unloaded, other} {
+			require.NoError(t, repo.Store(event))
+		}
+
+		tests := []struct {
+			name     string
+			query    handlingdomain.HandlingEventQuery
+			expected []handlingdomain.HandlingEvent
+		}{
+			{"tracking ID", handlingdomain.HandlingEventQuery{TrackingId: "cargo-1"}, []handlingdomain.HandlingEvent{received, loaded, unloaded}},
+			{"event type", handlingdomain.HandlingEventQuery{EventType: handlingdomain.HandlingEventTypeLoad}, []handlingdomain.HandlingEvent{loaded, other}},
+			{"location", handlingdomain.HandlingEventQuery{Location: "NLRTM"}, []handlingdomain.HandlingEvent{unloaded}},
+			{"voyage number", handlingdomain.HandlingEventQuery{VoyageNumber: "V001"}, []handlingdomain.HandlingEvent{loaded, unloaded}},
+			{"completion time range", handlingdomain.HandlingEventQuery{
+				CompletedFrom:   loaded.GetCompletionTime(),
+				CompletedBefore: unloaded.GetCompletionTime(),
+			}, []handlingdomain.HandlingEvent{loaded, other}},
+			{"combined", handlingdomain.HandlingEventQuery{TrackingId: "cargo-1", VoyageNumber: "V001", EventType: handlingdomain.HandlingEventTypeUnload}, []handlingdomain.HandlingEvent{unloaded}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// Execute
+				page, err := repo.FindByQuery(tt.query)
+
+				// Verify
+				require.NoError(t, err)
+				assert.ElementsMatch(t, handlingEventIds(tt.expected), handlingEventIds(page.Events))
+				assert.Empty(t, page.NextCursor)
+			})
+		}
+	})
+
+	t.Run("should page through handling events by completion time without skipping or repeating", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		var expected []handlingdomain.HandlingEvent
+		for i := 0; i < 5; i++ {
+			// Pairs of events share a completion time, so the event ID has to break the tie
+			event := newHandlingEvent(t, fmt.Sprintf("cargo-%d", i), handlingdomain.HandlingEventTypeReceive, "", time.Duration(5-i/2)*time.Hour)
+			require.NoError(t, repo.Store(event))
+			expected = append(expected, event)
+		}
+		query := handlingdomain.HandlingEventQuery{Limit: 2}
+		sort.Slice(expected, func(i, j int) bool { return query.Less(expected[i], expected[j]) })
+
+		// Execute
+		var listed []handlingdomain.HandlingEvent
+		pages := 0
+		for {
+			page, err := repo.FindByQuery(query)
+			require.NoError(t, err)
+			listed = append(listed, page.Events...)
+			pages++
+			if page.NextCursor == "" {
+				break
+			}
+			query.Cursor = page.NextCursor
+		}
+
+		// Verify
+		assert.Equal(t, 3, pages)
+		assert.Equal(t, handlingEventIds(expected), handlingEventIds(listed))
+	})
+
+	t.Run("should order handling events completed at other offsets by instant", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		now := baseTime()
+		later, err := handlingdomain.NewHandlingEvent("cargo-1", handlingdomain.HandlingEventTypeReceive, "USNYC", "",
+			now.Add(-time.Hour).In(time.FixedZone("UTC-5", -5*60*60)))
+		require.NoError(t, err)
+		earlier, err := handlingdomain.NewHandlingEvent("cargo-2", handlingdomain.HandlingEventTypeReceive, "USNYC", "",
+			now.Add(-2*time.Hour).In(time.FixedZone("UTC+9", 9*60*60)))
+		require.NoError(t, err)
+		require.NoError(t, repo.Store(later))
+		require.NoError(t, repo.Store(earlier))
+
+		// Execute
+		page, err := repo.FindByQuery(handlingdomain.HandlingEventQuery{CompletedFrom: now.Add(-90 * time.Minute)})
+		require.NoError(t, err)
+		all, err := repo.FindByQuery(handlingdomain.HandlingEventQuery{})
+		require.NoError(t, err)
+
+		// Verify
+		assert.Equal(t, handlingEventIds([]handlingdomain.HandlingEvent{later}), handlingEventIds(page.Events))
+		assert.Equal(t, handlingEventIds([]handlingdomain.HandlingEvent{earlier, later}), handlingEventIds(all.Events))
+	})
+}
+
+// HandlingEventOutboxContract verifies that a HandlingEventRepository records handling events in its outbox; newRepo
+// must return an empty repository together with the outbox it writes to
+func HandlingEventOutboxContract(t *testing.T, newRepo func(t *testing.T) (handlingsecondary.HandlingEventRepository, outbox.Store)) {
+	t.Run("should record the registered event on store", func(t *testing.T) {
+		// Setup
+		repo, store := newRepo(t)
+		event := newHandlingEvent(t, "cargo-1", handlingdomain.HandlingEventTypeReceive, "", time.Hour)
+
+		// Execute
+		require.NoError(t, repo.Store(event))
+		pending, err := store.FindPending(outboxEventNames, 10, 100)
+
+		// Verify
+		require.NoError(t, err)
+		assert.Equal(t, []string{handlingdomain.HandlingEventRegisteredEvent{}.EventName()}, eventNames(pending))
+		assert.Contains(t, string(pending[0].Payload), event.GetEventId().String())
+	})
+
+	t.Run("should not record the registered event again when a loaded event is stored", func(t *testing.T) {
+		// Setup
+		repo, store := newRepo(t)
+		event := newHandlingEvent(t, "cargo-1", handlingdomain.HandlingEventTypeReceive, "", time.Hour)
+		require.NoError(t, repo.Store(event))
+		loaded, err := repo.FindById(event.GetEventId())
+		require.NoError(t, err)
+
+		// Execute
+		require.NoError(t, repo.Store(loaded))
+		pending, err := store.FindPending(outboxEventNames, 10, 100)
+
+		// Verify
+		require.NoError(t, err)
+		assert.Len(t, pending, 1)
+	})
+
+	t.Run("should not record events of a rejected store", func(t *testing.T) {
+		// Setup
+		repo, store := newRepo(t)
+		require.NoError(t, repo.Store(newHandlingEvent(t, "cargo-1", handlingdomain.HandlingEventTypeReceive, "", time.Hour)))
+
+		// Execute
+		err := repo.Store(newHandlingEvent(t, "cargo-1", handlingdomain.HandlingEventTypeReceive, "", time.Hour))
+		pending, findErr := store.FindPending(outboxEventNames, 10, 100)
+
+		// Verify
+		require.Error(t, err)
+		require.NoError(t, findErr)
+		assert.Len(t, pending, 1)
+	})
+}
+
+// HandlingQuarantineContract verifies a HandlingQuarantine implementation; newQuarantine must return an empty quarantine
+func HandlingQuarantineContract(t *testing.T, newQuarantine func(t *testing.T) handlingsecondary.HandlingQuarantine) {
+	t.Run("should keep reports oldest first", func(t *testing.T) {
+		// Setup
+		quarantine := newQuarantine(t)
+		later := newQuarantinedReport("TRACK-1", handlingdomain.HandlingEventTypeUnload, "V100", baseTime().Add(time.Hour))
+		earlier := newQuarantinedReport("TRACK-2", handlingdomain.HandlingEventTypeLoad, "V200", baseTime())
+
+		// Execute
+		require.NoError(t, quarantine.Add(later))
+		require.NoError(t, quarantine.Add(earlier))
+		reports, err := quarantine.FindAll()
+
+		// Verify
+		require.NoError(t, err)
+		require.Len(t, reports, 2)
+		assertSameQuarantinedReport(t, earlier, reports[0])
+		assertSameQuarantinedReport(t, later, reports[1])
+	})
+
+	t.Run("should find report by ID", func(t *testing.T) {
+		// Setup
+		quarantine := newQuarantine(t)
+		report := newQuarantinedReport("TRACK-1", handlingdomain.HandlingEventTypeUnload, "V100", baseTime())
+		require.NoError(t, quarantine.Add(report))
+
+		// Execute
+		found, err := quarantine.FindById(report.Id)
+
+		// Verify
+		require.NoError(t, err)
+		assertSameQuarantinedReport(t, report, found)
+	})
+
+	t.Run("should return not found error for unknown ID", func(t *testing.T) {
+		// Setup
+		quarantine := newQuarantine(t)
+
+		// Execute
+		_, findErr := quarantine.FindById(uuid.NewString())
+		removeErr := quarantine.Remove("not-a-quarantine-id")
+
+		// Verify
+		var notFound handlingdomain.QuarantinedReportNotFoundError
+		assert.ErrorAs(t, findErr, &notFound)
+		assert.ErrorAs(t, removeErr, &notFound)
+	})
+
+	t.Run("should remove report", func(t *testing.T) {
+		// Setup
+		quarantine := newQuarantine(t)
+		removed := newQuarantinedReport("TRACK-1", handlingdomain.HandlingEventTypeUnload, "V100", baseTime())
+		kept := newQuarantinedReport("TRACK-2", handlingdomain.HandlingEventTypeLoad, "V200", baseTime().Add(time.Hour))
+		require.NoError(t, quarantine.Add(removed))
+		require.NoError(t, quarantine.Add(kept))
+
+		// Execute
+		err := quarantine.Remove(removed.Id)
+
+		// Verify
+		require.NoError(t, err)
+		reports, err := quarantine.FindAll()
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		assert.Equal(t, kept.Id, reports[0].Id)
+		_, err = quarantine.FindById(removed.Id)
+		var notFound handlingdomain.QuarantinedReportNotFoundError
+		assert.ErrorAs(t, err, &notFound)
+	})
+}
+
+// LocationRepositoryContract verifies a LocationRepository implementation; newRepo must return an empty repository
+func LocationRepositoryContract(t *testing.T, newRepo func(t *testing.T) routingsecondary.LocationRepository) {
+	t.Run("should store and find location by UN/LOCODE", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		location := newLocation(t, "USNYC", "New York", "US")
+
+		// Execute
+		require.NoError(t, repo.Store(location))
+		found, err := repo.FindByUnLocode(location.GetUnLocode())
+
+		// Verify
+		require.NoError(t, err)
+		assert.Equal(t, location.Data, found.Data)
+	})
+
+	t.Run("should return error for unknown UN/LOCODE", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		unLocode, err := routingdomain.NewUnLocode("XXXXX")
+		require.NoError(t, err)
+
+		// Execute
+		_, err = repo.FindByUnLocode(unLocode)
+
+		// Verify
+		var notFound routingdomain.LocationNotFoundError
+		assert.ErrorAs(t, err, &notFound)
+		assert.Contains(t, err.Error(), "not found")
+	})
+
+	t.Run("should replace location with the same UN/LOCODE", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		require.NoError(t, repo.Store(newLocation(t, "USNYC", "New York", "US")))
+		renamed := newLocation(t, "USNYC", "New York City", "US")
+
+		// Execute
+		require.NoError(t, repo.Store(renamed))
+		locations, err := repo.FindAll()
+
+		// Verify
+		require.NoError(t, err)
+		require.Len(t, locations, 1)
+		assert.Equal(t, "New York City", locations[0].Data.Name)
+	})
+
+	t.Run("should find all locations", func(t *testing.T) {
+		// Setup
+		repo := newRepo(t)
+		require.NoError(t, repo.Store(newLocation(t, "USNYC", "New York", "US")))
+		require.NoError(t, repo.Store(newLocation(t, "DEHAM", "Hamburg", "DE")))
+
+		// Execute
+		locations, err := repo.FindAll()
+
+		// Verify
+		require.NoError(t, err)
+		codes := make([]string, len(locations))
+		for i, location := range locations {
+			codes[i] = location.GetUnLocode().String()
+		}
+		assert.ElementsMatch(t, []string{"USNYC", "DEHAM"}, codes)
+	})
+}
+
+// VoyageRepositoryContract verifies a VoyageRepository implementation; newRepo must return an empty repository
+// together with the empty cargo repository its deletions are guarded by
+func VoyageRepositoryContract(t *testing.T, newRepo func(t *testing.T) (routingsecondary.VoyageRepository, bookingsecondary.CargoRepository)) {
+	t.Run("should store and find voyage by number", func(t *testing.T) {
+		// Setup
+		repo, _ := newRepo(t)
+		voyage := newVoyage(t, "USNYC", "NLRTM", "DEHAM")
+
+		// Execute
+		require.NoError(t, repo.Store(voyage))
+		found, err := repo.FindByVoyageNumber(voyage.GetVoyageNumber())
+
+		// Verify
+		require.NoError(t, err)
+		assertSameVoyage(t, voyage, found)
+	})
+
+	t.Run("should return error for unknown voyage number", func(t *testing.T) {
+		// Setup
+		repo, _ := newRepo(t)
+
+		// Execute
+		_, err := repo.FindByVoyageNumber(routingdomain.NewVoyageNumber())
+
+		// Verify
+		var notFound routingdomain.VoyageNotFoundError
+		assert.ErrorAs(t, err, &notFound)
+		assert.Contains(t, err.Error(), "not found")
+	})
+
+	t.Run("should find all voyages", func(t *testing.T) {
+		// Setup
+		repo, _ := newRepo(t)
+		first := newVoyage(t, "USNYC", "DEHAM")
+		second := newVoyage(t, "DEHAM", "CNSHA")
+		require.NoError(t, repo.Store(first))
+		require.NoError(t, repo.Store(second))
+
+		// Execute
+		voyages, err := repo.FindAll()
+
+		// Verify
+		require.NoError(t, err)
+		assert.ElementsMatch(t,
+			[]string{first.GetVoyageNumber().String(), second.GetVoyageNumber().String()},
+			voyageNumbers(voyages),
+		)
+	})
+
+	t.Run("should find voyages connecting two locations", func(t *testing.T) {
+		// Setup
+		repo, _ := newRepo(t)
+		endToEnd := newVoyage(t, "USNYC", "NLRTM", "DEHAM")
+		singleMovement := newVoyage(t, "GBLON", "USNYC", "DEHAM")
+		unrelated := newVoyage(t, "DEHAM", "USNYC")
+
+		for _, voyage := range []routingdomain.Voyage{endToEnd, singleMovement, unrelated} {
+			require.NoError(t, repo.Store(voyage))
+		}
+
+		origin, err := routingdomain.NewUnLocode("USNYC")
+		require.NoError(t, err)
+		destination, err := routingdomain.NewUnLocode("DEHAM")
+		require.NoError(t, err)
+
+		// Execute
+		voyages, err := repo.FindVoyagesConnecting(origin, destination)
+
+		// Verify
+		require.NoError(t, err)
+		assert.ElementsMatch(t,
+			[]string{endToEnd.GetVoyageNumber().String(), singleMovement.GetVoyageNumber().String()},
+			voyageNumbers(voyages),
+		)
+	})
+
+	t.Run("should replace the schedule of a stored voyage", func(t *testing.T) {
+		// Setup
+		repo, _ := newRepo(t)
+		voyage := newVoyage(t, "USNYC", "NLRTM", "DEHAM")
+		require.NoError(t, repo.Store(voyage))
+
+		rescheduled, err := routingdomain.NewVoyageFromExisting(
+			voyage.GetVoyageNumber(),
+			newVoyage(t, "USNYC", "DEHAM").GetSchedule().Movements,
+		)
+		require.NoError(t, err)
+
+		// Execute
+		require.NoError(t, repo.Store(rescheduled))
+		found, err := repo.FindByVoyageNumber(voyage.GetVoyageNumber())
+
+		// Verify
+		require.NoError(t, err)
+		assertSameVoyage(t, rescheduled, found)
+	})
+
+	t.Run("should delete voyage with its schedule", func(t *testing.T) {
+		// Setup
+		repo, _ := newRepo(t)
+		voyage := newVoyage(t, "USNYC", "DEHAM")
+		kept := newVoyage(t, "DEHAM", "CNSHA")
+		require.NoError(t, repo.Store(voyage))
+		require.NoError(t, repo.Store(kept))
+
+		// Execute
+		err := repo.Delete(voyage.GetVoyageNumber())
+
+		// Verify
+		require.NoError(t, err)
+		_, err = repo.FindByVoyageNumber(voyage.GetVoyageNumber())
+		var notFound routingdomain.VoyageNotFoundError
+		assert.ErrorAs(t, err, &notFound)
+
+		voyages, err := repo.FindAll()
+		require.NoError(t, err)
+		assert.Equal(t, []string{kept.GetVoyageNumber().String()}, voyageNumbers(voyages))
+	})
+
+	t.Run("should return error when deleting unknown voyage", func(t *testing.T) {
+		// Setup
+		repo, _ := newRepo(t)
+
+		// Execute
+		err := repo.Delete(routingdomain.NewVoyageNumber())
+
+		// Verify
+		var notFound routingdomain.VoyageNotFoundError
+		assert.ErrorAs(t, err, &notFound)
+	})
+
+	t.Run("should refuse to delete voyage cargo is routed on", func(t *testing.T) {
+		// Setup
+		repo, cargoRepo := newRepo(t)
+		voyage := newVoyage(t, "USNYC", "DEHAM")
+		require.NoError(t, repo.Store(voyage))
+		require.NoError(t, cargoRepo.Store(newCargoRoutedOn(t, voyage)))
+
+		// Execute
+		err := repo.Delete(voyage.GetVoyageNumber())
+
+		// Verify
+		var inUse routingdomain.VoyageInUseError
+		assert.ErrorAs(t, err, &inUse)
+		found, err := repo.FindByVoyageNumber(voyage.GetVoyageNumber())
+		require.NoError(t, err)
+		assertSameVoyage(t, voyage, found)
+	})
+
+	t.Run("should delete voyage once the cargo routed on it is cancelled", func(t *testing.T) {
+		// Setup
+		repo, cargoRepo := newRepo(t)
+		voyage := newVoyage(t, "USNYC", "DEHAM")
+		require.NoError(t, repo.Store(voyage))
+		cargo := newCargoRoutedOn(t, voyage)
+		require.NoError(t, cargo.Cancel("customer withdrew"))
+		require.NoError(t, cargoRepo.Store(cargo))
+
+		// Execute
+		err := repo.Delete(voyage.GetVoyageNumber())
+
+		// Verify
+		require.NoError(t, err)
+		_, err = repo.FindByVoyageNumber(voyage.GetVoyageNumber())
+		var notFound routingdomain.VoyageNotFoundError
+		assert.ErrorAs(t, err, &notFound)
+	})
+}
+
+// Fixtures use whole-second UTC times so every implementation can store them without loss
+func baseTime() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+func newCargo(t *testing.T, origin, destination string) bookingdomain.Cargo {
+	t.Helper()
+	cargo, err := bookingdomain.NewCargo(origin, destination, baseTime().Add(30*24*time.Hour))
+	require.NoError(t, err)
+	return cargo
+}
+
+func newRoutedCargo(t *testing.T) bookingdomain.Cargo {
+	t.Helper()
+	cargo := newCargo(t, "USNYC", "DEHAM")
+
+	start := baseTime().Add(24 * time.Hour)
+	firstLeg, err := bookingdomain.NewLeg("V001", "USNYC", "NLRTM", start, start.Add(5*24*time.Hour))
+	require.NoError(t, err)
+	secondLeg, err := bookingdomain.NewLeg("V002", "NLRTM", "DEHAM", start.Add(6*24*time.Hour), start.Add(7*24*time.Hour))
+	require.NoError(t, err)
+	itinerary, err := bookingdomain.NewItinerary([]bookingdomain.Leg{firstLeg, secondLeg})
+	require.NoError(t, err)
+
+	require.NoError(t, cargo.AssignToRoute(itinerary))
+	return cargo
+}
+
+// newCargoRoutedOn creates cargo routed from USNYC to DEHAM on the voyage
+func newCargoRoutedOn(t *testing.T, voyage routingdomain.Voyage) bookingdomain.Cargo {
+	t.Helper()
+	cargo := newCargo(t, "USNYC", "DEHAM")
+
+	leg, err := bookingdomain.NewLeg(voyage.GetVoyageNumber().String(), "USNYC", "DEHAM", voyage.GetDepartureTime(), voyage.GetArrivalTime())
+	require.NoError(t, err)
+	itinerary, err := bookingdomain.NewItinerary([]bookingdomain.Leg{leg})
+	require.NoError(t, err)
+
+	require.NoError(t, cargo.AssignToRoute(itinerary))
+	return cargo
+}
+
+// newMisdirectedCargo restores a cargo that was handled off its itinerary, on its way to CNSHA
+func newMisdirectedCargo(t *testing.T) bookingdomain.Cargo {
+	t.Helper()
+	routed := newRoutedCargo(t)
+	routeSpec, err := bookingdomain.NewRouteSpecification("USNYC", "CNSHA", baseTime().Add(40*24*time.Hour))
+	require.NoError(t, err)
+	delivery, err := bookingdomain.NewDelivery(bookingdomain.TransportStatusInPort, bookingdomain.RoutingStatusMisdirected, "SESTO", "", false)
+	require.NoError(t, err)
+	delivery.CalculatedAt = baseTime()
+
+	cargo, err := bookingdomain.NewCargoFromExisting(bookingdomain.NewTrackingId(), routeSpec, routed.GetItinerary(), delivery, routed.GetBookedAt(), routed.GetRoutedAt())
+	require.NoError(t, err)
+	return cargo
+}
+
+// newRerouteTask creates a task for cargo misdirected to NLRTM, with one candidate on to DEHAM
+func newRerouteTask(t *testing.T, createdAt time.Time) bookingdomain.RerouteTask {
+	t.Helper()
+	routeSpec, err := bookingdomain.NewRouteSpecification("NLRTM", "DEHAM", baseTime().Add(30*24*time.Hour))
+	require.NoError(t, err)
+	routeSpec.EarliestDeparture = baseTime()
+
+	leg, err := bookingdomain.NewLeg("V002", "NLRTM", "DEHAM", baseTime().Add(24*time.Hour), baseTime().Add(48*time.Hour))
+	require.NoError(t, err)
+	candidate, err := bookingdomain.NewItinerary([]bookingdomain.Leg{leg})
+	require.NoError(t, err)
+
+	task := bookingdomain.NewRerouteTask(bookingdomain.NewTrackingId(), routeSpec, []bookingdomain.Itinerary{candidate})
+	task.CreatedAt = createdAt
+	return task
+}
+
+func newHandlingEvent(t *testing.T, trackingId string, eventType handlingdomain.HandlingEventType, voyageNumber string, age time.Duration) handlingdomain.HandlingEvent {
+	t.Helper()
+	event, err := handlingdomain.NewHandlingEvent(trackingId, eventType, "USNYC", voyageNumber, baseTime().Add(-age))
+	require.NoError(t, err)
+	return event
+}
+
+func newQuarantinedReport(trackingId string, eventType handlingdomain.HandlingEventType, voyageNumber string, quarantinedAt time.Time) handlingdomain.QuarantinedReport {
+	report := handlingdomain.NewQuarantinedReport(handlingdomain.HandlingReport{
+		TrackingId:     trackingId,
+		EventType:      string(eventType),
+		Location:       "USNYC",
+		VoyageNumber:   voyageNumber,
+		CompletionTime: quarantinedAt.Add(-time.Hour).Format(time.RFC3339),
+		IdempotencyKey: "key-" + trackingId,
+	}, "cannot unload cargo that is not loaded")
+	report.QuarantinedAt = quarantinedAt
+	return report
+}
+
+func newLocation(t *testing.T, code, name, country string) routingdomain.Location {
+	t.Helper()
+	location, err := routingdomain.NewLocation(code, name, country)
+	require.NoError(t, err)
+	return location
+}
+
+// newVoyage creates a voyage calling at the given ports in order, one day per movement
+func newVoyage(t *testing.T, ports ...string) routingdomain.Voyage {
+	t.Helper()
+	departure := baseTime().Add(24 * time.Hour)
+
+	movements := make([]routingdomain.CarrierMovement, 0, len(ports)-1)
+	for i := 0; i < len(ports)-1; i++ {
+		from, err := routingdomain.NewUnLocode(ports[i])
+		require.NoError(t, err)
+		to, err := routingdomain.NewUnLocode(ports[i+1])
+		require.NoError(t, err)
+
+		movement, err := routingdomain.NewCarrierMovement(from, to, departure, departure.Add(20*time.Hour))
+		require.NoError(t, err)
+		movements = append(movements, movement)
+		departure = departure.Add(24 * time.Hour)
+	}
+
+	voyage, err := routingdomain.NewVoyage(movements)
+	require.NoError(t, err)
+	return voyage
+}
+
+func assertSameCargo(t *testing.T, expected, actual bookingdomain.Cargo) {
+	t.Helper()
+	assert.Equal(t, expected.GetTrackingId(), actual.GetTrackingId())
+	assert.Equal(t, expected.GetVersion()+1, actual.GetVersion(), "stored version")
+	assert.WithinDuration(t, expected.GetBookedAt(), actual.GetBookedAt(), time.Millisecond, "booked at")
+	assert.WithinDuration(t, expected.GetRoutedAt(), actual.GetRoutedAt(), time.Millisecond, "routed at")
+
+	expectedSpec, actualSpec := expected.GetRouteSpecification(), actual.GetRouteSpecification()
+	assert.True(t, expectedSpec.Equals(actualSpec), "route specification: expected %+v, got %+v", expectedSpec, actualSpec)
+
+	expectedDelivery, actualDelivery := expected.GetDelivery(), actual.GetDelivery()
+	assert.Equal(t, expectedDelivery.TransportStatus, actualDelivery.TransportStatus)
+	assert.Equal(t, expectedDelivery.RoutingStatus, actualDelivery.RoutingStatus)
+	assert.Equal(t, expectedDelivery.LastKnownLocation, actualDelivery.LastKnownLocation)
+	assert.Equal(t, expectedDelivery.CurrentVoyage, actualDelivery.CurrentVoyage)
+	assert.Equal(t, expectedDelivery.IsUnloadedAtDest, actualDelivery.IsUnloadedAtDest)
+	assert.WithinDuration(t, expectedDelivery.CalculatedAt, actualDelivery.CalculatedAt, time.Millisecond)
+	assert.True(t, expectedDelivery.LastEventTime.Equal(actualDelivery.LastEventTime),
+		"last event time: expected %v, got %v", expectedDelivery.LastEventTime, actualDelivery.LastEventTime)
+
+	if expected.GetItinerary() == nil {
+		assert.Nil(t, actual.GetItinerary())
+		return
+	}
+	require.NotNil(t, actual.GetItinerary())
+	expectedLegs, actualLegs := expected.GetItinerary().Legs, actual.GetItinerary().Legs
+	require.Len(t, actualLegs, len(expectedLegs))
+	for i := range expectedLegs {
+		assert.Equal(t, expectedLegs[i].VoyageNumber, actualLegs[i].VoyageNumber)
+		assert.Equal(t, expectedLegs[i].LoadLocation, actualLegs[i].LoadLocation)
+		assert.Equal(t, expectedLegs[i].UnloadLocation, actualLegs[i].UnloadLocation)
+		assert.True(t, expectedLegs[i].LoadTime.Equal(actualLegs[i].LoadTime))
+		assert.True(t, expectedLegs[i].UnloadTime.Equal(actualLegs[i].UnloadTime))
+	}
+}
+
+func assertSameRerouteTask(t *testing.T, expected, actual bookingdomain.RerouteTask) {
+	t.Helper()
+	assert.Equal(t, expected.TrackingId, actual.TrackingId)
+	assert.True(t, expected.RouteSpecification.Equals(actual.RouteSpecification),
+		"route specification: expected %+v, got %+v", expected.RouteSpecification, actual.RouteSpecification)
+	assert.True(t, expected.CreatedAt.Equal(actual.CreatedAt), "created at %s, want %s", actual.CreatedAt, expected.CreatedAt)
+
+	require.Len(t, actual.Candidates, len(expected.Candidates))
+	for i := range expected.Candidates {
+		expectedLegs, actualLegs := expected.Candidates[i].Legs, actual.Candidates[i].Legs
+		require.Len(t, actualLegs, len(expectedLegs))
+		for j := range expectedLegs {
+			assert.Equal(t, expectedLegs[j].VoyageNumber, actualLegs[j].VoyageNumber)
+			assert.Equal(t, expectedLegs[j].LoadLocation, actualLegs[j].LoadLocation)
+			assert.Equal(t, expectedLegs[j].UnloadLocation, actualLegs[j].UnloadLocation)
+			assert.True(t, expectedLegs[j].LoadTime.Equal(actualLegs[j].LoadTime))
+			assert.True(t, expectedLegs[j].UnloadTime.Equal(actualLegs[j].UnloadTime))
+		}
+	}
+}
+
+func assertSameHandlingEvent(t *testing.T, expected, actual handlingdomain.HandlingEvent) {
+	t.Helper()
+	assert.Equal(t, expected.GetEventId(), actual.GetEventId())
+	assert.Equal(t, expected.GetTrackingId(), actual.GetTrackingId())
+	assert.Equal(t, expected.GetEventType(), actual.GetEventType())
+	assert.Equal(t, expected.GetLocation(), actual.GetLocation())
+	assert.Equal(t, expected.GetVoyageNumber(), actual.GetVoyageNumber())
+	assert.True(t, expected.GetCompletionTime().Equal(actual.GetCompletionTime()))
+	assert.WithinDuration(t, expected.GetRegistrationTime(), actual.GetRegistrationTime(), time.Millisecond)
+}
+
+func assertSameQuarantinedReport(t *testing.T, expected, actual handlingdomain.QuarantinedReport) {
+	t.Helper()
+	assert.Equal(t, expected.Id, actual.Id)
+	assert.Equal(t, expected.Report, actual.Report)
+	assert.Equal(t, expected.Reason, actual.Reason)
+	assert.True(t, expected.QuarantinedAt.Equal(actual.QuarantinedAt), "quarantined at %s, want %s", actual.QuarantinedAt, expected.QuarantinedAt)
+}
+
+func assertSameVoyage(t *testing.T, expected, actual routingdomain.Voyage) {
+	t.Helper()
+	assert.Equal(t, expected.GetVoyageNumber(), actual.GetVoyageNumber())
+
+	expectedMovements, actualMovements := expected.GetSchedule().Movements, actual.GetSchedule().Movements
+	require.Len(t, actualMovements, len(expectedMovements))
+	for i := range expectedMovements {
+		assert.Equal(t, expectedMovements[i].DepartureLocation, actualMovements[i].DepartureLocation)
+		assert.Equal(t, expectedMovements[i].ArrivalLocation, actualMovements[i].ArrivalLocation)
+		assert.True(t, expectedMovements[i].DepartureTime.Equal(actualMovements[i].DepartureTime))
+		assert.True(t, expectedMovements[i].ArrivalTime.Equal(actualMovements[i].ArrivalTime))
+	}
+}
+
+func cargoTrackingIds(cargos []bookingdomain.Cargo) []string {
+	ids := make([]string, len(cargos))
+	for i, cargo := range cargos {
+		ids[i] = cargo.GetTrackingId().String()
+	}
+	return ids
+}
+
+func handlingEventIds(events []handlingdomain.HandlingEvent) []string {
+	ids := make([]string, len(events))
+	for i, event := range events {
+		ids[i] = event.GetEventId().String()
+	}
+	return ids
+}
+
+func voyageNumbers(voyages []routingdomain.Voyage) []string {
+	numbers := make([]string, len(voyages))
+	for i, voyage := range voyages {
+		numbers[i] = voyage.GetVoyageNumber().String()
+	}
+	return numbers
+}
+
+// outboxEventNames lists every event the repositories record in their outbox
+var outboxEventNames = []string{
+	bookingdomain.CargoBookedEvent{}.EventName(),
+	bookingdomain.CargoRoutedEvent{}.EventName(),
+	bookingdomain.CargoDeliveryUpdatedEvent{}.EventName(),
+	bookingdomain.CargoCancelledEvent{}.EventName(),
+	bookingdomain.CargoRouteSpecificationChangedEvent{}.EventName(),
+	handlingdomain.HandlingEventRegisteredEvent{}.EventName(),
+}
+
+func eventNames(messages []outbox.Message) []string {
+	names := make([]string, len(messages))
+	for i, message := range messages {
+		names[i] = message.EventName
+	}
+	return names
+}
+
+func messageIds(messages []outbox.Message) []string {
+	ids := make([]string, len(messages))
+	for i, message := range messages {
+		ids[i] = message.Id
+	}
+	return ids
+}
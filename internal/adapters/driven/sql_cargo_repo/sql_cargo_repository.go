@@ -0,0 +1,432 @@
+// Package sql_cargo_repo holds the CargoRepository shared by the SQL databases; the database adapters supply the
+// Dialect that spells placeholders and the few conditions their SQL writes differently.
+package sql_cargo_repo
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go_hex/internal/adapters/driven/sql_db"
+	"go_hex/internal/adapters/driven/sql_outbox"
+	"go_hex/internal/booking/bookingdomain"
+	"go_hex/internal/booking/ports/bookingsecondary"
+	"go_hex/internal/support/outbox"
+
+	"github.com/google/uuid"
+)
+
+const selectCargos = `
+	SELECT tracking_id, origin, destination, arrival_deadline, itinerary,
+		transport_status, routing_status, last_known_location, current_voyage,
+		is_unloaded_at_dest, delivery_calculated_at, last_event_time, booked_at, routed_at, version
+	FROM cargos`
+
+// Dialect describes how a SQL database spells what the cargo statements need
+type Dialect struct {
+	sql_db.Dialect
+
+	// HasLegOnVoyage returns the condition that the itinerary column has a leg on the voyage in the placeholder
+	HasLegOnVoyage func(voyage string) string
+}
+
+// RoutedOnVoyage returns the condition that cargo is neither delivered nor cancelled and has a leg on the voyage in
+// the placeholder, for the OnVoyage filter and the statements of other repositories that must not strand such cargo
+func (d Dialect) RoutedOnVoyage(voyage string) string {
+	return fmt.Sprintf("%s AND transport_status <> '%s' AND NOT (transport_status = '%s' AND is_unloaded_at_dest)",
+		d.HasLegOnVoyage(voyage),
+		bookingdomain.TransportStatusCancelled,
+		bookingdomain.TransportStatusClaimed,
+	)
+}
+
+// SQLCargoRepository provides a SQL implementation of the CargoRepository
+type SQLCargoRepository struct {
+	db      *sql.DB
+	dialect Dialect
+}
+
+// NewSQLCargoRepository creates a new SQL cargo repository for a database of the given dialect
+func NewSQLCargoRepository(db *sql.DB, dialect Dialect) bookingsecondary.CargoRepository {
+	return &SQLCargoRepository{
+		db:      db,
+		dialect: dialect,
+	}
+}
+
+// Store saves a new cargo to the repository together with its pending events
+func (r *SQLCargoRepository) Store(cargo bookingdomain.Cargo) error {
+	return r.save(cargo, r.insert)
+}
+
+// FindByTrackingId retrieves a cargo by its tracking ID
+func (r *SQLCargoRepository) FindByTrackingId(trackingId bookingdomain.TrackingId) (bookingdomain.Cargo, error) {
+	row := r.db.QueryRow(selectCargos+" WHERE tracking_id = "+r.dialect.Placeholder(1), trackingId.UUID)
+
+	cargo, err := scanCargo(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return bookingdomain.Cargo{}, bookingdomain.NewCargoNotFoundError(trackingId)
+	}
+	if err != nil {
+		return bookingdomain.Cargo{}, err
+	}
+	return cargo, nil
+}
+
+// FindAll retrieves all cargos in the repository
+func (r *SQLCargoRepository) FindAll() ([]bookingdomain.Cargo, error) {
+	cargos, err := r.query(selectCargos + " ORDER BY tracking_id")
+	if err != nil {
+		return nil, err
+	}
+	if cargos == nil {
+		cargos = make([]bookingdomain.Cargo, 0)
+	}
+	return cargos, nil
+}
+
+// FindByQuery retrieves one page of the cargo matching the query, in the query's sort order
+func (r *SQLCargoRepository) FindByQuery(query bookingdomain.CargoQuery) (bookingdomain.CargoPage, error) {
+	query, err := query.Normalize()
+	if err != nil {
+		return bookingdomain.CargoPage{}, err
+	}
+	cursor, hasCursor, err := query.DecodeCursor()
+	if err != nil {
+		return bookingdomain.CargoPage{}, err
+	}
+
+	where, orderBy, args := r.cargoQueryClauses(query, cursor, hasCursor)
+	args = append(args, query.Limit+1)
+	cargos, err := r.query(selectCargos+where+orderBy+" LIMIT "+r.dialect.Placeholder(len(args)), args...)
+	if err != nil {
+		return bookingdomain.CargoPage{}, err
+	}
+
+	page := bookingdomain.CargoPage{Cargos: cargos}
+	if page.Cargos == nil {
+		page.Cargos = make([]bookingdomain.Cargo, 0)
+	}
+	if len(cargos) > query.Limit {
+		page.Cargos = cargos[:query.Limit]
+		page.NextCursor = query.CursorAfter(page.Cargos[query.Limit-1])
+	}
+	return page, nil
+}
+
+// FindUnrouted retrieves all active cargos that don't have an assigned itinerary
+func (r *SQLCargoRepository) FindUnrouted() ([]bookingdomain.Cargo, error) {
+	return r.query(selectCargos+" WHERE itinerary IS NULL AND transport_status <> "+r.dialect.Placeholder(1)+" ORDER BY tracking_id",
+		string(bookingdomain.TransportStatusCancelled),
+	)
+}
+
+// Update updates an existing cargo together with its pending events, unless it was changed since it was loaded
+func (r *SQLCargoRepository) Update(cargo bookingdomain.Cargo) error {
+	return r.save(cargo, r.update)
+}
+
+// save writes the next version of the cargo with write and records its pending events in the outbox, in one transaction
+func (r *SQLCargoRepository) save(cargo bookingdomain.Cargo, write func(tx *sql.Tx, cargo bookingdomain.Cargo, args []any) error) error {
+	itinerary, err := marshalItinerary(cargo.GetItinerary())
+	if err != nil {
+		return err
+	}
+
+	messages, err := outbox.NewMessages(cargo.GetEvents())
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin storing cargo %s: %w", cargo.GetTrackingId().String(), err)
+	}
+	defer tx.Rollback()
+
+	routeSpec := cargo.GetRouteSpecification()
+	delivery := cargo.GetDelivery()
+
+	args := []any{
+		cargo.GetTrackingId().UUID,
+		routeSpec.Origin,
+		routeSpec.Destination,
+		routeSpec.ArrivalDeadline.UTC(),
+		itinerary,
+		string(delivery.TransportStatus),
+		string(delivery.RoutingStatus),
+		delivery.LastKnownLocation,
+		delivery.CurrentVoyage,
+		delivery.IsUnloadedAtDest,
+		delivery.CalculatedAt,
+		nullableTime(delivery.LastEventTime),
+		nullableTime(cargo.GetBookedAt()),
+		nullableTime(cargo.GetRoutedAt()),
+		cargo.GetVersion() + 1,
+	}
+	if err := write(tx, cargo, args); err != nil {
+		return err
+	}
+
+	if err := sql_outbox.Append(tx, r.dialect.Dialect, messages); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// insert adds a new cargo row, failing with a conflict if the tracking ID is taken
+func (r *SQLCargoRepository) insert(tx *sql.Tx, cargo bookingdomain.Cargo, args []any) error {
+	result, err := tx.Exec(`
+		INSERT INTO cargos (
+			tracking_id, origin, destination, arrival_deadline, itinerary,
+			transport_status, routing_status, last_known_location, current_voyage,
+			is_unloaded_at_dest, delivery_calculated_at, last_event_time, booked_at, routed_at, version
+		)
+		VALUES (`+r.dialect.Placeholders(1, len(args))+`)
+		ON CONFLICT (tracking_id) DO NOTHING`,
+		args...,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store cargo %s: %w", cargo.GetTrackingId().String(), err)
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to store cargo %s: %w", cargo.GetTrackingId().String(), err)
+	}
+	if inserted == 0 {
+		return bookingdomain.NewConcurrencyConflictError(cargo.GetTrackingId(), cargo.GetVersion())
+	}
+	return nil
+}
+
+// update overwrites the cargo row if it is still at the version the cargo was loaded at
+func (r *SQLCargoRepository) update(tx *sql.Tx, cargo bookingdomain.Cargo, args []any) error {
+	p := r.dialect.Placeholder
+	result, err := tx.Exec(`
+		UPDATE cargos SET
+			origin = `+p(2)+`,
+			destination = `+p(3)+`,
+			arrival_deadline = `+p(4)+`,
+			itinerary = `+p(5)+`,
+			transport_status = `+p(6)+`,
+			routing_status = `+p(7)+`,
+			last_known_location = `+p(8)+`,
+			current_voyage = `+p(9)+`,
+			is_unloaded_at_dest = `+p(10)+`,
+			delivery_calculated_at = `+p(11)+`,
+			last_event_time = `+p(12)+`,
+			booked_at = `+p(13)+`,
+			routed_at = `+p(14)+`,
+			version = `+p(15)+`
+		WHERE tracking_id = `+p(1)+` AND version = `+p(16),
+		append(args, cargo.GetVersion())...,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update cargo %s: %w", cargo.GetTrackingId().String(), err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update cargo %s: %w", cargo.GetTrackingId().String(), err)
+	}
+	if updated > 0 {
+		return nil
+	}
+
+	var exists bool
+	if err := tx.QueryRow("SELECT EXISTS (SELECT 1 FROM cargos WHERE tracking_id = "+p(1)+")", cargo.GetTrackingId().UUID).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to update cargo %s: %w", cargo.GetTrackingId().String(), err)
+	}
+	if !exists {
+		return bookingdomain.NewCargoNotFoundError(cargo.GetTrackingId())
+	}
+	return bookingdomain.NewConcurrencyConflictError(cargo.GetTrackingId(), cargo.GetVersion())
+}
+
+// query runs a cargo query and restores every returned row
+func (r *SQLCargoRepository) query(query string, args ...any) ([]bookingdomain.Cargo, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cargos: %w", err)
+	}
+	defer rows.Close()
+
+	var cargos []bookingdomain.Cargo
+	for rows.Next() {
+		cargo, err := scanCargo(rows)
+		if err != nil {
+			return nil, err
+		}
+		cargos = append(cargos, cargo)
+	}
+	return cargos, rows.Err()
+}
+
+// cargoQueryClauses translates the query filters and cursor into a WHERE clause, and the sort order into an ORDER BY
+// clause that breaks ties by tracking ID, so the next page continues after the last cargo of the previous one
+func (r *SQLCargoRepository) cargoQueryClauses(query bookingdomain.CargoQuery, cursor bookingdomain.CargoCursor, hasCursor bool) (string, string, []any) {
+	var (
+		conditions []string
+		args       []any
+	)
+	arg := func(value any) string {
+		args = append(args, value)
+		return r.dialect.Placeholder(len(args))
+	}
+
+	if query.RoutingStatus != "" {
+		conditions = append(conditions, "routing_status = "+arg(string(query.RoutingStatus)))
+	}
+	if query.TransportStatus != "" {
+		conditions = append(conditions, "transport_status = "+arg(string(query.TransportStatus)))
+	}
+	if query.Origin != "" {
+		conditions = append(conditions, "origin = "+arg(query.Origin))
+	}
+	if query.Destination != "" {
+		conditions = append(conditions, "destination = "+arg(query.Destination))
+	}
+	// Deadlines are stored and compared in UTC, so they order as instants even where the database compares them as text
+	if !query.DeadlineFrom.IsZero() {
+		conditions = append(conditions, "arrival_deadline >= "+arg(query.DeadlineFrom.UTC()))
+	}
+	if !query.DeadlineTo.IsZero() {
+		conditions = append(conditions, "arrival_deadline <= "+arg(query.DeadlineTo.UTC()))
+	}
+	if query.Overdue {
+		conditions = append(conditions, fmt.Sprintf(
+			"arrival_deadline < %s AND transport_status <> %s AND NOT (transport_status = %s AND is_unloaded_at_dest)",
+			arg(query.AsOf.UTC()),
+			arg(string(bookingdomain.TransportStatusCancelled)),
+			arg(string(bookingdomain.TransportStatusClaimed)),
+		))
+	}
+	if query.OnVoyage != "" {
+		conditions = append(conditions, r.dialect.RoutedOnVoyage(arg(query.OnVoyage)))
+	}
+
+	direction, comparison := "ASC", ">"
+	if query.Order == bookingdomain.SortDescending {
+		direction, comparison = "DESC", "<"
+	}
+
+	// Location codes are compared byte by byte to order like the other implementations
+	var (
+		column string
+		value  any
+	)
+	switch query.SortBy {
+	case bookingdomain.CargoSortByArrivalDeadline:
+		column, value = "arrival_deadline", cursor.Deadline.UTC()
+	case bookingdomain.CargoSortByOrigin:
+		column, value = r.dialect.Bytewise("origin"), cursor.SortValue
+	case bookingdomain.CargoSortByDestination:
+		column, value = r.dialect.Bytewise("destination"), cursor.SortValue
+	}
+
+	if hasCursor {
+		if column == "" {
+			conditions = append(conditions, "tracking_id "+comparison+" "+arg(cursor.TrackingId))
+		} else {
+			sortValue := arg(value)
+			trackingId := arg(cursor.TrackingId)
+			conditions = append(conditions, fmt.Sprintf("(%[1]s %[2]s %[3]s OR (%[1]s = %[3]s AND tracking_id %[2]s %[4]s))",
+				column, comparison, sortValue, trackingId))
+		}
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+	orderBy := " ORDER BY tracking_id " + direction
+	if column != "" {
+		orderBy = " ORDER BY " + column + " " + direction + ", tracking_id " + direction
+	}
+	return where, orderBy, args
+}
+
+// scanCargo restores a cargo from a result row
+func scanCargo(row interface{ Scan(...any) error }) (bookingdomain.Cargo, error) {
+	var (
+		trackingId uuid.UUID
+		routeSpec  bookingdomain.RouteSpecification
+		itinerary  []byte
+		delivery   bookingdomain.Delivery
+		transport  string
+		routing    string
+		lastEvent  sql.NullTime
+		bookedAt   sql.NullTime
+		routedAt   sql.NullTime
+		version    int
+	)
+	if err := row.Scan(
+		&trackingId,
+		&routeSpec.Origin,
+		&routeSpec.Destination,
+		&routeSpec.ArrivalDeadline,
+		&itinerary,
+		&transport,
+		&routing,
+		&delivery.LastKnownLocation,
+		&delivery.CurrentVoyage,
+		&delivery.IsUnloadedAtDest,
+		&delivery.CalculatedAt,
+		&lastEvent,
+		&bookedAt,
+		&routedAt,
+		&version,
+	); err != nil {
+		return bookingdomain.Cargo{}, fmt.Errorf("failed to read cargo: %w", err)
+	}
+
+	delivery.TransportStatus = bookingdomain.TransportStatus(transport)
+	delivery.RoutingStatus = bookingdomain.RoutingStatus(routing)
+	delivery.LastEventTime = lastEvent.Time
+
+	restoredItinerary, err := unmarshalItinerary(itinerary)
+	if err != nil {
+		return bookingdomain.Cargo{}, fmt.Errorf("failed to read itinerary of cargo %s: %w", trackingId.String(), err)
+	}
+
+	cargo, err := bookingdomain.NewCargoFromExisting(bookingdomain.TrackingId{UUID: trackingId}, routeSpec, restoredItinerary, delivery, bookedAt.Time, routedAt.Time)
+	if err != nil {
+		return bookingdomain.Cargo{}, err
+	}
+	cargo.SetVersion(version)
+	return cargo, nil
+}
+
+// marshalItinerary encodes an itinerary as JSON text, using NULL for unrouted cargo
+func marshalItinerary(itinerary *bookingdomain.Itinerary) (any, error) {
+	if itinerary == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(itinerary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode itinerary: %w", err)
+	}
+	return string(data), nil
+}
+
+// unmarshalItinerary decodes a stored itinerary, returning nil for unrouted cargo
+func unmarshalItinerary(data []byte) (*bookingdomain.Itinerary, error) {
+	if data == nil {
+		return nil, nil
+	}
+	var itinerary bookingdomain.Itinerary
+	if err := json.Unmarshal(data, &itinerary); err != nil {
+		return nil, err
+	}
+	return &itinerary, nil
+}
+
+// nullableTime stores a zero time as NULL and any other time in UTC
+func nullableTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t.UTC(), Valid: !t.IsZero()}
+}
@@ -0,0 +1,102 @@
+// Package sql_db holds what the SQL database adapters share: the Dialect that spells statements for one database and
+// the runner that applies a database's embedded schema migrations.
+package sql_db
+
+import (
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Dialect describes how a SQL database spells what the shared statements need
+type Dialect struct {
+	// Placeholder returns the placeholder of the n-th statement parameter, counting from 1
+	Placeholder func(n int) string
+
+	// Bytewise wraps a text column so it orders byte by byte, whatever the database collation
+	Bytewise func(column string) string
+
+	// LockMigrations serializes migrations across application instances sharing the database, within the
+	// migration's transaction; nil when the database cannot be shared
+	LockMigrations func(tx *sql.Tx) error
+
+	// Lock takes a lock on key until tx ends, so transactions locking the same key run one after another; nil when
+	// the database runs one transaction at a time
+	Lock func(tx *sql.Tx, key string) error
+}
+
+// Placeholders returns the placeholders of the statement parameters from first to last, separated by commas
+func (d Dialect) Placeholders(first, last int) string {
+	placeholders := make([]string, 0, last-first+1)
+	for n := first; n <= last; n++ {
+		placeholders = append(placeholders, d.Placeholder(n))
+	}
+	return strings.Join(placeholders, ", ")
+}
+
+// Migrate applies the migrations/*.sql files that have not been applied yet, in file name order
+func Migrate(db *sql.DB, dialect Dialect, migrations fs.FS) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    VARCHAR(255) PRIMARY KEY,
+		applied_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	names, err := fs.Glob(migrations, "migrations/*.sql")
+	if err != nil {
+		return fmt.Errorf("failed to list migrations: %w", err)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := applyMigration(db, dialect, migrations, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyMigration runs a single migration file in its own transaction unless already applied
+func applyMigration(db *sql.DB, dialect Dialect, migrations fs.FS, name string) error {
+	version := strings.TrimSuffix(path.Base(name), ".sql")
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin migration %s: %w", version, err)
+	}
+	defer tx.Rollback()
+
+	if dialect.LockMigrations != nil {
+		if err := dialect.LockMigrations(tx); err != nil {
+			return fmt.Errorf("failed to lock migrations: %w", err)
+		}
+	}
+
+	var applied bool
+	if err := tx.QueryRow("SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = "+dialect.Placeholder(1)+")", version).Scan(&applied); err != nil {
+		return fmt.Errorf("failed to check migration %s: %w", version, err)
+	}
+	if applied {
+		return nil
+	}
+
+	script, err := fs.ReadFile(migrations, name)
+	if err != nil {
+		return fmt.Errorf("failed to read migration %s: %w", version, err)
+	}
+
+	if _, err := tx.Exec(string(script)); err != nil {
+		return fmt.Errorf("failed to apply migration %s: %w", version, err)
+	}
+
+	if _, err := tx.Exec("INSERT INTO schema_migrations (version) VALUES ("+dialect.Placeholder(1)+")", version); err != nil {
+		return fmt.Errorf("failed to record migration %s: %w", version, err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,132 @@
+// Package sql_handling_quarantine holds the HandlingQuarantine shared by the SQL databases
+package sql_handling_quarantine
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"go_hex/internal/adapters/driven/sql_db"
+	"go_hex/internal/handling/handlingdomain"
+	"go_hex/internal/handling/ports/handlingsecondary"
+
+	"github.com/google/uuid"
+)
+
+const selectQuarantinedReports = `
+	SELECT id, tracking_id, event_type, location, voyage_number, completion_time, idempotency_key, reason, quarantined_at
+	FROM handling_quarantine`
+
+// SQLHandlingQuarantine provides a SQL implementation of the HandlingQuarantine
+type SQLHandlingQuarantine struct {
+	db      *sql.DB
+	dialect sql_db.Dialect
+}
+
+// NewSQLHandlingQuarantine creates a new SQL handling quarantine for a database of the given dialect
+func NewSQLHandlingQuarantine(db *sql.DB, dialect sql_db.Dialect) handlingsecondary.HandlingQuarantine {
+	return &SQLHandlingQuarantine{
+		db:      db,
+		dialect: dialect,
+	}
+}
+
+// Add keeps a quarantined report
+func (q *SQLHandlingQuarantine) Add(report handlingdomain.QuarantinedReport) error {
+	_, err := q.db.Exec(`
+		INSERT INTO handling_quarantine (id, tracking_id, event_type, location, voyage_number, completion_time, idempotency_key, reason, quarantined_at)
+		VALUES (`+q.dialect.Placeholders(1, 9)+`)`,
+		report.Id,
+		report.Report.TrackingId,
+		report.Report.EventType,
+		report.Report.Location,
+		report.Report.VoyageNumber,
+		report.Report.CompletionTime,
+		report.Report.IdempotencyKey,
+		report.Reason,
+		// Times are stored in UTC so their text compares in time order
+		report.QuarantinedAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to quarantine handling report %s: %w", report.Id, err)
+	}
+	return nil
+}
+
+// FindAll retrieves all quarantined reports, oldest first
+func (q *SQLHandlingQuarantine) FindAll() ([]handlingdomain.QuarantinedReport, error) {
+	rows, err := q.db.Query(selectQuarantinedReports + " ORDER BY quarantined_at, id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query quarantined handling reports: %w", err)
+	}
+	defer rows.Close()
+
+	reports := make([]handlingdomain.QuarantinedReport, 0)
+	for rows.Next() {
+		report, err := scanQuarantinedReport(rows)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, rows.Err()
+}
+
+// FindById retrieves a quarantined report by its ID
+func (q *SQLHandlingQuarantine) FindById(id string) (handlingdomain.QuarantinedReport, error) {
+	// IDs are UUIDs, so anything else cannot name a quarantined report
+	if uuid.Validate(id) != nil {
+		return handlingdomain.QuarantinedReport{}, handlingdomain.NewQuarantinedReportNotFoundError(id)
+	}
+
+	row := q.db.QueryRow(selectQuarantinedReports+" WHERE id = "+q.dialect.Placeholder(1), id)
+
+	report, err := scanQuarantinedReport(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return handlingdomain.QuarantinedReport{}, handlingdomain.NewQuarantinedReportNotFoundError(id)
+	}
+	if err != nil {
+		return handlingdomain.QuarantinedReport{}, err
+	}
+	return report, nil
+}
+
+// Remove takes a report out of the quarantine
+func (q *SQLHandlingQuarantine) Remove(id string) error {
+	if uuid.Validate(id) != nil {
+		return handlingdomain.NewQuarantinedReportNotFoundError(id)
+	}
+
+	result, err := q.db.Exec("DELETE FROM handling_quarantine WHERE id = "+q.dialect.Placeholder(1), id)
+	if err != nil {
+		return fmt.Errorf("failed to remove quarantined handling report %s: %w", id, err)
+	}
+
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to remove quarantined handling report %s: %w", id, err)
+	}
+	if removed == 0 {
+		return handlingdomain.NewQuarantinedReportNotFoundError(id)
+	}
+	return nil
+}
+
+// scanQuarantinedReport restores a quarantined report from a result row
+func scanQuarantinedReport(row interface{ Scan(...any) error }) (handlingdomain.QuarantinedReport, error) {
+	var report handlingdomain.QuarantinedReport
+	if err := row.Scan(
+		&report.Id,
+		&report.Report.TrackingId,
+		&report.Report.EventType,
+		&report.Report.Location,
+		&report.Report.VoyageNumber,
+		&report.Report.CompletionTime,
+		&report.Report.IdempotencyKey,
+		&report.Reason,
+		&report.QuarantinedAt,
+	); err != nil {
+		return handlingdomain.QuarantinedReport{}, fmt.Errorf("failed to read quarantined handling report: %w", err)
+	}
+	return report, nil
+}
@@ -0,0 +1,301 @@
+// Package sql_handling_repo holds the HandlingEventRepository shared by the SQL databases
+package sql_handling_repo
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"go_hex/internal/adapters/driven/sql_db"
+	"go_hex/internal/adapters/driven/sql_outbox"
+	"go_hex/internal/handling/handlingdomain"
+	"go_hex/internal/handling/ports/handlingsecondary"
+	"go_hex/internal/support/outbox"
+
+	"github.com/google/uuid"
+)
+
+const selectHandlingEvents = `
+	SELECT event_id, tracking_id, event_type, location, voyage_number, completion_time, registration_time, idempotency_key
+	FROM handling_events`
+
+// SQLHandlingEventRepository provides a SQL implementation of the HandlingEventRepository
+type SQLHandlingEventRepository struct {
+	db      *sql.DB
+	dialect sql_db.Dialect
+}
+
+// NewSQLHandlingEventRepository creates a new SQL handling event repository for a database of the given dialect
+func NewSQLHandlingEventRepository(db *sql.DB, dialect sql_db.Dialect) handlingsecondary.HandlingEventRepository {
+	return &SQLHandlingEventRepository{
+		db:      db,
+		dialect: dialect,
+	}
+}
+
+// Store saves a handling event and records its pending events in the outbox, in one transaction. It fails if another
+// event uses the same idempotency key or records the same handling.
+func (r *SQLHandlingEventRepository) Store(event handlingdomain.HandlingEvent) error {
+	return r.store([]handlingdomain.HandlingEvent{event}, func(tx *sql.Tx) error { return nil })
+}
+
+// Append saves a new handling event like Store, within a transaction holding the cargo's lock, provided the cargo
+// still has historyLength stored events
+func (r *SQLHandlingEventRepository) Append(event handlingdomain.HandlingEvent, historyLength int) error {
+	return r.AppendAll([]handlingdomain.HandlingEvent{event}, map[string]int{event.GetTrackingId(): historyLength})
+}
+
+// AppendAll saves new handling events like Append in one transaction, holding the locks of all their cargos
+func (r *SQLHandlingEventRepository) AppendAll(events []handlingdomain.HandlingEvent, historyLengths map[string]int) error {
+	return r.store(events, func(tx *sql.Tx) error {
+		// Cargos are locked in one order so concurrent batches cannot deadlock
+		for _, trackingId := range slices.Sorted(maps.Keys(historyLengths)) {
+			if err := r.checkHistoryLength(tx, trackingId, historyLengths[trackingId]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// checkHistoryLength locks the cargo's handling history and fails unless it holds historyLength stored events
+func (r *SQLHandlingEventRepository) checkHistoryLength(tx *sql.Tx, trackingId string, historyLength int) error {
+	if r.dialect.Lock != nil {
+		if err := r.dialect.Lock(tx, "handling_events:"+trackingId); err != nil {
+			return fmt.Errorf("failed to lock handling history of cargo %s: %w", trackingId, err)
+		}
+	}
+
+	var stored int
+	err := tx.QueryRow("SELECT COUNT(*) FROM handling_events WHERE tracking_id = "+r.dialect.Placeholder(1), trackingId).Scan(&stored)
+	if err != nil {
+		return fmt.Errorf("failed to count handling events of cargo %s: %w", trackingId, err)
+	}
+	if stored != historyLength {
+		return handlingdomain.NewHandlingHistoryChangedError(trackingId)
+	}
+	return nil
+}
+
+// store saves handling events and their outbox messages in one transaction, once check passes within it
+func (r *SQLHandlingEventRepository) store(events []handlingdomain.HandlingEvent, check func(tx *sql.Tx) error) error {
+	var messages []outbox.Message
+	for _, event := range events {
+		eventMessages, err := outbox.NewMessages(event.GetEvents())
+		if err != nil {
+			return err
+		}
+		messages = append(messages, eventMessages...)
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin storing handling events: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := check(tx); err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		if err := r.insert(tx, event); err != nil {
+			return err
+		}
+	}
+
+	if err := sql_outbox.Append(tx, r.dialect, messages); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// insert saves a handling event within tx, replacing a stored event with the same ID
+func (r *SQLHandlingEventRepository) insert(tx *sql.Tx, event handlingdomain.HandlingEvent) error {
+	_, err := tx.Exec(`
+		INSERT INTO handling_events (event_id, tracking_id, event_type, location, voyage_number, completion_time, registration_time, idempotency_key)
+		VALUES (`+r.dialect.Placeholders(1, 8)+`)
+		ON CONFLICT (event_id) DO UPDATE SET
+			tracking_id = EXCLUDED.tracking_id,
+			event_type = EXCLUDED.event_type,
+			location = EXCLUDED.location,
+			voyage_number = EXCLUDED.voyage_number,
+			completion_time = EXCLUDED.completion_time,
+			registration_time = EXCLUDED.registration_time,
+			idempotency_key = EXCLUDED.idempotency_key`,
+		event.GetEventId().UUID,
+		event.GetTrackingId(),
+		string(event.GetEventType()),
+		event.GetLocation(),
+		event.GetVoyageNumber(),
+		// Times are stored in UTC so their text compares in time order
+		event.GetCompletionTime().UTC(),
+		event.GetRegistrationTime().UTC(),
+		event.GetIdempotencyKey(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store handling event %s: %w", event.GetEventId().String(), err)
+	}
+	return nil
+}
+
+// FindById retrieves a handling event by its ID
+func (r *SQLHandlingEventRepository) FindById(eventId handlingdomain.HandlingEventId) (handlingdomain.HandlingEvent, error) {
+	row := r.db.QueryRow(selectHandlingEvents+" WHERE event_id = "+r.dialect.Placeholder(1), eventId.UUID)
+
+	event, err := scanHandlingEvent(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return handlingdomain.HandlingEvent{}, fmt.Errorf("handling event with ID %s not found", eventId.String())
+	}
+	if err != nil {
+		return handlingdomain.HandlingEvent{}, err
+	}
+	return event, nil
+}
+
+// FindByIdempotencyKey retrieves the handling event submitted with an idempotency key
+func (r *SQLHandlingEventRepository) FindByIdempotencyKey(key string) (handlingdomain.HandlingEvent, bool, error) {
+	row := r.db.QueryRow(selectHandlingEvents+" WHERE idempotency_key = "+r.dialect.Placeholder(1), key)
+
+	event, err := scanHandlingEvent(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return handlingdomain.HandlingEvent{}, false, nil
+	}
+	if err != nil {
+		return handlingdomain.HandlingEvent{}, false, err
+	}
+	return event, true, nil
+}
+
+// FindByTrackingId retrieves all handling events for a specific cargo, by completion time, registration time and event ID
+func (r *SQLHandlingEventRepository) FindByTrackingId(trackingId string) ([]handlingdomain.HandlingEvent, error) {
+	return r.query(selectHandlingEvents+" WHERE tracking_id = "+r.dialect.Placeholder(1)+" ORDER BY completion_time, registration_time, event_id", trackingId)
+}
+
+// FindAll retrieves all handling events in the repository
+func (r *SQLHandlingEventRepository) FindAll() ([]handlingdomain.HandlingEvent, error) {
+	return r.query(selectHandlingEvents + " ORDER BY completion_time")
+}
+
+// FindByQuery retrieves one page of the handling events matching the query, by completion time and then event ID
+func (r *SQLHandlingEventRepository) FindByQuery(query handlingdomain.HandlingEventQuery) (handlingdomain.HandlingEventPage, error) {
+	query, err := query.Normalize()
+	if err != nil {
+		return handlingdomain.HandlingEventPage{}, err
+	}
+	cursor, hasCursor, err := query.DecodeCursor()
+	if err != nil {
+		return handlingdomain.HandlingEventPage{}, err
+	}
+
+	where, args := r.handlingEventQueryWhere(query, cursor, hasCursor)
+	args = append(args, query.Limit+1)
+	events, err := r.query(selectHandlingEvents+where+" ORDER BY completion_time, event_id"+" LIMIT "+r.dialect.Placeholder(len(args)), args...)
+	if err != nil {
+		return handlingdomain.HandlingEventPage{}, err
+	}
+
+	page := handlingdomain.HandlingEventPage{Events: events}
+	if page.Events == nil {
+		page.Events = make([]handlingdomain.HandlingEvent, 0)
+	}
+	if len(events) > query.Limit {
+		page.Events = events[:query.Limit]
+		page.NextCursor = query.CursorAfter(page.Events[query.Limit-1])
+	}
+	return page, nil
+}
+
+// query runs a handling event query and restores every returned row
+func (r *SQLHandlingEventRepository) query(query string, args ...any) ([]handlingdomain.HandlingEvent, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query handling events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []handlingdomain.HandlingEvent
+	for rows.Next() {
+		event, err := scanHandlingEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// handlingEventQueryWhere translates the query filters and cursor into a WHERE clause. Every condition is an equality
+// or a range on completion time, and the cursor compares (completion_time, event_id) as a row, so the query can be
+// answered by walking an index on the filtered column, completion time and event ID.
+func (r *SQLHandlingEventRepository) handlingEventQueryWhere(query handlingdomain.HandlingEventQuery, cursor handlingdomain.HandlingEventCursor, hasCursor bool) (string, []any) {
+	var (
+		conditions []string
+		args       []any
+	)
+	arg := func(value any) string {
+		args = append(args, value)
+		return r.dialect.Placeholder(len(args))
+	}
+
+	if query.TrackingId != "" {
+		conditions = append(conditions, "tracking_id = "+arg(query.TrackingId))
+	}
+	if query.EventType != "" {
+		conditions = append(conditions, "event_type = "+arg(string(query.EventType)))
+	}
+	if query.Location != "" {
+		conditions = append(conditions, "location = "+arg(query.Location))
+	}
+	if query.VoyageNumber != "" {
+		conditions = append(conditions, "voyage_number = "+arg(query.VoyageNumber))
+	}
+	if !query.CompletedFrom.IsZero() {
+		conditions = append(conditions, "completion_time >= "+arg(query.CompletedFrom.UTC()))
+	}
+	if !query.CompletedBefore.IsZero() {
+		conditions = append(conditions, "completion_time < "+arg(query.CompletedBefore.UTC()))
+	}
+	if hasCursor {
+		completionTime := arg(cursor.CompletionTime.UTC())
+		conditions = append(conditions, "(completion_time, event_id) > ("+completionTime+", "+arg(cursor.EventId)+")")
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// scanHandlingEvent restores a handling event from a result row
+func scanHandlingEvent(row interface{ Scan(...any) error }) (handlingdomain.HandlingEvent, error) {
+	var (
+		eventId                          uuid.UUID
+		trackingId, eventType, location  string
+		voyageNumber, idempotencyKey     string
+		completionTime, registrationTime time.Time
+	)
+	if err := row.Scan(&eventId, &trackingId, &eventType, &location, &voyageNumber, &completionTime, &registrationTime, &idempotencyKey); err != nil {
+		return handlingdomain.HandlingEvent{}, fmt.Errorf("failed to read handling event: %w", err)
+	}
+
+	event, err := handlingdomain.NewHandlingEventFromExisting(
+		handlingdomain.HandlingEventId{UUID: eventId},
+		trackingId,
+		handlingdomain.HandlingEventType(eventType),
+		location,
+		voyageNumber,
+		completionTime,
+		registrationTime,
+		idempotencyKey,
+	)
+	if err != nil {
+		return handlingdomain.HandlingEvent{}, err
+	}
+	return event, nil
+}
@@ -0,0 +1,86 @@
+// Package sql_location_repo holds the LocationRepository shared by the SQL databases
+package sql_location_repo
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"go_hex/internal/adapters/driven/sql_db"
+	"go_hex/internal/routing/ports/routingsecondary"
+	"go_hex/internal/routing/routingdomain"
+)
+
+// SQLLocationRepository provides a SQL implementation of the LocationRepository
+type SQLLocationRepository struct {
+	db      *sql.DB
+	dialect sql_db.Dialect
+}
+
+// NewSQLLocationRepository creates a new SQL location repository for a database of the given dialect
+func NewSQLLocationRepository(db *sql.DB, dialect sql_db.Dialect) routingsecondary.LocationRepository {
+	return &SQLLocationRepository{
+		db:      db,
+		dialect: dialect,
+	}
+}
+
+// Store saves a location to the repository, replacing any location with the same UN/LOCODE
+func (r *SQLLocationRepository) Store(location routingdomain.Location) error {
+	_, err := r.db.Exec(`
+		INSERT INTO locations (unlocode, name, country)
+		VALUES (`+r.dialect.Placeholders(1, 3)+`)
+		ON CONFLICT (unlocode) DO UPDATE SET
+			name = EXCLUDED.name,
+			country = EXCLUDED.country`,
+		location.GetUnLocode().String(),
+		location.Data.Name,
+		location.Data.Country,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store location %s: %w", location.GetUnLocode().String(), err)
+	}
+	return nil
+}
+
+// FindByUnLocode retrieves a location by its UN/LOCODE
+func (r *SQLLocationRepository) FindByUnLocode(unLocode routingdomain.UnLocode) (routingdomain.Location, error) {
+	row := r.db.QueryRow("SELECT unlocode, name, country FROM locations WHERE unlocode = "+r.dialect.Placeholder(1), unLocode.String())
+
+	location, err := scanLocation(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return routingdomain.Location{}, routingdomain.NewLocationNotFoundError(unLocode)
+	}
+	if err != nil {
+		return routingdomain.Location{}, err
+	}
+	return location, nil
+}
+
+// FindAll retrieves all locations in the repository
+func (r *SQLLocationRepository) FindAll() ([]routingdomain.Location, error) {
+	rows, err := r.db.Query("SELECT unlocode, name, country FROM locations ORDER BY unlocode")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query locations: %w", err)
+	}
+	defer rows.Close()
+
+	locations := make([]routingdomain.Location, 0)
+	for rows.Next() {
+		location, err := scanLocation(rows)
+		if err != nil {
+			return nil, err
+		}
+		locations = append(locations, location)
+	}
+	return locations, rows.Err()
+}
+
+// scanLocation restores a location from a result row
+func scanLocation(row interface{ Scan(...any) error }) (routingdomain.Location, error) {
+	var code, name, country string
+	if err := row.Scan(&code, &name, &country); err != nil {
+		return routingdomain.Location{}, fmt.Errorf("failed to read location: %w", err)
+	}
+	return routingdomain.NewLocation(code, name, country)
+}
@@ -0,0 +1,105 @@
+// Package sql_outbox holds the outbox Store shared by the SQL databases, and Append, which records messages in the
+// transaction of the repository that raised them.
+package sql_outbox
+
+import (
+	"database/sql"
+	"fmt"
+
+	"go_hex/internal/adapters/driven/sql_db"
+	"go_hex/internal/support/outbox"
+)
+
+// SQLOutbox provides a SQL implementation of the outbox Store
+type SQLOutbox struct {
+	db      *sql.DB
+	dialect sql_db.Dialect
+}
+
+// NewSQLOutbox creates a new SQL outbox for a database of the given dialect
+func NewSQLOutbox(db *sql.DB, dialect sql_db.Dialect) outbox.Store {
+	return &SQLOutbox{
+		db:      db,
+		dialect: dialect,
+	}
+}
+
+// Append records messages within the caller's transaction, so they commit or roll back with the aggregate
+func Append(tx *sql.Tx, dialect sql_db.Dialect, messages []outbox.Message) error {
+	for _, message := range messages {
+		_, err := tx.Exec(`
+			INSERT INTO outbox_messages (id, event_name, payload, occurred_at)
+			VALUES (`+dialect.Placeholders(1, 4)+`)`,
+			message.Id,
+			message.EventName,
+			string(message.Payload),
+			message.OccurredAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to record event %s in outbox: %w", message.EventName, err)
+		}
+	}
+	return nil
+}
+
+// FindPending retrieves undispatched messages of the named events with fewer than maxAttempts failed deliveries,
+// oldest first
+func (o *SQLOutbox) FindPending(eventNames []string, maxAttempts, limit int) ([]outbox.Message, error) {
+	if len(eventNames) == 0 {
+		return nil, nil
+	}
+
+	args := []any{maxAttempts, limit}
+	for _, eventName := range eventNames {
+		args = append(args, eventName)
+	}
+	rows, err := o.db.Query(`
+		SELECT id, event_name, payload, occurred_at, attempts, last_error
+		FROM outbox_messages
+		WHERE dispatched_at IS NULL AND attempts < `+o.dialect.Placeholder(1)+`
+			AND event_name IN (`+o.dialect.Placeholders(3, len(args))+`)
+		ORDER BY position
+		LIMIT `+o.dialect.Placeholder(2),
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query outbox: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []outbox.Message
+	for rows.Next() {
+		var message outbox.Message
+		if err := rows.Scan(&message.Id, &message.EventName, &message.Payload, &message.OccurredAt, &message.Attempts, &message.LastError); err != nil {
+			return nil, fmt.Errorf("failed to read outbox message: %w", err)
+		}
+		messages = append(messages, message)
+	}
+	return messages, rows.Err()
+}
+
+// MarkDispatched records that a message was delivered
+func (o *SQLOutbox) MarkDispatched(id string) error {
+	return o.update("UPDATE outbox_messages SET dispatched_at = CURRENT_TIMESTAMP WHERE id = "+o.dialect.Placeholder(1), id)
+}
+
+// MarkFailed records a failed delivery attempt for a message
+func (o *SQLOutbox) MarkFailed(id string, reason string) error {
+	return o.update("UPDATE outbox_messages SET attempts = attempts + 1, last_error = "+o.dialect.Placeholder(2)+" WHERE id = "+o.dialect.Placeholder(1), id, reason)
+}
+
+// update settles a single message, failing if it does not exist
+func (o *SQLOutbox) update(query string, id string, args ...any) error {
+	result, err := o.db.Exec(query, append([]any{id}, args...)...)
+	if err != nil {
+		return fmt.Errorf("failed to update outbox message %s: %w", id, err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update outbox message %s: %w", id, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("outbox message %s not found", id)
+	}
+	return nil
+}
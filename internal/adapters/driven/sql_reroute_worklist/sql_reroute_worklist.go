@@ -0,0 +1,110 @@
+// Package sql_reroute_worklist holds the RerouteWorklist shared by the SQL databases
+package sql_reroute_worklist
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"go_hex/internal/adapters/driven/sql_db"
+	"go_hex/internal/booking/bookingdomain"
+	"go_hex/internal/booking/ports/bookingsecondary"
+)
+
+// SQLRerouteWorklist provides a SQL implementation of the RerouteWorklist
+type SQLRerouteWorklist struct {
+	db      *sql.DB
+	dialect sql_db.Dialect
+}
+
+// NewSQLRerouteWorklist creates a new SQL reroute worklist for a database of the given dialect
+func NewSQLRerouteWorklist(db *sql.DB, dialect sql_db.Dialect) bookingsecondary.RerouteWorklist {
+	return &SQLRerouteWorklist{
+		db:      db,
+		dialect: dialect,
+	}
+}
+
+// Put adds a task, replacing any earlier task of the same cargo
+func (w *SQLRerouteWorklist) Put(task bookingdomain.RerouteTask) error {
+	routeSpec, err := json.Marshal(task.RouteSpecification)
+	if err != nil {
+		return fmt.Errorf("failed to encode route specification: %w", err)
+	}
+	candidates, err := json.Marshal(task.Candidates)
+	if err != nil {
+		return fmt.Errorf("failed to encode reroute candidates: %w", err)
+	}
+
+	_, err = w.db.Exec(`
+		INSERT INTO reroute_worklist (tracking_id, route_specification, candidates, created_at)
+		VALUES (`+w.dialect.Placeholders(1, 4)+`)
+		ON CONFLICT (tracking_id) DO UPDATE SET
+			route_specification = EXCLUDED.route_specification,
+			candidates = EXCLUDED.candidates,
+			created_at = EXCLUDED.created_at`,
+		task.TrackingId.String(),
+		string(routeSpec),
+		string(candidates),
+		// Times are stored in UTC so their text compares in time order
+		task.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to put cargo %s on reroute worklist: %w", task.TrackingId.String(), err)
+	}
+	return nil
+}
+
+// Remove takes the cargo off the worklist
+func (w *SQLRerouteWorklist) Remove(trackingId bookingdomain.TrackingId) error {
+	if _, err := w.db.Exec("DELETE FROM reroute_worklist WHERE tracking_id = "+w.dialect.Placeholder(1), trackingId.String()); err != nil {
+		return fmt.Errorf("failed to take cargo %s off reroute worklist: %w", trackingId.String(), err)
+	}
+	return nil
+}
+
+// FindAll retrieves all tasks, oldest first
+func (w *SQLRerouteWorklist) FindAll() ([]bookingdomain.RerouteTask, error) {
+	rows, err := w.db.Query(`
+		SELECT tracking_id, route_specification, candidates, created_at
+		FROM reroute_worklist
+		ORDER BY created_at, tracking_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reroute worklist: %w", err)
+	}
+	defer rows.Close()
+
+	tasks := make([]bookingdomain.RerouteTask, 0)
+	for rows.Next() {
+		task, err := scanRerouteTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+// scanRerouteTask restores a reroute task from a result row
+func scanRerouteTask(row interface{ Scan(...any) error }) (bookingdomain.RerouteTask, error) {
+	var (
+		task                  bookingdomain.RerouteTask
+		trackingId            string
+		routeSpec, candidates []byte
+	)
+	if err := row.Scan(&trackingId, &routeSpec, &candidates, &task.CreatedAt); err != nil {
+		return bookingdomain.RerouteTask{}, fmt.Errorf("failed to read reroute task: %w", err)
+	}
+
+	var err error
+	if task.TrackingId, err = bookingdomain.TrackingIdFromString(trackingId); err != nil {
+		return bookingdomain.RerouteTask{}, fmt.Errorf("failed to read reroute task: %w", err)
+	}
+	if err := json.Unmarshal(routeSpec, &task.RouteSpecification); err != nil {
+		return bookingdomain.RerouteTask{}, fmt.Errorf("failed to decode route specification of cargo %s: %w", trackingId, err)
+	}
+	if err := json.Unmarshal(candidates, &task.Candidates); err != nil {
+		return bookingdomain.RerouteTask{}, fmt.Errorf("failed to decode reroute candidates of cargo %s: %w", trackingId, err)
+	}
+	return task, nil
+}
@@ -0,0 +1,233 @@
+// Package sql_voyage_repo holds the VoyageRepository shared by the SQL databases; the database adapters supply the
+// Dialect that spells placeholders and the cargo condition deletions are guarded by.
+package sql_voyage_repo
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"go_hex/internal/adapters/driven/sql_db"
+	"go_hex/internal/routing/ports/routingsecondary"
+	"go_hex/internal/routing/routingdomain"
+
+	"github.com/google/uuid"
+)
+
+const selectMovements = `
+	SELECT voyage_number, departure_location, arrival_location, departure_time, arrival_time
+	FROM carrier_movements`
+
+// Dialect describes how a SQL database spells what the voyage statements need
+type Dialect struct {
+	sql_db.Dialect
+
+	// RoutedOnVoyage returns the condition that a row of the cargos table is neither delivered nor cancelled and has a
+	// leg on the voyage in the placeholder
+	RoutedOnVoyage func(voyage string) string
+}
+
+// SQLVoyageRepository provides a SQL implementation of the VoyageRepository
+// Voyages share the database with cargo, so a deletion checks the cargo routed on the voyage in the same statement.
+type SQLVoyageRepository struct {
+	db      *sql.DB
+	dialect Dialect
+}
+
+// NewSQLVoyageRepository creates a new SQL voyage repository for a database of the given dialect
+func NewSQLVoyageRepository(db *sql.DB, dialect Dialect) routingsecondary.VoyageRepository {
+	return &SQLVoyageRepository{
+		db:      db,
+		dialect: dialect,
+	}
+}
+
+// Store saves a voyage and its schedule, replacing any voyage with the same number
+func (r *SQLVoyageRepository) Store(voyage routingdomain.Voyage) error {
+	voyageNumber := voyage.GetVoyageNumber()
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin storing voyage %s: %w", voyageNumber.String(), err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("INSERT INTO voyages (voyage_number) VALUES ("+r.dialect.Placeholder(1)+") ON CONFLICT DO NOTHING", voyageNumber.UUID); err != nil {
+		return fmt.Errorf("failed to store voyage %s: %w", voyageNumber.String(), err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM carrier_movements WHERE voyage_number = "+r.dialect.Placeholder(1), voyageNumber.UUID); err != nil {
+		return fmt.Errorf("failed to replace schedule of voyage %s: %w", voyageNumber.String(), err)
+	}
+
+	for i, movement := range voyage.GetSchedule().Movements {
+		_, err := tx.Exec(`
+			INSERT INTO carrier_movements (voyage_number, sequence, departure_location, arrival_location, departure_time, arrival_time)
+			VALUES (`+r.dialect.Placeholders(1, 6)+`)`,
+			voyageNumber.UUID,
+			i,
+			movement.DepartureLocation.String(),
+			movement.ArrivalLocation.String(),
+			movement.DepartureTime,
+			movement.ArrivalTime,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store schedule of voyage %s: %w", voyageNumber.String(), err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// FindByVoyageNumber retrieves a voyage by its voyage number
+func (r *SQLVoyageRepository) FindByVoyageNumber(voyageNumber routingdomain.VoyageNumber) (routingdomain.Voyage, error) {
+	voyages, err := r.query(selectMovements+" WHERE voyage_number = "+r.dialect.Placeholder(1)+" ORDER BY sequence", voyageNumber.UUID)
+	if err != nil {
+		return routingdomain.Voyage{}, err
+	}
+	if len(voyages) == 0 {
+		return routingdomain.Voyage{}, routingdomain.NewVoyageNotFoundError(voyageNumber)
+	}
+	return voyages[0], nil
+}
+
+// Delete removes a voyage, its carrier movements going with it, unless cargo is routed on it
+func (r *SQLVoyageRepository) Delete(voyageNumber routingdomain.VoyageNumber) error {
+	result, err := r.db.Exec(`
+		DELETE FROM voyages
+		WHERE voyage_number = `+r.dialect.Placeholder(1)+`
+			AND NOT EXISTS (SELECT 1 FROM cargos WHERE `+r.dialect.RoutedOnVoyage(r.dialect.Placeholder(2))+`)`,
+		voyageNumber.UUID,
+		voyageNumber.String(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete voyage %s: %w", voyageNumber.String(), err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete voyage %s: %w", voyageNumber.String(), err)
+	}
+	if deleted > 0 {
+		return nil
+	}
+
+	// Nothing was deleted, either because the voyage does not exist or because cargo is routed on it
+	var exists bool
+	if err := r.db.QueryRow("SELECT EXISTS (SELECT 1 FROM voyages WHERE voyage_number = "+r.dialect.Placeholder(1)+")", voyageNumber.UUID).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to delete voyage %s: %w", voyageNumber.String(), err)
+	}
+	if exists {
+		return routingdomain.NewVoyageInUseError(voyageNumber)
+	}
+	return routingdomain.NewVoyageNotFoundError(voyageNumber)
+}
+
+// FindAll retrieves all voyages in the repository
+func (r *SQLVoyageRepository) FindAll() ([]routingdomain.Voyage, error) {
+	voyages, err := r.query(selectMovements + " ORDER BY voyage_number, sequence")
+	if err != nil {
+		return nil, err
+	}
+	if voyages == nil {
+		voyages = make([]routingdomain.Voyage, 0)
+	}
+	return voyages, nil
+}
+
+// FindVoyagesConnecting finds voyages that connect two locations
+func (r *SQLVoyageRepository) FindVoyagesConnecting(origin, destination routingdomain.UnLocode) ([]routingdomain.Voyage, error) {
+	originParam, destinationParam := r.dialect.Placeholder(1), r.dialect.Placeholder(2)
+	return r.query(selectMovements+`
+		WHERE voyage_number IN (
+			-- Voyages with a single movement from origin to destination
+			SELECT voyage_number FROM carrier_movements
+			WHERE departure_location = `+originParam+` AND arrival_location = `+destinationParam+`
+			UNION
+			-- Voyages starting at origin and ending at destination
+			SELECT first_move.voyage_number
+			FROM carrier_movements first_move
+			JOIN carrier_movements last_move ON last_move.voyage_number = first_move.voyage_number
+			WHERE first_move.sequence = 0
+				AND first_move.departure_location = `+originParam+`
+				AND last_move.arrival_location = `+destinationParam+`
+				AND last_move.sequence = (SELECT MAX(sequence) FROM carrier_movements m WHERE m.voyage_number = first_move.voyage_number)
+		)
+		ORDER BY voyage_number, sequence`,
+		origin.String(),
+		destination.String(),
+	)
+}
+
+// query loads the movements returned by a query, ordered by voyage and sequence, and groups them into voyages
+func (r *SQLVoyageRepository) query(query string, args ...any) ([]routingdomain.Voyage, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query voyages: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		voyages   []routingdomain.Voyage
+		current   uuid.UUID
+		movements []routingdomain.CarrierMovement
+	)
+
+	flush := func() error {
+		if len(movements) == 0 {
+			return nil
+		}
+		voyage, err := routingdomain.NewVoyageFromExisting(routingdomain.VoyageNumber{UUID: current}, movements)
+		if err != nil {
+			return err
+		}
+		voyages = append(voyages, voyage)
+		movements = nil
+		return nil
+	}
+
+	for rows.Next() {
+		var (
+			voyageNumber               uuid.UUID
+			departureCode, arrivalCode string
+			departureTime, arrivalTime time.Time
+		)
+		if err := rows.Scan(&voyageNumber, &departureCode, &arrivalCode, &departureTime, &arrivalTime); err != nil {
+			return nil, fmt.Errorf("failed to read carrier movement: %w", err)
+		}
+
+		if voyageNumber != current {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			current = voyageNumber
+		}
+
+		movement, err := scanMovement(departureCode, arrivalCode, departureTime, arrivalTime)
+		if err != nil {
+			return nil, err
+		}
+		movements = append(movements, movement)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read carrier movements: %w", err)
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return voyages, nil
+}
+
+// scanMovement restores a carrier movement from its stored columns
+func scanMovement(departureCode, arrivalCode string, departureTime, arrivalTime time.Time) (routingdomain.CarrierMovement, error) {
+	departure, err := routingdomain.NewUnLocode(departureCode)
+	if err != nil {
+		return routingdomain.CarrierMovement{}, err
+	}
+	arrival, err := routingdomain.NewUnLocode(arrivalCode)
+	if err != nil {
+		return routingdomain.CarrierMovement{}, err
+	}
+	return routingdomain.NewCarrierMovement(departure, arrival, departureTime, arrivalTime)
+}
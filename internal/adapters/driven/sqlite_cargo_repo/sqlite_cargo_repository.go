@@ -0,0 +1,28 @@
+package sqlite_cargo_repo
+
+import (
+	"database/sql"
+
+	"go_hex/internal/adapters/driven/sql_cargo_repo"
+	"go_hex/internal/adapters/driven/sqlite_db"
+	"go_hex/internal/booking/ports/bookingsecondary"
+)
+
+// dialect spells the cargo statements for SQLite
+var dialect = sql_cargo_repo.Dialect{
+	Dialect: sqlite_db.Dialect,
+	HasLegOnVoyage: func(voyage string) string {
+		return "EXISTS (SELECT 1 FROM json_each(itinerary, '$.legs') WHERE json_extract(value, '$.voyage_number') = " + voyage + ")"
+	},
+}
+
+// RoutedOnVoyage returns the condition that a row of the cargos table is neither delivered nor cancelled and has a leg
+// on the voyage in the placeholder, for the voyage repository to guard deletions with
+func RoutedOnVoyage(voyage string) string {
+	return dialect.RoutedOnVoyage(voyage)
+}
+
+// NewSQLiteCargoRepository creates a new SQLite cargo repository
+func NewSQLiteCargoRepository(db *sql.DB) bookingsecondary.CargoRepository {
+	return sql_cargo_repo.NewSQLCargoRepository(db, dialect)
+}
@@ -0,0 +1,24 @@
+package sqlite_cargo_repo
+
+import (
+	"testing"
+
+	"go_hex/internal/adapters/driven/repository_contract"
+	"go_hex/internal/adapters/driven/sqlite_db"
+	"go_hex/internal/adapters/driven/sqlite_outbox"
+	"go_hex/internal/booking/ports/bookingsecondary"
+	"go_hex/internal/support/outbox"
+)
+
+func TestSQLiteCargoRepository(t *testing.T) {
+	repository_contract.CargoRepositoryContract(t, func(t *testing.T) bookingsecondary.CargoRepository {
+		return NewSQLiteCargoRepository(sqlite_db.OpenTestDatabase(t))
+	})
+}
+
+func TestSQLiteCargoOutbox(t *testing.T) {
+	repository_contract.CargoOutboxContract(t, func(t *testing.T) (bookingsecondary.CargoRepository, outbox.Store) {
+		db := sqlite_db.OpenTestDatabase(t)
+		return NewSQLiteCargoRepository(db), sqlite_outbox.NewSQLiteOutbox(db)
+	})
+}
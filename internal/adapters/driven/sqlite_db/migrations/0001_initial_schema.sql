@@ -0,0 +1,49 @@
+CREATE TABLE IF NOT EXISTS locations (
+    unlocode TEXT PRIMARY KEY,
+    name     TEXT NOT NULL,
+    country  TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS voyages (
+    voyage_number TEXT PRIMARY KEY
+);
+
+CREATE TABLE IF NOT EXISTS carrier_movements (
+    voyage_number      TEXT      NOT NULL REFERENCES voyages (voyage_number) ON DELETE CASCADE,
+    sequence           INTEGER   NOT NULL,
+    departure_location TEXT      NOT NULL,
+    arrival_location   TEXT      NOT NULL,
+    departure_time     TIMESTAMP NOT NULL,
+    arrival_time       TIMESTAMP NOT NULL,
+    PRIMARY KEY (voyage_number, sequence)
+);
+
+CREATE INDEX IF NOT EXISTS idx_carrier_movements_route
+    ON carrier_movements (departure_location, arrival_location);
+
+CREATE TABLE IF NOT EXISTS cargos (
+    tracking_id            TEXT      PRIMARY KEY,
+    origin                 TEXT      NOT NULL,
+    destination            TEXT      NOT NULL,
+    arrival_deadline       TIMESTAMP NOT NULL,
+    itinerary              TEXT,
+    transport_status       TEXT      NOT NULL,
+    routing_status         TEXT      NOT NULL,
+    last_known_location    TEXT      NOT NULL DEFAULT '',
+    current_voyage         TEXT      NOT NULL DEFAULT '',
+    is_unloaded_at_dest    BOOLEAN   NOT NULL DEFAULT FALSE,
+    delivery_calculated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS handling_events (
+    event_id          TEXT      PRIMARY KEY,
+    tracking_id       TEXT      NOT NULL,
+    event_type        TEXT      NOT NULL,
+    location          TEXT      NOT NULL,
+    voyage_number     TEXT      NOT NULL DEFAULT '',
+    completion_time   TIMESTAMP NOT NULL,
+    registration_time TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_handling_events_tracking_id
+    ON handling_events (tracking_id);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS outbox_messages (
+    position      INTEGER   PRIMARY KEY AUTOINCREMENT,
+    id            TEXT      NOT NULL UNIQUE,
+    event_name    TEXT      NOT NULL,
+    payload       TEXT      NOT NULL,
+    occurred_at   TIMESTAMP NOT NULL,
+    attempts      INTEGER   NOT NULL DEFAULT 0,
+    last_error    TEXT      NOT NULL DEFAULT '',
+    dispatched_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_pending
+    ON outbox_messages (position) WHERE dispatched_at IS NULL;
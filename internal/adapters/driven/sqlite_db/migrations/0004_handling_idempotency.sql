@@ -0,0 +1,5 @@
+ALTER TABLE handling_events ADD COLUMN idempotency_key TEXT NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_handling_events_idempotency_key
+    ON handling_events (idempotency_key)
+    WHERE idempotency_key <> '';
@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS idx_cargos_status
+    ON cargos (routing_status, transport_status);
@@ -0,0 +1,19 @@
+-- Completion times are compared as text, which only orders them correctly when they are all in UTC.
+-- Rewrite those stored with another offset the way the driver writes UTC times, without trailing zeros.
+UPDATE handling_events
+SET completion_time = rtrim(rtrim(strftime('%Y-%m-%d %H:%M:%f', completion_time), '0'), '.') || '+00:00'
+WHERE completion_time NOT LIKE '%+00:00';
+
+DROP INDEX IF EXISTS idx_handling_events_tracking_id;
+
+CREATE INDEX IF NOT EXISTS idx_handling_events_tracking_id_completion
+    ON handling_events (tracking_id, completion_time, event_id);
+
+CREATE INDEX IF NOT EXISTS idx_handling_events_location_completion
+    ON handling_events (location, completion_time, event_id);
+
+CREATE INDEX IF NOT EXISTS idx_handling_events_voyage_completion
+    ON handling_events (voyage_number, completion_time, event_id);
+
+CREATE INDEX IF NOT EXISTS idx_handling_events_completion
+    ON handling_events (completion_time, event_id);
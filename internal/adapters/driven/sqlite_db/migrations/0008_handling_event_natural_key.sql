@@ -0,0 +1,15 @@
+-- A handling event is recorded once per physical handling. Keep the first registration of any handling recorded
+-- twice before this was enforced, so concurrent reports of the same handling can no longer both be stored.
+DELETE FROM handling_events
+WHERE EXISTS (
+    SELECT 1 FROM handling_events AS earlier
+    WHERE earlier.tracking_id = handling_events.tracking_id
+      AND earlier.event_type = handling_events.event_type
+      AND earlier.location = handling_events.location
+      AND earlier.voyage_number = handling_events.voyage_number
+      AND earlier.completion_time = handling_events.completion_time
+      AND (earlier.registration_time, earlier.event_id) < (handling_events.registration_time, handling_events.event_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_handling_events_natural_key
+    ON handling_events (tracking_id, event_type, location, voyage_number, completion_time);
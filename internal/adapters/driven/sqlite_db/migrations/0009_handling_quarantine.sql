@@ -0,0 +1,15 @@
+-- Handling reports the lenient sequence policy sets aside for review, kept until they are released
+CREATE TABLE IF NOT EXISTS handling_quarantine (
+    id              TEXT      PRIMARY KEY,
+    tracking_id     TEXT      NOT NULL,
+    event_type      TEXT      NOT NULL,
+    location        TEXT      NOT NULL,
+    voyage_number   TEXT      NOT NULL DEFAULT '',
+    completion_time TEXT      NOT NULL,
+    idempotency_key TEXT      NOT NULL DEFAULT '',
+    reason          TEXT      NOT NULL,
+    quarantined_at  TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_handling_quarantine_quarantined_at
+    ON handling_quarantine (quarantined_at);
@@ -0,0 +1,9 @@
+-- Arrival deadlines are compared as text, which orders them as instants only when all are written in UTC.
+-- Deadlines stored with another offset are rewritten in UTC, in the format the driver writes them, so the
+-- deadline filters and sort can use an index.
+UPDATE cargos
+SET arrival_deadline = rtrim(rtrim(strftime('%Y-%m-%d %H:%M:%f', arrival_deadline), '0'), '.') || '+00:00'
+WHERE arrival_deadline NOT LIKE '%+00:00';
+
+CREATE INDEX IF NOT EXISTS idx_cargos_arrival_deadline
+    ON cargos (arrival_deadline, tracking_id);
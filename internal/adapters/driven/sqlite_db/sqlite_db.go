@@ -0,0 +1,67 @@
+package sqlite_db
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"go_hex/internal/adapters/driven/sql_db"
+
+	_ "modernc.org/sqlite" // Registers the "sqlite" database/sql driver
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Dialect spells the shared SQL statements for SQLite. It needs no locks: Open allows a single connection, which runs
+// one transaction at a time.
+var Dialect = sql_db.Dialect{
+	Placeholder: func(n int) string {
+		return fmt.Sprintf("?%d", n)
+	},
+	// The default BINARY collation already compares byte by byte
+	Bytewise: func(column string) string {
+		return column
+	},
+}
+
+// Open opens (creating if needed) the SQLite database file at filePath and applies any pending schema migrations
+func Open(filePath string) (*sql.DB, error) {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create sqlite database directory: %w", err)
+	}
+
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Set("_time_format", "sqlite")
+
+	db, err := sql.Open("sqlite", "file:"+filePath+"?"+params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite database: %w", err)
+	}
+
+	// SQLite allows a single writer; one connection avoids lock contention between requests
+	db.SetMaxOpenConns(1)
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to open sqlite database %s: %w", filePath, err)
+	}
+
+	if err := Migrate(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// Migrate applies the embedded migrations that have not been applied yet, in file name order
+func Migrate(db *sql.DB) error {
+	return sql_db.Migrate(db, Dialect, migrationFiles)
+}
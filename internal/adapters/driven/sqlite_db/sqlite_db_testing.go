@@ -0,0 +1,20 @@
+package sqlite_db
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+)
+
+// OpenTestDatabase creates a migrated SQLite database in a temporary directory removed after the test
+func OpenTestDatabase(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
@@ -0,0 +1,14 @@
+package sqlite_handling_quarantine
+
+import (
+	"database/sql"
+
+	"go_hex/internal/adapters/driven/sql_handling_quarantine"
+	"go_hex/internal/adapters/driven/sqlite_db"
+	"go_hex/internal/handling/ports/handlingsecondary"
+)
+
+// NewSQLiteHandlingQuarantine creates a new SQLite handling quarantine
+func NewSQLiteHandlingQuarantine(db *sql.DB) handlingsecondary.HandlingQuarantine {
+	return sql_handling_quarantine.NewSQLHandlingQuarantine(db, sqlite_db.Dialect)
+}
@@ -0,0 +1,15 @@
+package sqlite_handling_quarantine
+
+import (
+	"testing"
+
+	"go_hex/internal/adapters/driven/repository_contract"
+	"go_hex/internal/adapters/driven/sqlite_db"
+	"go_hex/internal/handling/ports/handlingsecondary"
+)
+
+func TestSQLiteHandlingQuarantine(t *testing.T) {
+	repository_contract.HandlingQuarantineContract(t, func(t *testing.T) handlingsecondary.HandlingQuarantine {
+		return NewSQLiteHandlingQuarantine(sqlite_db.OpenTestDatabase(t))
+	})
+}
@@ -0,0 +1,14 @@
+package sqlite_handling_repo
+
+import (
+	"database/sql"
+
+	"go_hex/internal/adapters/driven/sql_handling_repo"
+	"go_hex/internal/adapters/driven/sqlite_db"
+	"go_hex/internal/handling/ports/handlingsecondary"
+)
+
+// NewSQLiteHandlingEventRepository creates a new SQLite handling event repository
+func NewSQLiteHandlingEventRepository(db *sql.DB) handlingsecondary.HandlingEventRepository {
+	return sql_handling_repo.NewSQLHandlingEventRepository(db, sqlite_db.Dialect)
+}
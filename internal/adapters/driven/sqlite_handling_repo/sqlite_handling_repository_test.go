@@ -0,0 +1,24 @@
+package sqlite_handling_repo
+
+import (
+	"testing"
+
+	"go_hex/internal/adapters/driven/repository_contract"
+	"go_hex/internal/adapters/driven/sqlite_db"
+	"go_hex/internal/adapters/driven/sqlite_outbox"
+	"go_hex/internal/handling/ports/handlingsecondary"
+	"go_hex/internal/support/outbox"
+)
+
+func TestSQLiteHandlingEventRepository(t *testing.T) {
+	repository_contract.HandlingEventRepositoryContract(t, func(t *testing.T) handlingsecondary.HandlingEventRepository {
+		return NewSQLiteHandlingEventRepository(sqlite_db.OpenTestDatabase(t))
+	})
+}
+
+func TestSQLiteHandlingEventOutbox(t *testing.T) {
+	repository_contract.HandlingEventOutboxContract(t, func(t *testing.T) (handlingsecondary.HandlingEventRepository, outbox.Store) {
+		db := sqlite_db.OpenTestDatabase(t)
+		return NewSQLiteHandlingEventRepository(db), sqlite_outbox.NewSQLiteOutbox(db)
+	})
+}
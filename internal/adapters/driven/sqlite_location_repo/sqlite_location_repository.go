@@ -0,0 +1,14 @@
+package sqlite_location_repo
+
+import (
+	"database/sql"
+
+	"go_hex/internal/adapters/driven/sql_location_repo"
+	"go_hex/internal/adapters/driven/sqlite_db"
+	"go_hex/internal/routing/ports/routingsecondary"
+)
+
+// NewSQLiteLocationRepository creates a new SQLite location repository
+func NewSQLiteLocationRepository(db *sql.DB) routingsecondary.LocationRepository {
+	return sql_location_repo.NewSQLLocationRepository(db, sqlite_db.Dialect)
+}
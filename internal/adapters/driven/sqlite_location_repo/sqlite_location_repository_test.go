@@ -0,0 +1,15 @@
+package sqlite_location_repo
+
+import (
+	"testing"
+
+	"go_hex/internal/adapters/driven/repository_contract"
+	"go_hex/internal/adapters/driven/sqlite_db"
+	"go_hex/internal/routing/ports/routingsecondary"
+)
+
+func TestSQLiteLocationRepository(t *testing.T) {
+	repository_contract.LocationRepositoryContract(t, func(t *testing.T) routingsecondary.LocationRepository {
+		return NewSQLiteLocationRepository(sqlite_db.OpenTestDatabase(t))
+	})
+}
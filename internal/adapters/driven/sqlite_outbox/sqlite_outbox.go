@@ -0,0 +1,14 @@
+package sqlite_outbox
+
+import (
+	"database/sql"
+
+	"go_hex/internal/adapters/driven/sql_outbox"
+	"go_hex/internal/adapters/driven/sqlite_db"
+	"go_hex/internal/support/outbox"
+)
+
+// NewSQLiteOutbox creates a new SQLite outbox
+func NewSQLiteOutbox(db *sql.DB) outbox.Store {
+	return sql_outbox.NewSQLOutbox(db, sqlite_db.Dialect)
+}
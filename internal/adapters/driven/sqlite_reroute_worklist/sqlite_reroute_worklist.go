@@ -0,0 +1,14 @@
+package sqlite_reroute_worklist
+
+import (
+	"database/sql"
+
+	"go_hex/internal/adapters/driven/sql_reroute_worklist"
+	"go_hex/internal/adapters/driven/sqlite_db"
+	"go_hex/internal/booking/ports/bookingsecondary"
+)
+
+// NewSQLiteRerouteWorklist creates a new SQLite reroute worklist
+func NewSQLiteRerouteWorklist(db *sql.DB) bookingsecondary.RerouteWorklist {
+	return sql_reroute_worklist.NewSQLRerouteWorklist(db, sqlite_db.Dialect)
+}
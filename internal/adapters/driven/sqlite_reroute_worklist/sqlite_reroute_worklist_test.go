@@ -0,0 +1,15 @@
+package sqlite_reroute_worklist
+
+import (
+	"testing"
+
+	"go_hex/internal/adapters/driven/repository_contract"
+	"go_hex/internal/adapters/driven/sqlite_db"
+	"go_hex/internal/booking/ports/bookingsecondary"
+)
+
+func TestSQLiteRerouteWorklist(t *testing.T) {
+	repository_contract.RerouteWorklistContract(t, func(t *testing.T) bookingsecondary.RerouteWorklist {
+		return NewSQLiteRerouteWorklist(sqlite_db.OpenTestDatabase(t))
+	})
+}
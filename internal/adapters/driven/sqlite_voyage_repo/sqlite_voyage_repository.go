@@ -0,0 +1,21 @@
+package sqlite_voyage_repo
+
+import (
+	"database/sql"
+
+	"go_hex/internal/adapters/driven/sql_voyage_repo"
+	"go_hex/internal/adapters/driven/sqlite_cargo_repo"
+	"go_hex/internal/adapters/driven/sqlite_db"
+	"go_hex/internal/routing/ports/routingsecondary"
+)
+
+// dialect spells the voyage statements for SQLite, guarding deletions with the cargo repository's condition
+var dialect = sql_voyage_repo.Dialect{
+	Dialect:        sqlite_db.Dialect,
+	RoutedOnVoyage: sqlite_cargo_repo.RoutedOnVoyage,
+}
+
+// NewSQLiteVoyageRepository creates a new SQLite voyage repository
+func NewSQLiteVoyageRepository(db *sql.DB) routingsecondary.VoyageRepository {
+	return sql_voyage_repo.NewSQLVoyageRepository(db, dialect)
+}
@@ -0,0 +1,18 @@
+package sqlite_voyage_repo
+
+import (
+	"testing"
+
+	"go_hex/internal/adapters/driven/repository_contract"
+	"go_hex/internal/adapters/driven/sqlite_cargo_repo"
+	"go_hex/internal/adapters/driven/sqlite_db"
+	"go_hex/internal/booking/ports/bookingsecondary"
+	"go_hex/internal/routing/ports/routingsecondary"
+)
+
+func TestSQLiteVoyageRepository(t *testing.T) {
+	repository_contract.VoyageRepositoryContract(t, func(t *testing.T) (routingsecondary.VoyageRepository, bookingsecondary.CargoRepository) {
+		db := sqlite_db.OpenTestDatabase(t)
+		return NewSQLiteVoyageRepository(db), sqlite_cargo_repo.NewSQLiteCargoRepository(db)
+	})
+}
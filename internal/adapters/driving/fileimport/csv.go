@@ -0,0 +1,96 @@
+package fileimport
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"go_hex/internal/handling/handlingdomain"
+)
+
+// csvColumns are the header names of a handling report CSV file; voyage_number may be left out
+var csvColumns = []string{"tracking_id", "event_type", "location", "voyage_number", "completion_time"}
+
+// ParseCSV reads handling reports from a CSV file with a header row naming the columns in any order.
+// A row that cannot be read becomes an entry with Err set, so the other rows are still imported.
+func ParseCSV(r io.Reader) ([]Entry, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("CSV file is empty")
+		}
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	positions, err := csvColumnPositions(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) && errors.Is(parseErr.Err, csv.ErrFieldCount) {
+			entries = append(entries, Entry{
+				Source: fmt.Sprintf("line %d", parseErr.Line),
+				Err:    fmt.Errorf("expected %d fields, got %d", len(header), len(record)),
+			})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		entry := Entry{Source: fmt.Sprintf("line %d", line)}
+
+		field := func(column string) string {
+			position, ok := positions[column]
+			if !ok {
+				return ""
+			}
+			return strings.TrimSpace(record[position])
+		}
+
+		entry.Report = handlingdomain.HandlingReport{
+			TrackingId:     field("tracking_id"),
+			EventType:      strings.ToUpper(field("event_type")),
+			Location:       strings.ToUpper(field("location")),
+			VoyageNumber:   field("voyage_number"),
+			CompletionTime: field("completion_time"),
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// csvColumnPositions maps the known column names to their position in header
+func csvColumnPositions(header []string) (map[string]int, error) {
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, duplicate := positions[name]; duplicate {
+			return nil, fmt.Errorf("CSV header names column %s twice", name)
+		}
+		positions[name] = i
+	}
+
+	for _, column := range csvColumns {
+		if _, ok := positions[column]; !ok && column != "voyage_number" {
+			return nil, fmt.Errorf("CSV header is missing column %s", column)
+		}
+	}
+
+	return positions, nil
+}
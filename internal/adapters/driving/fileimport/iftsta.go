@@ -0,0 +1,217 @@
+package fileimport
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"go_hex/internal/handling/handlingdomain"
+)
+
+// iftstaStatusCodes maps the STS status codes our partner terminals send to handling event types
+var iftstaStatusCodes = map[string]handlingdomain.HandlingEventType{
+	"RCV": handlingdomain.HandlingEventTypeReceive,
+	"LOD": handlingdomain.HandlingEventTypeLoad,
+	"DIS": handlingdomain.HandlingEventTypeUnload,
+	"DLV": handlingdomain.HandlingEventTypeClaim,
+	"CUS": handlingdomain.HandlingEventTypeCustoms,
+}
+
+// iftstaDateFormats maps DTM date/time format qualifiers to Go layouts; times without zone are UTC
+var iftstaDateFormats = map[string]string{
+	"203": "200601021504",
+	"204": "20060102150405",
+}
+
+// edifactSyntax holds the separators of an EDIFACT interchange, as declared by its UNA segment
+type edifactSyntax struct {
+	component byte
+	element   byte
+	release   byte
+	segment   byte
+}
+
+// defaultEdifactSyntax is used when an interchange has no UNA segment
+var defaultEdifactSyntax = edifactSyntax{component: ':', element: '+', release: '?', segment: '\''}
+
+// ParseIFTSTA reads handling reports from a UN/EDIFACT IFTSTA status message. Only the subset our
+// partner terminals use is understood:
+//
+//	CNI+<seq>+<tracking id>'              starts a consignment
+//	STS+<seq>+<status code>'              starts a status, see iftstaStatusCodes
+//	LOC+175+<UN/LOCODE>'                  place where the status happened
+//	DTM+334:<date/time>:<format>'         when it happened, format 203 or 204
+//	TDT+20+<voyage number>'               voyage for load and discharge statuses
+//
+// Each STS becomes one entry; a status that cannot be read becomes an entry with Err set.
+func ParseIFTSTA(r io.Reader) ([]Entry, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read EDIFACT file: %w", err)
+	}
+
+	syntax, body := readServiceStringAdvice(string(content))
+	segments := splitEdifact(body, syntax.segment, syntax.release)
+
+	var entries []Entry
+	var trackingId string
+	var current *Entry
+	messageType := ""
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		if current.Err == nil {
+			current.Err = checkIFTSTAStatus(current.Report)
+		}
+		entries = append(entries, *current)
+		current = nil
+	}
+
+	for i, segment := range segments {
+		segment = strings.TrimSpace(segment)
+		if segment == "" {
+			continue
+		}
+
+		elements := splitEdifact(segment, syntax.element, syntax.release)
+		element := func(index, component int) string {
+			if index >= len(elements) {
+				return ""
+			}
+			components := splitEdifact(elements[index], syntax.component, syntax.release)
+			if component >= len(components) {
+				return ""
+			}
+			return unescapeEdifact(components[component], syntax.release)
+		}
+
+		switch elements[0] {
+		case "UNH":
+			flush()
+			messageType = element(2, 0)
+			if messageType != "IFTSTA" {
+				return nil, fmt.Errorf("segment %d: message type %s is not IFTSTA", i+1, messageType)
+			}
+		case "CNI":
+			flush()
+			trackingId = element(2, 0)
+		case "STS":
+			flush()
+			current = &Entry{
+				Source: fmt.Sprintf("segment %d", i+1),
+				Report: handlingdomain.HandlingReport{TrackingId: trackingId},
+			}
+			code := element(2, 0)
+			eventType, ok := iftstaStatusCodes[code]
+			if !ok {
+				current.Err = fmt.Errorf("unsupported status code %q", code)
+				continue
+			}
+			current.Report.EventType = string(eventType)
+		case "LOC":
+			if current != nil && element(1, 0) == "175" {
+				current.Report.Location = element(2, 0)
+			}
+		case "DTM":
+			if current != nil && element(1, 0) == "334" {
+				completionTime, err := parseIFTSTADate(element(1, 1), element(1, 2))
+				if err != nil && current.Err == nil {
+					current.Err = err
+				}
+				current.Report.CompletionTime = completionTime
+			}
+		case "TDT":
+			if current != nil && element(1, 0) == "20" {
+				current.Report.VoyageNumber = element(2, 0)
+			}
+		case "UNT", "UNZ":
+			flush()
+			trackingId = ""
+		}
+	}
+	flush()
+
+	if messageType == "" {
+		return nil, errors.New("EDIFACT file contains no message header (UNH)")
+	}
+
+	return entries, nil
+}
+
+// checkIFTSTAStatus reports segments a status group needs but did not have
+func checkIFTSTAStatus(report handlingdomain.HandlingReport) error {
+	switch {
+	case report.TrackingId == "":
+		return errors.New("status is not part of a consignment (CNI)")
+	case report.Location == "":
+		return errors.New("status has no activity location (LOC+175)")
+	case report.CompletionTime == "":
+		return errors.New("status has no status date/time (DTM+334)")
+	}
+	return nil
+}
+
+// parseIFTSTADate converts a DTM value in the given format to RFC3339
+func parseIFTSTADate(value, format string) (string, error) {
+	layout, ok := iftstaDateFormats[format]
+	if !ok {
+		return "", fmt.Errorf("unsupported date/time format %q", format)
+	}
+	parsed, err := time.Parse(layout, value)
+	if err != nil {
+		return "", fmt.Errorf("invalid date/time %q for format %s", value, format)
+	}
+	return parsed.Format(time.RFC3339), nil
+}
+
+// readServiceStringAdvice takes the separators from a leading UNA segment and returns the rest of the interchange
+func readServiceStringAdvice(content string) (edifactSyntax, string) {
+	content = strings.TrimLeft(content, " \t\r\n\ufeff")
+	if !strings.HasPrefix(content, "UNA") || len(content) < 9 {
+		return defaultEdifactSyntax, content
+	}
+
+	// UNA is followed by the component, element, decimal, release, reserved and segment characters
+	syntax := edifactSyntax{
+		component: content[3],
+		element:   content[4],
+		release:   content[6],
+		segment:   content[8],
+	}
+	return syntax, content[9:]
+}
+
+// splitEdifact splits s at separator, ignoring separators escaped with the release character
+func splitEdifact(s string, separator, release byte) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case release:
+			i++
+		case separator:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// unescapeEdifact removes release characters from a data value
+func unescapeEdifact(s string, release byte) string {
+	if strings.IndexByte(s, release) < 0 {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == release && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
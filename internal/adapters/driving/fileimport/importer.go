@@ -0,0 +1,161 @@
+// Package fileimport is the driving adapter for partner terminals that deliver handling reports as files
+package fileimport
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go_hex/internal/handling/handlingdomain"
+	"go_hex/internal/handling/ports/handlingprimary"
+	"go_hex/internal/support/auth"
+)
+
+// submitBatchSize bounds how many reports are handed to the handling service in one call
+const submitBatchSize = 1000
+
+// Entry is a handling report read from a file, with where in the file it came from
+type Entry struct {
+	Source string
+	Report handlingdomain.HandlingReport
+	Err    error // set when the entry could not be read into a report
+}
+
+// Problem is an entry of a file that was not registered
+type Problem struct {
+	Source string
+	Reason string
+}
+
+// FileResult summarizes the import of one file
+type FileResult struct {
+	File        string
+	Accepted    int
+	Quarantined int
+	Duplicates  int // entries already registered, e.g. by an earlier import of the same file
+	Problems    []Problem
+}
+
+// Failed reports whether any entry of the file was not registered
+func (r FileResult) Failed() bool {
+	return len(r.Problems) > 0
+}
+
+// UnreadableFileError reports a file that cannot be read into handling reports, so importing it again fails the same way
+type UnreadableFileError struct {
+	File string
+	Err  error
+}
+
+func (e UnreadableFileError) Error() string {
+	return e.Err.Error()
+}
+
+func (e UnreadableFileError) Unwrap() error {
+	return e.Err
+}
+
+// Importer reads handling report files and submits their reports to the handling context
+type Importer struct {
+	handlingReportService handlingprimary.HandlingReportService
+	quarantineRetained    bool
+	logger                *slog.Logger
+}
+
+// NewImporter creates a new file importer. Unless quarantineRetained is set, the handling service's quarantine
+// does not outlive the process, so quarantined entries are reported as not registered instead of being lost.
+func NewImporter(handlingReportService handlingprimary.HandlingReportService, quarantineRetained bool, logger *slog.Logger) *Importer {
+	return &Importer{
+		handlingReportService: handlingReportService,
+		quarantineRetained:    quarantineRetained,
+		logger:                logger,
+	}
+}
+
+// ImportFile imports the handling reports of the file at path, choosing the format by its extension
+func (i *Importer) ImportFile(ctx context.Context, path string) (FileResult, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return FileResult{File: path}, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	return i.Import(ctx, path, file)
+}
+
+// Import imports handling reports read from r; name selects the format: .csv for CSV, .edi or .iftsta for IFTSTA
+func (i *Importer) Import(ctx context.Context, name string, r io.Reader) (FileResult, error) {
+	result := FileResult{File: name}
+
+	var entries []Entry
+	var err error
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		entries, err = ParseCSV(r)
+	case ".edi", ".iftsta":
+		entries, err = ParseIFTSTA(r)
+	default:
+		return result, UnreadableFileError{File: name, Err: fmt.Errorf("unsupported file type %q, expected .csv, .edi or .iftsta", filepath.Ext(name))}
+	}
+	if err != nil {
+		return result, UnreadableFileError{File: name, Err: fmt.Errorf("failed to parse %s: %w", name, err)}
+	}
+
+	i.logger.Info("Importing handling report file", "file", name, "entries", len(entries))
+
+	var reports []handlingdomain.HandlingReport
+	var sources []string
+	for _, entry := range entries {
+		if entry.Err != nil {
+			result.Problems = append(result.Problems, Problem{Source: entry.Source, Reason: entry.Err.Error()})
+			continue
+		}
+		reports = append(reports, entry.Report)
+		sources = append(sources, entry.Source)
+	}
+
+	importCtx := auth.WithServiceClaims(ctx, "file-import",
+		auth.BookingClaims{}, auth.RoutingClaims{}, auth.HandlingClaims{CanSubmitHandling: true})
+
+	for start := 0; start < len(reports); start += submitBatchSize {
+		end := min(start+submitBatchSize, len(reports))
+
+		results, err := i.handlingReportService.SubmitHandlingReports(importCtx, reports[start:end])
+		if err != nil {
+			return result, fmt.Errorf("failed to submit handling reports of %s: %w", name, err)
+		}
+
+		for _, submitted := range results {
+			switch submitted.Status {
+			case handlingdomain.HandlingReportAccepted:
+				result.Accepted++
+			case handlingdomain.HandlingReportQuarantined:
+				if !i.quarantineRetained {
+					result.Problems = append(result.Problems, Problem{
+						Source: sources[start+submitted.Index],
+						Reason: submitted.Reason + " (quarantine is not retained, resubmit the entry)",
+					})
+					continue
+				}
+				result.Quarantined++
+			case handlingdomain.HandlingReportDuplicate:
+				result.Duplicates++
+			default:
+				result.Problems = append(result.Problems, Problem{Source: sources[start+submitted.Index], Reason: submitted.Reason})
+			}
+		}
+	}
+
+	i.logger.Info("Imported handling report file",
+		"file", name,
+		"accepted", result.Accepted,
+		"quarantined", result.Quarantined,
+		"duplicates", result.Duplicates,
+		"problems", len(result.Problems),
+	)
+	return result, nil
+}
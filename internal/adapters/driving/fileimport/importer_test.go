@@ -0,0 +1,225 @@
+package fileimport
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go_hex/internal/handling/handlingdomain"
+	"go_hex/internal/handling/ports/handlingprimary"
+	"go_hex/internal/support/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockHandlingReportService struct {
+	mock.Mock
+}
+
+func (m *MockHandlingReportService) SubmitHandlingReport(ctx context.Context, report handlingdomain.HandlingReport) (handlingdomain.HandlingReceipt, error) {
+	args := m.Called(ctx, report)
+	return args.Get(0).(handlingdomain.HandlingReceipt), args.Error(1)
+}
+
+func (m *MockHandlingReportService) SubmitHandlingReports(ctx context.Context, reports []handlingdomain.HandlingReport) ([]handlingdomain.HandlingReportResult, error) {
+	args := m.Called(ctx, reports)
+	results, _ := args.Get(0).([]handlingdomain.HandlingReportResult)
+	return results, args.Error(1)
+}
+
+func (m *MockHandlingReportService) BackfillHandlingReports(ctx context.Context, reports []handlingdomain.HandlingReport) ([]handlingdomain.HandlingReportResult, error) {
+	args := m.Called(ctx, reports)
+	results, _ := args.Get(0).([]handlingdomain.HandlingReportResult)
+	return results, args.Error(1)
+}
+
+func (m *MockHandlingReportService) StartHandlingReportBatch(ctx context.Context) (handlingprimary.HandlingReportBatch, error) {
+	return mockHandlingReportBatch(m.SubmitHandlingReports), nil
+}
+
+func (m *MockHandlingReportService) StartHandlingBackfill(ctx context.Context) (handlingprimary.HandlingReportBatch, error) {
+	return mockHandlingReportBatch(m.BackfillHandlingReports), nil
+}
+
+func (m *MockHandlingReportService) ListQuarantinedReports(ctx context.Context) ([]handlingdomain.QuarantinedReport, error) {
+	args := m.Called(ctx)
+	reports, _ := args.Get(0).([]handlingdomain.QuarantinedReport)
+	return reports, args.Error(1)
+}
+
+func (m *MockHandlingReportService) ReleaseQuarantinedReport(ctx context.Context, id string) (handlingdomain.HandlingReceipt, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(handlingdomain.HandlingReceipt), args.Error(1)
+}
+
+// mockHandlingReportBatch submits each part of a batch to the mocked batch method, so the parts are expected as calls of it
+type mockHandlingReportBatch func(context.Context, []handlingdomain.HandlingReport) ([]handlingdomain.HandlingReportResult, error)
+
+func (b mockHandlingReportBatch) Submit(ctx context.Context, reports []handlingdomain.HandlingReport) ([]handlingdomain.HandlingReportResult, error) {
+	return b(ctx, reports)
+}
+
+const importCSV = "tracking_id,event_type,location,voyage_number,completion_time\n" +
+	"TEST123,RECEIVE,SESTO,,2024-01-20T08:00:00Z\n" +
+	"TEST123,LOAD\n" +
+	"TEST123,LOAD,SESTO,V999,2024-01-20T09:30:00Z\n"
+
+func TestImporter(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	t.Run("should submit readable entries and collect problems", func(t *testing.T) {
+		// Setup
+		service := &MockHandlingReportService{}
+		service.On("SubmitHandlingReports", mock.MatchedBy(func(ctx context.Context) bool {
+			claims, err := auth.ExtractClaims(ctx)
+			return err == nil && claims.HandlingClaims.CanSubmitHandling
+		}), mock.MatchedBy(func(reports []handlingdomain.HandlingReport) bool {
+			return len(reports) == 2
+		})).Return([]handlingdomain.HandlingReportResult{
+			{Index: 0, TrackingId: "TEST123", Status: handlingdomain.HandlingReportAccepted},
+			{Index: 1, TrackingId: "TEST123", Status: handlingdomain.HandlingReportRejected, Reason: "handling report rejected: voyage V999 is unknown"},
+		}, nil)
+		importer := NewImporter(service, true, logger)
+
+		// Execute
+		result, err := importer.Import(context.Background(), "scans.csv", strings.NewReader(importCSV))
+
+		// Verify
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Accepted)
+		assert.True(t, result.Failed())
+		assert.Equal(t, []Problem{
+			{Source: "line 3", Reason: "expected 5 fields, got 2"},
+			{Source: "line 4", Reason: "handling report rejected: voyage V999 is unknown"},
+		}, result.Problems)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("should reject unsupported file type", func(t *testing.T) {
+		// Setup
+		service := &MockHandlingReportService{}
+		importer := NewImporter(service, true, logger)
+
+		// Execute
+		_, err := importer.Import(context.Background(), "scans.xlsx", strings.NewReader(""))
+
+		// Verify
+		assert.ErrorContains(t, err, "unsupported file type")
+		assert.ErrorAs(t, err, &UnreadableFileError{})
+		service.AssertNotCalled(t, "SubmitHandlingReports", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should fail when reports cannot be submitted", func(t *testing.T) {
+		// Setup
+		service := &MockHandlingReportService{}
+		service.On("SubmitHandlingReports", mock.Anything, mock.Anything).Return(nil, errors.New("database unavailable"))
+		importer := NewImporter(service, true, logger)
+
+		// Execute
+		_, err := importer.Import(context.Background(), "scans.csv", strings.NewReader(importCSV))
+
+		// Verify
+		assert.ErrorContains(t, err, "database unavailable")
+		assert.False(t, errors.As(err, &UnreadableFileError{}))
+	})
+
+	t.Run("should report quarantined entries as problems when the quarantine is not retained", func(t *testing.T) {
+		// Setup
+		service := &MockHandlingReportService{}
+		service.On("SubmitHandlingReports", mock.Anything, mock.Anything).Return([]handlingdomain.HandlingReportResult{
+			{Index: 0, Status: handlingdomain.HandlingReportAccepted},
+			{Index: 1, Status: handlingdomain.HandlingReportQuarantined, Reason: "handling report quarantined: out of sequence"},
+		}, nil)
+		importer := NewImporter(service, false, logger)
+
+		// Execute
+		result, err := importer.Import(context.Background(), "scans.csv", strings.NewReader(importCSV))
+
+		// Verify
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Quarantined)
+		assert.True(t, result.Failed())
+		assert.Contains(t, result.Problems, Problem{
+			Source: "line 4",
+			Reason: "handling report quarantined: out of sequence (quarantine is not retained, resubmit the entry)",
+		})
+	})
+}
+
+func TestDirectoryWatcher(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	setupWithError := func(t *testing.T, results []handlingdomain.HandlingReportResult, err error) (*DirectoryWatcher, string) {
+		service := &MockHandlingReportService{}
+		service.On("SubmitHandlingReports", mock.Anything, mock.Anything).Return(results, err)
+		inbox := t.TempDir()
+		return NewDirectoryWatcher(NewImporter(service, true, logger), inbox, 0, logger), inbox
+	}
+	setup := func(t *testing.T, results []handlingdomain.HandlingReportResult) (*DirectoryWatcher, string) {
+		return setupWithError(t, results, nil)
+	}
+
+	t.Run("should move fully imported file to done", func(t *testing.T) {
+		// Setup
+		watcher, inbox := setup(t, []handlingdomain.HandlingReportResult{
+			{Index: 0, Status: handlingdomain.HandlingReportAccepted},
+		})
+		content := "tracking_id,event_type,location,completion_time\nTEST123,RECEIVE,SESTO,2024-01-20T08:00:00Z\n"
+		require.NoError(t, os.WriteFile(filepath.Join(inbox, "scans.csv"), []byte(content), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(inbox, "upload.csv.tmp"), []byte(content), 0o644))
+
+		// Execute
+		err := watcher.Scan(context.Background())
+
+		// Verify
+		require.NoError(t, err)
+		assert.FileExists(t, filepath.Join(inbox, "done", "scans.csv"))
+		assert.NoFileExists(t, filepath.Join(inbox, "scans.csv"))
+		assert.FileExists(t, filepath.Join(inbox, "upload.csv.tmp"))
+	})
+
+	t.Run("should move file with problems to failed next to an error report", func(t *testing.T) {
+		// Setup
+		watcher, inbox := setup(t, []handlingdomain.HandlingReportResult{
+			{Index: 0, Status: handlingdomain.HandlingReportAccepted},
+			{Index: 1, Status: handlingdomain.HandlingReportRejected, Reason: "handling report rejected: voyage V999 is unknown"},
+		})
+		require.NoError(t, os.WriteFile(filepath.Join(inbox, "scans.csv"), []byte(importCSV), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(inbox, "notes.txt"), []byte("hello"), 0o644))
+
+		// Execute
+		err := watcher.Scan(context.Background())
+
+		// Verify
+		require.NoError(t, err)
+		assert.FileExists(t, filepath.Join(inbox, "failed", "scans.csv"))
+		report, err := os.ReadFile(filepath.Join(inbox, "failed", "scans.csv.errors.txt"))
+		require.NoError(t, err)
+		assert.Contains(t, string(report), "scans.csv: 1 accepted, 0 quarantined, 0 duplicate, 2 not registered")
+		assert.Contains(t, string(report), "line 4: handling report rejected: voyage V999 is unknown")
+
+		notes, err := os.ReadFile(filepath.Join(inbox, "failed", "notes.txt.errors.txt"))
+		require.NoError(t, err)
+		assert.Contains(t, string(notes), "unsupported file type")
+	})
+
+	t.Run("should leave file in inbox when reports cannot be submitted", func(t *testing.T) {
+		// Setup
+		watcher, inbox := setupWithError(t, nil, errors.New("database unavailable"))
+		require.NoError(t, os.WriteFile(filepath.Join(inbox, "scans.csv"), []byte(importCSV), 0o644))
+
+		// Execute
+		err := watcher.Scan(context.Background())
+
+		// Verify
+		require.NoError(t, err)
+		assert.FileExists(t, filepath.Join(inbox, "scans.csv"))
+		assert.NoFileExists(t, filepath.Join(inbox, "failed", "scans.csv"))
+	})
+}
@@ -0,0 +1,143 @@
+package fileimport
+
+import (
+	"strings"
+	"testing"
+
+	"go_hex/internal/handling/handlingdomain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCSV(t *testing.T) {
+	t.Run("should read reports with columns in any order", func(t *testing.T) {
+		// Setup
+		content := "completion_time,tracking_id,event_type,location,voyage_number\n" +
+			"2024-01-20T08:00:00Z,550e8400-e29b-41d4-a716-446655440000,receive,sesto,\n" +
+			"2024-01-20T09:30:00Z,550e8400-e29b-41d4-a716-446655440000,LOAD,SESTO,V001\n"
+
+		// Execute
+		entries, err := ParseCSV(strings.NewReader(content))
+
+		// Verify
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "line 2", entries[0].Source)
+		assert.Equal(t, handlingdomain.HandlingReport{
+			TrackingId:     "550e8400-e29b-41d4-a716-446655440000",
+			EventType:      "RECEIVE",
+			Location:       "SESTO",
+			CompletionTime: "2024-01-20T08:00:00Z",
+		}, entries[0].Report)
+		assert.Equal(t, "V001", entries[1].Report.VoyageNumber)
+		assert.NoError(t, entries[1].Err)
+	})
+
+	t.Run("should keep reading after a row with the wrong number of fields", func(t *testing.T) {
+		// Setup
+		content := "tracking_id,event_type,location,completion_time\n" +
+			"TEST123,RECEIVE\n" +
+			"TEST123,RECEIVE,SESTO,2024-01-20T08:00:00Z\n"
+
+		// Execute
+		entries, err := ParseCSV(strings.NewReader(content))
+
+		// Verify
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "line 2", entries[0].Source)
+		assert.ErrorContains(t, entries[0].Err, "expected 4 fields, got 2")
+		assert.NoError(t, entries[1].Err)
+	})
+
+	t.Run("should reject file without required column", func(t *testing.T) {
+		// Execute
+		_, err := ParseCSV(strings.NewReader("tracking_id,event_type,completion_time\n"))
+
+		// Verify
+		assert.ErrorContains(t, err, "missing column location")
+	})
+
+	t.Run("should reject empty file", func(t *testing.T) {
+		// Execute
+		_, err := ParseCSV(strings.NewReader(""))
+
+		// Verify
+		assert.ErrorContains(t, err, "empty")
+	})
+}
+
+func TestParseIFTSTA(t *testing.T) {
+	t.Run("should read one report per status", func(t *testing.T) {
+		// Setup
+		content := "UNA:+.? '\n" +
+			"UNB+UNOC:3+TERMINAL+GOHEX+240120:0900+1'\n" +
+			"UNH+1+IFTSTA:D:99B:UN'\n" +
+			"BGM+23+STATUS1+9'\n" +
+			"CNI+1+550e8400-e29b-41d4-a716-446655440000'\n" +
+			"STS+1+RCV'\n" +
+			"DTM+334:202401200800:203'\n" +
+			"LOC+175+SESTO'\n" +
+			"STS+2+LOD'\n" +
+			"DTM+334:20240120093000:204'\n" +
+			"LOC+175+SESTO'\n" +
+			"TDT+20+V?+001'\n" +
+			"UNT+12+1'\n" +
+			"UNZ+1+1'\n"
+
+		// Execute
+		entries, err := ParseIFTSTA(strings.NewReader(content))
+
+		// Verify
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "segment 5", entries[0].Source)
+		assert.NoError(t, entries[0].Err)
+		assert.Equal(t, handlingdomain.HandlingReport{
+			TrackingId:     "550e8400-e29b-41d4-a716-446655440000",
+			EventType:      "RECEIVE",
+			Location:       "SESTO",
+			CompletionTime: "2024-01-20T08:00:00Z",
+		}, entries[0].Report)
+		assert.Equal(t, "LOAD", entries[1].Report.EventType)
+		assert.Equal(t, "V+001", entries[1].Report.VoyageNumber)
+		assert.Equal(t, "2024-01-20T09:30:00Z", entries[1].Report.CompletionTime)
+	})
+
+	t.Run("should report statuses that cannot be read", func(t *testing.T) {
+		// Setup
+		content := "UNH+1+IFTSTA:D:99B:UN'" +
+			"CNI+1+TEST123'" +
+			"STS+1+XXX'DTM+334:202401200800:203'LOC+175+SESTO'" +
+			"STS+2+RCV'DTM+334:202401200800:203'" +
+			"STS+3+RCV'DTM+334:20240120:102'LOC+175+SESTO'" +
+			"UNT+9+1'"
+
+		// Execute
+		entries, err := ParseIFTSTA(strings.NewReader(content))
+
+		// Verify
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+		assert.ErrorContains(t, entries[0].Err, `unsupported status code "XXX"`)
+		assert.ErrorContains(t, entries[1].Err, "no activity location")
+		assert.ErrorContains(t, entries[2].Err, `unsupported date/time format "102"`)
+	})
+
+	t.Run("should reject other message types", func(t *testing.T) {
+		// Execute
+		_, err := ParseIFTSTA(strings.NewReader("UNH+1+IFTMIN:D:99B:UN'UNT+2+1'"))
+
+		// Verify
+		assert.ErrorContains(t, err, "not IFTSTA")
+	})
+
+	t.Run("should reject file without message header", func(t *testing.T) {
+		// Execute
+		_, err := ParseIFTSTA(strings.NewReader("tracking_id,event_type\n"))
+
+		// Verify
+		assert.ErrorContains(t, err, "no message header")
+	})
+}
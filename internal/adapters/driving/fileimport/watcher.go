@@ -0,0 +1,147 @@
+package fileimport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DirectoryWatcher imports every file dropped into an inbox directory. Imported files are moved to the
+// done folder; unreadable files and files with entries that were not registered are moved to the failed
+// folder next to an error report listing those entries. A file whose import fails for another reason,
+// such as unavailable storage, stays in the inbox and is imported again on the next scan. Partners should
+// write files under a name starting with "." or ending in ".tmp" and rename them when complete, so
+// half-written files are not picked up.
+type DirectoryWatcher struct {
+	importer  *Importer
+	inbox     string
+	doneDir   string
+	failedDir string
+	interval  time.Duration
+	logger    *slog.Logger
+}
+
+// NewDirectoryWatcher creates a watcher polling inbox every interval, using the done and failed folders inside it
+func NewDirectoryWatcher(importer *Importer, inbox string, interval time.Duration, logger *slog.Logger) *DirectoryWatcher {
+	return &DirectoryWatcher{
+		importer:  importer,
+		inbox:     inbox,
+		doneDir:   filepath.Join(inbox, "done"),
+		failedDir: filepath.Join(inbox, "failed"),
+		interval:  interval,
+		logger:    logger,
+	}
+}
+
+// Run scans the inbox until ctx is cancelled, logging failed scans and trying again on the next tick
+func (w *DirectoryWatcher) Run(ctx context.Context) {
+	w.logger.Info("Watching handling report inbox", "inbox", w.inbox, "interval", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.Scan(ctx); err != nil {
+			w.logger.Error("Failed to scan handling report inbox", "inbox", w.inbox, "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Stopped watching handling report inbox", "inbox", w.inbox)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Scan imports the files currently in the inbox, in name order. A file that cannot be moved out of the
+// inbox is logged and left for the next scan.
+func (w *DirectoryWatcher) Scan(ctx context.Context) error {
+	for _, dir := range []string{w.doneDir, w.failedDir} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+	}
+
+	files, err := os.ReadDir(w.inbox)
+	if err != nil {
+		return fmt.Errorf("failed to read inbox %s: %w", w.inbox, err)
+	}
+
+	for _, file := range files {
+		if ctx.Err() != nil {
+			return nil
+		}
+		name := file.Name()
+		if !file.Type().IsRegular() || strings.HasPrefix(name, ".") || strings.HasSuffix(name, ".tmp") {
+			continue
+		}
+
+		if err := w.process(ctx, name); err != nil {
+			w.logger.Error("Failed to file away handling report file", "file", name, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// process imports one inbox file and moves it to done or failed, leaving it in the inbox when the import may succeed later
+func (w *DirectoryWatcher) process(ctx context.Context, name string) error {
+	path := filepath.Join(w.inbox, name)
+
+	result, err := w.importer.ImportFile(ctx, path)
+	if err != nil {
+		var unreadable UnreadableFileError
+		if !errors.As(err, &unreadable) {
+			w.logger.Warn("Failed to import handling report file, retrying on next scan", "file", path, "error", err)
+			return nil
+		}
+		w.logger.Error("Failed to import handling report file", "file", path, "error", err)
+		result.Problems = append(result.Problems, Problem{Source: "file", Reason: err.Error()})
+	}
+
+	if !result.Failed() {
+		_, err := moveFile(path, w.doneDir)
+		return err
+	}
+
+	moved, err := moveFile(path, w.failedDir)
+	if err != nil {
+		return err
+	}
+	return writeErrorReport(moved+".errors.txt", result)
+}
+
+// moveFile moves path into dir, adding a timestamp to the name if a file of that name is already there
+func moveFile(path, dir string) (string, error) {
+	target := filepath.Join(dir, filepath.Base(path))
+	if _, err := os.Stat(target); err == nil {
+		ext := filepath.Ext(target)
+		target = fmt.Sprintf("%s-%s%s", strings.TrimSuffix(target, ext), time.Now().UTC().Format("20060102T150405.000000000"), ext)
+	}
+
+	if err := os.Rename(path, target); err != nil {
+		return "", fmt.Errorf("failed to move %s to %s: %w", path, dir, err)
+	}
+	return target, nil
+}
+
+// writeErrorReport writes one line per entry of result that was not registered
+func writeErrorReport(path string, result FileResult) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %d accepted, %d quarantined, %d duplicate, %d not registered\n",
+		filepath.Base(result.File), result.Accepted, result.Quarantined, result.Duplicates, len(result.Problems))
+	for _, problem := range result.Problems {
+		fmt.Fprintf(&b, "%s: %s\n", problem.Source, problem.Reason)
+	}
+
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write error report %s: %w", path, err)
+	}
+	return nil
+}
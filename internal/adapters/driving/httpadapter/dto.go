@@ -6,6 +6,7 @@ import (
 	"go_hex/internal/booking/bookingdomain"
 	"go_hex/internal/handling/handlingdomain"
 	"go_hex/internal/routing/routingdomain"
+	"go_hex/internal/tracking/trackingdomain"
 )
 
 // BookCargoRequest represents the request payload for booking cargo
@@ -39,6 +40,29 @@ type CargoDetailsResponse struct {
 	LastKnownLocation   *string       `json:"lastKnownLocation,omitempty"`
 	CurrentVoyageNumber *string       `json:"currentVoyageNumber,omitempty"`
 	Itinerary           *ItineraryDTO `json:"itinerary,omitempty"`
+	Version             int           `json:"version"`
+
+	// NextExpectedActivity is the handling the itinerary plans next; omitted when none is expected
+	NextExpectedActivity *HandlingActivityDTO `json:"nextExpectedActivity,omitempty"`
+
+	// EstimatedTimeOfArrival follows the handling progress; it and the slack to the arrival deadline are omitted
+	// when no arrival can be estimated
+	EstimatedTimeOfArrival *string `json:"estimatedTimeOfArrival,omitempty"`
+	ArrivalSlackSeconds    *int64  `json:"arrivalSlackSeconds,omitempty"`
+	IsLate                 bool    `json:"isLate"`
+}
+
+// HandlingActivityDTO represents a handling step a cargo is expected to go through
+type HandlingActivityDTO struct {
+	Type         string `json:"type"`
+	Location     string `json:"location"`
+	VoyageNumber string `json:"voyageNumber,omitempty"`
+}
+
+// CargoListResponse represents one page of a cargo listing
+type CargoListResponse struct {
+	Cargos     []CargoDetailsResponse `json:"cargos"`
+	NextCursor string                 `json:"nextCursor,omitempty"`
 }
 
 // ItineraryDTO represents an itinerary for API responses
@@ -55,17 +79,88 @@ type LegDTO struct {
 	UnloadTime     string `json:"unloadTime"`
 }
 
+// CargoTimelineResponse represents the booking, itinerary progress and handling history of a cargo
+type CargoTimelineResponse struct {
+	TrackingId       string             `json:"trackingId"`
+	Origin           string             `json:"origin"`
+	Destination      string             `json:"destination"`
+	ArrivalDeadline  string             `json:"arrivalDeadline"`
+	RoutingStatus    string             `json:"routingStatus"`
+	TransportStatus  string             `json:"transportStatus"`
+	Legs             []TimelineLegDTO   `json:"legs"`
+	Milestones       []MilestoneDTO     `json:"milestones"`
+	Events           []TimelineEventDTO `json:"events"`
+	ExpectedPosition *PositionDTO       `json:"expectedPosition,omitempty"`
+	ActualPosition   *PositionDTO       `json:"actualPosition,omitempty"`
+	IsOnSchedule     bool               `json:"isOnSchedule"`
+	AsOf             string             `json:"asOf"`
+
+	// EstimatedTimeOfArrival and ArrivalSlackSeconds are omitted when no arrival can be estimated
+	EstimatedTimeOfArrival *string `json:"estimatedTimeOfArrival,omitempty"`
+	ArrivalSlackSeconds    *int64  `json:"arrivalSlackSeconds,omitempty"`
+	IsLate                 bool    `json:"isLate"`
+}
+
+// TimelineLegDTO represents a leg of the itinerary with the handling that happened on it
+type TimelineLegDTO struct {
+	LegDTO
+	Status           string  `json:"status"`
+	ActualLoadTime   *string `json:"actualLoadTime,omitempty"`
+	ActualUnloadTime *string `json:"actualUnloadTime,omitempty"`
+}
+
+// MilestoneDTO represents a step of the booking on the timeline, such as the booking or the route assignment
+type MilestoneDTO struct {
+	Type string `json:"type"`
+	Time string `json:"time"`
+}
+
+// TimelineEventDTO represents a handling event placed against the itinerary
+type TimelineEventDTO struct {
+	HandlingEventDTO
+	RegisteredAt string `json:"registeredAt"`
+	Leg          *int   `json:"leg,omitempty"`
+	Expected     bool   `json:"expected"`
+}
+
+// PositionDTO represents where a cargo is: in port at location, or aboard voyageNumber after loading there
+type PositionDTO struct {
+	Location     string `json:"location"`
+	VoyageNumber string `json:"voyageNumber,omitempty"`
+}
+
 // RouteCandidatesResponse represents available route options
 type RouteCandidatesResponse struct {
 	TrackingId string         `json:"trackingId"`
 	Routes     []ItineraryDTO `json:"routes"`
 }
 
+// RerouteTaskResponse represents misdirected cargo waiting on the reroute worklist with its route candidates
+type RerouteTaskResponse struct {
+	TrackingId      string         `json:"trackingId"`
+	From            string         `json:"from"`
+	Destination     string         `json:"destination"`
+	ArrivalDeadline string         `json:"arrivalDeadline"`
+	Candidates      []ItineraryDTO `json:"candidates"`
+	Since           string         `json:"since"`
+}
+
 // AssignRouteRequest represents the request to assign a route to cargo
 type AssignRouteRequest struct {
 	Legs []LegDTO `json:"legs" validate:"required,min=1,dive"`
 }
 
+// ChangeRouteSpecificationRequest represents the request to change a cargo's destination and/or arrival deadline
+type ChangeRouteSpecificationRequest struct {
+	Destination     string `json:"destination,omitempty" validate:"required_without=ArrivalDeadline,omitempty,min=2,max=10"`
+	ArrivalDeadline string `json:"arrivalDeadline,omitempty" validate:"required_without=Destination"`
+}
+
+// CancelCargoRequest represents the request to cancel a cargo booking
+type CancelCargoRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
 // HandlingEventRequest represents a request to register a handling event
 type HandlingEventRequest struct {
 	TrackingId     string `json:"trackingId" validate:"required"`
@@ -73,6 +168,7 @@ type HandlingEventRequest struct {
 	Location       string `json:"location" validate:"required,min=2,max=10"`
 	VoyageNumber   string `json:"voyageNumber,omitempty"`
 	CompletionTime string `json:"completionTime" validate:"required"`
+	IdempotencyKey string `json:"idempotencyKey,omitempty" validate:"max=255"`
 }
 
 // HandlingEventResponse represents the response after registering a handling event
@@ -86,6 +182,43 @@ type HandlingEventResponse struct {
 	RegisteredAt   string `json:"registeredAt"`
 }
 
+// HandlingEventListResponse represents one page of a handling event listing
+type HandlingEventListResponse struct {
+	Events     []HandlingEventResponse `json:"events"`
+	NextCursor string                  `json:"nextCursor,omitempty"`
+}
+
+// HandlingReportResultDTO tells a batch submitter what happened to the report at Index
+type HandlingReportResultDTO struct {
+	Index      int    `json:"index"`
+	TrackingId string `json:"trackingId"`
+	Status     string `json:"status"`
+	EventId    string `json:"eventId,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// HandlingReportBatchResponse represents the outcome of a handling report batch
+type HandlingReportBatchResponse struct {
+	Accepted    int                       `json:"accepted"`
+	Rejected    int                       `json:"rejected"`
+	Quarantined int                       `json:"quarantined"`
+	Duplicate   int                       `json:"duplicate"`
+	Results     []HandlingReportResultDTO `json:"results"`
+}
+
+// QuarantinedReportResponse represents a handling report held in the quarantine for review
+type QuarantinedReportResponse struct {
+	QuarantineId   string `json:"quarantineId"`
+	TrackingId     string `json:"trackingId"`
+	EventType      string `json:"eventType"`
+	Location       string `json:"location"`
+	VoyageNumber   string `json:"voyageNumber,omitempty"`
+	CompletionTime string `json:"completionTime"`
+	IdempotencyKey string `json:"idempotencyKey,omitempty"`
+	Reason         string `json:"reason"`
+	QuarantinedAt  string `json:"quarantinedAt"`
+}
+
 // HandlingEventDTO represents a handling event for API responses
 type HandlingEventDTO struct {
 	EventId        string `json:"eventId"`
@@ -95,10 +228,10 @@ type HandlingEventDTO struct {
 	CompletionTime string `json:"completionTime"`
 }
 
-// VoyageRequest represents a request to create a new voyage
+// VoyageRequest represents a request to create or reschedule a voyage
+// The voyage number of each leg is ignored: it is taken from the URL, or generated for a new voyage.
 type VoyageRequest struct {
-	VoyageNumber string   `json:"voyageNumber" validate:"required"`
-	Schedule     []LegDTO `json:"schedule" validate:"required,min=1"`
+	Schedule []LegDTO `json:"schedule" validate:"required,min=1"`
 }
 
 // VoyageResponse represents a voyage in API responses
@@ -135,6 +268,7 @@ func CargoToResponse(cargo bookingdomain.Cargo) CargoDetailsResponse {
 		IsOnTrack:        delivery.IsOnTrack(),
 		IsMisdirected:    delivery.RoutingStatus == bookingdomain.RoutingStatusMisdirected,
 		IsUnloadedAtDest: delivery.IsUnloadedAtDest,
+		Version:          cargo.GetVersion(),
 	}
 
 	if delivery.LastKnownLocation != "" {
@@ -149,6 +283,20 @@ func CargoToResponse(cargo bookingdomain.Cargo) CargoDetailsResponse {
 		response.Itinerary = ItineraryToDTO(*cargo.GetItinerary())
 	}
 
+	if activity := delivery.NextExpectedActivity; activity != nil {
+		response.NextExpectedActivity = &HandlingActivityDTO{
+			Type:         activity.Type,
+			Location:     activity.Location,
+			VoyageNumber: activity.VoyageNumber,
+		}
+	}
+
+	response.EstimatedTimeOfArrival = formatOptionalTime(cargo.GetEstimatedTimeOfArrival())
+	if slack, ok := cargo.GetArrivalSlack(); ok {
+		response.ArrivalSlackSeconds = slackSeconds(slack)
+		response.IsLate = cargo.IsLate()
+	}
+
 	return response
 }
 
@@ -170,6 +318,22 @@ func ItineraryToDTO(itinerary bookingdomain.Itinerary) *ItineraryDTO {
 	return dto
 }
 
+func RerouteTaskToResponse(task bookingdomain.RerouteTask) RerouteTaskResponse {
+	candidates := make([]ItineraryDTO, len(task.Candidates))
+	for i, candidate := range task.Candidates {
+		candidates[i] = *ItineraryToDTO(candidate)
+	}
+
+	return RerouteTaskResponse{
+		TrackingId:      task.TrackingId.String(),
+		From:            task.RouteSpecification.Origin,
+		Destination:     task.RouteSpecification.Destination,
+		ArrivalDeadline: task.RouteSpecification.ArrivalDeadline.Format(time.RFC3339),
+		Candidates:      candidates,
+		Since:           task.CreatedAt.Format(time.RFC3339),
+	}
+}
+
 func BookCargoToResponse(cargo bookingdomain.Cargo) BookCargoResponse {
 	return BookCargoResponse{
 		TrackingId:      cargo.GetTrackingId().String(),
@@ -183,6 +347,20 @@ func BookCargoToResponse(cargo bookingdomain.Cargo) BookCargoResponse {
 
 // Additional DTO conversion helper functions
 
+func QuarantinedReportToResponse(quarantined handlingdomain.QuarantinedReport) QuarantinedReportResponse {
+	return QuarantinedReportResponse{
+		QuarantineId:   quarantined.Id,
+		TrackingId:     quarantined.Report.TrackingId,
+		EventType:      quarantined.Report.EventType,
+		Location:       quarantined.Report.Location,
+		VoyageNumber:   quarantined.Report.VoyageNumber,
+		CompletionTime: quarantined.Report.CompletionTime,
+		IdempotencyKey: quarantined.Report.IdempotencyKey,
+		Reason:         quarantined.Reason,
+		QuarantinedAt:  quarantined.QuarantinedAt.Format(time.RFC3339),
+	}
+}
+
 func HandlingEventToDTO(event handlingdomain.HandlingEvent) HandlingEventDTO {
 	return HandlingEventDTO{
 		EventId:        event.GetEventId().String(),
@@ -193,6 +371,90 @@ func HandlingEventToDTO(event handlingdomain.HandlingEvent) HandlingEventDTO {
 	}
 }
 
+func CargoTimelineToResponse(timeline trackingdomain.CargoTimeline) CargoTimelineResponse {
+	cargo := timeline.Cargo
+	response := CargoTimelineResponse{
+		TrackingId:       cargo.TrackingId,
+		Origin:           cargo.Origin,
+		Destination:      cargo.Destination,
+		ArrivalDeadline:  cargo.ArrivalDeadline.Format(time.RFC3339),
+		RoutingStatus:    cargo.RoutingStatus,
+		TransportStatus:  cargo.TransportStatus,
+		Legs:             make([]TimelineLegDTO, len(timeline.Legs)),
+		Milestones:       make([]MilestoneDTO, len(timeline.Milestones)),
+		Events:           make([]TimelineEventDTO, len(timeline.Events)),
+		ExpectedPosition: positionToDTO(timeline.Expected),
+		ActualPosition:   positionToDTO(timeline.Actual),
+		IsOnSchedule:     timeline.IsOnSchedule(),
+		AsOf:             timeline.AsOf.Format(time.RFC3339),
+
+		EstimatedTimeOfArrival: formatOptionalTime(cargo.EstimatedTimeOfArrival),
+	}
+	if slack, ok := cargo.ArrivalSlack(); ok {
+		response.ArrivalSlackSeconds = slackSeconds(slack)
+		response.IsLate = slack < 0
+	}
+
+	for i, leg := range timeline.Legs {
+		response.Legs[i] = TimelineLegDTO{
+			LegDTO: LegDTO{
+				VoyageNumber:   leg.VoyageNumber,
+				LoadLocation:   leg.LoadLocation,
+				UnloadLocation: leg.UnloadLocation,
+				LoadTime:       leg.LoadTime.Format(time.RFC3339),
+				UnloadTime:     leg.UnloadTime.Format(time.RFC3339),
+			},
+			Status:           string(leg.Status),
+			ActualLoadTime:   formatOptionalTime(leg.ActualLoadTime),
+			ActualUnloadTime: formatOptionalTime(leg.ActualUnloadTime),
+		}
+	}
+
+	for i, milestone := range timeline.Milestones {
+		response.Milestones[i] = MilestoneDTO{Type: milestone.Type, Time: milestone.Time.Format(time.RFC3339)}
+	}
+
+	for i, event := range timeline.Events {
+		response.Events[i] = TimelineEventDTO{
+			HandlingEventDTO: HandlingEventDTO{
+				EventId:        event.EventId,
+				EventType:      event.Type,
+				Location:       event.Location,
+				VoyageNumber:   event.VoyageNumber,
+				CompletionTime: event.CompletionTime.Format(time.RFC3339),
+			},
+			RegisteredAt: event.RegistrationTime.Format(time.RFC3339),
+			Expected:     event.Expected,
+		}
+		if event.LegIndex >= 0 {
+			leg := event.LegIndex
+			response.Events[i].Leg = &leg
+		}
+	}
+
+	return response
+}
+
+func positionToDTO(position *trackingdomain.Position) *PositionDTO {
+	if position == nil {
+		return nil
+	}
+	return &PositionDTO{Location: position.Location, VoyageNumber: position.VoyageNumber}
+}
+
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format(time.RFC3339)
+	return &formatted
+}
+
+func slackSeconds(slack time.Duration) *int64 {
+	seconds := int64(slack / time.Second)
+	return &seconds
+}
+
 func VoyageToResponseFromDomain(voyage routingdomain.Voyage) VoyageResponse {
 	schedule := voyage.GetSchedule()
 	legs := make([]LegDTO, len(schedule.Movements))
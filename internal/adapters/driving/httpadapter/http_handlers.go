@@ -1,7 +1,11 @@
 package httpadapter
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"go_hex/internal/adapters/driving/httpadapter/httpmiddleware"
 	"go_hex/internal/booking/bookingdomain"
@@ -10,21 +14,43 @@ import (
 	"go_hex/internal/handling/ports/handlingprimary"
 	"go_hex/internal/routing/ports/routingprimary"
 	"go_hex/internal/routing/routingdomain"
+	"go_hex/internal/support/auth"
 	"go_hex/internal/support/validation"
+	"go_hex/internal/tracking/ports/trackingprimary"
+	"go_hex/internal/tracking/trackingdomain"
+	"mime"
 	"net/http"
 	"net/url"
 	"path"
+	"strconv"
 	"strings"
 	"time"
 )
 
+const (
+	// maxHandlingBatchSize is the largest number of handling reports accepted in one batch
+	maxHandlingBatchSize = 10000
+
+	// maxHandlingBatchBytes bounds the body of a handling report batch
+	maxHandlingBatchBytes = 16 << 20
+
+	// maxHandlingLineBytes bounds a single line of an NDJSON handling report batch
+	maxHandlingLineBytes = 64 << 10
+
+	// handlingChunkLines is how many lines of an NDJSON handling report batch are registered together
+	handlingChunkLines = 100
+)
+
 // Handler is the main HTTP handler for the cargo shipping application.
 type Handler struct {
 	authMiddleware        *httpmiddleware.AuthMiddleware
 	bookingService        bookingprimary.BookingService
 	routingService        routingprimary.RouteFinder
+	voyageScheduler       routingprimary.VoyageScheduler
 	handlingReportService handlingprimary.HandlingReportService
 	handlingQueryService  handlingprimary.HandlingEventQueryService
+	timelineService       trackingprimary.CargoTimelineService
+	rerouteService        bookingprimary.RerouteService
 }
 
 // NewHandler creates a new HTTP handler with the given services and middleware.
@@ -32,15 +58,21 @@ func NewHandler(
 	authMiddleware *httpmiddleware.AuthMiddleware,
 	bookingService bookingprimary.BookingService,
 	routingService routingprimary.RouteFinder,
+	voyageScheduler routingprimary.VoyageScheduler,
 	handlingReportService handlingprimary.HandlingReportService,
 	handlingQueryService handlingprimary.HandlingEventQueryService,
+	timelineService trackingprimary.CargoTimelineService,
+	rerouteService bookingprimary.RerouteService,
 ) *Handler {
 	return &Handler{
 		authMiddleware:        authMiddleware,
 		bookingService:        bookingService,
 		routingService:        routingService,
+		voyageScheduler:       voyageScheduler,
 		handlingReportService: handlingReportService,
 		handlingQueryService:  handlingQueryService,
+		timelineService:       timelineService,
+		rerouteService:        rerouteService,
 	}
 }
 
@@ -103,6 +135,131 @@ func (h *Handler) getQueryParameter(r *http.Request, key string) string {
 	return r.URL.Query().Get(key)
 }
 
+// parseIfMatch reads the cargo version a client expects from the If-Match header
+// A missing header or "*" means the client does not care which version it modifies.
+func (h *Handler) parseIfMatch(r *http.Request) (int, error) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return bookingprimary.AnyVersion, nil
+	}
+
+	unquoted, err := strconv.Unquote(header)
+	if err != nil {
+		return 0, errors.New("If-Match must be a quoted entity tag")
+	}
+	version, err := strconv.Atoi(unquoted)
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("If-Match entity tag %s is not a cargo version", header)
+	}
+	return version, nil
+}
+
+// setCargoETag exposes the cargo version so clients can send it back in If-Match
+func (h *Handler) setCargoETag(w http.ResponseWriter, cargo bookingdomain.Cargo) {
+	w.Header().Set("ETag", strconv.Quote(strconv.Itoa(cargo.GetVersion())))
+}
+
+// writeCargoModificationError reports a failed cargo modification, telling unknown cargo,
+// changes the cargo's state forbids and concurrent modifications apart
+func (h *Handler) writeCargoModificationError(w http.ResponseWriter, errorCode string, err error) {
+	var notFound bookingdomain.CargoNotFoundError
+	if errors.As(err, &notFound) {
+		h.writeErrorResponse(w, "cargo_not_found", "Cargo not found", http.StatusNotFound)
+		return
+	}
+	var authErr auth.AuthorizationError
+	if errors.As(err, &authErr) {
+		h.writeErrorResponse(w, "forbidden", err.Error(), http.StatusForbidden)
+		return
+	}
+	var validationErr bookingdomain.DomainValidationError
+	if errors.As(err, &validationErr) {
+		h.writeErrorResponse(w, "invalid_cargo_state", err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	var conflict bookingdomain.ConcurrencyConflictError
+	if errors.As(err, &conflict) {
+		h.writeErrorResponse(w, "concurrent_modification", err.Error(), http.StatusConflict)
+		return
+	}
+	h.writeErrorResponse(w, errorCode, err.Error(), http.StatusInternalServerError)
+}
+
+// writeHandlingReportError reports a handling report that was not registered, telling sequence and reference violations
+// apart. A quarantined report was accepted for review, so it is answered with 202 and the quarantined report.
+func (h *Handler) writeHandlingReportError(w http.ResponseWriter, err error) {
+	var quarantined handlingdomain.ReportQuarantinedError
+	if errors.As(err, &quarantined) {
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(SuccessResponse{
+			Status: "quarantined",
+			Data:   QuarantinedReportToResponse(quarantined.Quarantined),
+		})
+		return
+	}
+	var notFound handlingdomain.QuarantinedReportNotFoundError
+	if errors.As(err, &notFound) {
+		h.writeErrorResponse(w, "quarantined_report_not_found", err.Error(), http.StatusNotFound)
+		return
+	}
+	var authErr auth.AuthorizationError
+	if errors.As(err, &authErr) {
+		h.writeErrorResponse(w, "forbidden", err.Error(), http.StatusForbidden)
+		return
+	}
+	var sequenceErr handlingdomain.HandlingSequenceError
+	if errors.As(err, &sequenceErr) {
+		h.writeErrorResponse(w, "invalid_handling_sequence", err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	var referenceErr handlingdomain.UnknownReferenceError
+	if errors.As(err, &referenceErr) {
+		h.writeErrorResponse(w, "invalid_handling_reference", err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	var conflictErr handlingdomain.IdempotencyKeyConflictError
+	if errors.As(err, &conflictErr) {
+		h.writeErrorResponse(w, "idempotency_key_conflict", err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	var changedErr handlingdomain.HandlingHistoryChangedError
+	if errors.As(err, &changedErr) {
+		h.writeErrorResponse(w, "concurrent_modification", err.Error(), http.StatusConflict)
+		return
+	}
+	var validationErr handlingdomain.DomainValidationError
+	if errors.As(err, &validationErr) {
+		h.writeErrorResponse(w, "validation_error", err.Error(), http.StatusBadRequest)
+		return
+	}
+	h.writeErrorResponse(w, "handling_report_failed", err.Error(), http.StatusInternalServerError)
+}
+
+// writeVoyageError reports a failed voyage operation, telling unknown voyages and invalid schedules apart
+func (h *Handler) writeVoyageError(w http.ResponseWriter, errorCode string, err error) {
+	var notFound routingdomain.VoyageNotFoundError
+	if errors.As(err, &notFound) {
+		h.writeErrorResponse(w, "voyage_not_found", "Voyage not found", http.StatusNotFound)
+		return
+	}
+	var inUse routingdomain.VoyageInUseError
+	if errors.As(err, &inUse) {
+		h.writeErrorResponse(w, "voyage_in_use", err.Error(), http.StatusConflict)
+		return
+	}
+	var authErr auth.AuthorizationError
+	if errors.As(err, &authErr) {
+		h.writeErrorResponse(w, "forbidden", err.Error(), http.StatusForbidden)
+		return
+	}
+	var validationErr routingdomain.DomainValidationError
+	if errors.As(err, &validationErr) {
+		h.writeErrorResponse(w, "invalid_schedule", err.Error(), http.StatusBadRequest)
+		return
+	}
+	h.writeErrorResponse(w, errorCode, err.Error(), http.StatusInternalServerError)
+}
+
 // parseRequestBody parses JSON request body into the provided destination
 func (h *Handler) parseRequestBody(r *http.Request, dest interface{}) error {
 	defer r.Body.Close()
@@ -229,6 +386,7 @@ func (h *Handler) TrackCargoHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Return response
+	h.setCargoETag(w, cargo)
 	response := CargoToResponse(cargo)
 	json.NewEncoder(w).Encode(SuccessResponse{
 		Status: "success",
@@ -236,6 +394,46 @@ func (h *Handler) TrackCargoHandler(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// CargoTimelineHandler returns the booking, itinerary progress and handling history of a cargo in one view.
+func (h *Handler) CargoTimelineHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Extract tracking ID from URL path
+	trackingIdStr, err := h.extractResourceIDFromPath(r.URL.Path, "/api/v1/cargos")
+	if err != nil {
+		h.writeErrorResponse(w, "invalid_request", "Tracking ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Parse tracking ID
+	if _, err := bookingdomain.TrackingIdFromString(trackingIdStr); err != nil {
+		h.writeErrorResponse(w, "invalid_tracking_id", "Invalid tracking ID format", http.StatusBadRequest)
+		return
+	}
+
+	// Build the timeline
+	timeline, err := h.timelineService.GetCargoTimeline(r.Context(), trackingIdStr)
+	if err != nil {
+		var notFound trackingdomain.CargoNotFoundError
+		if errors.As(err, &notFound) {
+			h.writeErrorResponse(w, "cargo_not_found", "Cargo not found", http.StatusNotFound)
+			return
+		}
+		var authErr auth.AuthorizationError
+		if errors.As(err, &authErr) {
+			h.writeErrorResponse(w, "forbidden", err.Error(), http.StatusForbidden)
+			return
+		}
+		h.writeErrorResponse(w, "timeline_failed", "Failed to build cargo timeline", http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(SuccessResponse{
+		Status: "success",
+		Data:   CargoTimelineToResponse(timeline),
+	})
+}
+
 // RequestRouteCandidatesHandler handles route candidate requests.
 func (h *Handler) RequestRouteCandidatesHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -243,6 +441,8 @@ func (h *Handler) RequestRouteCandidatesHandler(w http.ResponseWriter, r *http.R
 	// Parse request body
 	var req struct {
 		TrackingId string `json:"trackingId" validate:"required"`
+		Strategy   string `json:"strategy,omitempty" validate:"omitempty,oneof=earliest_arrival fewest_legs shortest_transit minimum_idle"`
+		MaxResults int    `json:"maxResults,omitempty" validate:"gte=0,lte=100"`
 	}
 	if err := h.parseRequestBody(r, &req); err != nil {
 		h.writeErrorResponse(w, "invalid_request", "Invalid JSON format", http.StatusBadRequest)
@@ -262,9 +462,20 @@ func (h *Handler) RequestRouteCandidatesHandler(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	criteria := bookingdomain.RankingCriteria{
+		Strategy:   req.Strategy,
+		MaxResults: req.MaxResults,
+	}
+
 	// Get route candidates
-	candidates, err := h.bookingService.RequestRouteCandidates(r.Context(), trackingId)
+	candidates, err := h.bookingService.RequestRouteCandidates(r.Context(), trackingId, criteria)
 	if err != nil {
+		// Claimed or cancelled cargo, and cargo on board an unplanned voyage, cannot be routed from where it is
+		var validationErr bookingdomain.DomainValidationError
+		if errors.As(err, &validationErr) {
+			h.writeErrorResponse(w, "cargo_not_routable", err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
 		h.writeErrorResponse(w, "route_search_failed", err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -282,7 +493,8 @@ func (h *Handler) RequestRouteCandidatesHandler(w http.ResponseWriter, r *http.R
 	})
 }
 
-// SubmitHandlingReportHandler handles handling report submissions.
+// SubmitHandlingReportHandler handles handling report submissions. Clients may send an Idempotency-Key header
+// to retry safely; a report that was already registered is answered with 200 and the original event.
 func (h *Handler) SubmitHandlingReportHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
@@ -294,58 +506,382 @@ func (h *Handler) SubmitHandlingReportHandler(w http.ResponseWriter, r *http.Req
 	}
 
 	// Validate request
-	if err := validation.Validate(req); err != nil {
-		h.writeErrorResponse(w, "validation_error", err.Error(), http.StatusBadRequest)
-		return
-	}
-
-	// Validate tracking ID format
-	_, err := bookingdomain.TrackingIdFromString(req.TrackingId)
+	report, errorCode, err := handlingReportFromRequest(req)
 	if err != nil {
-		h.writeErrorResponse(w, "invalid_tracking_id", "Invalid tracking ID format", http.StatusBadRequest)
+		h.writeErrorResponse(w, errorCode, err.Error(), http.StatusBadRequest)
 		return
 	}
+	if key := r.Header.Get("Idempotency-Key"); key != "" {
+		if len(key) > 255 {
+			h.writeErrorResponse(w, "validation_error", "Idempotency-Key must be at most 255 characters", http.StatusBadRequest)
+			return
+		}
+		report.IdempotencyKey = key
+	}
 
-	// Validate completion time format
-	_, err = time.Parse(time.RFC3339, req.CompletionTime)
+	// Submit handling report
+	receipt, err := h.handlingReportService.SubmitHandlingReport(r.Context(), report)
 	if err != nil {
-		h.writeErrorResponse(w, "invalid_time", "Invalid completion time format, expected RFC3339", http.StatusBadRequest)
+		h.writeHandlingReportError(w, err)
 		return
 	}
 
-	// Submit handling report
-	report := handlingdomain.HandlingReport{
+	// Create response
+	response := HandlingEventResponse{
+		EventId:        receipt.EventId.String(),
 		TrackingId:     req.TrackingId,
 		EventType:      req.EventType,
 		Location:       req.Location,
 		VoyageNumber:   req.VoyageNumber,
 		CompletionTime: req.CompletionTime,
+		RegisteredAt:   receipt.RegisteredAt.Format(time.RFC3339),
+	}
+
+	status := http.StatusCreated
+	if receipt.Duplicate {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(SuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
+
+// SubmitHandlingReportBatchHandler handles batches of handling reports sent as a JSON array or, with
+// Content-Type application/x-ndjson, as one JSON report per line. Each report is validated on its own and
+// the response carries one result per submitted report; NDJSON batches are answered in chunks of lines.
+func (h *Handler) SubmitHandlingReportBatchHandler(w http.ResponseWriter, r *http.Request) {
+	h.handleHandlingReportBatch(w, r, h.handlingReportService.StartHandlingReportBatch)
+}
+
+// BackfillHandlingReportsHandler handles batches of historical handling reports, in the same formats as
+// SubmitHandlingReportBatchHandler, without the limit on how long ago the handling was completed.
+// Only callers allowed to backfill handling may use it.
+func (h *Handler) BackfillHandlingReportsHandler(w http.ResponseWriter, r *http.Request) {
+	h.handleHandlingReportBatch(w, r, h.handlingReportService.StartHandlingBackfill)
+}
+
+// ListQuarantinedReportsHandler handles GET /api/v1/handling-events/quarantine, listing the reports held for review
+func (h *Handler) ListQuarantinedReportsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	reports, err := h.handlingReportService.ListQuarantinedReports(r.Context())
+	if err != nil {
+		var authErr auth.AuthorizationError
+		if errors.As(err, &authErr) {
+			h.writeErrorResponse(w, "forbidden", err.Error(), http.StatusForbidden)
+			return
+		}
+		h.writeErrorResponse(w, "quarantine_list_failed", "Failed to list quarantined handling reports", http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]QuarantinedReportResponse, len(reports))
+	for i, report := range reports {
+		response[i] = QuarantinedReportToResponse(report)
+	}
+
+	json.NewEncoder(w).Encode(SuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
+
+// ReleaseQuarantinedReportHandler handles POST /api/v1/handling-events/quarantine/{quarantineId}/release, registering
+// a reviewed report despite the sequence violation it was quarantined for
+func (h *Handler) ReleaseQuarantinedReportHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Extract quarantine ID from URL path
+	urlPath := strings.TrimSuffix(r.URL.Path, "/release")
+	quarantineId, err := h.extractResourceIDFromPath(urlPath, "/api/v1/handling-events/quarantine")
+	if err != nil {
+		h.writeErrorResponse(w, "invalid_request", "Quarantine ID is required", http.StatusBadRequest)
+		return
 	}
 
-	err = h.handlingReportService.SubmitHandlingReport(r.Context(), report)
+	receipt, err := h.handlingReportService.ReleaseQuarantinedReport(r.Context(), quarantineId)
 	if err != nil {
-		h.writeErrorResponse(w, "handling_report_failed", err.Error(), http.StatusInternalServerError)
+		h.writeHandlingReportError(w, err)
+		return
+	}
+
+	status := http.StatusCreated
+	if receipt.Duplicate {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(SuccessResponse{
+		Status: "success",
+		Data: map[string]string{
+			"eventId":      receipt.EventId.String(),
+			"registeredAt": receipt.RegisteredAt.Format(time.RFC3339),
+		},
+	})
+}
+
+// handlingReportSubmitter registers a batch of handling reports, returning one result per report
+type handlingReportSubmitter func(context.Context, []handlingdomain.HandlingReport) ([]handlingdomain.HandlingReportResult, error)
+
+// handlingBatchStarter authorizes the caller for a batch of handling reports
+type handlingBatchStarter func(context.Context) (handlingprimary.HandlingReportBatch, error)
+
+// handleHandlingReportBatch reads a handling report batch, validates each report and hands the valid ones to the batch
+// start returns. The batch is started, and the caller authorized, once, when the first valid reports are submitted.
+func (h *Handler) handleHandlingReportBatch(w http.ResponseWriter, r *http.Request, start handlingBatchStarter) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxHandlingBatchBytes)
+	submit := startOnFirstSubmit(start)
+	if isNDJSON(r) {
+		h.streamHandlingReportLines(w, r, submit)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+
+	// Parse request body
+	var requests []HandlingEventRequest
+	if err := h.parseRequestBody(r, &requests); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			h.writeErrorResponse(w, "batch_too_large", fmt.Sprintf("Batch body exceeds %d bytes", maxHandlingBatchBytes), http.StatusRequestEntityTooLarge)
+			return
+		}
+		h.writeErrorResponse(w, "invalid_request", "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+	if len(requests) == 0 {
+		h.writeErrorResponse(w, "validation_error", "Batch must contain at least one handling report", http.StatusBadRequest)
+		return
+	}
+	if len(requests) > maxHandlingBatchSize {
+		h.writeErrorResponse(w, "batch_too_large", fmt.Sprintf("Batch exceeds %d handling reports", maxHandlingBatchSize), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	results := make([]HandlingReportResultDTO, len(requests))
+	if err := submitHandlingRequests(r.Context(), submit, requests, results, 0); err != nil {
+		h.writeHandlingBatchError(w, err)
 		return
 	}
 
 	// Create response
-	response := HandlingEventResponse{
-		EventId:        "", // We don't get the event ID back from this interface
-		TrackingId:     req.TrackingId,
-		EventType:      req.EventType,
-		Location:       req.Location,
-		VoyageNumber:   req.VoyageNumber,
-		CompletionTime: req.CompletionTime,
-		RegisteredAt:   time.Now().Format(time.RFC3339),
+	response := HandlingReportBatchResponse{Results: results}
+	for _, result := range results {
+		switch handlingdomain.HandlingReportStatus(result.Status) {
+		case handlingdomain.HandlingReportAccepted:
+			response.Accepted++
+		case handlingdomain.HandlingReportQuarantined:
+			response.Quarantined++
+		case handlingdomain.HandlingReportDuplicate:
+			response.Duplicate++
+		default:
+			response.Rejected++
+		}
 	}
 
-	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(SuccessResponse{
 		Status: "success",
 		Data:   response,
 	})
 }
 
+// startOnFirstSubmit returns a submitter handing reports to the batch start returns, started when it is first used
+func startOnFirstSubmit(start handlingBatchStarter) handlingReportSubmitter {
+	var batch handlingprimary.HandlingReportBatch
+	return func(ctx context.Context, reports []handlingdomain.HandlingReport) ([]handlingdomain.HandlingReportResult, error) {
+		if batch == nil {
+			started, err := start(ctx)
+			if err != nil {
+				return nil, err
+			}
+			batch = started
+		}
+		return batch.Submit(ctx, reports)
+	}
+}
+
+// streamHandlingReportLines processes an NDJSON batch as it is read: its lines are registered in chunks, and the
+// results of a chunk are written and flushed as NDJSON lines before the next chunk is read, so neither the batch nor
+// its results are held in memory. Blank lines are skipped; a line that is not valid JSON gets a rejected result.
+// Failures before the first result are answered with the usual error response; later ones end the stream with an
+// error line.
+func (h *Handler) streamHandlingReportLines(w http.ResponseWriter, r *http.Request, submit handlingReportSubmitter) {
+	defer r.Body.Close()
+
+	encoder := json.NewEncoder(w)
+	controller := http.NewResponseController(w)
+	written := 0
+
+	fail := func(errorCode, message string, httpStatus int) {
+		if written == 0 {
+			w.Header().Set("Content-Type", "application/json")
+			h.writeErrorResponse(w, errorCode, message, httpStatus)
+			return
+		}
+		encoder.Encode(ErrorResponse{Error: errorCode, Message: message, Code: httpStatus})
+	}
+
+	// flush registers the lines read since the last flush and writes their results; it reports whether it succeeded
+	var requests []HandlingEventRequest
+	var results []HandlingReportResultDTO
+	flush := func() bool {
+		if len(requests) == 0 {
+			return true
+		}
+		if err := submitHandlingRequests(r.Context(), submit, requests, results, written); err != nil {
+			if written == 0 {
+				h.writeHandlingBatchError(w, err)
+				return false
+			}
+			fail("handling_report_failed", err.Error(), http.StatusInternalServerError)
+			return false
+		}
+
+		if written == 0 {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.WriteHeader(http.StatusOK)
+		}
+		for _, result := range results {
+			encoder.Encode(result)
+		}
+		controller.Flush()
+		written += len(results)
+		requests, results = nil, nil
+		return true
+	}
+
+	scanner := bufio.NewScanner(r.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxHandlingLineBytes)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		if written+len(requests) == maxHandlingBatchSize {
+			if flush() {
+				fail("batch_too_large", fmt.Sprintf("Batch exceeds %d handling reports", maxHandlingBatchSize), http.StatusRequestEntityTooLarge)
+			}
+			return
+		}
+
+		var request HandlingEventRequest
+		var result HandlingReportResultDTO
+		if err := json.Unmarshal(text, &request); err != nil {
+			result.Status = string(handlingdomain.HandlingReportRejected)
+			result.Reason = fmt.Sprintf("line %d is not a valid JSON handling report", line)
+		}
+		requests = append(requests, request)
+		results = append(results, result)
+
+		if len(requests) == handlingChunkLines && !flush() {
+			return
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if !flush() {
+			return
+		}
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			fail("batch_too_large", fmt.Sprintf("Batch body exceeds %d bytes", maxHandlingBatchBytes), http.StatusRequestEntityTooLarge)
+			return
+		}
+		fail("invalid_request", "Invalid NDJSON format", http.StatusBadRequest)
+		return
+	}
+	if !flush() {
+		return
+	}
+
+	if written == 0 {
+		fail("validation_error", "Batch must contain at least one handling report", http.StatusBadRequest)
+	}
+}
+
+// submitHandlingRequests validates each request and hands the valid ones to submit as one batch, filling in results.
+// Results that already have a status, for requests that could not be parsed, are kept; result indexes start at offset.
+func submitHandlingRequests(ctx context.Context, submit handlingReportSubmitter, requests []HandlingEventRequest, results []HandlingReportResultDTO, offset int) error {
+	// Validate each request, remembering where the valid ones came from
+	var reports []handlingdomain.HandlingReport
+	var positions []int
+	for i, req := range requests {
+		results[i].Index = offset + i
+		results[i].TrackingId = req.TrackingId
+		if results[i].Status != "" {
+			continue // line could not be parsed
+		}
+
+		report, _, err := handlingReportFromRequest(req)
+		if err != nil {
+			results[i].Status = string(handlingdomain.HandlingReportRejected)
+			results[i].Reason = err.Error()
+			continue
+		}
+		reports = append(reports, report)
+		positions = append(positions, i)
+	}
+	if len(reports) == 0 {
+		return nil
+	}
+
+	// Submit the valid reports as one batch
+	submitted, err := submit(ctx, reports)
+	if err != nil {
+		return err
+	}
+	for _, result := range submitted {
+		i := positions[result.Index]
+		results[i].Status = string(result.Status)
+		results[i].EventId = result.EventId
+		results[i].Reason = result.Reason
+	}
+	return nil
+}
+
+// writeHandlingBatchError writes the error response for a handling report batch the service refused
+func (h *Handler) writeHandlingBatchError(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	var authErr auth.AuthorizationError
+	if errors.As(err, &authErr) {
+		h.writeErrorResponse(w, "forbidden", err.Error(), http.StatusForbidden)
+		return
+	}
+	h.writeErrorResponse(w, "handling_report_failed", err.Error(), http.StatusInternalServerError)
+}
+
+// isNDJSON reports whether the request body is newline-delimited JSON
+func isNDJSON(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == "application/x-ndjson"
+}
+
+// handlingReportFromRequest validates a handling event request and converts it into a handling report.
+// On failure it also returns the error code to report to the client.
+func handlingReportFromRequest(req HandlingEventRequest) (handlingdomain.HandlingReport, string, error) {
+	if err := validation.Validate(req); err != nil {
+		return handlingdomain.HandlingReport{}, "validation_error", err
+	}
+
+	// Validate tracking ID format
+	if _, err := bookingdomain.TrackingIdFromString(req.TrackingId); err != nil {
+		return handlingdomain.HandlingReport{}, "invalid_tracking_id", errors.New("Invalid tracking ID format")
+	}
+
+	// Validate completion time format
+	if _, err := time.Parse(time.RFC3339, req.CompletionTime); err != nil {
+		return handlingdomain.HandlingReport{}, "invalid_time", errors.New("Invalid completion time format, expected RFC3339")
+	}
+
+	return handlingdomain.HandlingReport{
+		TrackingId:     req.TrackingId,
+		EventType:      req.EventType,
+		Location:       req.Location,
+		VoyageNumber:   req.VoyageNumber,
+		CompletionTime: req.CompletionTime,
+		IdempotencyKey: req.IdempotencyKey,
+	}, "", nil
+}
+
 // AssignRouteHandler handles route assignment to cargo.
 func (h *Handler) AssignRouteHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -411,10 +947,17 @@ func (h *Handler) AssignRouteHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Read the version the client expects to modify
+	expectedVersion, err := h.parseIfMatch(r)
+	if err != nil {
+		h.writeErrorResponse(w, "invalid_precondition", err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Assign route to cargo
-	err = h.bookingService.AssignRouteToCargo(r.Context(), trackingId, itinerary)
+	err = h.bookingService.AssignRouteToCargo(r.Context(), trackingId, itinerary, expectedVersion)
 	if err != nil {
-		h.writeErrorResponse(w, "route_assignment_failed", err.Error(), http.StatusInternalServerError)
+		h.writeCargoModificationError(w, "route_assignment_failed", err)
 		return
 	}
 
@@ -425,29 +968,255 @@ func (h *Handler) AssignRouteHandler(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// ListCargoHandler handles listing all cargo.
-func (h *Handler) ListCargoHandler(w http.ResponseWriter, r *http.Request) {
+// ChangeRouteSpecificationHandler handles changes to a cargo's destination and arrival deadline.
+func (h *Handler) ChangeRouteSpecificationHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
-	// Get unrouted cargo (for simplicity, we'll just return unrouted cargo)
-	cargoList, err := h.bookingService.ListUnroutedCargo(r.Context())
+	// Handle the special case where URL has "/route-specification" suffix
+	urlPath := strings.TrimSuffix(r.URL.Path, "/route-specification")
+
+	// Extract tracking ID from URL path using improved extraction
+	trackingIdStr, err := h.extractResourceIDFromPath(urlPath, "/api/v1/cargos")
 	if err != nil {
-		h.writeErrorResponse(w, "list_failed", err.Error(), http.StatusInternalServerError)
+		h.writeErrorResponse(w, "invalid_request", "Tracking ID is required", http.StatusBadRequest)
 		return
 	}
 
-	// Convert to responses
-	responses := make([]CargoDetailsResponse, len(cargoList))
-	for i, cargo := range cargoList {
+	// Parse request body
+	var req ChangeRouteSpecificationRequest
+	if err := h.parseRequestBody(r, &req); err != nil {
+		h.writeErrorResponse(w, "invalid_request", "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	// Validate request
+	if err := validation.Validate(req); err != nil {
+		h.writeErrorResponse(w, "validation_error", err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Parse tracking ID
+	trackingId, err := bookingdomain.TrackingIdFromString(trackingIdStr)
+	if err != nil {
+		h.writeErrorResponse(w, "invalid_tracking_id", "Invalid tracking ID format", http.StatusBadRequest)
+		return
+	}
+
+	// Read the version the client expects to modify
+	expectedVersion, err := h.parseIfMatch(r)
+	if err != nil {
+		h.writeErrorResponse(w, "invalid_precondition", err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Change route specification
+	cargo, err := h.bookingService.ChangeRouteSpecification(r.Context(), trackingId, req.Destination, req.ArrivalDeadline, expectedVersion)
+	if err != nil {
+		h.writeCargoModificationError(w, "route_specification_change_failed", err)
+		return
+	}
+
+	// Return response
+	h.setCargoETag(w, cargo)
+	response := CargoToResponse(cargo)
+	json.NewEncoder(w).Encode(SuccessResponse{
+		Status: "success",
+		Data:   response,
+	})
+}
+
+// CancelCargoHandler handles cargo booking cancellation.
+func (h *Handler) CancelCargoHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Handle the special case where URL has "/cancel" suffix
+	urlPath := strings.TrimSuffix(r.URL.Path, "/cancel")
+
+	// Extract tracking ID from URL path using improved extraction
+	trackingIdStr, err := h.extractResourceIDFromPath(urlPath, "/api/v1/cargos")
+	if err != nil {
+		h.writeErrorResponse(w, "invalid_request", "Tracking ID is required", http.StatusBadRequest)
+		return
+	}
+
+	// Parse request body
+	var req CancelCargoRequest
+	if err := h.parseRequestBody(r, &req); err != nil {
+		h.writeErrorResponse(w, "invalid_request", "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	h.cancelCargo(w, r, trackingIdStr, req)
+}
+
+// DeleteCargoHandler handles DELETE /api/v1/cargos/{trackingId}, cancelling the booking.
+// DELETE requests carry no body, so the reason comes from the reason query parameter.
+func (h *Handler) DeleteCargoHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Extract tracking ID from URL path
+	trackingIdStr, err := h.extractResourceIDFromPath(r.URL.Path, "/api/v1/cargos")
+	if err != nil {
+		h.writeErrorResponse(w, "invalid_request", "Tracking ID is required", http.StatusBadRequest)
+		return
+	}
+
+	h.cancelCargo(w, r, trackingIdStr, CancelCargoRequest{Reason: h.getQueryParameter(r, "reason")})
+}
+
+// cancelCargo validates a cancellation request and cancels the cargo, writing the response
+func (h *Handler) cancelCargo(w http.ResponseWriter, r *http.Request, trackingIdStr string, req CancelCargoRequest) {
+	// Validate request
+	if err := validation.Validate(req); err != nil {
+		h.writeErrorResponse(w, "validation_error", err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Parse tracking ID
+	trackingId, err := bookingdomain.TrackingIdFromString(trackingIdStr)
+	if err != nil {
+		h.writeErrorResponse(w, "invalid_tracking_id", "Invalid tracking ID format", http.StatusBadRequest)
+		return
+	}
+
+	// Read the version the client expects to modify
+	expectedVersion, err := h.parseIfMatch(r)
+	if err != nil {
+		h.writeErrorResponse(w, "invalid_precondition", err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Cancel cargo
+	err = h.bookingService.CancelCargo(r.Context(), trackingId, req.Reason, expectedVersion)
+	if err != nil {
+		h.writeCargoModificationError(w, "cancellation_failed", err)
+		return
+	}
+
+	// Return success response
+	json.NewEncoder(w).Encode(SuccessResponse{
+		Status: "success",
+		Data:   map[string]string{"message": "Cargo cancelled successfully"},
+	})
+}
+
+// ListCargoHandler handles GET /api/v1/cargos, listing booked cargo a page at a time.
+// Query parameters filter, sort and page the listing; see parseCargoQuery.
+func (h *Handler) ListCargoHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Parse query parameters
+	query, err := h.parseCargoQuery(r)
+	if err != nil {
+		h.writeErrorResponse(w, "invalid_query", err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// List the requested page
+	page, err := h.bookingService.ListAllCargo(r.Context(), query)
+	if err != nil {
+		var validationErr bookingdomain.DomainValidationError
+		if errors.As(err, &validationErr) {
+			h.writeErrorResponse(w, "invalid_query", err.Error(), http.StatusBadRequest)
+			return
+		}
+		var authErr auth.AuthorizationError
+		if errors.As(err, &authErr) {
+			h.writeErrorResponse(w, "forbidden", err.Error(), http.StatusForbidden)
+			return
+		}
+		h.writeErrorResponse(w, "list_failed", err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Convert to responses
+	responses := make([]CargoDetailsResponse, len(page.Cargos))
+	for i, cargo := range page.Cargos {
 		responses[i] = CargoToResponse(cargo)
 	}
 
+	json.NewEncoder(w).Encode(SuccessResponse{
+		Status: "success",
+		Data: CargoListResponse{
+			Cargos:     responses,
+			NextCursor: page.NextCursor,
+		},
+	})
+}
+
+// ListReroutesHandler handles GET /api/v1/reroutes, listing misdirected cargo waiting for a planner
+// to choose one of its route candidates, oldest first
+func (h *Handler) ListReroutesHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	tasks, err := h.rerouteService.ListRerouteWorklist(r.Context())
+	if err != nil {
+		var authErr auth.AuthorizationError
+		if errors.As(err, &authErr) {
+			h.writeErrorResponse(w, "forbidden", err.Error(), http.StatusForbidden)
+			return
+		}
+		h.writeErrorResponse(w, "list_failed", err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	responses := make([]RerouteTaskResponse, len(tasks))
+	for i, task := range tasks {
+		responses[i] = RerouteTaskToResponse(task)
+	}
+
 	json.NewEncoder(w).Encode(SuccessResponse{
 		Status: "success",
 		Data:   responses,
 	})
 }
 
+// parseCargoQuery reads the cargo listing query parameters: routing_status, transport_status, origin, destination,
+// deadline_from and deadline_to (RFC3339), misdirected and overdue (booleans), sort, order, cursor and limit
+func (h *Handler) parseCargoQuery(r *http.Request) (bookingdomain.CargoQuery, error) {
+	params := r.URL.Query()
+	query := bookingdomain.CargoQuery{
+		RoutingStatus:   bookingdomain.RoutingStatus(strings.ToUpper(params.Get("routing_status"))),
+		TransportStatus: bookingdomain.TransportStatus(strings.ToUpper(params.Get("transport_status"))),
+		Origin:          params.Get("origin"),
+		Destination:     params.Get("destination"),
+		SortBy:          bookingdomain.CargoSortField(params.Get("sort")),
+		Order:           bookingdomain.SortOrder(strings.ToLower(params.Get("order"))),
+		Cursor:          params.Get("cursor"),
+	}
+
+	for _, param := range []struct {
+		name   string
+		target *time.Time
+	}{{"deadline_from", &query.DeadlineFrom}, {"deadline_to", &query.DeadlineTo}} {
+		if value := params.Get(param.name); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return bookingdomain.CargoQuery{}, fmt.Errorf("%s must be an RFC3339 time", param.name)
+			}
+			*param.target = parsed
+		}
+	}
+
+	if value := params.Get("overdue"); value != "" {
+		overdue, err := strconv.ParseBool(value)
+		if err != nil {
+			return bookingdomain.CargoQuery{}, errors.New("overdue must be true or false")
+		}
+		query.Overdue = overdue
+	}
+
+	if value := params.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			return bookingdomain.CargoQuery{}, errors.New("limit must be a positive number")
+		}
+		query.Limit = limit
+	}
+
+	return query, nil
+}
+
 // ListVoyagesHandler handles GET /api/v1/voyages
 func (h *Handler) ListVoyagesHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -471,6 +1240,180 @@ func (h *Handler) ListVoyagesHandler(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// CreateVoyageHandler handles POST /api/v1/voyages, scheduling a new voyage.
+func (h *Handler) CreateVoyageHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Parse request body
+	var req VoyageRequest
+	if err := h.parseRequestBody(r, &req); err != nil {
+		h.writeErrorResponse(w, "invalid_request", "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	// Validate request
+	if err := validation.Validate(req); err != nil {
+		h.writeErrorResponse(w, "validation_error", err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Convert request schedule to carrier movements
+	movements, err := h.parseCarrierMovements(req.Schedule)
+	if err != nil {
+		h.writeErrorResponse(w, "invalid_schedule", err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Create voyage
+	voyage, err := h.voyageScheduler.CreateVoyage(r.Context(), movements)
+	if err != nil {
+		h.writeVoyageError(w, "voyage_creation_failed", err)
+		return
+	}
+
+	// Return response
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(SuccessResponse{
+		Status: "success",
+		Data:   VoyageToResponse(voyage),
+	})
+}
+
+// GetVoyageHandler handles GET /api/v1/voyages/{voyageNumber}
+func (h *Handler) GetVoyageHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Extract and parse voyage number from URL path
+	voyageNumber, ok := h.parseVoyageNumberFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	// Get voyage from the routing service
+	voyage, err := h.routingService.GetVoyage(r.Context(), voyageNumber)
+	if err != nil {
+		h.writeVoyageError(w, "voyage_retrieval_failed", err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(SuccessResponse{
+		Status: "success",
+		Data:   VoyageToResponse(voyage),
+	})
+}
+
+// RescheduleVoyageHandler handles PUT /api/v1/voyages/{voyageNumber}, replacing the schedule of a voyage.
+func (h *Handler) RescheduleVoyageHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Extract and parse voyage number from URL path
+	voyageNumber, ok := h.parseVoyageNumberFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	// Parse request body
+	var req VoyageRequest
+	if err := h.parseRequestBody(r, &req); err != nil {
+		h.writeErrorResponse(w, "invalid_request", "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	// Validate request
+	if err := validation.Validate(req); err != nil {
+		h.writeErrorResponse(w, "validation_error", err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Convert request schedule to carrier movements
+	movements, err := h.parseCarrierMovements(req.Schedule)
+	if err != nil {
+		h.writeErrorResponse(w, "invalid_schedule", err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Reschedule voyage
+	voyage, err := h.voyageScheduler.RescheduleVoyage(r.Context(), voyageNumber, movements)
+	if err != nil {
+		h.writeVoyageError(w, "voyage_reschedule_failed", err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(SuccessResponse{
+		Status: "success",
+		Data:   VoyageToResponse(voyage),
+	})
+}
+
+// DeleteVoyageHandler handles DELETE /api/v1/voyages/{voyageNumber}
+func (h *Handler) DeleteVoyageHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Extract and parse voyage number from URL path
+	voyageNumber, ok := h.parseVoyageNumberFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	// Delete voyage
+	if err := h.voyageScheduler.DeleteVoyage(r.Context(), voyageNumber); err != nil {
+		h.writeVoyageError(w, "voyage_deletion_failed", err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(SuccessResponse{
+		Status: "success",
+		Data:   map[string]string{"message": "Voyage deleted successfully"},
+	})
+}
+
+// parseVoyageNumberFromPath reads the voyage number from the URL path, writing a 400 response if it is missing or malformed
+func (h *Handler) parseVoyageNumberFromPath(w http.ResponseWriter, r *http.Request) (routingdomain.VoyageNumber, bool) {
+	voyageNumberStr, err := h.extractResourceIDFromPath(r.URL.Path, "/api/v1/voyages")
+	if err != nil {
+		h.writeErrorResponse(w, "invalid_request", "Voyage number is required", http.StatusBadRequest)
+		return routingdomain.VoyageNumber{}, false
+	}
+
+	voyageNumber, err := routingdomain.VoyageNumberFromString(voyageNumberStr)
+	if err != nil {
+		h.writeErrorResponse(w, "invalid_voyage_number", "Invalid voyage number format", http.StatusBadRequest)
+		return routingdomain.VoyageNumber{}, false
+	}
+
+	return voyageNumber, true
+}
+
+// parseCarrierMovements converts the legs of a voyage request to carrier movements, in sailing order
+func (h *Handler) parseCarrierMovements(legs []LegDTO) ([]routingdomain.CarrierMovement, error) {
+	movements := make([]routingdomain.CarrierMovement, 0, len(legs))
+	for i, leg := range legs {
+		departureLocation, err := routingdomain.NewUnLocode(leg.LoadLocation)
+		if err != nil {
+			return nil, fmt.Errorf("schedule[%d]: invalid load location %q", i, leg.LoadLocation)
+		}
+		arrivalLocation, err := routingdomain.NewUnLocode(leg.UnloadLocation)
+		if err != nil {
+			return nil, fmt.Errorf("schedule[%d]: invalid unload location %q", i, leg.UnloadLocation)
+		}
+		departureTime, err := time.Parse(time.RFC3339, leg.LoadTime)
+		if err != nil {
+			return nil, fmt.Errorf("schedule[%d]: invalid load time format, expected RFC3339", i)
+		}
+		arrivalTime, err := time.Parse(time.RFC3339, leg.UnloadTime)
+		if err != nil {
+			return nil, fmt.Errorf("schedule[%d]: invalid unload time format, expected RFC3339", i)
+		}
+
+		movement, err := routingdomain.NewCarrierMovement(departureLocation, arrivalLocation, departureTime, arrivalTime)
+		if err != nil {
+			return nil, fmt.Errorf("schedule[%d]: %w", i, err)
+		}
+		movements = append(movements, movement)
+	}
+	return movements, nil
+}
+
 // ListLocationsHandler handles GET /api/v1/locations
 func (h *Handler) ListLocationsHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -494,48 +1437,86 @@ func (h *Handler) ListLocationsHandler(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// ListHandlingEventsHandler handles GET /api/v1/handling-events
+// ListHandlingEventsHandler handles GET /api/v1/handling-events, listing handling events a page at a time in
+// completion order. Query parameters filter and page the listing; see parseHandlingEventQuery.
 func (h *Handler) ListHandlingEventsHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
-	// Check for tracking_id query parameter
-	trackingID := h.getQueryParameter(r, "tracking_id")
-
-	var events []HandlingEventResponse
+	// Parse query parameters
+	query, err := h.parseHandlingEventQuery(r)
+	if err != nil {
+		h.writeErrorResponse(w, "invalid_query", err.Error(), http.StatusBadRequest)
+		return
+	}
 
-	if trackingID != "" {
-		// Get events for specific cargo
-		handlingHistory, queryErr := h.handlingQueryService.GetHandlingHistory(r.Context(), trackingID)
-		if queryErr != nil {
-			h.writeErrorResponse(w, "INTERNAL_ERROR", "Failed to get handling events", http.StatusInternalServerError)
+	// List the requested page
+	page, err := h.handlingQueryService.ListHandlingEvents(r.Context(), query)
+	if err != nil {
+		var validationErr handlingdomain.DomainValidationError
+		if errors.As(err, &validationErr) {
+			h.writeErrorResponse(w, "invalid_query", err.Error(), http.StatusBadRequest)
 			return
 		}
-
-		// Convert handling history to response format
-		events = make([]HandlingEventResponse, len(handlingHistory.Events))
-		for i, event := range handlingHistory.Events {
-			events[i] = HandlingEventToResponse(event)
-		}
-	} else {
-		// Get all events using the new method
-		allEvents, queryErr := h.handlingQueryService.ListAllHandlingEvents(r.Context())
-		if queryErr != nil {
-			h.writeErrorResponse(w, "INTERNAL_ERROR", "Failed to list all handling events", http.StatusInternalServerError)
+		var authErr auth.AuthorizationError
+		if errors.As(err, &authErr) {
+			h.writeErrorResponse(w, "forbidden", err.Error(), http.StatusForbidden)
 			return
 		}
+		h.writeErrorResponse(w, "INTERNAL_ERROR", "Failed to list handling events", http.StatusInternalServerError)
+		return
+	}
 
-		events = make([]HandlingEventResponse, len(allEvents))
-		for i, event := range allEvents {
-			events[i] = HandlingEventToResponse(event)
-		}
+	// Convert to responses
+	events := make([]HandlingEventResponse, len(page.Events))
+	for i, event := range page.Events {
+		events[i] = HandlingEventToResponse(event)
 	}
 
 	json.NewEncoder(w).Encode(SuccessResponse{
 		Status: "success",
-		Data:   events,
+		Data: HandlingEventListResponse{
+			Events:     events,
+			NextCursor: page.NextCursor,
+		},
 	})
 }
 
+// parseHandlingEventQuery reads the handling event listing query parameters: tracking_id, event_type, location,
+// voyage_number, completed_from and completed_before (RFC3339, the latter exclusive), cursor and limit
+func (h *Handler) parseHandlingEventQuery(r *http.Request) (handlingdomain.HandlingEventQuery, error) {
+	params := r.URL.Query()
+	query := handlingdomain.HandlingEventQuery{
+		TrackingId:   params.Get("tracking_id"),
+		EventType:    handlingdomain.HandlingEventType(strings.ToUpper(params.Get("event_type"))),
+		Location:     params.Get("location"),
+		VoyageNumber: params.Get("voyage_number"),
+		Cursor:       params.Get("cursor"),
+	}
+
+	for _, param := range []struct {
+		name   string
+		target *time.Time
+	}{{"completed_from", &query.CompletedFrom}, {"completed_before", &query.CompletedBefore}} {
+		if value := params.Get(param.name); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return handlingdomain.HandlingEventQuery{}, fmt.Errorf("%s must be an RFC3339 time", param.name)
+			}
+			*param.target = parsed
+		}
+	}
+
+	if value := params.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			return handlingdomain.HandlingEventQuery{}, errors.New("limit must be a positive number")
+		}
+		query.Limit = limit
+	}
+
+	return query, nil
+}
+
 // AuthMeResponse represents the response for /auth/me endpoint.
 type AuthMeResponse struct {
 	UserID   string   `json:"user_id"`
@@ -4,14 +4,20 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"go_hex/internal/booking/bookingdomain"
+	"go_hex/internal/booking/ports/bookingprimary"
 	"go_hex/internal/handling/handlingdomain"
+	"go_hex/internal/handling/ports/handlingprimary"
+	"go_hex/internal/routing/routingdomain"
 	"go_hex/internal/support/auth"
+	"go_hex/internal/tracking/trackingdomain"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -33,18 +39,18 @@ func (m *MockBookingService) GetCargoDetails(ctx context.Context, trackingId boo
 	return args.Get(0).(bookingdomain.Cargo), args.Error(1)
 }
 
-func (m *MockBookingService) ListAllCargo(ctx context.Context) ([]bookingdomain.Cargo, error) {
-	args := m.Called(ctx)
-	return args.Get(0).([]bookingdomain.Cargo), args.Error(1)
+func (m *MockBookingService) ListAllCargo(ctx context.Context, query bookingdomain.CargoQuery) (bookingdomain.CargoPage, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(bookingdomain.CargoPage), args.Error(1)
 }
 
-func (m *MockBookingService) AssignRouteToCargo(ctx context.Context, trackingId bookingdomain.TrackingId, itinerary bookingdomain.Itinerary) error {
-	args := m.Called(ctx, trackingId, itinerary)
+func (m *MockBookingService) AssignRouteToCargo(ctx context.Context, trackingId bookingdomain.TrackingId, itinerary bookingdomain.Itinerary, expectedVersion int) error {
+	args := m.Called(ctx, trackingId, itinerary, expectedVersion)
 	return args.Error(0)
 }
 
-func (m *MockBookingService) RequestRouteCandidates(ctx context.Context, trackingId bookingdomain.TrackingId) ([]bookingdomain.Itinerary, error) {
-	args := m.Called(ctx, trackingId)
+func (m *MockBookingService) RequestRouteCandidates(ctx context.Context, trackingId bookingdomain.TrackingId, criteria bookingdomain.RankingCriteria) ([]bookingdomain.Itinerary, error) {
+	args := m.Called(ctx, trackingId, criteria)
 	return args.Get(0).([]bookingdomain.Itinerary), args.Error(1)
 }
 
@@ -58,8 +64,23 @@ func (m *MockBookingService) TrackCargo(ctx context.Context, trackingId bookingd
 	return args.Get(0).(bookingdomain.Cargo), args.Error(1)
 }
 
-func (m *MockBookingService) UpdateCargoDelivery(ctx context.Context, trackingId bookingdomain.TrackingId, handlingHistory []bookingdomain.HandlingEventSummary) error {
-	args := m.Called(ctx, trackingId, handlingHistory)
+func (m *MockBookingService) ChangeRouteSpecification(ctx context.Context, trackingId bookingdomain.TrackingId, destination, arrivalDeadline string, expectedVersion int) (bookingdomain.Cargo, error) {
+	args := m.Called(ctx, trackingId, destination, arrivalDeadline, expectedVersion)
+	return args.Get(0).(bookingdomain.Cargo), args.Error(1)
+}
+
+func (m *MockBookingService) CancelCargo(ctx context.Context, trackingId bookingdomain.TrackingId, reason string, expectedVersion int) error {
+	args := m.Called(ctx, trackingId, reason, expectedVersion)
+	return args.Error(0)
+}
+
+func (m *MockBookingService) UpdateCargoDelivery(ctx context.Context, trackingId bookingdomain.TrackingId) error {
+	args := m.Called(ctx, trackingId)
+	return args.Error(0)
+}
+
+func (m *MockBookingService) ReviewVoyageSchedule(ctx context.Context, voyageNumber string) error {
+	args := m.Called(ctx, voyageNumber)
 	return args.Error(0)
 }
 
@@ -79,11 +100,54 @@ func (m *MockRoutingService) ListAllLocations(ctx context.Context) ([]interface{
 
 type MockHandlingReportService struct {
 	mock.Mock
+
+	// batchesStarted counts the batches started, for checking that a request authorizes once
+	batchesStarted int
 }
 
-func (m *MockHandlingReportService) SubmitHandlingReport(ctx context.Context, report handlingdomain.HandlingReport) error {
+func (m *MockHandlingReportService) SubmitHandlingReport(ctx context.Context, report handlingdomain.HandlingReport) (handlingdomain.HandlingReceipt, error) {
 	args := m.Called(ctx, report)
-	return args.Error(0)
+	return args.Get(0).(handlingdomain.HandlingReceipt), args.Error(1)
+}
+
+func (m *MockHandlingReportService) SubmitHandlingReports(ctx context.Context, reports []handlingdomain.HandlingReport) ([]handlingdomain.HandlingReportResult, error) {
+	args := m.Called(ctx, reports)
+	results, _ := args.Get(0).([]handlingdomain.HandlingReportResult)
+	return results, args.Error(1)
+}
+
+func (m *MockHandlingReportService) BackfillHandlingReports(ctx context.Context, reports []handlingdomain.HandlingReport) ([]handlingdomain.HandlingReportResult, error) {
+	args := m.Called(ctx, reports)
+	results, _ := args.Get(0).([]handlingdomain.HandlingReportResult)
+	return results, args.Error(1)
+}
+
+func (m *MockHandlingReportService) StartHandlingReportBatch(ctx context.Context) (handlingprimary.HandlingReportBatch, error) {
+	m.batchesStarted++
+	return mockHandlingReportBatch(m.SubmitHandlingReports), nil
+}
+
+func (m *MockHandlingReportService) StartHandlingBackfill(ctx context.Context) (handlingprimary.HandlingReportBatch, error) {
+	m.batchesStarted++
+	return mockHandlingReportBatch(m.BackfillHandlingReports), nil
+}
+
+func (m *MockHandlingReportService) ListQuarantinedReports(ctx context.Context) ([]handlingdomain.QuarantinedReport, error) {
+	args := m.Called(ctx)
+	reports, _ := args.Get(0).([]handlingdomain.QuarantinedReport)
+	return reports, args.Error(1)
+}
+
+func (m *MockHandlingReportService) ReleaseQuarantinedReport(ctx context.Context, id string) (handlingdomain.HandlingReceipt, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(handlingdomain.HandlingReceipt), args.Error(1)
+}
+
+// mockHandlingReportBatch submits each part of a batch to the mocked batch method, so the parts are expected as calls of it
+type mockHandlingReportBatch func(context.Context, []handlingdomain.HandlingReport) ([]handlingdomain.HandlingReportResult, error)
+
+func (b mockHandlingReportBatch) Submit(ctx context.Context, reports []handlingdomain.HandlingReport) ([]handlingdomain.HandlingReportResult, error) {
+	return b(ctx, reports)
 }
 
 type MockHandlingQueryService struct {
@@ -95,6 +159,11 @@ func (m *MockHandlingQueryService) ListAllHandlingEvents(ctx context.Context) ([
 	return args.Get(0).([]handlingdomain.HandlingEvent), args.Error(1)
 }
 
+func (m *MockHandlingQueryService) ListHandlingEvents(ctx context.Context, query handlingdomain.HandlingEventQuery) (handlingdomain.HandlingEventPage, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(handlingdomain.HandlingEventPage), args.Error(1)
+}
+
 func (m *MockHandlingQueryService) GetHandlingHistory(ctx context.Context, trackingId string) (handlingdomain.HandlingHistory, error) {
 	args := m.Called(ctx, trackingId)
 	return args.Get(0).(handlingdomain.HandlingHistory), args.Error(1)
@@ -105,6 +174,15 @@ func (m *MockHandlingQueryService) GetHandlingEvent(ctx context.Context, eventId
 	return args.Get(0).(handlingdomain.HandlingEvent), args.Error(1)
 }
 
+type MockCargoTimelineService struct {
+	mock.Mock
+}
+
+func (m *MockCargoTimelineService) GetCargoTimeline(ctx context.Context, trackingId string) (trackingdomain.CargoTimeline, error) {
+	args := m.Called(ctx, trackingId)
+	return args.Get(0).(trackingdomain.CargoTimeline), args.Error(1)
+}
+
 func TestBookCargoHandler(t *testing.T) {
 	t.Run("should call booking service to book new cargo", func(t *testing.T) {
 		// Setup
@@ -187,146 +265,1711 @@ func TestTrackCargoHandler(t *testing.T) {
 		mockBookingService.AssertExpectations(t)
 		mockBookingService.AssertCalled(t, "GetCargoDetails", mock.Anything, trackingId)
 	})
-}
 
-func TestRequestRouteCandidatesHandler(t *testing.T) {
-	t.Run("should call booking service to request route candidates", func(t *testing.T) {
+	t.Run("should expose cargo version as ETag", func(t *testing.T) {
 		// Setup
 		mockBookingService := &MockBookingService{}
 		handler := createTestHandler(t, mockBookingService, nil, nil, nil)
 
-		// Create test data
 		testCargo := createTestCargo(t)
+		testCargo.SetVersion(3)
 		trackingId := testCargo.GetTrackingId()
-		testItineraries := []bookingdomain.Itinerary{createTestItinerary(t)}
-		mockBookingService.On("RequestRouteCandidates", mock.Anything, trackingId).Return(testItineraries, nil)
+		mockBookingService.On("GetCargoDetails", mock.Anything, trackingId).Return(testCargo, nil)
 
-		// Create request
-		reqBody := struct {
-			TrackingId string `json:"trackingId"`
-		}{
-			TrackingId: trackingId.String(),
-		}
-		jsonBody, _ := json.Marshal(reqBody)
-		req := httptest.NewRequest("POST", "/api/v1/route-candidates", bytes.NewBuffer(jsonBody))
+		req := httptest.NewRequest("GET", "/api/v1/cargos/"+trackingId.String(), nil)
 		req = addAuthContext(req)
 		w := httptest.NewRecorder()
 
 		// Execute
-		handler.RequestRouteCandidatesHandler(w, req)
+		handler.TrackCargoHandler(w, req)
 
 		// Verify
 		assert.Equal(t, http.StatusOK, w.Code)
-		mockBookingService.AssertExpectations(t)
-		mockBookingService.AssertCalled(t, "RequestRouteCandidates", mock.Anything, trackingId)
+		assert.Equal(t, `"3"`, w.Header().Get("ETag"))
 	})
-}
 
-func TestSubmitHandlingReportHandler(t *testing.T) {
-	t.Run("should call handling service to submit handling report", func(t *testing.T) {
+	t.Run("should expose the next expected activity", func(t *testing.T) {
 		// Setup
-		mockHandlingService := &MockHandlingReportService{}
-		handler := createTestHandler(t, nil, nil, mockHandlingService, nil)
+		mockBookingService := &MockBookingService{}
+		handler := createTestHandler(t, mockBookingService, nil, nil, nil)
 
-		// Setup mock
-		mockHandlingService.On("SubmitHandlingReport", mock.Anything, mock.Anything).Return(nil)
+		testCargo := createTestCargo(t)
+		require.NoError(t, testCargo.AssignToRoute(createTestItinerary(t)))
+		trackingId := testCargo.GetTrackingId()
+		mockBookingService.On("GetCargoDetails", mock.Anything, trackingId).Return(testCargo, nil)
 
-		// Create request
-		reqBody := HandlingEventRequest{
-			TrackingId:     "550e8400-e29b-41d4-a716-446655440000", // Valid UUID
-			EventType:      "RECEIVE",
-			Location:       "USNYC",
-			VoyageNumber:   "",
-			CompletionTime: time.Now().Add(-time.Hour).Format(time.RFC3339),
-		}
-		jsonBody, _ := json.Marshal(reqBody)
-		req := httptest.NewRequest("POST", "/api/v1/handling-events", bytes.NewBuffer(jsonBody))
-		req = addAuthContext(req)
+		req := addAuthContext(httptest.NewRequest("GET", "/api/v1/cargos/"+trackingId.String(), nil))
 		w := httptest.NewRecorder()
 
 		// Execute
-		handler.SubmitHandlingReportHandler(w, req)
+		handler.TrackCargoHandler(w, req)
 
 		// Verify
-		assert.Equal(t, http.StatusCreated, w.Code)
-		mockHandlingService.AssertExpectations(t)
-		mockHandlingService.AssertCalled(t, "SubmitHandlingReport", mock.Anything, mock.Anything)
+		require.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Data CargoDetailsResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, &HandlingActivityDTO{Type: "RECEIVE", Location: "USNYC"}, response.Data.NextExpectedActivity)
 	})
-}
 
-func TestAssignRouteHandler(t *testing.T) {
-	t.Run("should call booking service to assign route", func(t *testing.T) {
+	t.Run("should expose the estimated time of arrival and lateness", func(t *testing.T) {
 		// Setup
 		mockBookingService := &MockBookingService{}
 		handler := createTestHandler(t, mockBookingService, nil, nil, nil)
 
-		// Create test data
 		testCargo := createTestCargo(t)
+		itinerary := createTestItinerary(t)
+		require.NoError(t, testCargo.AssignToRoute(itinerary))
+		require.NoError(t, testCargo.ChangeArrivalDeadline(itinerary.FinalArrivalTime().Add(time.Hour)))
+		leg := itinerary.Legs[0]
+		require.NoError(t, testCargo.DeriveDeliveryProgress([]bookingdomain.HandlingEventSummary{
+			{Type: "LOAD", Location: "USNYC", VoyageNumber: "V001", Timestamp: leg.LoadTime.Add(3 * time.Hour)},
+		}))
 		trackingId := testCargo.GetTrackingId()
-		mockBookingService.On("AssignRouteToCargo", mock.Anything, trackingId, mock.AnythingOfType("bookingdomain.Itinerary")).Return(nil)
+		mockBookingService.On("GetCargoDetails", mock.Anything, trackingId).Return(testCargo, nil)
 
-		// Create request
-		reqBody := AssignRouteRequest{
-			Legs: []LegDTO{
-				{
-					VoyageNumber:   "V001",
-					LoadLocation:   "USNYC",
-					UnloadLocation: "DEHAM",
-					LoadTime:       time.Now().Add(time.Hour).Format(time.RFC3339),
-					UnloadTime:     time.Now().Add(2 * time.Hour).Format(time.RFC3339),
-				},
+		req := addAuthContext(httptest.NewRequest("GET", "/api/v1/cargos/"+trackingId.String(), nil))
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.TrackCargoHandler(w, req)
+
+		// Verify
+		require.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Data CargoDetailsResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.NotNil(t, response.Data.EstimatedTimeOfArrival)
+		assert.Equal(t, leg.UnloadTime.Add(3*time.Hour).Format(time.RFC3339), *response.Data.EstimatedTimeOfArrival)
+		require.NotNil(t, response.Data.ArrivalSlackSeconds)
+		assert.Equal(t, int64(-2*60*60), *response.Data.ArrivalSlackSeconds)
+		assert.True(t, response.Data.IsLate)
+	})
+}
+
+type MockRerouteService struct {
+	mock.Mock
+}
+
+func (m *MockRerouteService) RerouteMisdirectedCargo(ctx context.Context, trackingId bookingdomain.TrackingId) error {
+	args := m.Called(ctx, trackingId)
+	return args.Error(0)
+}
+
+func (m *MockRerouteService) ResolveReroute(ctx context.Context, trackingId bookingdomain.TrackingId) error {
+	args := m.Called(ctx, trackingId)
+	return args.Error(0)
+}
+
+func (m *MockRerouteService) ListRerouteWorklist(ctx context.Context) ([]bookingdomain.RerouteTask, error) {
+	args := m.Called(ctx)
+	tasks, _ := args.Get(0).([]bookingdomain.RerouteTask)
+	return tasks, args.Error(1)
+}
+
+func TestCargoTimelineHandler(t *testing.T) {
+	t.Run("should return the cargo timeline", func(t *testing.T) {
+		// Setup
+		timelineService := &MockCargoTimelineService{}
+		handler := createTestHandler(t, nil, nil, nil, nil)
+		handler.timelineService = timelineService
+
+		trackingId := bookingdomain.NewTrackingId().String()
+		departure := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+		eta := departure.Add(49 * time.Hour)
+		cargo := trackingdomain.BookedCargo{
+			TrackingId:      trackingId,
+			Origin:          "USNYC",
+			Destination:     "DEHAM",
+			ArrivalDeadline: departure.Add(72 * time.Hour),
+			RoutingStatus:   "ROUTED",
+			TransportStatus: "ONBOARD_CARRIER",
+			BookedAt:        departure.Add(-48 * time.Hour),
+			RoutedAt:        departure.Add(-24 * time.Hour),
+			Legs: []trackingdomain.PlannedLeg{
+				{VoyageNumber: "V001", LoadLocation: "USNYC", UnloadLocation: "DEHAM", LoadTime: departure, UnloadTime: departure.Add(48 * time.Hour)},
 			},
+			EstimatedTimeOfArrival: &eta,
 		}
-		jsonBody, _ := json.Marshal(reqBody)
-		req := httptest.NewRequest("PUT", "/api/v1/cargos/"+trackingId.String()+"/route", bytes.NewBuffer(jsonBody))
-		req = addAuthContext(req)
+		loaded := trackingdomain.HandledEvent{EventId: "event-1", Type: "LOAD", Location: "USNYC", VoyageNumber: "V001",
+			CompletionTime: departure.Add(time.Hour), RegistrationTime: departure.Add(2 * time.Hour)}
+		placed := trackingdomain.TimelineEvent{HandledEvent: loaded, LegIndex: 0, Expected: true}
+		timeline := trackingdomain.NewCargoTimeline(cargo, []trackingdomain.TimelineEvent{placed}, departure.Add(3*time.Hour))
+		timelineService.On("GetCargoTimeline", mock.Anything, trackingId).Return(timeline, nil)
+
+		req := addAuthContext(httptest.NewRequest("GET", "/api/v1/cargos/"+trackingId+"/timeline", nil))
 		w := httptest.NewRecorder()
 
 		// Execute
-		handler.AssignRouteHandler(w, req)
+		handler.CargoTimelineHandler(w, req)
 
 		// Verify
-		assert.Equal(t, http.StatusOK, w.Code)
-		mockBookingService.AssertExpectations(t)
-		mockBookingService.AssertCalled(t, "AssignRouteToCargo", mock.Anything, trackingId, mock.AnythingOfType("bookingdomain.Itinerary"))
+		require.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Data CargoTimelineResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, trackingId, response.Data.TrackingId)
+		require.Len(t, response.Data.Legs, 1)
+		assert.Equal(t, "ONBOARD", response.Data.Legs[0].Status)
+		assert.Equal(t, "2024-03-01T09:00:00Z", *response.Data.Legs[0].ActualLoadTime)
+		assert.Equal(t, []MilestoneDTO{
+			{Type: "BOOKED", Time: "2024-02-28T08:00:00Z"},
+			{Type: "ROUTED", Time: "2024-02-29T08:00:00Z"},
+		}, response.Data.Milestones)
+		require.Len(t, response.Data.Events, 1)
+		assert.Equal(t, 0, *response.Data.Events[0].Leg)
+		assert.True(t, response.Data.Events[0].Expected)
+		assert.Equal(t, &PositionDTO{Location: "USNYC", VoyageNumber: "V001"}, response.Data.ActualPosition)
+		assert.True(t, response.Data.IsOnSchedule)
+		assert.Equal(t, "2024-03-03T09:00:00Z", *response.Data.EstimatedTimeOfArrival)
+		assert.Equal(t, int64(23*60*60), *response.Data.ArrivalSlackSeconds)
+		assert.False(t, response.Data.IsLate)
+	})
+
+	t.Run("should return 404 for cargo that is not booked", func(t *testing.T) {
+		// Setup
+		timelineService := &MockCargoTimelineService{}
+		handler := createTestHandler(t, nil, nil, nil, nil)
+		handler.timelineService = timelineService
+
+		trackingId := bookingdomain.NewTrackingId().String()
+		timelineService.On("GetCargoTimeline", mock.Anything, trackingId).
+			Return(trackingdomain.CargoTimeline{}, fmt.Errorf("failed to load cargo: %w", trackingdomain.NewCargoNotFoundError(trackingId)))
+
+		req := addAuthContext(httptest.NewRequest("GET", "/api/v1/cargos/"+trackingId+"/timeline", nil))
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.CargoTimelineHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "cargo_not_found")
+	})
+
+	t.Run("should return 403 when the caller may not view the cargo or its handling", func(t *testing.T) {
+		// Setup
+		timelineService := &MockCargoTimelineService{}
+		handler := createTestHandler(t, nil, nil, nil, nil)
+		handler.timelineService = timelineService
+
+		trackingId := bookingdomain.NewTrackingId().String()
+		timelineService.On("GetCargoTimeline", mock.Anything, trackingId).
+			Return(trackingdomain.CargoTimeline{}, auth.NewAuthorizationError("insufficient permissions for handling operation"))
+
+		req := addAuthContext(httptest.NewRequest("GET", "/api/v1/cargos/"+trackingId+"/timeline", nil))
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.CargoTimelineHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("should reject malformed tracking ID", func(t *testing.T) {
+		// Setup
+		timelineService := &MockCargoTimelineService{}
+		handler := createTestHandler(t, nil, nil, nil, nil)
+		handler.timelineService = timelineService
+
+		req := addAuthContext(httptest.NewRequest("GET", "/api/v1/cargos/not-a-tracking-id/timeline", nil))
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.CargoTimelineHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		timelineService.AssertNotCalled(t, "GetCargoTimeline", mock.Anything, mock.Anything)
 	})
 }
 
-func TestListCargoHandler(t *testing.T) {
-	t.Run("should call booking service to list all cargo", func(t *testing.T) {
+func TestListReroutesHandler(t *testing.T) {
+	t.Run("should list misdirected cargo with its route candidates", func(t *testing.T) {
 		// Setup
-		mockBookingService := &MockBookingService{}
-		handler := createTestHandler(t, mockBookingService, nil, nil, nil)
+		rerouteService := &MockRerouteService{}
+		handler := createTestHandler(t, nil, nil, nil, nil)
+		handler.rerouteService = rerouteService
+
+		departure := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+		routeSpec := bookingdomain.RouteSpecification{Origin: "NLRTM", Destination: "DEHAM", ArrivalDeadline: departure.Add(72 * time.Hour)}
+		candidate := bookingdomain.Itinerary{Legs: []bookingdomain.Leg{
+			{VoyageNumber: "V002", LoadLocation: "NLRTM", UnloadLocation: "DEHAM", LoadTime: departure, UnloadTime: departure.Add(24 * time.Hour)},
+		}}
+		task := bookingdomain.NewRerouteTask(bookingdomain.NewTrackingId(), routeSpec, []bookingdomain.Itinerary{candidate})
+		task.CreatedAt = departure.Add(-time.Hour)
+		rerouteService.On("ListRerouteWorklist", mock.Anything).Return([]bookingdomain.RerouteTask{task}, nil)
+
+		req := addAuthContext(httptest.NewRequest("GET", "/api/v1/reroutes", nil))
+		w := httptest.NewRecorder()
 
-		// Setup mock
-		testCargos := []bookingdomain.Cargo{createTestCargo(t)}
-		mockBookingService.On("ListUnroutedCargo", mock.Anything).Return(testCargos, nil)
+		// Execute
+		handler.ListReroutesHandler(w, req)
 
-		// Create request
-		req := httptest.NewRequest("GET", "/api/v1/cargos", nil)
-		req = addAuthContext(req)
+		// Verify
+		require.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Data []RerouteTaskResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Data, 1)
+		assert.Equal(t, task.TrackingId.String(), response.Data[0].TrackingId)
+		assert.Equal(t, "NLRTM", response.Data[0].From)
+		assert.Equal(t, "DEHAM", response.Data[0].Destination)
+		assert.Equal(t, "2024-03-04T08:00:00Z", response.Data[0].ArrivalDeadline)
+		assert.Equal(t, "2024-03-01T07:00:00Z", response.Data[0].Since)
+		require.Len(t, response.Data[0].Candidates, 1)
+		assert.Equal(t, "V002", response.Data[0].Candidates[0].Legs[0].VoyageNumber)
+	})
+
+	t.Run("should return 403 when the caller may not view cargo", func(t *testing.T) {
+		// Setup
+		rerouteService := &MockRerouteService{}
+		handler := createTestHandler(t, nil, nil, nil, nil)
+		handler.rerouteService = rerouteService
+		rerouteService.On("ListRerouteWorklist", mock.Anything).
+			Return(nil, auth.NewAuthorizationError("insufficient permissions for booking operation"))
+
+		req := addAuthContext(httptest.NewRequest("GET", "/api/v1/reroutes", nil))
 		w := httptest.NewRecorder()
 
 		// Execute
-		handler.ListCargoHandler(w, req)
+		handler.ListReroutesHandler(w, req)
 
 		// Verify
-		assert.Equal(t, http.StatusOK, w.Code)
-		mockBookingService.AssertExpectations(t)
-		mockBookingService.AssertCalled(t, "ListUnroutedCargo", mock.Anything)
+		assert.Equal(t, http.StatusForbidden, w.Code)
 	})
 }
 
-// Helper functions
+type MockRouteFinder struct {
+	mock.Mock
+}
 
-func createTestHandler(t *testing.T, bookingService *MockBookingService, routingService *MockRoutingService, handlingReportService *MockHandlingReportService, handlingQueryService *MockHandlingQueryService) *Handler {
-	return &Handler{
-		authMiddleware:        nil, // Not needed for unit tests
-		bookingService:        bookingService,
-		routingService:        nil, // Routing service not used in most tests
-		handlingReportService: handlingReportService,
-		handlingQueryService:  handlingQueryService,
-	}
+func (m *MockRouteFinder) FindOptimalItineraries(ctx context.Context, routeSpec routingdomain.RouteSpecification, criteria routingdomain.RankingCriteria) ([]routingdomain.Itinerary, error) {
+	args := m.Called(ctx, routeSpec, criteria)
+	itineraries, _ := args.Get(0).([]routingdomain.Itinerary)
+	return itineraries, args.Error(1)
+}
+
+func (m *MockRouteFinder) ListAllVoyages(ctx context.Context) ([]routingdomain.Voyage, error) {
+	args := m.Called(ctx)
+	voyages, _ := args.Get(0).([]routingdomain.Voyage)
+	return voyages, args.Error(1)
+}
+
+func (m *MockRouteFinder) ListAllLocations(ctx context.Context) ([]routingdomain.Location, error) {
+	args := m.Called(ctx)
+	locations, _ := args.Get(0).([]routingdomain.Location)
+	return locations, args.Error(1)
+}
+
+func (m *MockRouteFinder) GetVoyage(ctx context.Context, voyageNumber routingdomain.VoyageNumber) (routingdomain.Voyage, error) {
+	args := m.Called(ctx, voyageNumber)
+	return args.Get(0).(routingdomain.Voyage), args.Error(1)
+}
+
+func (m *MockRouteFinder) GetLocation(ctx context.Context, unLocode routingdomain.UnLocode) (routingdomain.Location, error) {
+	args := m.Called(ctx, unLocode)
+	return args.Get(0).(routingdomain.Location), args.Error(1)
+}
+
+type MockVoyageScheduler struct {
+	mock.Mock
+}
+
+func (m *MockVoyageScheduler) CreateVoyage(ctx context.Context, movements []routingdomain.CarrierMovement) (routingdomain.Voyage, error) {
+	args := m.Called(ctx, movements)
+	return args.Get(0).(routingdomain.Voyage), args.Error(1)
+}
+
+func (m *MockVoyageScheduler) RescheduleVoyage(ctx context.Context, voyageNumber routingdomain.VoyageNumber, movements []routingdomain.CarrierMovement) (routingdomain.Voyage, error) {
+	args := m.Called(ctx, voyageNumber, movements)
+	return args.Get(0).(routingdomain.Voyage), args.Error(1)
+}
+
+func (m *MockVoyageScheduler) DeleteVoyage(ctx context.Context, voyageNumber routingdomain.VoyageNumber) error {
+	args := m.Called(ctx, voyageNumber)
+	return args.Error(0)
+}
+
+func TestCreateVoyageHandler(t *testing.T) {
+	voyage := createTestVoyage(t)
+	movements := voyage.GetSchedule().Movements
+
+	t.Run("should schedule a voyage from its legs", func(t *testing.T) {
+		// Setup
+		voyageScheduler := &MockVoyageScheduler{}
+		handler := createTestHandler(t, nil, nil, nil, nil)
+		handler.voyageScheduler = voyageScheduler
+		voyageScheduler.On("CreateVoyage", mock.Anything, movements).Return(voyage, nil)
+
+		body, _ := json.Marshal(VoyageRequest{Schedule: VoyageToResponse(voyage).Schedule})
+		req := addAuthContext(httptest.NewRequest("POST", "/api/v1/voyages", bytes.NewBuffer(body)))
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.CreateVoyageHandler(w, req)
+
+		// Verify
+		require.Equal(t, http.StatusCreated, w.Code)
+		var response struct {
+			Data VoyageResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, voyage.GetVoyageNumber().String(), response.Data.VoyageNumber)
+		assert.Len(t, response.Data.Schedule, 2)
+		voyageScheduler.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 for a leg with an invalid time", func(t *testing.T) {
+		// Setup
+		voyageScheduler := &MockVoyageScheduler{}
+		handler := createTestHandler(t, nil, nil, nil, nil)
+		handler.voyageScheduler = voyageScheduler
+
+		body, _ := json.Marshal(VoyageRequest{Schedule: []LegDTO{
+			{LoadLocation: "USNYC", UnloadLocation: "DEHAM", LoadTime: "tomorrow", UnloadTime: "2024-03-02T08:00:00Z"},
+		}})
+		req := addAuthContext(httptest.NewRequest("POST", "/api/v1/voyages", bytes.NewBuffer(body)))
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.CreateVoyageHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid_schedule")
+		voyageScheduler.AssertNotCalled(t, "CreateVoyage", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return 403 when the caller may not manage schedules", func(t *testing.T) {
+		// Setup
+		voyageScheduler := &MockVoyageScheduler{}
+		handler := createTestHandler(t, nil, nil, nil, nil)
+		handler.voyageScheduler = voyageScheduler
+		voyageScheduler.On("CreateVoyage", mock.Anything, movements).
+			Return(routingdomain.Voyage{}, auth.NewAuthorizationError("insufficient permissions for routing operation"))
+
+		body, _ := json.Marshal(VoyageRequest{Schedule: VoyageToResponse(voyage).Schedule})
+		req := addAuthContext(httptest.NewRequest("POST", "/api/v1/voyages", bytes.NewBuffer(body)))
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.CreateVoyageHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestGetVoyageHandler(t *testing.T) {
+	t.Run("should return the voyage", func(t *testing.T) {
+		// Setup
+		routeFinder := &MockRouteFinder{}
+		handler := createTestHandler(t, nil, nil, nil, nil)
+		handler.routingService = routeFinder
+		voyage := createTestVoyage(t)
+		routeFinder.On("GetVoyage", mock.Anything, voyage.GetVoyageNumber()).Return(voyage, nil)
+
+		req := addAuthContext(httptest.NewRequest("GET", "/api/v1/voyages/"+voyage.GetVoyageNumber().String(), nil))
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.GetVoyageHandler(w, req)
+
+		// Verify
+		require.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Data VoyageResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, VoyageToResponse(voyage), response.Data)
+	})
+
+	t.Run("should return 404 for unknown voyage", func(t *testing.T) {
+		// Setup
+		routeFinder := &MockRouteFinder{}
+		handler := createTestHandler(t, nil, nil, nil, nil)
+		handler.routingService = routeFinder
+		unknown := routingdomain.NewVoyageNumber()
+		routeFinder.On("GetVoyage", mock.Anything, unknown).Return(routingdomain.Voyage{}, routingdomain.NewVoyageNotFoundError(unknown))
+
+		req := addAuthContext(httptest.NewRequest("GET", "/api/v1/voyages/"+unknown.String(), nil))
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.GetVoyageHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("should return 400 for malformed voyage number", func(t *testing.T) {
+		// Setup
+		routeFinder := &MockRouteFinder{}
+		handler := createTestHandler(t, nil, nil, nil, nil)
+		handler.routingService = routeFinder
+
+		req := addAuthContext(httptest.NewRequest("GET", "/api/v1/voyages/V001", nil))
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.GetVoyageHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		routeFinder.AssertNotCalled(t, "GetVoyage", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRescheduleVoyageHandler(t *testing.T) {
+	voyage := createTestVoyage(t)
+	movements := voyage.GetSchedule().Movements
+
+	t.Run("should replace the schedule of the voyage", func(t *testing.T) {
+		// Setup
+		voyageScheduler := &MockVoyageScheduler{}
+		handler := createTestHandler(t, nil, nil, nil, nil)
+		handler.voyageScheduler = voyageScheduler
+		voyageScheduler.On("RescheduleVoyage", mock.Anything, voyage.GetVoyageNumber(), movements).Return(voyage, nil)
+
+		body, _ := json.Marshal(VoyageRequest{Schedule: VoyageToResponse(voyage).Schedule})
+		req := addAuthContext(httptest.NewRequest("PUT", "/api/v1/voyages/"+voyage.GetVoyageNumber().String(), bytes.NewBuffer(body)))
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.RescheduleVoyageHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusOK, w.Code)
+		voyageScheduler.AssertExpectations(t)
+	})
+
+	t.Run("should return 400 for a disconnected schedule", func(t *testing.T) {
+		// Setup
+		voyageScheduler := &MockVoyageScheduler{}
+		handler := createTestHandler(t, nil, nil, nil, nil)
+		handler.voyageScheduler = voyageScheduler
+		voyageScheduler.On("RescheduleVoyage", mock.Anything, voyage.GetVoyageNumber(), movements).
+			Return(routingdomain.Voyage{}, routingdomain.NewDomainValidationError("movements must be connected - arrival location must match next movement's departure location", nil))
+
+		body, _ := json.Marshal(VoyageRequest{Schedule: VoyageToResponse(voyage).Schedule})
+		req := addAuthContext(httptest.NewRequest("PUT", "/api/v1/voyages/"+voyage.GetVoyageNumber().String(), bytes.NewBuffer(body)))
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.RescheduleVoyageHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid_schedule")
+	})
+
+	t.Run("should return 404 for unknown voyage", func(t *testing.T) {
+		// Setup
+		voyageScheduler := &MockVoyageScheduler{}
+		handler := createTestHandler(t, nil, nil, nil, nil)
+		handler.voyageScheduler = voyageScheduler
+		voyageScheduler.On("RescheduleVoyage", mock.Anything, voyage.GetVoyageNumber(), movements).
+			Return(routingdomain.Voyage{}, routingdomain.NewVoyageNotFoundError(voyage.GetVoyageNumber()))
+
+		body, _ := json.Marshal(VoyageRequest{Schedule: VoyageToResponse(voyage).Schedule})
+		req := addAuthContext(httptest.NewRequest("PUT", "/api/v1/voyages/"+voyage.GetVoyageNumber().String(), bytes.NewBuffer(body)))
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.RescheduleVoyageHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestDeleteVoyageHandler(t *testing.T) {
+	t.Run("should delete the voyage", func(t *testing.T) {
+		// Setup
+		voyageScheduler := &MockVoyageScheduler{}
+		handler := createTestHandler(t, nil, nil, nil, nil)
+		handler.voyageScheduler = voyageScheduler
+		voyageNumber := routingdomain.NewVoyageNumber()
+		voyageScheduler.On("DeleteVoyage", mock.Anything, voyageNumber).Return(nil)
+
+		req := addAuthContext(httptest.NewRequest("DELETE", "/api/v1/voyages/"+voyageNumber.String(), nil))
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.DeleteVoyageHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusOK, w.Code)
+		voyageScheduler.AssertExpectations(t)
+	})
+
+	t.Run("should return 404 for unknown voyage", func(t *testing.T) {
+		// Setup
+		voyageScheduler := &MockVoyageScheduler{}
+		handler := createTestHandler(t, nil, nil, nil, nil)
+		handler.voyageScheduler = voyageScheduler
+		unknown := routingdomain.NewVoyageNumber()
+		voyageScheduler.On("DeleteVoyage", mock.Anything, unknown).Return(routingdomain.NewVoyageNotFoundError(unknown))
+
+		req := addAuthContext(httptest.NewRequest("DELETE", "/api/v1/voyages/"+unknown.String(), nil))
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.DeleteVoyageHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("should return 409 while cargo is routed on the voyage", func(t *testing.T) {
+		// Setup
+		voyageScheduler := &MockVoyageScheduler{}
+		handler := createTestHandler(t, nil, nil, nil, nil)
+		handler.voyageScheduler = voyageScheduler
+		voyageNumber := routingdomain.NewVoyageNumber()
+		voyageScheduler.On("DeleteVoyage", mock.Anything, voyageNumber).Return(routingdomain.NewVoyageInUseError(voyageNumber))
+
+		req := addAuthContext(httptest.NewRequest("DELETE", "/api/v1/voyages/"+voyageNumber.String(), nil))
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.DeleteVoyageHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "voyage_in_use")
+	})
+}
+
+func TestRequestRouteCandidatesHandler(t *testing.T) {
+	t.Run("should call booking service to request route candidates", func(t *testing.T) {
+		// Setup
+		mockBookingService := &MockBookingService{}
+		handler := createTestHandler(t, mockBookingService, nil, nil, nil)
+
+		// Create test data
+		testCargo := createTestCargo(t)
+		trackingId := testCargo.GetTrackingId()
+		testItineraries := []bookingdomain.Itinerary{createTestItinerary(t)}
+		mockBookingService.On("RequestRouteCandidates", mock.Anything, trackingId, bookingdomain.RankingCriteria{}).Return(testItineraries, nil)
+
+		// Create request
+		reqBody := struct {
+			TrackingId string `json:"trackingId"`
+		}{
+			TrackingId: trackingId.String(),
+		}
+		jsonBody, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest("POST", "/api/v1/route-candidates", bytes.NewBuffer(jsonBody))
+		req = addAuthContext(req)
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.RequestRouteCandidatesHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockBookingService.AssertExpectations(t)
+		mockBookingService.AssertCalled(t, "RequestRouteCandidates", mock.Anything, trackingId, bookingdomain.RankingCriteria{})
+	})
+
+	t.Run("should return 422 for cargo that cannot be routed from where it is", func(t *testing.T) {
+		// Setup
+		mockBookingService := &MockBookingService{}
+		handler := createTestHandler(t, mockBookingService, nil, nil, nil)
+
+		trackingId := createTestCargo(t).GetTrackingId()
+		mockBookingService.On("RequestRouteCandidates", mock.Anything, trackingId, bookingdomain.RankingCriteria{}).
+			Return([]bookingdomain.Itinerary(nil), bookingdomain.NewDomainValidationError("cannot route delivered, claimed or cancelled cargo", nil))
+
+		jsonBody, _ := json.Marshal(map[string]string{"trackingId": trackingId.String()})
+		req := addAuthContext(httptest.NewRequest("POST", "/api/v1/route-candidates", bytes.NewBuffer(jsonBody)))
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.RequestRouteCandidatesHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Contains(t, w.Body.String(), "cargo_not_routable")
+	})
+
+	t.Run("should pass ranking criteria to booking service", func(t *testing.T) {
+		// Setup
+		mockBookingService := &MockBookingService{}
+		handler := createTestHandler(t, mockBookingService, nil, nil, nil)
+
+		// Create test data
+		testCargo := createTestCargo(t)
+		trackingId := testCargo.GetTrackingId()
+		criteria := bookingdomain.RankingCriteria{Strategy: "fewest_legs", MaxResults: 3}
+		mockBookingService.On("RequestRouteCandidates", mock.Anything, trackingId, criteria).Return([]bookingdomain.Itinerary{}, nil)
+
+		// Create request
+		reqBody := map[string]interface{}{
+			"trackingId": trackingId.String(),
+			"strategy":   "fewest_legs",
+			"maxResults": 3,
+		}
+		jsonBody, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest("POST", "/api/v1/route-candidates", bytes.NewBuffer(jsonBody))
+		req = addAuthContext(req)
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.RequestRouteCandidatesHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockBookingService.AssertCalled(t, "RequestRouteCandidates", mock.Anything, trackingId, criteria)
+	})
+
+	t.Run("should reject unknown ranking strategy", func(t *testing.T) {
+		// Setup
+		mockBookingService := &MockBookingService{}
+		handler := createTestHandler(t, mockBookingService, nil, nil, nil)
+
+		// Create request
+		reqBody := map[string]interface{}{
+			"trackingId": createTestCargo(t).GetTrackingId().String(),
+			"strategy":   "cheapest",
+		}
+		jsonBody, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest("POST", "/api/v1/route-candidates", bytes.NewBuffer(jsonBody))
+		req = addAuthContext(req)
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.RequestRouteCandidatesHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockBookingService.AssertNotCalled(t, "RequestRouteCandidates", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSubmitHandlingReportHandler(t *testing.T) {
+	t.Run("should call handling service to submit handling report", func(t *testing.T) {
+		// Setup
+		mockHandlingService := &MockHandlingReportService{}
+		handler := createTestHandler(t, nil, nil, mockHandlingService, nil)
+
+		// Setup mock
+		eventId := handlingdomain.NewHandlingEventId()
+		mockHandlingService.On("SubmitHandlingReport", mock.Anything, mock.Anything).Return(handlingdomain.HandlingReceipt{EventId: eventId, RegisteredAt: time.Now()}, nil)
+
+		// Create request
+		reqBody := HandlingEventRequest{
+			TrackingId:     "550e8400-e29b-41d4-a716-446655440000", // Valid UUID
+			EventType:      "RECEIVE",
+			Location:       "USNYC",
+			VoyageNumber:   "",
+			CompletionTime: time.Now().Add(-time.Hour).Format(time.RFC3339),
+		}
+		jsonBody, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest("POST", "/api/v1/handling-events", bytes.NewBuffer(jsonBody))
+		req = addAuthContext(req)
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.SubmitHandlingReportHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), eventId.String())
+		mockHandlingService.AssertExpectations(t)
+		mockHandlingService.AssertCalled(t, "SubmitHandlingReport", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should answer replayed report with the original event", func(t *testing.T) {
+		// Setup
+		mockHandlingService := &MockHandlingReportService{}
+		handler := createTestHandler(t, nil, nil, mockHandlingService, nil)
+
+		eventId := handlingdomain.NewHandlingEventId()
+		mockHandlingService.On("SubmitHandlingReport", mock.Anything, mock.MatchedBy(func(report handlingdomain.HandlingReport) bool {
+			return report.IdempotencyKey == "scan-42"
+		})).Return(handlingdomain.HandlingReceipt{EventId: eventId, RegisteredAt: time.Now().Add(-time.Minute), Duplicate: true}, nil)
+
+		reqBody := HandlingEventRequest{
+			TrackingId:     "550e8400-e29b-41d4-a716-446655440000",
+			EventType:      "RECEIVE",
+			Location:       "USNYC",
+			CompletionTime: time.Now().Add(-time.Hour).Format(time.RFC3339),
+			IdempotencyKey: "ignored-in-favour-of-header",
+		}
+		jsonBody, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest("POST", "/api/v1/handling-events", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Idempotency-Key", "scan-42")
+		req = addAuthContext(req)
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.SubmitHandlingReportHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Data HandlingEventResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, eventId.String(), response.Data.EventId)
+		mockHandlingService.AssertExpectations(t)
+	})
+
+	submit := func(t *testing.T, serviceErr error) *httptest.ResponseRecorder {
+		mockHandlingService := &MockHandlingReportService{}
+		handler := createTestHandler(t, nil, nil, mockHandlingService, nil)
+		mockHandlingService.On("SubmitHandlingReport", mock.Anything, mock.Anything).Return(handlingdomain.HandlingReceipt{}, serviceErr)
+
+		reqBody := HandlingEventRequest{
+			TrackingId:     "550e8400-e29b-41d4-a716-446655440000",
+			EventType:      "LOAD",
+			Location:       "USNYC",
+			VoyageNumber:   "V001",
+			CompletionTime: time.Now().Add(-time.Hour).Format(time.RFC3339),
+		}
+		jsonBody, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest("POST", "/api/v1/handling-events", bytes.NewBuffer(jsonBody))
+		req = addAuthContext(req)
+		w := httptest.NewRecorder()
+
+		handler.SubmitHandlingReportHandler(w, req)
+		return w
+	}
+
+	t.Run("should reject out-of-sequence report", func(t *testing.T) {
+		// Setup
+		serviceErr := fmt.Errorf("handling report rejected: %w", handlingdomain.NewHandlingSequenceError("first handling event must be RECEIVE"))
+
+		// Execute
+		w := submit(t, serviceErr)
+
+		// Verify
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid_handling_sequence")
+	})
+
+	t.Run("should reject report with unknown references", func(t *testing.T) {
+		// Setup
+		serviceErr := fmt.Errorf("handling report rejected: %w", handlingdomain.NewUnknownReferenceError("voyage V001 is unknown"))
+
+		// Execute
+		w := submit(t, serviceErr)
+
+		// Verify
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid_handling_reference")
+	})
+
+	t.Run("should reject report outside the time window", func(t *testing.T) {
+		// Setup
+		serviceErr := fmt.Errorf("failed to create handling event: %w", handlingdomain.NewDomainValidationError("completion time cannot be more than 30 days in the past", nil))
+
+		// Execute
+		w := submit(t, serviceErr)
+
+		// Verify
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "validation_error")
+	})
+
+	t.Run("should reject idempotency key reused for a different report", func(t *testing.T) {
+		// Setup
+		serviceErr := fmt.Errorf("handling report rejected: %w", handlingdomain.NewIdempotencyKeyConflictError("scan-42"))
+
+		// Execute
+		w := submit(t, serviceErr)
+
+		// Verify
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Contains(t, w.Body.String(), "idempotency_key_conflict")
+	})
+
+	t.Run("should report quarantined report", func(t *testing.T) {
+		// Setup
+		quarantined := handlingdomain.NewQuarantinedReport(handlingdomain.HandlingReport{
+			TrackingId:     "550e8400-e29b-41d4-a716-446655440000",
+			EventType:      "LOAD",
+			Location:       "USNYC",
+			VoyageNumber:   "V001",
+			CompletionTime: "2024-01-20T08:00:00Z",
+		}, "first handling event must be RECEIVE")
+		serviceErr := handlingdomain.NewReportQuarantinedError(quarantined, handlingdomain.NewHandlingSequenceError("first handling event must be RECEIVE"))
+
+		// Execute
+		w := submit(t, serviceErr)
+
+		// Verify
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		var response struct {
+			Status string                    `json:"status"`
+			Data   QuarantinedReportResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "quarantined", response.Status)
+		assert.Equal(t, quarantined.Id, response.Data.QuarantineId)
+		assert.Equal(t, "first handling event must be RECEIVE", response.Data.Reason)
+		assert.NotContains(t, w.Body.String(), "error")
+	})
+}
+
+func TestSubmitHandlingReportBatchHandler(t *testing.T) {
+	const trackingId = "550e8400-e29b-41d4-a716-446655440000"
+	completionTime := time.Now().Add(-time.Hour).Format(time.RFC3339)
+
+	submit := func(t *testing.T, mockHandlingService *MockHandlingReportService, contentType, body string) *httptest.ResponseRecorder {
+		handler := createTestHandler(t, nil, nil, mockHandlingService, nil)
+		req := httptest.NewRequest("POST", "/api/v1/handling-events/batch", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", contentType)
+		req = addAuthContext(req)
+		w := httptest.NewRecorder()
+
+		handler.SubmitHandlingReportBatchHandler(w, req)
+		return w
+	}
+
+	decode := func(t *testing.T, w *httptest.ResponseRecorder) HandlingReportBatchResponse {
+		var response struct {
+			Data HandlingReportBatchResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Data
+	}
+
+	t.Run("should submit valid reports of a JSON array and reject invalid ones", func(t *testing.T) {
+		// Setup
+		mockHandlingService := &MockHandlingReportService{}
+		mockHandlingService.On("SubmitHandlingReports", mock.Anything, mock.MatchedBy(func(reports []handlingdomain.HandlingReport) bool {
+			return len(reports) == 2 && reports[0].EventType == "RECEIVE" && reports[1].EventType == "LOAD"
+		})).Return([]handlingdomain.HandlingReportResult{
+			{Index: 0, TrackingId: trackingId, Status: handlingdomain.HandlingReportAccepted},
+			{Index: 1, TrackingId: trackingId, Status: handlingdomain.HandlingReportQuarantined, Reason: "handling report quarantined"},
+		}, nil)
+
+		requests := []HandlingEventRequest{
+			{TrackingId: trackingId, EventType: "RECEIVE", Location: "USNYC", CompletionTime: completionTime},
+			{TrackingId: trackingId, EventType: "LOAD", Location: "USNYC", VoyageNumber: "V001", CompletionTime: "yesterday"},
+			{TrackingId: trackingId, EventType: "LOAD", Location: "USNYC", VoyageNumber: "V001", CompletionTime: completionTime},
+		}
+		body, _ := json.Marshal(requests)
+
+		// Execute
+		w := submit(t, mockHandlingService, "application/json", string(body))
+
+		// Verify
+		require.Equal(t, http.StatusOK, w.Code)
+		response := decode(t, w)
+		assert.Equal(t, 1, response.Accepted)
+		assert.Equal(t, 1, response.Rejected)
+		assert.Equal(t, 1, response.Quarantined)
+		require.Len(t, response.Results, 3)
+		assert.Equal(t, "accepted", response.Results[0].Status)
+		assert.Equal(t, "rejected", response.Results[1].Status)
+		assert.Contains(t, response.Results[1].Reason, "RFC3339")
+		assert.Equal(t, "quarantined", response.Results[2].Status)
+		assert.Equal(t, 2, response.Results[2].Index)
+		mockHandlingService.AssertExpectations(t)
+	})
+
+	t.Run("should count duplicates and pass item idempotency keys", func(t *testing.T) {
+		// Setup
+		eventId := handlingdomain.NewHandlingEventId().String()
+		mockHandlingService := &MockHandlingReportService{}
+		mockHandlingService.On("SubmitHandlingReports", mock.Anything, mock.MatchedBy(func(reports []handlingdomain.HandlingReport) bool {
+			return len(reports) == 1 && reports[0].IdempotencyKey == "scan-42"
+		})).Return([]handlingdomain.HandlingReportResult{
+			{Index: 0, TrackingId: trackingId, Status: handlingdomain.HandlingReportDuplicate, EventId: eventId},
+		}, nil)
+
+		requests := []HandlingEventRequest{
+			{TrackingId: trackingId, EventType: "RECEIVE", Location: "USNYC", CompletionTime: completionTime, IdempotencyKey: "scan-42"},
+		}
+		body, _ := json.Marshal(requests)
+
+		// Execute
+		w := submit(t, mockHandlingService, "application/json", string(body))
+
+		// Verify
+		require.Equal(t, http.StatusOK, w.Code)
+		response := decode(t, w)
+		assert.Equal(t, 1, response.Duplicate)
+		assert.Equal(t, 0, response.Rejected)
+		require.Len(t, response.Results, 1)
+		assert.Equal(t, "duplicate", response.Results[0].Status)
+		assert.Equal(t, eventId, response.Results[0].EventId)
+		mockHandlingService.AssertExpectations(t)
+	})
+
+	decodeLines := func(t *testing.T, w *httptest.ResponseRecorder) []HandlingReportResultDTO {
+		var results []HandlingReportResultDTO
+		decoder := json.NewDecoder(w.Body)
+		for decoder.More() {
+			var result HandlingReportResultDTO
+			require.NoError(t, decoder.Decode(&result))
+			results = append(results, result)
+		}
+		return results
+	}
+
+	t.Run("should answer NDJSON batches in chunks of lines", func(t *testing.T) {
+		// Setup
+		mockHandlingService := &MockHandlingReportService{}
+		mockHandlingService.On("SubmitHandlingReports", mock.Anything, mock.MatchedBy(func(reports []handlingdomain.HandlingReport) bool {
+			return len(reports) == 2 && reports[0].EventType == "RECEIVE" && reports[1].EventType == "RECEIVE"
+		})).Return([]handlingdomain.HandlingReportResult{
+			{Index: 0, TrackingId: trackingId, Status: handlingdomain.HandlingReportAccepted},
+			{Index: 1, TrackingId: trackingId, Status: handlingdomain.HandlingReportAccepted},
+		}, nil).Once()
+
+		line := fmt.Sprintf(`{"trackingId":%q,"eventType":"RECEIVE","location":"USNYC","completionTime":%q}`, trackingId, completionTime)
+		body := line + "\n\n{not json\n" + line + "\n"
+
+		// Execute
+		w := submit(t, mockHandlingService, "application/x-ndjson", body)
+
+		// Verify
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+		assert.True(t, w.Flushed)
+		results := decodeLines(t, w)
+		require.Len(t, results, 3)
+		assert.Equal(t, "accepted", results[0].Status)
+		assert.Equal(t, "rejected", results[1].Status)
+		assert.Contains(t, results[1].Reason, "line 3")
+		assert.Equal(t, "accepted", results[2].Status)
+		assert.Equal(t, []int{0, 1, 2}, []int{results[0].Index, results[1].Index, results[2].Index})
+		mockHandlingService.AssertExpectations(t)
+	})
+
+	t.Run("should end an NDJSON answer with an error line when a later chunk fails", func(t *testing.T) {
+		// Setup
+		accepted := make([]handlingdomain.HandlingReportResult, handlingChunkLines)
+		for i := range accepted {
+			accepted[i] = handlingdomain.HandlingReportResult{Index: i, TrackingId: trackingId, Status: handlingdomain.HandlingReportAccepted}
+		}
+		mockHandlingService := &MockHandlingReportService{}
+		mockHandlingService.On("SubmitHandlingReports", mock.Anything, mock.Anything).Return(accepted, nil).Once()
+		mockHandlingService.On("SubmitHandlingReports", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("handling storage unavailable")).Once()
+
+		line := fmt.Sprintf(`{"trackingId":%q,"eventType":"RECEIVE","location":"USNYC","completionTime":%q}`, trackingId, completionTime)
+
+		// Execute
+		w := submit(t, mockHandlingService, "application/x-ndjson", strings.Repeat(line+"\n", handlingChunkLines+1))
+
+		// Verify
+		require.Equal(t, http.StatusOK, w.Code)
+		lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+		require.Len(t, lines, handlingChunkLines+1)
+		assert.Contains(t, lines[0], `"status":"accepted"`)
+		assert.Contains(t, lines[handlingChunkLines], "handling_report_failed")
+		mockHandlingService.AssertNumberOfCalls(t, "SubmitHandlingReports", 2)
+		assert.Equal(t, 1, mockHandlingService.batchesStarted)
+	})
+
+	t.Run("should refuse an NDJSON batch the caller may not submit", func(t *testing.T) {
+		// Setup
+		mockHandlingService := &MockHandlingReportService{}
+		mockHandlingService.On("SubmitHandlingReports", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("unauthorized handling submission: %w", auth.NewAuthorizationError("missing permission submit_handling")))
+		line := fmt.Sprintf(`{"trackingId":%q,"eventType":"RECEIVE","location":"USNYC","completionTime":%q}`, trackingId, completionTime)
+
+		// Execute
+		w := submit(t, mockHandlingService, "application/x-ndjson", line+"\n")
+
+		// Verify
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "forbidden")
+	})
+
+	t.Run("should reject empty NDJSON batch", func(t *testing.T) {
+		// Execute
+		w := submit(t, &MockHandlingReportService{}, "application/x-ndjson", "\n\n")
+
+		// Verify
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "validation_error")
+	})
+
+	t.Run("should not call the service when every report is invalid", func(t *testing.T) {
+		// Setup
+		mockHandlingService := &MockHandlingReportService{}
+		body := `[{"trackingId":"not-a-uuid","eventType":"RECEIVE","location":"USNYC","completionTime":"2024-01-01T00:00:00Z"}]`
+
+		// Execute
+		w := submit(t, mockHandlingService, "application/json", body)
+
+		// Verify
+		require.Equal(t, http.StatusOK, w.Code)
+		response := decode(t, w)
+		assert.Equal(t, 1, response.Rejected)
+		mockHandlingService.AssertNotCalled(t, "SubmitHandlingReports", mock.Anything, mock.Anything)
+		assert.Zero(t, mockHandlingService.batchesStarted)
+	})
+
+	t.Run("should reject malformed JSON array", func(t *testing.T) {
+		// Execute
+		w := submit(t, &MockHandlingReportService{}, "application/json", `[{"trackingId":`)
+
+		// Verify
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid_request")
+	})
+
+	t.Run("should reject empty batch", func(t *testing.T) {
+		// Execute
+		w := submit(t, &MockHandlingReportService{}, "application/json", `[]`)
+
+		// Verify
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("should report service failure", func(t *testing.T) {
+		// Setup
+		mockHandlingService := &MockHandlingReportService{}
+		mockHandlingService.On("SubmitHandlingReports", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("failed to load handling history: storage unavailable"))
+		body := fmt.Sprintf(`[{"trackingId":%q,"eventType":"RECEIVE","location":"USNYC","completionTime":%q}]`, trackingId, completionTime)
+
+		// Execute
+		w := submit(t, mockHandlingService, "application/json", body)
+
+		// Verify
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "handling_report_failed")
+	})
+}
+
+func TestBackfillHandlingReportsHandler(t *testing.T) {
+	const trackingId = "550e8400-e29b-41d4-a716-446655440000"
+	body, _ := json.Marshal([]HandlingEventRequest{
+		{TrackingId: trackingId, EventType: "RECEIVE", Location: "USNYC", CompletionTime: time.Now().AddDate(0, -6, 0).Format(time.RFC3339)},
+	})
+
+	submit := func(t *testing.T, mockHandlingService *MockHandlingReportService) *httptest.ResponseRecorder {
+		handler := createTestHandler(t, nil, nil, mockHandlingService, nil)
+		req := httptest.NewRequest("POST", "/api/v1/handling-events/backfill", bytes.NewBuffer(body))
+		req = addAuthContext(req)
+		w := httptest.NewRecorder()
+
+		handler.BackfillHandlingReportsHandler(w, req)
+		return w
+	}
+
+	t.Run("should hand reports to the backfill", func(t *testing.T) {
+		// Setup
+		mockHandlingService := &MockHandlingReportService{}
+		mockHandlingService.On("BackfillHandlingReports", mock.Anything, mock.MatchedBy(func(reports []handlingdomain.HandlingReport) bool {
+			return len(reports) == 1
+		})).Return([]handlingdomain.HandlingReportResult{
+			{Index: 0, TrackingId: trackingId, Status: handlingdomain.HandlingReportAccepted},
+		}, nil)
+
+		// Execute
+		w := submit(t, mockHandlingService)
+
+		// Verify
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"accepted":1`)
+		mockHandlingService.AssertExpectations(t)
+		mockHandlingService.AssertNotCalled(t, "SubmitHandlingReports", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should forbid callers without the backfill permission", func(t *testing.T) {
+		// Setup
+		mockHandlingService := &MockHandlingReportService{}
+		mockHandlingService.On("BackfillHandlingReports", mock.Anything, mock.Anything).
+			Return(nil, fmt.Errorf("unauthorized handling backfill: %w", auth.NewAuthorizationError("insufficient permissions for handling operation")))
+
+		// Execute
+		w := submit(t, mockHandlingService)
+
+		// Verify
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "forbidden")
+	})
+}
+
+func TestListQuarantinedReportsHandler(t *testing.T) {
+	t.Run("should list quarantined reports", func(t *testing.T) {
+		// Setup
+		mockHandlingService := &MockHandlingReportService{}
+		quarantined := handlingdomain.NewQuarantinedReport(handlingdomain.HandlingReport{
+			TrackingId:     "550e8400-e29b-41d4-a716-446655440000",
+			EventType:      "UNLOAD",
+			Location:       "DEHAM",
+			VoyageNumber:   "V001",
+			CompletionTime: "2024-01-20T08:00:00Z",
+		}, "cannot unload cargo that is not loaded")
+		mockHandlingService.On("ListQuarantinedReports", mock.Anything).Return([]handlingdomain.QuarantinedReport{quarantined}, nil)
+		handler := createTestHandler(t, nil, nil, mockHandlingService, nil)
+		req := addAuthContext(httptest.NewRequest("GET", "/api/v1/handling-events/quarantine", nil))
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.ListQuarantinedReportsHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Data []QuarantinedReportResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Data, 1)
+		assert.Equal(t, quarantined.Id, response.Data[0].QuarantineId)
+		assert.Equal(t, "UNLOAD", response.Data[0].EventType)
+	})
+}
+
+func TestReleaseQuarantinedReportHandler(t *testing.T) {
+	release := func(t *testing.T, mockHandlingService *MockHandlingReportService, quarantineId string) *httptest.ResponseRecorder {
+		handler := createTestHandler(t, nil, nil, mockHandlingService, nil)
+		req := addAuthContext(httptest.NewRequest("POST", "/api/v1/handling-events/quarantine/"+quarantineId+"/release", nil))
+		w := httptest.NewRecorder()
+
+		handler.ReleaseQuarantinedReportHandler(w, req)
+		return w
+	}
+
+	t.Run("should register released report", func(t *testing.T) {
+		// Setup
+		mockHandlingService := &MockHandlingReportService{}
+		eventId := handlingdomain.NewHandlingEventId()
+		mockHandlingService.On("ReleaseQuarantinedReport", mock.Anything, "q-1").
+			Return(handlingdomain.HandlingReceipt{EventId: eventId, RegisteredAt: time.Now()}, nil)
+
+		// Execute
+		w := release(t, mockHandlingService, "q-1")
+
+		// Verify
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), eventId.String())
+		mockHandlingService.AssertExpectations(t)
+	})
+
+	t.Run("should return not found for unknown quarantined report", func(t *testing.T) {
+		// Setup
+		mockHandlingService := &MockHandlingReportService{}
+		mockHandlingService.On("ReleaseQuarantinedReport", mock.Anything, "q-1").
+			Return(handlingdomain.HandlingReceipt{}, handlingdomain.NewQuarantinedReportNotFoundError("q-1"))
+
+		// Execute
+		w := release(t, mockHandlingService, "q-1")
+
+		// Verify
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "quarantined_report_not_found")
+	})
+
+	t.Run("should forbid callers without the backfill permission", func(t *testing.T) {
+		// Setup
+		mockHandlingService := &MockHandlingReportService{}
+		mockHandlingService.On("ReleaseQuarantinedReport", mock.Anything, "q-1").
+			Return(handlingdomain.HandlingReceipt{}, fmt.Errorf("unauthorized handling quarantine release: %w", auth.NewAuthorizationError("insufficient permissions for handling operation")))
+
+		// Execute
+		w := release(t, mockHandlingService, "q-1")
+
+		// Verify
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestAssignRouteHandler(t *testing.T) {
+	t.Run("should call booking service to assign route", func(t *testing.T) {
+		// Setup
+		mockBookingService := &MockBookingService{}
+		handler := createTestHandler(t, mockBookingService, nil, nil, nil)
+
+		// Create test data
+		testCargo := createTestCargo(t)
+		trackingId := testCargo.GetTrackingId()
+		mockBookingService.On("AssignRouteToCargo", mock.Anything, trackingId, mock.AnythingOfType("bookingdomain.Itinerary"), bookingprimary.AnyVersion).Return(nil)
+
+		// Create request
+		reqBody := AssignRouteRequest{
+			Legs: []LegDTO{
+				{
+					VoyageNumber:   "V001",
+					LoadLocation:   "USNYC",
+					UnloadLocation: "DEHAM",
+					LoadTime:       time.Now().Add(time.Hour).Format(time.RFC3339),
+					UnloadTime:     time.Now().Add(2 * time.Hour).Format(time.RFC3339),
+				},
+			},
+		}
+		jsonBody, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest("PUT", "/api/v1/cargos/"+trackingId.String()+"/route", bytes.NewBuffer(jsonBody))
+		req = addAuthContext(req)
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.AssignRouteHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockBookingService.AssertExpectations(t)
+		mockBookingService.AssertCalled(t, "AssignRouteToCargo", mock.Anything, trackingId, mock.AnythingOfType("bookingdomain.Itinerary"), bookingprimary.AnyVersion)
+	})
+}
+
+func TestChangeRouteSpecificationHandler(t *testing.T) {
+	t.Run("should call booking service to change destination", func(t *testing.T) {
+		// Setup
+		mockBookingService := &MockBookingService{}
+		handler := createTestHandler(t, mockBookingService, nil, nil, nil)
+
+		// Create test data
+		testCargo := createTestCargo(t)
+		trackingId := testCargo.GetTrackingId()
+		mockBookingService.On("ChangeRouteSpecification", mock.Anything, trackingId, "SEGOT", "", bookingprimary.AnyVersion).Return(testCargo, nil)
+
+		// Create request
+		reqBody := ChangeRouteSpecificationRequest{Destination: "SEGOT"}
+		jsonBody, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest("PATCH", "/api/v1/cargos/"+trackingId.String()+"/route-specification", bytes.NewBuffer(jsonBody))
+		req = addAuthContext(req)
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.ChangeRouteSpecificationHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockBookingService.AssertExpectations(t)
+	})
+
+	t.Run("should return validation error when nothing is changed", func(t *testing.T) {
+		// Setup
+		mockBookingService := &MockBookingService{}
+		handler := createTestHandler(t, mockBookingService, nil, nil, nil)
+
+		// Create request
+		trackingId := createTestCargo(t).GetTrackingId()
+		jsonBody, _ := json.Marshal(ChangeRouteSpecificationRequest{})
+		req := httptest.NewRequest("PATCH", "/api/v1/cargos/"+trackingId.String()+"/route-specification", bytes.NewBuffer(jsonBody))
+		req = addAuthContext(req)
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.ChangeRouteSpecificationHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockBookingService.AssertNotCalled(t, "ChangeRouteSpecification", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestCancelCargoHandler(t *testing.T) {
+	t.Run("should call booking service to cancel cargo", func(t *testing.T) {
+		// Setup
+		mockBookingService := &MockBookingService{}
+		handler := createTestHandler(t, mockBookingService, nil, nil, nil)
+
+		// Create test data
+		testCargo := createTestCargo(t)
+		trackingId := testCargo.GetTrackingId()
+		mockBookingService.On("CancelCargo", mock.Anything, trackingId, "customer request", bookingprimary.AnyVersion).Return(nil)
+
+		// Create request
+		reqBody := CancelCargoRequest{Reason: "customer request"}
+		jsonBody, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest("POST", "/api/v1/cargos/"+trackingId.String()+"/cancel", bytes.NewBuffer(jsonBody))
+		req = addAuthContext(req)
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.CancelCargoHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockBookingService.AssertExpectations(t)
+	})
+
+	t.Run("should return validation error when reason is missing", func(t *testing.T) {
+		// Setup
+		mockBookingService := &MockBookingService{}
+		handler := createTestHandler(t, mockBookingService, nil, nil, nil)
+
+		// Create request
+		trackingId := createTestCargo(t).GetTrackingId()
+		jsonBody, _ := json.Marshal(CancelCargoRequest{})
+		req := httptest.NewRequest("POST", "/api/v1/cargos/"+trackingId.String()+"/cancel", bytes.NewBuffer(jsonBody))
+		req = addAuthContext(req)
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.CancelCargoHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockBookingService.AssertNotCalled(t, "CancelCargo", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should pass If-Match version to booking service", func(t *testing.T) {
+		// Setup
+		mockBookingService := &MockBookingService{}
+		handler := createTestHandler(t, mockBookingService, nil, nil, nil)
+
+		trackingId := createTestCargo(t).GetTrackingId()
+		mockBookingService.On("CancelCargo", mock.Anything, trackingId, "customer request", 2).Return(nil)
+
+		jsonBody, _ := json.Marshal(CancelCargoRequest{Reason: "customer request"})
+		req := httptest.NewRequest("POST", "/api/v1/cargos/"+trackingId.String()+"/cancel", bytes.NewBuffer(jsonBody))
+		req.Header.Set("If-Match", `"2"`)
+		req = addAuthContext(req)
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.CancelCargoHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockBookingService.AssertExpectations(t)
+	})
+
+	t.Run("should return conflict when cargo was modified concurrently", func(t *testing.T) {
+		// Setup
+		mockBookingService := &MockBookingService{}
+		handler := createTestHandler(t, mockBookingService, nil, nil, nil)
+
+		trackingId := createTestCargo(t).GetTrackingId()
+		mockBookingService.On("CancelCargo", mock.Anything, trackingId, "customer request", 2).
+			Return(bookingdomain.NewConcurrencyConflictError(trackingId, 2))
+
+		jsonBody, _ := json.Marshal(CancelCargoRequest{Reason: "customer request"})
+		req := httptest.NewRequest("POST", "/api/v1/cargos/"+trackingId.String()+"/cancel", bytes.NewBuffer(jsonBody))
+		req.Header.Set("If-Match", `"2"`)
+		req = addAuthContext(req)
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.CancelCargoHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "concurrent_modification")
+	})
+
+	t.Run("should reject malformed If-Match header", func(t *testing.T) {
+		// Setup
+		mockBookingService := &MockBookingService{}
+		handler := createTestHandler(t, mockBookingService, nil, nil, nil)
+
+		trackingId := createTestCargo(t).GetTrackingId()
+		jsonBody, _ := json.Marshal(CancelCargoRequest{Reason: "customer request"})
+		req := httptest.NewRequest("POST", "/api/v1/cargos/"+trackingId.String()+"/cancel", bytes.NewBuffer(jsonBody))
+		req.Header.Set("If-Match", "latest")
+		req = addAuthContext(req)
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.CancelCargoHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockBookingService.AssertNotCalled(t, "CancelCargo", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return not found for unknown cargo", func(t *testing.T) {
+		// Setup
+		mockBookingService := &MockBookingService{}
+		handler := createTestHandler(t, mockBookingService, nil, nil, nil)
+
+		trackingId := createTestCargo(t).GetTrackingId()
+		mockBookingService.On("CancelCargo", mock.Anything, trackingId, "customer request", bookingprimary.AnyVersion).
+			Return(bookingdomain.NewCargoNotFoundError(trackingId))
+
+		jsonBody, _ := json.Marshal(CancelCargoRequest{Reason: "customer request"})
+		req := httptest.NewRequest("POST", "/api/v1/cargos/"+trackingId.String()+"/cancel", bytes.NewBuffer(jsonBody))
+		req = addAuthContext(req)
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.CancelCargoHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "cargo_not_found")
+	})
+
+	t.Run("should return unprocessable entity when cargo can no longer be cancelled", func(t *testing.T) {
+		// Setup
+		mockBookingService := &MockBookingService{}
+		handler := createTestHandler(t, mockBookingService, nil, nil, nil)
+
+		trackingId := createTestCargo(t).GetTrackingId()
+		mockBookingService.On("CancelCargo", mock.Anything, trackingId, "customer request", bookingprimary.AnyVersion).
+			Return(bookingdomain.NewDomainValidationError("cannot cancel cargo that has been loaded or claimed", nil))
+
+		jsonBody, _ := json.Marshal(CancelCargoRequest{Reason: "customer request"})
+		req := httptest.NewRequest("POST", "/api/v1/cargos/"+trackingId.String()+"/cancel", bytes.NewBuffer(jsonBody))
+		req = addAuthContext(req)
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.CancelCargoHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid_cargo_state")
+	})
+}
+
+func TestDeleteCargoHandler(t *testing.T) {
+	t.Run("should cancel cargo with reason from query", func(t *testing.T) {
+		// Setup
+		mockBookingService := &MockBookingService{}
+		handler := createTestHandler(t, mockBookingService, nil, nil, nil)
+
+		trackingId := createTestCargo(t).GetTrackingId()
+		mockBookingService.On("CancelCargo", mock.Anything, trackingId, "customer request", 3).Return(nil)
+
+		req := httptest.NewRequest("DELETE", "/api/v1/cargos/"+trackingId.String()+"?reason=customer+request", nil)
+		req.Header.Set("If-Match", `"3"`)
+		req = addAuthContext(req)
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.DeleteCargoHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockBookingService.AssertExpectations(t)
+	})
+
+	t.Run("should return validation error when reason is missing", func(t *testing.T) {
+		// Setup
+		mockBookingService := &MockBookingService{}
+		handler := createTestHandler(t, mockBookingService, nil, nil, nil)
+
+		trackingId := createTestCargo(t).GetTrackingId()
+		req := httptest.NewRequest("DELETE", "/api/v1/cargos/"+trackingId.String(), nil)
+		req = addAuthContext(req)
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.DeleteCargoHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockBookingService.AssertNotCalled(t, "CancelCargo", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestListCargoHandler(t *testing.T) {
+	t.Run("should list a page of all cargo with the next cursor", func(t *testing.T) {
+		// Setup
+		mockBookingService := &MockBookingService{}
+		handler := createTestHandler(t, mockBookingService, nil, nil, nil)
+
+		// Setup mock
+		testCargos := []bookingdomain.Cargo{createTestCargo(t)}
+		mockBookingService.On("ListAllCargo", mock.Anything, bookingdomain.CargoQuery{}).
+			Return(bookingdomain.CargoPage{Cargos: testCargos, NextCursor: "next"}, nil)
+
+		// Create request
+		req := httptest.NewRequest("GET", "/api/v1/cargos", nil)
+		req = addAuthContext(req)
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.ListCargoHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Data CargoListResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Data.Cargos, 1)
+		assert.Equal(t, "next", response.Data.NextCursor)
+		mockBookingService.AssertExpectations(t)
+	})
+
+	t.Run("should pass query parameters to the booking service", func(t *testing.T) {
+		// Setup
+		mockBookingService := &MockBookingService{}
+		handler := createTestHandler(t, mockBookingService, nil, nil, nil)
+
+		expected := bookingdomain.CargoQuery{
+			RoutingStatus:   bookingdomain.RoutingStatusRouted,
+			TransportStatus: bookingdomain.TransportStatusInPort,
+			Origin:          "USNYC",
+			Destination:     "DEHAM",
+			DeadlineFrom:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			DeadlineTo:      time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+			Overdue:         true,
+			SortBy:          bookingdomain.CargoSortByArrivalDeadline,
+			Order:           bookingdomain.SortDescending,
+			Cursor:          "abc",
+			Limit:           10,
+		}
+		mockBookingService.On("ListAllCargo", mock.Anything, expected).Return(bookingdomain.CargoPage{}, nil)
+
+		// Create request
+		req := httptest.NewRequest("GET", "/api/v1/cargos?routing_status=routed&transport_status=IN_PORT&origin=USNYC&destination=DEHAM"+
+			"&deadline_from=2025-01-01T00:00:00Z&deadline_to=2025-02-01T00:00:00Z&overdue=1"+
+			"&sort=arrival_deadline&order=desc&cursor=abc&limit=10", nil)
+		req = addAuthContext(req)
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.ListCargoHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockBookingService.AssertExpectations(t)
+	})
+
+	t.Run("should reject malformed query parameters", func(t *testing.T) {
+		for _, rawQuery := range []string{"limit=abc", "limit=0", "deadline_from=yesterday", "overdue=maybe"} {
+			// Setup
+			mockBookingService := &MockBookingService{}
+			handler := createTestHandler(t, mockBookingService, nil, nil, nil)
+			req := addAuthContext(httptest.NewRequest("GET", "/api/v1/cargos?"+rawQuery, nil))
+			w := httptest.NewRecorder()
+
+			// Execute
+			handler.ListCargoHandler(w, req)
+
+			// Verify
+			assert.Equal(t, http.StatusBadRequest, w.Code, rawQuery)
+			mockBookingService.AssertNotCalled(t, "ListAllCargo", mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("should return 400 when the booking service rejects the query", func(t *testing.T) {
+		// Setup
+		mockBookingService := &MockBookingService{}
+		handler := createTestHandler(t, mockBookingService, nil, nil, nil)
+		mockBookingService.On("ListAllCargo", mock.Anything, mock.Anything).
+			Return(bookingdomain.CargoPage{}, bookingdomain.NewDomainValidationError("invalid cursor", nil))
+
+		req := addAuthContext(httptest.NewRequest("GET", "/api/v1/cargos?cursor=bogus", nil))
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.ListCargoHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid_query")
+	})
+}
+
+func TestListHandlingEventsHandler(t *testing.T) {
+	t.Run("should pass filters to the query service and return the next cursor", func(t *testing.T) {
+		// Setup
+		mockQueryService := &MockHandlingQueryService{}
+		handler := createTestHandler(t, nil, nil, nil, mockQueryService)
+
+		event, err := handlingdomain.NewHandlingEvent("TEST123", handlingdomain.HandlingEventTypeLoad, "USNYC", "V001", time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		mockQueryService.On("ListHandlingEvents", mock.Anything, handlingdomain.HandlingEventQuery{
+			TrackingId:      "TEST123",
+			EventType:       handlingdomain.HandlingEventTypeLoad,
+			Location:        "USNYC",
+			VoyageNumber:    "V001",
+			CompletedFrom:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			CompletedBefore: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+			Cursor:          "abc",
+			Limit:           25,
+		}).Return(handlingdomain.HandlingEventPage{Events: []handlingdomain.HandlingEvent{event}, NextCursor: "next"}, nil)
+
+		req := httptest.NewRequest("GET", "/api/v1/handling-events?tracking_id=TEST123&event_type=load&location=USNYC&voyage_number=V001"+
+			"&completed_from=2025-01-01T00:00:00Z&completed_before=2025-01-02T00:00:00Z&cursor=abc&limit=25", nil)
+		req = addAuthContext(req)
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.ListHandlingEventsHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Data HandlingEventListResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Data.Events, 1)
+		assert.Equal(t, event.GetEventId().String(), response.Data.Events[0].EventId)
+		assert.Equal(t, "next", response.Data.NextCursor)
+		mockQueryService.AssertExpectations(t)
+	})
+
+	t.Run("should reject malformed query parameters", func(t *testing.T) {
+		for _, rawQuery := range []string{"limit=-1", "completed_from=today"} {
+			// Setup
+			mockQueryService := &MockHandlingQueryService{}
+			handler := createTestHandler(t, nil, nil, nil, mockQueryService)
+			req := addAuthContext(httptest.NewRequest("GET", "/api/v1/handling-events?"+rawQuery, nil))
+			w := httptest.NewRecorder()
+
+			// Execute
+			handler.ListHandlingEventsHandler(w, req)
+
+			// Verify
+			assert.Equal(t, http.StatusBadRequest, w.Code, rawQuery)
+			mockQueryService.AssertNotCalled(t, "ListHandlingEvents", mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("should return 400 when the query service rejects the query", func(t *testing.T) {
+		// Setup
+		mockQueryService := &MockHandlingQueryService{}
+		handler := createTestHandler(t, nil, nil, nil, mockQueryService)
+		mockQueryService.On("ListHandlingEvents", mock.Anything, mock.Anything).
+			Return(handlingdomain.HandlingEventPage{}, handlingdomain.NewDomainValidationError("invalid cursor", nil))
+
+		req := addAuthContext(httptest.NewRequest("GET", "/api/v1/handling-events?cursor=bogus", nil))
+		w := httptest.NewRecorder()
+
+		// Execute
+		handler.ListHandlingEventsHandler(w, req)
+
+		// Verify
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid_query")
+	})
+}
+
+// Helper functions
+
+func createTestHandler(t *testing.T, bookingService *MockBookingService, routingService *MockRoutingService, handlingReportService *MockHandlingReportService, handlingQueryService *MockHandlingQueryService) *Handler {
+	return &Handler{
+		authMiddleware:        nil, // Not needed for unit tests
+		bookingService:        bookingService,
+		routingService:        nil, // Routing service not used in most tests
+		handlingReportService: handlingReportService,
+		handlingQueryService:  handlingQueryService,
+	}
+}
+
+// createTestVoyage builds a voyage USNYC -> NLRTM -> DEHAM with whole-second UTC times, so its legs survive RFC3339
+func createTestVoyage(t *testing.T) routingdomain.Voyage {
+	codes := []string{"USNYC", "NLRTM", "DEHAM"}
+	departure := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	var movements []routingdomain.CarrierMovement
+	for i := 0; i < len(codes)-1; i++ {
+		from, err := routingdomain.NewUnLocode(codes[i])
+		require.NoError(t, err)
+		to, err := routingdomain.NewUnLocode(codes[i+1])
+		require.NoError(t, err)
+
+		movement, err := routingdomain.NewCarrierMovement(from, to, departure, departure.Add(24*time.Hour))
+		require.NoError(t, err)
+		movements = append(movements, movement)
+		departure = departure.Add(28 * time.Hour)
+	}
+
+	voyage, err := routingdomain.NewVoyage(movements)
+	require.NoError(t, err)
+	return voyage
 }
 
 func addAuthContext(req *http.Request) *http.Request {
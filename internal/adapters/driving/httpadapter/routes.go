@@ -28,7 +28,11 @@ func RegisterRoutes(mux *http.ServeMux, handler *Handler) {
 	})
 
 	// GET /api/v1/cargos/{trackingId} - get specific cargo
+	// GET /api/v1/cargos/{trackingId}/timeline - booking, itinerary progress and handling history of a cargo
 	// PUT /api/v1/cargos/{trackingId}/route - assign route to cargo
+	// PATCH /api/v1/cargos/{trackingId}/route-specification - change destination and/or arrival deadline
+	// POST /api/v1/cargos/{trackingId}/cancel - cancel cargo booking
+	// DELETE /api/v1/cargos/{trackingId}?reason=... - cancel cargo booking
 	mux.HandleFunc("/api/v1/cargos/", func(w http.ResponseWriter, r *http.Request) {
 		path := r.URL.Path
 
@@ -39,10 +43,40 @@ func RegisterRoutes(mux *http.ServeMux, handler *Handler) {
 			return
 		}
 
-		// Otherwise, it's a GET for specific cargo
+		// Check if it's a route specification change request
+		if strings.HasSuffix(path, "/route-specification") && r.Method == http.MethodPatch {
+			handler.authMiddleware.RequireAuth(handler.ChangeRouteSpecificationHandler)(w, r)
+			return
+		}
+
+		// Check if it's a cancellation request
+		if strings.HasSuffix(path, "/cancel") && r.Method == http.MethodPost {
+			handler.authMiddleware.RequireAuth(handler.CancelCargoHandler)(w, r)
+			return
+		}
+
+		// Check if it's a timeline request
+		if strings.HasSuffix(path, "/timeline") && r.Method == http.MethodGet {
+			handler.authMiddleware.RequireAuth(handler.CargoTimelineHandler)(w, r)
+			return
+		}
+
+		// Otherwise, it's a GET or DELETE for specific cargo
 		switch r.Method {
 		case http.MethodGet:
 			handler.authMiddleware.RequireAuth(handler.TrackCargoHandler)(w, r)
+		case http.MethodDelete:
+			handler.authMiddleware.RequireAuth(handler.DeleteCargoHandler)(w, r)
+		default:
+			writeMethodNotAllowedError(w)
+		}
+	})
+
+	// GET /api/v1/reroutes - misdirected cargo waiting to be rerouted, with route candidates
+	mux.HandleFunc("/api/v1/reroutes", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handler.authMiddleware.RequireAuth(handler.ListReroutesHandler)(w, r)
 		default:
 			writeMethodNotAllowedError(w)
 		}
@@ -59,9 +93,11 @@ func RegisterRoutes(mux *http.ServeMux, handler *Handler) {
 		}
 	})
 
-	// GET /api/v1/voyages - list voyages
+	// GET/POST /api/v1/voyages - list/schedule voyages
 	mux.HandleFunc("/api/v1/voyages", func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
+		case http.MethodPost:
+			handler.authMiddleware.RequireAuth(handler.CreateVoyageHandler)(w, r)
 		case http.MethodGet:
 			handler.authMiddleware.RequireAuth(handler.ListVoyagesHandler)(w, r)
 		default:
@@ -69,6 +105,22 @@ func RegisterRoutes(mux *http.ServeMux, handler *Handler) {
 		}
 	})
 
+	// GET /api/v1/voyages/{voyageNumber} - get specific voyage
+	// PUT /api/v1/voyages/{voyageNumber} - replace the schedule of a voyage
+	// DELETE /api/v1/voyages/{voyageNumber} - remove a voyage
+	mux.HandleFunc("/api/v1/voyages/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handler.authMiddleware.RequireAuth(handler.GetVoyageHandler)(w, r)
+		case http.MethodPut:
+			handler.authMiddleware.RequireAuth(handler.RescheduleVoyageHandler)(w, r)
+		case http.MethodDelete:
+			handler.authMiddleware.RequireAuth(handler.DeleteVoyageHandler)(w, r)
+		default:
+			writeMethodNotAllowedError(w)
+		}
+	})
+
 	// GET /api/v1/locations - list locations
 	mux.HandleFunc("/api/v1/locations", func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
@@ -94,6 +146,45 @@ func RegisterRoutes(mux *http.ServeMux, handler *Handler) {
 		}
 	})
 
+	// POST /api/v1/handling-events/batch - submit a batch of handling events as a JSON array or NDJSON
+	mux.HandleFunc("/api/v1/handling-events/batch", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			handler.authMiddleware.RequireAuth(handler.SubmitHandlingReportBatchHandler)(w, r)
+		default:
+			writeMethodNotAllowedError(w)
+		}
+	})
+
+	// POST /api/v1/handling-events/backfill - submit a batch of historical handling events (admin)
+	mux.HandleFunc("/api/v1/handling-events/backfill", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			handler.authMiddleware.RequireAuth(handler.BackfillHandlingReportsHandler)(w, r)
+		default:
+			writeMethodNotAllowedError(w)
+		}
+	})
+
+	// GET /api/v1/handling-events/quarantine - list handling reports held for review
+	mux.HandleFunc("/api/v1/handling-events/quarantine", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handler.authMiddleware.RequireAuth(handler.ListQuarantinedReportsHandler)(w, r)
+		default:
+			writeMethodNotAllowedError(w)
+		}
+	})
+
+	// POST /api/v1/handling-events/quarantine/{quarantineId}/release - register a reviewed quarantined report (admin)
+	mux.HandleFunc("/api/v1/handling-events/quarantine/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/release") && r.Method == http.MethodPost {
+			handler.authMiddleware.RequireAuth(handler.ReleaseQuarantinedReportHandler)(w, r)
+			return
+		}
+		writeMethodNotAllowedError(w)
+	})
+
 	// Default handler for undefined routes
 	mux.HandleFunc("/", handler.DefaultHandler)
 }
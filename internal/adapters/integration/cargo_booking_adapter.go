@@ -0,0 +1,49 @@
+package integration
+
+import (
+	"context"
+	"errors"
+
+	"go_hex/internal/booking/bookingdomain"
+	"go_hex/internal/booking/ports/bookingprimary"
+	"go_hex/internal/handling/ports/handlingsecondary"
+	"go_hex/internal/support/auth"
+)
+
+// CargoBookingAdapter adapts the Booking context's application service
+// to the interface expected by the Handling context (Anti-Corruption Layer)
+type CargoBookingAdapter struct {
+	bookingService bookingprimary.BookingService
+}
+
+// NewCargoBookingAdapter creates a new adapter for the booking service
+func NewCargoBookingAdapter(bookingService bookingprimary.BookingService) handlingsecondary.CargoBookingService {
+	return &CargoBookingAdapter{
+		bookingService: bookingService,
+	}
+}
+
+// IsCargoBooked reports whether the booking context holds a live booking for the cargo
+// Cancelled cargo no longer counts as booked, so handling reports against it are rejected.
+func (a *CargoBookingAdapter) IsCargoBooked(ctx context.Context, trackingId string) (bool, error) {
+	// A tracking ID the booking context cannot parse was never issued by it
+	bookingTrackingId, err := bookingdomain.TrackingIdFromString(trackingId)
+	if err != nil {
+		return false, nil
+	}
+
+	// The reporting system may not be allowed to view cargo, so look it up as the integration itself
+	integrationCtx := auth.WithServiceClaims(ctx, "handling-integration",
+		auth.BookingClaims{CanViewCargo: true}, auth.RoutingClaims{}, auth.HandlingClaims{})
+
+	cargo, err := a.bookingService.GetCargoDetails(integrationCtx, bookingTrackingId)
+	var notFound bookingdomain.CargoNotFoundError
+	if errors.As(err, &notFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return !cargo.GetDelivery().IsCancelled(), nil
+}
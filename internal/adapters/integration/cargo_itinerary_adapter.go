@@ -0,0 +1,32 @@
+package integration
+
+import (
+	"context"
+
+	"go_hex/internal/booking/ports/bookingprimary"
+	"go_hex/internal/routing/ports/routingsecondary"
+	"go_hex/internal/routing/routingdomain"
+	"go_hex/internal/support/auth"
+)
+
+// CargoItineraryAdapter adapts the Booking context's application service
+// to the interface expected by the Routing context (Anti-Corruption Layer)
+type CargoItineraryAdapter struct {
+	bookingService bookingprimary.BookingService
+}
+
+// NewCargoItineraryAdapter creates a new adapter for the booking service
+func NewCargoItineraryAdapter(bookingService bookingprimary.BookingService) routingsecondary.CargoItineraryService {
+	return &CargoItineraryAdapter{
+		bookingService: bookingService,
+	}
+}
+
+// ReviewItineraries has the booking context re-evaluate the cargo routed on a rescheduled voyage
+func (a *CargoItineraryAdapter) ReviewItineraries(ctx context.Context, voyageNumber routingdomain.VoyageNumber) error {
+	// The schedule manager may not be allowed to route cargo, so review it as the integration itself
+	integrationCtx := auth.WithServiceClaims(ctx, "routing-integration",
+		auth.BookingClaims{CanViewCargo: true, CanAssignRoute: true}, auth.RoutingClaims{}, auth.HandlingClaims{})
+
+	return a.bookingService.ReviewVoyageSchedule(integrationCtx, voyageNumber.String())
+}
@@ -0,0 +1,48 @@
+package integration
+
+import (
+	"context"
+
+	"go_hex/internal/booking/bookingdomain"
+	"go_hex/internal/booking/ports/bookingsecondary"
+	"go_hex/internal/handling/ports/handlingprimary"
+	"go_hex/internal/support/auth"
+)
+
+// HandlingHistoryAdapter adapts the Handling context's query service
+// to the interface expected by the Booking context (Anti-Corruption Layer)
+type HandlingHistoryAdapter struct {
+	handlingQueryService handlingprimary.HandlingEventQueryService
+}
+
+// NewHandlingHistoryAdapter creates a new adapter for the handling query service
+func NewHandlingHistoryAdapter(handlingQueryService handlingprimary.HandlingEventQueryService) bookingsecondary.HandlingHistoryService {
+	return &HandlingHistoryAdapter{
+		handlingQueryService: handlingQueryService,
+	}
+}
+
+// GetHandlingHistory adapts the handling service's history to the booking context's needs
+func (a *HandlingHistoryAdapter) GetHandlingHistory(ctx context.Context, trackingId bookingdomain.TrackingId) ([]bookingdomain.HandlingEventSummary, error) {
+	// Delivery updates run outside any user request, so read the history as the integration itself
+	integrationCtx := auth.WithServiceClaims(ctx, "booking-integration",
+		auth.BookingClaims{}, auth.RoutingClaims{}, auth.HandlingClaims{CanViewHandling: true})
+
+	history, err := a.handlingQueryService.GetHandlingHistory(integrationCtx, trackingId.String())
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert Handling domain events to Booking domain format (Anti-Corruption Layer)
+	summaries := make([]bookingdomain.HandlingEventSummary, len(history.Events))
+	for i, event := range history.Events {
+		summaries[i] = bookingdomain.HandlingEventSummary{
+			Type:         string(event.GetEventType()),
+			Location:     event.GetLocation(),
+			VoyageNumber: event.GetVoyageNumber(),
+			Timestamp:    event.GetCompletionTime(),
+		}
+	}
+
+	return summaries, nil
+}
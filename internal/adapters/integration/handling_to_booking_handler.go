@@ -46,16 +46,8 @@ func (h *HandlingToBookingEventHandler) HandleCargoWasHandled(ctx context.Contex
 		return err
 	}
 
-	// Convert handling event to delivery update format (Anti-Corruption Layer)
-	handlingEventSummary := bookingdomain.HandlingEventSummary{
-		Type:         string(handlingEvent.EventType),
-		Location:     handlingEvent.Location,
-		VoyageNumber: handlingEvent.VoyageNumber,
-		Timestamp:    handlingEvent.CompletionTime,
-	}
-
-	// Update cargo delivery status in Booking context
-	if err := h.bookingService.UpdateCargoDelivery(ctx, trackingId, []bookingdomain.HandlingEventSummary{handlingEventSummary}); err != nil {
+	// Re-derive cargo delivery status in Booking context from the full handling history
+	if err := h.bookingService.UpdateCargoDelivery(ctx, trackingId); err != nil {
 		h.logger.Error("Failed to update cargo delivery status",
 			"trackingId", trackingId.String(),
 			"error", err)
@@ -0,0 +1,91 @@
+package integration
+
+import (
+	"context"
+	"log/slog"
+
+	"go_hex/internal/booking/bookingdomain"
+	"go_hex/internal/booking/ports/bookingprimary"
+	"go_hex/internal/support/auth"
+	"go_hex/internal/support/basedomain"
+)
+
+// MisdirectedCargoEventHandler reroutes cargo that handling has shown to be misdirected,
+// and takes it off the reroute worklist once it is back on track
+type MisdirectedCargoEventHandler struct {
+	rerouteService bookingprimary.RerouteService
+	logger         *slog.Logger
+}
+
+// NewMisdirectedCargoEventHandler creates a new event handler for rerouting misdirected cargo
+func NewMisdirectedCargoEventHandler(
+	rerouteService bookingprimary.RerouteService,
+	logger *slog.Logger,
+) *MisdirectedCargoEventHandler {
+	return &MisdirectedCargoEventHandler{
+		rerouteService: rerouteService,
+		logger:         logger,
+	}
+}
+
+// HandleCargoDeliveryUpdated reroutes misdirected cargo, and resolves the reroute of cargo that is no longer misdirected
+func (h *MisdirectedCargoEventHandler) HandleCargoDeliveryUpdated(ctx context.Context, event basedomain.DomainEvent) error {
+	deliveryEvent, ok := event.(bookingdomain.CargoDeliveryUpdatedEvent)
+	if !ok {
+		h.logger.Error("Invalid event type for CargoDeliveryUpdated handler")
+		return bookingdomain.NewDomainValidationError("invalid event type", nil)
+	}
+
+	integrationCtx := auth.WithServiceClaims(ctx, "booking-integration",
+		auth.BookingClaims{CanViewCargo: true, CanAssignRoute: true}, auth.RoutingClaims{CanPlanRoutes: true}, auth.HandlingClaims{})
+
+	if !deliveryEvent.Delivery.IsMisdirected() {
+		return h.rerouteService.ResolveReroute(integrationCtx, deliveryEvent.TrackingId)
+	}
+
+	h.logger.Info("Cargo is misdirected", "trackingId", deliveryEvent.TrackingId.String(),
+		"lastKnownLocation", deliveryEvent.Delivery.LastKnownLocation)
+
+	if err := h.rerouteService.RerouteMisdirectedCargo(integrationCtx, deliveryEvent.TrackingId); err != nil {
+		h.logger.Error("Failed to reroute misdirected cargo",
+			"trackingId", deliveryEvent.TrackingId.String(),
+			"error", err)
+		return err
+	}
+
+	return nil
+}
+
+// HandleCargoRouted takes cargo that has been given a new route off the reroute worklist
+func (h *MisdirectedCargoEventHandler) HandleCargoRouted(ctx context.Context, event basedomain.DomainEvent) error {
+	routedEvent, ok := event.(bookingdomain.CargoRoutedEvent)
+	if !ok {
+		h.logger.Error("Invalid event type for CargoRouted handler")
+		return bookingdomain.NewDomainValidationError("invalid event type", nil)
+	}
+
+	return h.resolve(ctx, routedEvent.TrackingId)
+}
+
+// HandleCargoCancelled takes cancelled cargo off the reroute worklist
+func (h *MisdirectedCargoEventHandler) HandleCargoCancelled(ctx context.Context, event basedomain.DomainEvent) error {
+	cancelledEvent, ok := event.(bookingdomain.CargoCancelledEvent)
+	if !ok {
+		h.logger.Error("Invalid event type for CargoCancelled handler")
+		return bookingdomain.NewDomainValidationError("invalid event type", nil)
+	}
+
+	return h.resolve(ctx, cancelledEvent.TrackingId)
+}
+
+func (h *MisdirectedCargoEventHandler) resolve(ctx context.Context, trackingId bookingdomain.TrackingId) error {
+	integrationCtx := auth.WithServiceClaims(ctx, "booking-integration",
+		auth.BookingClaims{CanViewCargo: true, CanAssignRoute: true}, auth.RoutingClaims{CanPlanRoutes: true}, auth.HandlingClaims{})
+
+	if err := h.rerouteService.ResolveReroute(integrationCtx, trackingId); err != nil {
+		h.logger.Error("Failed to resolve cargo reroute", "trackingId", trackingId.String(), "error", err)
+		return err
+	}
+
+	return nil
+}
@@ -24,16 +24,24 @@ func NewRoutingServiceAdapter(routingService routingprimary.RouteFinder) booking
 }
 
 // FindOptimalItineraries adapts the routing service's interface to the booking context's needs
-func (a *RoutingServiceAdapter) FindOptimalItineraries(ctx context.Context, routeSpec bookingdomain.RouteSpecification) ([]bookingdomain.Itinerary, error) {
+func (a *RoutingServiceAdapter) FindOptimalItineraries(ctx context.Context, routeSpec bookingdomain.RouteSpecification, criteria bookingdomain.RankingCriteria) ([]bookingdomain.Itinerary, error) {
 	// Convert Booking domain RouteSpecification to Routing domain format (Anti-Corruption Layer)
 	routingRouteSpec := routingdomain.RouteSpecification{
 		Origin:          routeSpec.Origin,
 		Destination:     routeSpec.Destination,
 		ArrivalDeadline: routeSpec.ArrivalDeadline.Format(time.RFC3339), // Convert to string for routing service
 	}
+	if !routeSpec.EarliestDeparture.IsZero() {
+		routingRouteSpec.EarliestDeparture = routeSpec.EarliestDeparture.Format(time.RFC3339)
+	}
+
+	routingCriteria := routingdomain.RankingCriteria{
+		Strategy:   routingdomain.RankingStrategy(criteria.Strategy),
+		MaxResults: criteria.MaxResults,
+	}
 
 	// Call the routing service
-	routingItineraries, err := a.routingService.FindOptimalItineraries(ctx, routingRouteSpec)
+	routingItineraries, err := a.routingService.FindOptimalItineraries(ctx, routingRouteSpec, routingCriteria)
 	if err != nil {
 		return nil, err
 	}
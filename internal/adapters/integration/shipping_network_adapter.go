@@ -0,0 +1,76 @@
+package integration
+
+import (
+	"context"
+	"errors"
+
+	"go_hex/internal/handling/ports/handlingsecondary"
+	"go_hex/internal/routing/ports/routingprimary"
+	"go_hex/internal/routing/routingdomain"
+	"go_hex/internal/support/auth"
+)
+
+// ShippingNetworkAdapter adapts the Routing context's application service
+// to the interface expected by the Handling context (Anti-Corruption Layer)
+type ShippingNetworkAdapter struct {
+	routingService routingprimary.RouteFinder
+}
+
+// NewShippingNetworkAdapter creates a new adapter for the routing service
+func NewShippingNetworkAdapter(routingService routingprimary.RouteFinder) handlingsecondary.ShippingNetworkService {
+	return &ShippingNetworkAdapter{
+		routingService: routingService,
+	}
+}
+
+// IsKnownLocation reports whether the routing context knows the location
+func (a *ShippingNetworkAdapter) IsKnownLocation(ctx context.Context, unLocode string) (bool, error) {
+	routingUnLocode, err := routingdomain.NewUnLocode(unLocode)
+	if err != nil {
+		return false, nil
+	}
+
+	integrationCtx := auth.WithServiceClaims(ctx, "handling-integration",
+		auth.BookingClaims{}, auth.RoutingClaims{CanViewVoyages: true, CanViewLocations: true}, auth.HandlingClaims{})
+
+	_, err = a.routingService.GetLocation(integrationCtx, routingUnLocode)
+	var notFound routingdomain.LocationNotFoundError
+	if errors.As(err, &notFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// FindVoyagePortCalls converts the voyage's schedule into the locations it calls at
+func (a *ShippingNetworkAdapter) FindVoyagePortCalls(ctx context.Context, voyageNumber string) ([]string, bool, error) {
+	routingVoyageNumber, err := routingdomain.VoyageNumberFromString(voyageNumber)
+	if err != nil {
+		return nil, false, nil
+	}
+
+	integrationCtx := auth.WithServiceClaims(ctx, "handling-integration",
+		auth.BookingClaims{}, auth.RoutingClaims{CanViewVoyages: true, CanViewLocations: true}, auth.HandlingClaims{})
+
+	voyage, err := a.routingService.GetVoyage(integrationCtx, routingVoyageNumber)
+	var notFound routingdomain.VoyageNotFoundError
+	if errors.As(err, &notFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Convert Routing domain schedule to Handling port calls (Anti-Corruption Layer)
+	movements := voyage.GetSchedule().Movements
+	portCalls := make([]string, 0, len(movements)+1)
+	portCalls = append(portCalls, voyage.GetDepartureLocation().String())
+	for _, movement := range movements {
+		portCalls = append(portCalls, movement.ArrivalLocation.String())
+	}
+
+	return portCalls, true, nil
+}
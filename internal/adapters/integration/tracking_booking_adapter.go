@@ -0,0 +1,107 @@
+package integration
+
+import (
+	"context"
+	"errors"
+
+	"go_hex/internal/booking/bookingdomain"
+	"go_hex/internal/booking/ports/bookingprimary"
+	"go_hex/internal/tracking/ports/trackingsecondary"
+	"go_hex/internal/tracking/trackingdomain"
+)
+
+// TrackingBookingAdapter adapts the Booking context's application service
+// to the interface expected by the Tracking context (Anti-Corruption Layer)
+type TrackingBookingAdapter struct {
+	bookingService bookingprimary.BookingService
+}
+
+// NewTrackingBookingAdapter creates a new adapter for the booking service
+func NewTrackingBookingAdapter(bookingService bookingprimary.BookingService) trackingsecondary.CargoBookingService {
+	return &TrackingBookingAdapter{
+		bookingService: bookingService,
+	}
+}
+
+// GetBookedCargo reads the cargo as the caller, so the booking context applies the caller's permissions
+func (a *TrackingBookingAdapter) GetBookedCargo(ctx context.Context, trackingId string) (trackingdomain.BookedCargo, error) {
+	// A tracking ID the booking context cannot parse was never issued by it
+	bookingTrackingId, err := bookingdomain.TrackingIdFromString(trackingId)
+	if err != nil {
+		return trackingdomain.BookedCargo{}, trackingdomain.NewCargoNotFoundError(trackingId)
+	}
+
+	cargo, err := a.bookingService.GetCargoDetails(ctx, bookingTrackingId)
+	var notFound bookingdomain.CargoNotFoundError
+	if errors.As(err, &notFound) {
+		return trackingdomain.BookedCargo{}, trackingdomain.NewCargoNotFoundError(trackingId)
+	}
+	if err != nil {
+		return trackingdomain.BookedCargo{}, err
+	}
+
+	// Convert Booking domain cargo to Tracking domain format (Anti-Corruption Layer)
+	routeSpec := cargo.GetRouteSpecification()
+	delivery := cargo.GetDelivery()
+	booked := trackingdomain.BookedCargo{
+		TrackingId:      cargo.GetTrackingId().String(),
+		Origin:          routeSpec.Origin,
+		Destination:     routeSpec.Destination,
+		ArrivalDeadline: routeSpec.ArrivalDeadline,
+		RoutingStatus:   string(delivery.RoutingStatus),
+		TransportStatus: string(delivery.TransportStatus),
+		BookedAt:        cargo.GetBookedAt(),
+		RoutedAt:        cargo.GetRoutedAt(),
+
+		EstimatedTimeOfArrival: cargo.GetEstimatedTimeOfArrival(),
+	}
+	if itinerary := cargo.GetItinerary(); itinerary != nil {
+		booked.Legs = make([]trackingdomain.PlannedLeg, len(itinerary.Legs))
+		for i, leg := range itinerary.Legs {
+			booked.Legs[i] = trackingdomain.PlannedLeg{
+				VoyageNumber:   leg.VoyageNumber,
+				LoadLocation:   leg.LoadLocation,
+				UnloadLocation: leg.UnloadLocation,
+				LoadTime:       leg.LoadTime,
+				UnloadTime:     leg.UnloadTime,
+			}
+		}
+	}
+
+	return booked, nil
+}
+
+// PlaceHandledEvents places the events with the booking context's rules, so the timeline agrees with the routing
+// status the booking context derives from the same handling
+func (a *TrackingBookingAdapter) PlaceHandledEvents(cargo trackingdomain.BookedCargo, events []trackingdomain.HandledEvent) []trackingdomain.TimelineEvent {
+	itinerary := bookingdomain.Itinerary{Legs: make([]bookingdomain.Leg, len(cargo.Legs))}
+	for i, leg := range cargo.Legs {
+		itinerary.Legs[i] = bookingdomain.Leg{
+			VoyageNumber:   leg.VoyageNumber,
+			LoadLocation:   leg.LoadLocation,
+			UnloadLocation: leg.UnloadLocation,
+			LoadTime:       leg.LoadTime,
+			UnloadTime:     leg.UnloadTime,
+		}
+	}
+
+	summaries := make([]bookingdomain.HandlingEventSummary, len(events))
+	for i, event := range events {
+		summaries[i] = bookingdomain.HandlingEventSummary{
+			Type:         event.Type,
+			Location:     event.Location,
+			VoyageNumber: event.VoyageNumber,
+			Timestamp:    event.CompletionTime,
+		}
+	}
+
+	placed := make([]trackingdomain.TimelineEvent, len(events))
+	for i, placement := range itinerary.PlaceHandling(summaries) {
+		placed[i] = trackingdomain.TimelineEvent{
+			HandledEvent: events[i],
+			LegIndex:     placement.LegIndex,
+			Expected:     placement.Expected,
+		}
+	}
+	return placed
+}
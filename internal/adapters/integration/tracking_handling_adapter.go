@@ -0,0 +1,45 @@
+package integration
+
+import (
+	"context"
+
+	"go_hex/internal/handling/ports/handlingprimary"
+	"go_hex/internal/tracking/ports/trackingsecondary"
+	"go_hex/internal/tracking/trackingdomain"
+)
+
+// TrackingHandlingAdapter adapts the Handling context's query service
+// to the interface expected by the Tracking context (Anti-Corruption Layer)
+type TrackingHandlingAdapter struct {
+	handlingQueryService handlingprimary.HandlingEventQueryService
+}
+
+// NewTrackingHandlingAdapter creates a new adapter for the handling query service
+func NewTrackingHandlingAdapter(handlingQueryService handlingprimary.HandlingEventQueryService) trackingsecondary.HandlingHistoryService {
+	return &TrackingHandlingAdapter{
+		handlingQueryService: handlingQueryService,
+	}
+}
+
+// GetHandlingHistory reads the history as the caller, so the handling context applies the caller's permissions
+func (a *TrackingHandlingAdapter) GetHandlingHistory(ctx context.Context, trackingId string) ([]trackingdomain.HandledEvent, error) {
+	history, err := a.handlingQueryService.GetHandlingHistory(ctx, trackingId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert Handling domain events to Tracking domain format (Anti-Corruption Layer)
+	events := make([]trackingdomain.HandledEvent, len(history.Events))
+	for i, event := range history.Events {
+		events[i] = trackingdomain.HandledEvent{
+			EventId:          event.GetEventId().String(),
+			Type:             string(event.GetEventType()),
+			Location:         event.GetLocation(),
+			VoyageNumber:     event.GetVoyageNumber(),
+			CompletionTime:   event.GetCompletionTime(),
+			RegistrationTime: event.GetRegistrationTime(),
+		}
+	}
+
+	return events, nil
+}
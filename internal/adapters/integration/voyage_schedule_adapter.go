@@ -0,0 +1,86 @@
+package integration
+
+import (
+	"context"
+	"errors"
+
+	"go_hex/internal/booking/bookingdomain"
+	"go_hex/internal/booking/ports/bookingsecondary"
+	"go_hex/internal/routing/ports/routingprimary"
+	"go_hex/internal/routing/routingdomain"
+	"go_hex/internal/support/auth"
+)
+
+// VoyageScheduleAdapter adapts the Routing context's application service
+// to the interface expected by the Booking context for voyage schedules (Anti-Corruption Layer)
+type VoyageScheduleAdapter struct {
+	routingService routingprimary.RouteFinder
+}
+
+// NewVoyageScheduleAdapter creates a new adapter for the routing service
+func NewVoyageScheduleAdapter(routingService routingprimary.RouteFinder) bookingsecondary.VoyageScheduleService {
+	return &VoyageScheduleAdapter{
+		routingService: routingService,
+	}
+}
+
+// FindLegSchedules returns, for each leg in turn, when its voyage currently departs from the leg's load location and
+// arrives at its unload location
+func (a *VoyageScheduleAdapter) FindLegSchedules(ctx context.Context, itinerary bookingdomain.Itinerary) ([]bookingdomain.LegSchedule, error) {
+	// The caller may not be allowed to view voyages, so look them up as the integration itself
+	integrationCtx := auth.WithServiceClaims(ctx, "booking-integration",
+		auth.BookingClaims{}, auth.RoutingClaims{CanViewVoyages: true}, auth.HandlingClaims{})
+
+	voyages := make(map[string][]routingdomain.CarrierMovement)
+	schedules := make([]bookingdomain.LegSchedule, len(itinerary.Legs))
+	for i, leg := range itinerary.Legs {
+		movements, known := voyages[leg.VoyageNumber]
+		if !known {
+			var err error
+			movements, err = a.findMovements(integrationCtx, leg.VoyageNumber)
+			if err != nil {
+				return nil, err
+			}
+			voyages[leg.VoyageNumber] = movements
+		}
+
+		schedules[i] = scheduleLeg(leg, movements)
+	}
+
+	return schedules, nil
+}
+
+// findMovements returns the carrier movements of a voyage; none for a voyage the routing context does not know
+func (a *VoyageScheduleAdapter) findMovements(ctx context.Context, voyageNumber string) ([]routingdomain.CarrierMovement, error) {
+	routingVoyageNumber, err := routingdomain.VoyageNumberFromString(voyageNumber)
+	if err != nil {
+		return nil, nil
+	}
+
+	voyage, err := a.routingService.GetVoyage(ctx, routingVoyageNumber)
+	var notFound routingdomain.VoyageNotFoundError
+	if errors.As(err, &notFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return voyage.GetSchedule().Movements, nil
+}
+
+// scheduleLeg converts the carrier movements from the leg's load location to its unload location into the leg's
+// schedule (Anti-Corruption Layer); the leg is cancelled when the voyage no longer calls at both in turn
+func scheduleLeg(leg bookingdomain.Leg, movements []routingdomain.CarrierMovement) bookingdomain.LegSchedule {
+	for i, departure := range movements {
+		if departure.DepartureLocation.String() != leg.LoadLocation {
+			continue
+		}
+		for _, arrival := range movements[i:] {
+			if arrival.ArrivalLocation.String() == leg.UnloadLocation {
+				return bookingdomain.LegSchedule{LoadTime: departure.DepartureTime, UnloadTime: arrival.ArrivalTime}
+			}
+		}
+	}
+	return bookingdomain.LegSchedule{Cancelled: true}
+}
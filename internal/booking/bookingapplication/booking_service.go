@@ -2,6 +2,7 @@ package bookingapplication
 
 import (
 	"context"
+	"errors"
 	"go_hex/internal/booking/bookingdomain"
 	"go_hex/internal/booking/ports/bookingprimary"
 	"go_hex/internal/booking/ports/bookingsecondary"
@@ -10,12 +11,16 @@ import (
 	"time"
 )
 
+// maxDeliveryUpdateAttempts bounds how often a delivery update is retried after losing a concurrent write
+const maxDeliveryUpdateAttempts = 3
+
 // BookingApplicationService implements the primary ports for booking operations
 type BookingApplicationService struct {
-	cargoRepo      bookingsecondary.CargoRepository
-	routingService bookingsecondary.RoutingService
-	eventPublisher bookingsecondary.EventPublisher
-	logger         *slog.Logger
+	cargoRepo       bookingsecondary.CargoRepository
+	routingService  bookingsecondary.RoutingService
+	voyageSchedules bookingsecondary.VoyageScheduleService
+	handlingHistory bookingsecondary.HandlingHistoryService
+	logger          *slog.Logger
 }
 
 // Ensure BookingApplicationService implements the primary ports
@@ -25,14 +30,16 @@ var _ bookingprimary.BookingService = (*BookingApplicationService)(nil)
 func NewBookingApplicationService(
 	cargoRepo bookingsecondary.CargoRepository,
 	routingService bookingsecondary.RoutingService,
-	eventPublisher bookingsecondary.EventPublisher,
+	voyageSchedules bookingsecondary.VoyageScheduleService,
+	handlingHistory bookingsecondary.HandlingHistoryService,
 	logger *slog.Logger,
 ) *BookingApplicationService {
 	return &BookingApplicationService{
-		cargoRepo:      cargoRepo,
-		routingService: routingService,
-		eventPublisher: eventPublisher,
-		logger:         logger,
+		cargoRepo:       cargoRepo,
+		routingService:  routingService,
+		voyageSchedules: voyageSchedules,
+		handlingHistory: handlingHistory,
+		logger:          logger,
 	}
 }
 
@@ -69,20 +76,17 @@ func (s *BookingApplicationService) BookNewCargo(ctx context.Context, origin, de
 	}
 
 	// Store cargo
-	if err := s.cargoRepo.Store(cargo); err != nil {
+	if err := storeCargo(s.cargoRepo, &cargo); err != nil {
 		s.logger.Error("Failed to store cargo", "trackingId", cargo.GetTrackingId(), "error", err)
 		return bookingdomain.Cargo{}, err
 	}
 
-	// Publish domain events
-	s.publishCargoEvents(cargo)
-
 	s.logger.Info("Cargo booked successfully", "trackingId", cargo.GetTrackingId())
 	return cargo, nil
 }
 
 // AssignRouteToCargo assigns a chosen itinerary to an existing cargo
-func (s *BookingApplicationService) AssignRouteToCargo(ctx context.Context, trackingId bookingdomain.TrackingId, itinerary bookingdomain.Itinerary) error {
+func (s *BookingApplicationService) AssignRouteToCargo(ctx context.Context, trackingId bookingdomain.TrackingId, itinerary bookingdomain.Itinerary, expectedVersion int) error {
 	// Check permissions
 	claims, err := auth.ExtractClaims(ctx)
 	if err != nil {
@@ -103,6 +107,11 @@ func (s *BookingApplicationService) AssignRouteToCargo(ctx context.Context, trac
 		return err
 	}
 
+	if err := checkExpectedVersion(cargo, expectedVersion); err != nil {
+		s.logger.Warn("Cargo version does not match", "trackingId", trackingId, "expectedVersion", expectedVersion, "version", cargo.GetVersion())
+		return err
+	}
+
 	// Assign route
 	if err := cargo.AssignToRoute(itinerary); err != nil {
 		s.logger.Error("Failed to assign route", "trackingId", trackingId, "error", err)
@@ -110,14 +119,11 @@ func (s *BookingApplicationService) AssignRouteToCargo(ctx context.Context, trac
 	}
 
 	// Update cargo
-	if err := s.cargoRepo.Update(cargo); err != nil {
+	if err := updateCargo(s.cargoRepo, &cargo); err != nil {
 		s.logger.Error("Failed to update cargo", "trackingId", trackingId, "error", err)
 		return err
 	}
 
-	// Publish domain events
-	s.publishCargoEvents(cargo)
-
 	s.logger.Info("Route assigned successfully", "trackingId", trackingId)
 	return nil
 }
@@ -143,6 +149,10 @@ func (s *BookingApplicationService) GetCargoDetails(ctx context.Context, trackin
 		return bookingdomain.Cargo{}, err
 	}
 
+	if err := s.followVoyageSchedules(ctx, &cargo); err != nil {
+		return bookingdomain.Cargo{}, err
+	}
+
 	return cargo, nil
 }
 
@@ -187,8 +197,8 @@ func (s *BookingApplicationService) ListUnroutedCargo(ctx context.Context) ([]bo
 	return cargo, nil
 }
 
-// RequestRouteCandidates gets possible itineraries for a cargo
-func (s *BookingApplicationService) RequestRouteCandidates(ctx context.Context, trackingId bookingdomain.TrackingId) ([]bookingdomain.Itinerary, error) {
+// RequestRouteCandidates gets possible itineraries for a cargo, ranked by the given criteria
+func (s *BookingApplicationService) RequestRouteCandidates(ctx context.Context, trackingId bookingdomain.TrackingId, criteria bookingdomain.RankingCriteria) ([]bookingdomain.Itinerary, error) {
 	// Check permissions
 	claims, err := auth.ExtractClaims(ctx)
 	if err != nil {
@@ -200,7 +210,7 @@ func (s *BookingApplicationService) RequestRouteCandidates(ctx context.Context,
 		return nil, err
 	}
 
-	s.logger.Info("Requesting route candidates", "trackingId", trackingId)
+	s.logger.Info("Requesting route candidates", "trackingId", trackingId, "strategy", criteria.Strategy)
 
 	// Find cargo
 	cargo, err := s.cargoRepo.FindByTrackingId(trackingId)
@@ -209,9 +219,15 @@ func (s *BookingApplicationService) RequestRouteCandidates(ctx context.Context,
 		return nil, err
 	}
 
+	// Cargo that has been received is routed on from where it is, not from its origin
+	routeSpec, err := cargo.RemainingRouteSpecification()
+	if err != nil {
+		s.logger.Error("Cargo cannot be routed", "trackingId", trackingId, "error", err)
+		return nil, err
+	}
+
 	// Request route candidates from routing service
-	routeSpec := cargo.GetRouteSpecification()
-	candidates, err := s.routingService.FindOptimalItineraries(ctx, routeSpec)
+	candidates, err := s.routingService.FindOptimalItineraries(ctx, routeSpec, criteria)
 	if err != nil {
 		s.logger.Error("Failed to find route candidates", "trackingId", trackingId, "error", err)
 		return nil, err
@@ -221,10 +237,146 @@ func (s *BookingApplicationService) RequestRouteCandidates(ctx context.Context,
 	return candidates, nil
 }
 
-// UpdateCargoDelivery updates cargo delivery status based on handling events
-func (s *BookingApplicationService) UpdateCargoDelivery(ctx context.Context, trackingId bookingdomain.TrackingId, handlingHistory []bookingdomain.HandlingEventSummary) error {
+// ChangeRouteSpecification changes the destination and/or arrival deadline of a booked cargo
+func (s *BookingApplicationService) ChangeRouteSpecification(ctx context.Context, trackingId bookingdomain.TrackingId, destination, arrivalDeadlineStr string, expectedVersion int) (bookingdomain.Cargo, error) {
+	// Check permissions
+	claims, err := auth.ExtractClaims(ctx)
+	if err != nil {
+		s.logger.Warn("Unauthorized route specification change attempt", "trackingId", trackingId, "error", err)
+		return bookingdomain.Cargo{}, err
+	}
+	if err := RequireBookingPermission(claims, auth.PermissionBookCargo); err != nil {
+		s.logger.Warn("Unauthorized route specification change attempt", "trackingId", trackingId, "error", err)
+		return bookingdomain.Cargo{}, err
+	}
+
+	s.logger.Info("Changing route specification",
+		"trackingId", trackingId,
+		"destination", destination,
+		"arrivalDeadline", arrivalDeadlineStr)
+
+	if destination == "" && arrivalDeadlineStr == "" {
+		return bookingdomain.Cargo{}, bookingdomain.NewDomainValidationError("destination or arrival deadline must be provided", nil)
+	}
+
+	// Find cargo
+	cargo, err := s.cargoRepo.FindByTrackingId(trackingId)
+	if err != nil {
+		s.logger.Error("Cargo not found", "trackingId", trackingId, "error", err)
+		return bookingdomain.Cargo{}, err
+	}
+
+	if err := checkExpectedVersion(cargo, expectedVersion); err != nil {
+		s.logger.Warn("Cargo version does not match", "trackingId", trackingId, "expectedVersion", expectedVersion, "version", cargo.GetVersion())
+		return bookingdomain.Cargo{}, err
+	}
+
+	// The changed cargo is returned, so its arrival is estimated as it is for viewing
+	if err := s.followVoyageSchedules(ctx, &cargo); err != nil {
+		return bookingdomain.Cargo{}, err
+	}
+
+	// Fill in the parts of the specification that are not changing
+	currentSpec := cargo.GetRouteSpecification()
+	if destination == "" {
+		destination = currentSpec.Destination
+	}
+
+	arrivalDeadline := currentSpec.ArrivalDeadline
+	if arrivalDeadlineStr != "" {
+		arrivalDeadline, err = time.Parse(time.RFC3339, arrivalDeadlineStr)
+		if err != nil {
+			s.logger.Error("Invalid arrival deadline format", "error", err)
+			return bookingdomain.Cargo{}, bookingdomain.NewDomainValidationError("invalid arrival deadline format, expected RFC3339", err)
+		}
+	}
+
+	routeSpec, err := bookingdomain.NewRouteSpecification(currentSpec.Origin, destination, arrivalDeadline)
+	if err != nil {
+		s.logger.Error("Invalid route specification", "trackingId", trackingId, "error", err)
+		return bookingdomain.Cargo{}, err
+	}
+
+	// Replace route specification
+	if err := cargo.SpecifyNewRoute(routeSpec); err != nil {
+		s.logger.Error("Failed to change route specification", "trackingId", trackingId, "error", err)
+		return bookingdomain.Cargo{}, err
+	}
+
+	// Update cargo
+	if err := updateCargo(s.cargoRepo, &cargo); err != nil {
+		s.logger.Error("Failed to update cargo", "trackingId", trackingId, "error", err)
+		return bookingdomain.Cargo{}, err
+	}
+
+	s.logger.Info("Route specification changed successfully",
+		"trackingId", trackingId,
+		"routingStatus", cargo.GetDelivery().RoutingStatus)
+	return cargo, nil
+}
+
+// CancelCargo cancels a booking that has not yet been loaded or claimed
+func (s *BookingApplicationService) CancelCargo(ctx context.Context, trackingId bookingdomain.TrackingId, reason string, expectedVersion int) error {
+	// Check permissions
+	claims, err := auth.ExtractClaims(ctx)
+	if err != nil {
+		s.logger.Warn("Unauthorized cargo cancellation attempt", "trackingId", trackingId, "error", err)
+		return err
+	}
+	if err := RequireBookingPermission(claims, auth.PermissionBookCargo); err != nil {
+		s.logger.Warn("Unauthorized cargo cancellation attempt", "trackingId", trackingId, "error", err)
+		return err
+	}
+
+	s.logger.Info("Cancelling cargo", "trackingId", trackingId, "reason", reason)
+
+	// Find cargo
+	cargo, err := s.cargoRepo.FindByTrackingId(trackingId)
+	if err != nil {
+		s.logger.Error("Cargo not found", "trackingId", trackingId, "error", err)
+		return err
+	}
+
+	if err := checkExpectedVersion(cargo, expectedVersion); err != nil {
+		s.logger.Warn("Cargo version does not match", "trackingId", trackingId, "expectedVersion", expectedVersion, "version", cargo.GetVersion())
+		return err
+	}
+
+	// Cancel booking
+	if err := cargo.Cancel(reason); err != nil {
+		s.logger.Error("Failed to cancel cargo", "trackingId", trackingId, "error", err)
+		return err
+	}
+
+	// Update cargo
+	if err := updateCargo(s.cargoRepo, &cargo); err != nil {
+		s.logger.Error("Failed to update cargo", "trackingId", trackingId, "error", err)
+		return err
+	}
+
+	s.logger.Info("Cargo cancelled successfully", "trackingId", trackingId)
+	return nil
+}
+
+// UpdateCargoDelivery re-derives cargo delivery status from the complete handling history
+// Replaying the whole history keeps the status correct when reports arrive out of order.
+func (s *BookingApplicationService) UpdateCargoDelivery(ctx context.Context, trackingId bookingdomain.TrackingId) error {
 	s.logger.Info("Updating cargo delivery status", "trackingId", trackingId)
 
+	for attempt := 1; ; attempt++ {
+		err := s.deriveAndUpdateDelivery(ctx, trackingId)
+
+		var conflict bookingdomain.ConcurrencyConflictError
+		if err == nil || !errors.As(err, &conflict) || attempt == maxDeliveryUpdateAttempts {
+			return err
+		}
+
+		s.logger.Warn("Cargo changed during delivery update, retrying", "trackingId", trackingId, "attempt", attempt)
+	}
+}
+
+// deriveAndUpdateDelivery makes a single attempt at re-deriving and storing the delivery status
+func (s *BookingApplicationService) deriveAndUpdateDelivery(ctx context.Context, trackingId bookingdomain.TrackingId) error {
 	// Find cargo
 	cargo, err := s.cargoRepo.FindByTrackingId(trackingId)
 	if err != nil {
@@ -232,6 +384,18 @@ func (s *BookingApplicationService) UpdateCargoDelivery(ctx context.Context, tra
 		return err
 	}
 
+	// The delivery update reports whether the cargo is late, so estimate its arrival as voyages now keep time
+	if err := s.followVoyageSchedules(ctx, &cargo); err != nil {
+		return err
+	}
+
+	// Fetch handling history from the handling context
+	handlingHistory, err := s.handlingHistory.GetHandlingHistory(ctx, trackingId)
+	if err != nil {
+		s.logger.Error("Failed to retrieve handling history", "trackingId", trackingId, "error", err)
+		return err
+	}
+
 	// Update delivery progress
 	if err := cargo.DeriveDeliveryProgress(handlingHistory); err != nil {
 		s.logger.Error("Failed to derive delivery progress", "trackingId", trackingId, "error", err)
@@ -239,53 +403,189 @@ func (s *BookingApplicationService) UpdateCargoDelivery(ctx context.Context, tra
 	}
 
 	// Update cargo
-	if err := s.cargoRepo.Update(cargo); err != nil {
+	if err := updateCargo(s.cargoRepo, &cargo); err != nil {
 		s.logger.Error("Failed to update cargo", "trackingId", trackingId, "error", err)
 		return err
 	}
 
-	// Publish domain events
-	s.publishCargoEvents(cargo)
-
 	s.logger.Info("Cargo delivery status updated", "trackingId", trackingId)
 	return nil
 }
 
-// ListAllCargo retrieves all cargo from the repository
-func (s *BookingApplicationService) ListAllCargo(ctx context.Context) ([]bookingdomain.Cargo, error) {
+// ReviewVoyageSchedule re-evaluates the cargo routed on a rescheduled voyage against the current voyage schedules
+// Cargo that can no longer make its connections is marked misdirected, so that it is rerouted.
+func (s *BookingApplicationService) ReviewVoyageSchedule(ctx context.Context, voyageNumber string) error {
+	// Check permissions
+	claims, err := auth.ExtractClaims(ctx)
+	if err != nil {
+		s.logger.Warn("Unauthorized voyage schedule review attempt", "voyageNumber", voyageNumber, "error", err)
+		return err
+	}
+	if err := RequireBookingPermission(claims, auth.PermissionAssignRoute); err != nil {
+		s.logger.Warn("Unauthorized voyage schedule review attempt", "voyageNumber", voyageNumber, "error", err)
+		return err
+	}
+
+	s.logger.Info("Reviewing cargo routed on rescheduled voyage", "voyageNumber", voyageNumber)
+
+	query, err := bookingdomain.CargoQuery{OnVoyage: voyageNumber, Limit: bookingdomain.MaxCargoPageSize}.Normalize()
+	if err != nil {
+		return err
+	}
+
+	reviewed, misdirected := 0, 0
+	for {
+		page, err := s.cargoRepo.FindByQuery(query)
+		if err != nil {
+			s.logger.Error("Failed to list cargo on voyage", "voyageNumber", voyageNumber, "error", err)
+			return err
+		}
+
+		for _, cargo := range page.Cargos {
+			wasMisdirected, err := s.reviewCargoSchedules(ctx, cargo.GetTrackingId())
+			if err != nil {
+				return err
+			}
+			reviewed++
+			if wasMisdirected {
+				misdirected++
+			}
+		}
+
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	s.logger.Info("Reviewed cargo routed on rescheduled voyage",
+		"voyageNumber", voyageNumber,
+		"reviewed", reviewed,
+		"misdirected", misdirected)
+	return nil
+}
+
+// reviewCargoSchedules re-evaluates one cargo against the current voyage schedules, reporting whether it was marked
+// misdirected. Concurrent modifications are retried, as they are for delivery updates.
+func (s *BookingApplicationService) reviewCargoSchedules(ctx context.Context, trackingId bookingdomain.TrackingId) (bool, error) {
+	for attempt := 1; ; attempt++ {
+		misdirected, err := s.reviewAndUpdateSchedules(ctx, trackingId)
+
+		var conflict bookingdomain.ConcurrencyConflictError
+		if err == nil || !errors.As(err, &conflict) || attempt == maxDeliveryUpdateAttempts {
+			return misdirected, err
+		}
+
+		s.logger.Warn("Cargo changed during voyage schedule review, retrying", "trackingId", trackingId, "attempt", attempt)
+	}
+}
+
+// reviewAndUpdateSchedules makes a single attempt at re-evaluating a cargo and storing it if it was marked misdirected
+func (s *BookingApplicationService) reviewAndUpdateSchedules(ctx context.Context, trackingId bookingdomain.TrackingId) (bool, error) {
+	// Find cargo
+	cargo, err := s.cargoRepo.FindByTrackingId(trackingId)
+	if err != nil {
+		s.logger.Error("Cargo not found", "trackingId", trackingId, "error", err)
+		return false, err
+	}
+
+	if err := s.followVoyageSchedules(ctx, &cargo); err != nil {
+		return false, err
+	}
+
+	misdirected, err := cargo.ReviewVoyageSchedules()
+	if err != nil {
+		s.logger.Error("Failed to review voyage schedules", "trackingId", trackingId, "error", err)
+		return false, err
+	}
+	if !misdirected {
+		return false, nil
+	}
+
+	// Update cargo
+	if err := updateCargo(s.cargoRepo, &cargo); err != nil {
+		s.logger.Error("Failed to update cargo", "trackingId", trackingId, "error", err)
+		return false, err
+	}
+
+	s.logger.Info("Cargo misses a connection on the current voyage schedules", "trackingId", trackingId)
+	return true, nil
+}
+
+// followVoyageSchedules estimates the arrival of routed cargo from the current schedules of its voyages
+func (s *BookingApplicationService) followVoyageSchedules(ctx context.Context, cargo *bookingdomain.Cargo) error {
+	itinerary := cargo.GetItinerary()
+	if itinerary == nil {
+		return nil
+	}
+
+	schedules, err := s.voyageSchedules.FindLegSchedules(ctx, *itinerary)
+	if err != nil {
+		s.logger.Error("Failed to read voyage schedules", "trackingId", cargo.GetTrackingId(), "error", err)
+		return err
+	}
+
+	return cargo.FollowVoyageSchedules(schedules)
+}
+
+// storeCargo persists a new cargo and advances it to the version it was stored at
+func storeCargo(cargoRepo bookingsecondary.CargoRepository, cargo *bookingdomain.Cargo) error {
+	if err := cargoRepo.Store(*cargo); err != nil {
+		return err
+	}
+	cargo.SetVersion(cargo.GetVersion() + 1)
+	return nil
+}
+
+// updateCargo persists a modified cargo and advances it to the version it was stored at
+func updateCargo(cargoRepo bookingsecondary.CargoRepository, cargo *bookingdomain.Cargo) error {
+	if err := cargoRepo.Update(*cargo); err != nil {
+		return err
+	}
+	cargo.SetVersion(cargo.GetVersion() + 1)
+	return nil
+}
+
+// checkExpectedVersion enforces the caller's precondition that the cargo has not changed since they read it
+func checkExpectedVersion(cargo bookingdomain.Cargo, expectedVersion int) error {
+	if expectedVersion != bookingprimary.AnyVersion && cargo.GetVersion() != expectedVersion {
+		return bookingdomain.NewConcurrencyConflictError(cargo.GetTrackingId(), expectedVersion)
+	}
+	return nil
+}
+
+// ListAllCargo retrieves one page of the booked cargo matching the query, whatever its routing state
+func (s *BookingApplicationService) ListAllCargo(ctx context.Context, query bookingdomain.CargoQuery) (bookingdomain.CargoPage, error) {
 	// Check permissions
 	claims, err := auth.ExtractClaims(ctx)
 	if err != nil {
 		s.logger.Warn("Unauthorized cargo list attempt", "error", err)
-		return nil, err
+		return bookingdomain.CargoPage{}, err
 	}
 	if err := RequireBookingPermission(claims, auth.PermissionViewCargo); err != nil {
 		s.logger.Warn("Unauthorized cargo list attempt", "error", err)
-		return nil, err
+		return bookingdomain.CargoPage{}, err
 	}
 
-	s.logger.Info("Listing all cargo")
-
-	// Get all cargo from repository
-	allCargo, err := s.cargoRepo.FindAll()
+	query, err = query.Normalize()
 	if err != nil {
-		s.logger.Error("Failed to retrieve all cargo", "error", err)
-		return nil, err
+		return bookingdomain.CargoPage{}, err
 	}
 
-	s.logger.Info("Retrieved all cargo", "count", len(allCargo))
-	return allCargo, nil
-}
+	s.logger.Debug("Listing cargo", "sortBy", query.SortBy, "order", query.Order, "limit", query.Limit)
 
-// publishCargoEvents publishes all pending events from the cargo aggregate
-func (s *BookingApplicationService) publishCargoEvents(cargo bookingdomain.Cargo) {
-	events := cargo.GetEvents()
-	for _, event := range events {
-		if err := s.eventPublisher.Publish(event); err != nil {
-			s.logger.Error("Failed to publish event",
-				"eventName", event.EventName(),
-				"error", err)
+	page, err := s.cargoRepo.FindByQuery(query)
+	if err != nil {
+		s.logger.Error("Failed to list cargo", "error", err)
+		return bookingdomain.CargoPage{}, err
+	}
+
+	for i := range page.Cargos {
+		if err := s.followVoyageSchedules(ctx, &page.Cargos[i]); err != nil {
+			return bookingdomain.CargoPage{}, err
 		}
 	}
-	cargo.ClearEvents()
+
+	s.logger.Debug("Listed cargo", "count", len(page.Cargos), "more", page.NextCursor != "")
+	return page, nil
 }
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"go_hex/internal/booking/bookingdomain"
+	"go_hex/internal/booking/ports/bookingprimary"
 	"go_hex/internal/support/auth"
 	"go_hex/internal/support/basedomain"
 	"log/slog"
@@ -36,6 +37,11 @@ func (m *MockCargoRepository) FindAll() ([]bookingdomain.Cargo, error) {
 	return args.Get(0).([]bookingdomain.Cargo), args.Error(1)
 }
 
+func (m *MockCargoRepository) FindByQuery(query bookingdomain.CargoQuery) (bookingdomain.CargoPage, error) {
+	args := m.Called(query)
+	return args.Get(0).(bookingdomain.CargoPage), args.Error(1)
+}
+
 func (m *MockCargoRepository) FindUnrouted() ([]bookingdomain.Cargo, error) {
 	args := m.Called()
 	return args.Get(0).([]bookingdomain.Cargo), args.Error(1)
@@ -50,38 +56,55 @@ type MockRoutingService struct {
 	mock.Mock
 }
 
-func (m *MockRoutingService) FindOptimalItineraries(ctx context.Context, routeSpec bookingdomain.RouteSpecification) ([]bookingdomain.Itinerary, error) {
-	args := m.Called(ctx, routeSpec)
+func (m *MockRoutingService) FindOptimalItineraries(ctx context.Context, routeSpec bookingdomain.RouteSpecification, criteria bookingdomain.RankingCriteria) ([]bookingdomain.Itinerary, error) {
+	args := m.Called(ctx, routeSpec, criteria)
 	return args.Get(0).([]bookingdomain.Itinerary), args.Error(1)
 }
 
-type MockEventPublisher struct {
+type MockVoyageScheduleService struct {
 	mock.Mock
 }
 
-func (m *MockEventPublisher) Publish(event basedomain.DomainEvent) error {
-	args := m.Called(event)
-	return args.Error(0)
+func (m *MockVoyageScheduleService) FindLegSchedules(ctx context.Context, itinerary bookingdomain.Itinerary) ([]bookingdomain.LegSchedule, error) {
+	args := m.Called(ctx, itinerary)
+	return args.Get(0).([]bookingdomain.LegSchedule), args.Error(1)
+}
+
+type MockHandlingHistoryService struct {
+	mock.Mock
+}
+
+func (m *MockHandlingHistoryService) GetHandlingHistory(ctx context.Context, trackingId bookingdomain.TrackingId) ([]bookingdomain.HandlingEventSummary, error) {
+	args := m.Called(ctx, trackingId)
+	return args.Get(0).([]bookingdomain.HandlingEventSummary), args.Error(1)
+}
+
+// recordedEvent reports whether the cargo handed to the repository carries an event of type T
+func recordedEvent[T basedomain.DomainEvent](cargo bookingdomain.Cargo) bool {
+	for _, event := range cargo.GetEvents() {
+		if _, ok := event.(T); ok {
+			return true
+		}
+	}
+	return false
 }
 
 func TestBookingApplicationService_BookNewCargo(t *testing.T) {
-	setup := func() (*BookingApplicationService, *MockCargoRepository, *MockRoutingService, *MockEventPublisher) {
+	setup := func() (*BookingApplicationService, *MockCargoRepository, *MockRoutingService) {
 		cargoRepo := &MockCargoRepository{}
 		routingService := &MockRoutingService{}
-		eventPublisher := &MockEventPublisher{}
 		logger := slog.Default()
 
-		service := NewBookingApplicationService(cargoRepo, routingService, eventPublisher, logger)
+		service := NewBookingApplicationService(cargoRepo, routingService, &MockVoyageScheduleService{}, &MockHandlingHistoryService{}, logger)
 
-		return service, cargoRepo, routingService, eventPublisher
+		return service, cargoRepo, routingService
 	}
 
 	t.Run("should book new cargo successfully", func(t *testing.T) {
-		service, cargoRepo, _, eventPublisher := setup()
+		service, cargoRepo, _ := setup()
 
 		// Setup mocks
-		cargoRepo.On("Store", mock.AnythingOfType("bookingdomain.Cargo")).Return(nil)
-		eventPublisher.On("Publish", mock.Anything).Return(nil)
+		cargoRepo.On("Store", mock.MatchedBy(recordedEvent[bookingdomain.CargoBookedEvent])).Return(nil)
 
 		// Create context with valid claims
 		ctx := createContextWithClaims(t, []string{}) // admin role has all permissions
@@ -96,11 +119,10 @@ func TestBookingApplicationService_BookNewCargo(t *testing.T) {
 		assert.Equal(t, "DEHAM", cargo.GetRouteSpecification().Destination)
 		assert.False(t, cargo.IsRouted())
 		cargoRepo.AssertExpectations(t)
-		eventPublisher.AssertExpectations(t)
 	})
 
 	t.Run("should fail with unauthorized context", func(t *testing.T) {
-		service, _, _, _ := setup()
+		service, _, _ := setup()
 
 		// Create context without proper claims
 		ctx := context.Background()
@@ -114,7 +136,7 @@ func TestBookingApplicationService_BookNewCargo(t *testing.T) {
 	})
 
 	t.Run("should fail with invalid arrival deadline", func(t *testing.T) {
-		service, _, _, _ := setup()
+		service, _, _ := setup()
 
 		// Create context with valid claims
 		ctx := createContextWithClaims(t, []string{})
@@ -128,7 +150,7 @@ func TestBookingApplicationService_BookNewCargo(t *testing.T) {
 	})
 
 	t.Run("should fail when repository store fails", func(t *testing.T) {
-		service, cargoRepo, _, _ := setup()
+		service, cargoRepo, _ := setup()
 
 		// Setup mocks
 		cargoRepo.On("Store", mock.AnythingOfType("bookingdomain.Cargo")).Return(errors.New("storage error"))
@@ -147,19 +169,18 @@ func TestBookingApplicationService_BookNewCargo(t *testing.T) {
 }
 
 func TestBookingApplicationService_GetCargoDetails(t *testing.T) {
-	setup := func() (*BookingApplicationService, *MockCargoRepository, *MockRoutingService, *MockEventPublisher) {
+	setup := func() (*BookingApplicationService, *MockCargoRepository, *MockVoyageScheduleService) {
 		cargoRepo := &MockCargoRepository{}
-		routingService := &MockRoutingService{}
-		eventPublisher := &MockEventPublisher{}
+		voyageSchedules := &MockVoyageScheduleService{}
 		logger := slog.Default()
 
-		service := NewBookingApplicationService(cargoRepo, routingService, eventPublisher, logger)
+		service := NewBookingApplicationService(cargoRepo, &MockRoutingService{}, voyageSchedules, &MockHandlingHistoryService{}, logger)
 
-		return service, cargoRepo, routingService, eventPublisher
+		return service, cargoRepo, voyageSchedules
 	}
 
 	t.Run("should return cargo details successfully", func(t *testing.T) {
-		service, cargoRepo, _, _ := setup()
+		service, cargoRepo, _ := setup()
 
 		// Create test cargo
 		cargo := createTestCargo(t)
@@ -180,8 +201,60 @@ func TestBookingApplicationService_GetCargoDetails(t *testing.T) {
 		cargoRepo.AssertExpectations(t)
 	})
 
+	t.Run("should estimate the arrival of routed cargo from the current voyage schedules", func(t *testing.T) {
+		service, cargoRepo, voyageSchedules := setup()
+
+		cargo := createTestCargo(t)
+		itinerary := createTestItinerary(t, cargo.GetRouteSpecification())
+		require.NoError(t, cargo.AssignToRoute(itinerary))
+		trackingId := cargo.GetTrackingId()
+
+		schedules := []bookingdomain.LegSchedule{{
+			LoadTime:   itinerary.Legs[0].LoadTime,
+			UnloadTime: itinerary.Legs[0].UnloadTime.Add(3 * time.Hour),
+		}}
+
+		// Setup mocks
+		cargoRepo.On("FindByTrackingId", trackingId).Return(cargo, nil)
+		voyageSchedules.On("FindLegSchedules", mock.Anything, itinerary).Return(schedules, nil)
+
+		// Create context with valid claims
+		ctx := createContextWithClaims(t, []string{})
+
+		// Execute
+		result, err := service.GetCargoDetails(ctx, trackingId)
+
+		// Verify
+		require.NoError(t, err)
+		require.NotNil(t, result.GetEstimatedTimeOfArrival())
+		assert.Equal(t, schedules[0].UnloadTime, *result.GetEstimatedTimeOfArrival())
+		voyageSchedules.AssertExpectations(t)
+	})
+
+	t.Run("should fail when voyage schedules are unavailable", func(t *testing.T) {
+		service, cargoRepo, voyageSchedules := setup()
+
+		cargo := createTestCargo(t)
+		require.NoError(t, cargo.AssignToRoute(createTestItinerary(t, cargo.GetRouteSpecification())))
+		trackingId := cargo.GetTrackingId()
+
+		// Setup mocks
+		cargoRepo.On("FindByTrackingId", trackingId).Return(cargo, nil)
+		voyageSchedules.On("FindLegSchedules", mock.Anything, mock.Anything).Return([]bookingdomain.LegSchedule(nil), errors.New("routing unavailable"))
+
+		// Create context with valid claims
+		ctx := createContextWithClaims(t, []string{})
+
+		// Execute
+		_, err := service.GetCargoDetails(ctx, trackingId)
+
+		// Verify
+		assert.Error(t, err)
+		voyageSchedules.AssertExpectations(t)
+	})
+
 	t.Run("should fail with unauthorized context", func(t *testing.T) {
-		service, _, _, _ := setup()
+		service, _, _ := setup()
 
 		trackingId := bookingdomain.NewTrackingId()
 		ctx := context.Background()
@@ -194,7 +267,7 @@ func TestBookingApplicationService_GetCargoDetails(t *testing.T) {
 	})
 
 	t.Run("should fail when cargo not found", func(t *testing.T) {
-		service, cargoRepo, _, _ := setup()
+		service, cargoRepo, _ := setup()
 
 		trackingId := bookingdomain.NewTrackingId()
 
@@ -214,19 +287,18 @@ func TestBookingApplicationService_GetCargoDetails(t *testing.T) {
 }
 
 func TestBookingApplicationService_AssignRouteToCargo(t *testing.T) {
-	setup := func() (*BookingApplicationService, *MockCargoRepository, *MockRoutingService, *MockEventPublisher) {
+	setup := func() (*BookingApplicationService, *MockCargoRepository, *MockRoutingService) {
 		cargoRepo := &MockCargoRepository{}
 		routingService := &MockRoutingService{}
-		eventPublisher := &MockEventPublisher{}
 		logger := slog.Default()
 
-		service := NewBookingApplicationService(cargoRepo, routingService, eventPublisher, logger)
+		service := NewBookingApplicationService(cargoRepo, routingService, &MockVoyageScheduleService{}, &MockHandlingHistoryService{}, logger)
 
-		return service, cargoRepo, routingService, eventPublisher
+		return service, cargoRepo, routingService
 	}
 
 	t.Run("should assign route successfully", func(t *testing.T) {
-		service, cargoRepo, _, eventPublisher := setup()
+		service, cargoRepo, _ := setup()
 
 		// Create test cargo and itinerary
 		cargo := createTestCargo(t)
@@ -236,36 +308,34 @@ func TestBookingApplicationService_AssignRouteToCargo(t *testing.T) {
 		// Setup mocks
 		cargoRepo.On("FindByTrackingId", trackingId).Return(cargo, nil)
 		cargoRepo.On("Update", mock.AnythingOfType("bookingdomain.Cargo")).Return(nil)
-		eventPublisher.On("Publish", mock.Anything).Return(nil)
 
 		// Create context with valid claims
 		ctx := createContextWithClaims(t, []string{})
 
 		// Execute
-		err := service.AssignRouteToCargo(ctx, trackingId, itinerary)
+		err := service.AssignRouteToCargo(ctx, trackingId, itinerary, bookingprimary.AnyVersion)
 
 		// Verify
 		require.NoError(t, err)
 		cargoRepo.AssertExpectations(t)
-		eventPublisher.AssertExpectations(t)
 	})
 
 	t.Run("should fail with unauthorized context", func(t *testing.T) {
-		service, _, _, _ := setup()
+		service, _, _ := setup()
 
 		trackingId := bookingdomain.NewTrackingId()
 		itinerary := bookingdomain.Itinerary{}
 		ctx := context.Background()
 
 		// Execute
-		err := service.AssignRouteToCargo(ctx, trackingId, itinerary)
+		err := service.AssignRouteToCargo(ctx, trackingId, itinerary, bookingprimary.AnyVersion)
 
 		// Verify
 		assert.Error(t, err)
 	})
 
 	t.Run("should fail when cargo not found", func(t *testing.T) {
-		service, cargoRepo, _, _ := setup()
+		service, cargoRepo, _ := setup()
 
 		trackingId := bookingdomain.NewTrackingId()
 		itinerary := bookingdomain.Itinerary{}
@@ -277,7 +347,7 @@ func TestBookingApplicationService_AssignRouteToCargo(t *testing.T) {
 		ctx := createContextWithClaims(t, []string{})
 
 		// Execute
-		err := service.AssignRouteToCargo(ctx, trackingId, itinerary)
+		err := service.AssignRouteToCargo(ctx, trackingId, itinerary, bookingprimary.AnyVersion)
 
 		// Verify
 		assert.Error(t, err)
@@ -285,62 +355,148 @@ func TestBookingApplicationService_AssignRouteToCargo(t *testing.T) {
 	})
 }
 
+func TestBookingApplicationService_RequestRouteCandidates(t *testing.T) {
+	setup := func() (*BookingApplicationService, *MockCargoRepository, *MockRoutingService) {
+		cargoRepo := &MockCargoRepository{}
+		routingService := &MockRoutingService{}
+
+		service := NewBookingApplicationService(cargoRepo, routingService, &MockVoyageScheduleService{}, &MockHandlingHistoryService{}, slog.Default())
+
+		return service, cargoRepo, routingService
+	}
+
+	t.Run("should search from the origin of cargo that has not been received", func(t *testing.T) {
+		service, cargoRepo, routingService := setup()
+		cargo := createTestCargo(t)
+		candidates := []bookingdomain.Itinerary{createTestItinerary(t, cargo.GetRouteSpecification())}
+
+		// Setup mocks
+		cargoRepo.On("FindByTrackingId", cargo.GetTrackingId()).Return(cargo, nil)
+		routingService.On("FindOptimalItineraries", mock.Anything, cargo.GetRouteSpecification(), bookingdomain.RankingCriteria{}).Return(candidates, nil)
+
+		// Execute
+		result, err := service.RequestRouteCandidates(createContextWithClaims(t, nil), cargo.GetTrackingId(), bookingdomain.RankingCriteria{})
+
+		// Verify
+		require.NoError(t, err)
+		assert.Equal(t, candidates, result)
+	})
+
+	t.Run("should search from the port cargo under way was last handled in", func(t *testing.T) {
+		service, cargoRepo, routingService := setup()
+		cargo := createMisdirectedCargo(t, true)
+		expected := bookingdomain.RouteSpecification{
+			Origin:            "NLRTM",
+			Destination:       "DEHAM",
+			ArrivalDeadline:   cargo.GetRouteSpecification().ArrivalDeadline,
+			EarliestDeparture: cargo.GetDelivery().LastEventTime,
+		}
+
+		// Setup mocks
+		cargoRepo.On("FindByTrackingId", cargo.GetTrackingId()).Return(cargo, nil)
+		routingService.On("FindOptimalItineraries", mock.Anything, expected, bookingdomain.RankingCriteria{}).Return([]bookingdomain.Itinerary{}, nil)
+
+		// Execute
+		_, err := service.RequestRouteCandidates(createContextWithClaims(t, nil), cargo.GetTrackingId(), bookingdomain.RankingCriteria{})
+
+		// Verify
+		require.NoError(t, err)
+		routingService.AssertExpectations(t)
+	})
+
+	t.Run("should not search for cargo on board a voyage its itinerary does not plan", func(t *testing.T) {
+		service, cargoRepo, routingService := setup()
+		cargo := createMisdirectedCargo(t, false)
+
+		// Setup mocks
+		cargoRepo.On("FindByTrackingId", cargo.GetTrackingId()).Return(cargo, nil)
+
+		// Execute
+		_, err := service.RequestRouteCandidates(createContextWithClaims(t, nil), cargo.GetTrackingId(), bookingdomain.RankingCriteria{})
+
+		// Verify
+		var validationErr bookingdomain.DomainValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		routingService.AssertNotCalled(t, "FindOptimalItineraries", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
 func TestBookingApplicationService_ListAllCargo(t *testing.T) {
-	setup := func() (*BookingApplicationService, *MockCargoRepository, *MockRoutingService, *MockEventPublisher) {
+	setup := func() (*BookingApplicationService, *MockCargoRepository, *MockRoutingService) {
 		cargoRepo := &MockCargoRepository{}
 		routingService := &MockRoutingService{}
-		eventPublisher := &MockEventPublisher{}
 		logger := slog.Default()
 
-		service := NewBookingApplicationService(cargoRepo, routingService, eventPublisher, logger)
+		service := NewBookingApplicationService(cargoRepo, routingService, &MockVoyageScheduleService{}, &MockHandlingHistoryService{}, logger)
 
-		return service, cargoRepo, routingService, eventPublisher
+		return service, cargoRepo, routingService
 	}
 
-	t.Run("should list all cargo successfully", func(t *testing.T) {
-		service, cargoRepo, _, _ := setup()
+	t.Run("should list a page of cargo with the default sort order and page size", func(t *testing.T) {
+		service, cargoRepo, _ := setup()
 
 		// Create test cargo list
 		cargoList := []bookingdomain.Cargo{createTestCargo(t), createTestCargo(t)}
 
 		// Setup mocks
-		cargoRepo.On("FindAll").Return(cargoList, nil)
+		cargoRepo.On("FindByQuery", mock.MatchedBy(func(query bookingdomain.CargoQuery) bool {
+			return query.RoutingStatus == bookingdomain.RoutingStatusRouted &&
+				query.SortBy == bookingdomain.CargoSortByTrackingId &&
+				query.Order == bookingdomain.SortAscending &&
+				query.Limit == bookingdomain.DefaultCargoPageSize &&
+				!query.AsOf.IsZero()
+		})).Return(bookingdomain.CargoPage{Cargos: cargoList, NextCursor: "next"}, nil)
 
 		// Create context with valid claims
 		ctx := createContextWithClaims(t, []string{})
 
 		// Execute
-		result, err := service.ListAllCargo(ctx)
+		result, err := service.ListAllCargo(ctx, bookingdomain.CargoQuery{RoutingStatus: bookingdomain.RoutingStatusRouted})
 
 		// Verify
 		require.NoError(t, err)
-		assert.Len(t, result, 2)
+		assert.Len(t, result.Cargos, 2)
+		assert.Equal(t, "next", result.NextCursor)
 		cargoRepo.AssertExpectations(t)
 	})
 
+	t.Run("should reject invalid query", func(t *testing.T) {
+		service, cargoRepo, _ := setup()
+
+		ctx := createContextWithClaims(t, []string{})
+
+		// Execute
+		_, err := service.ListAllCargo(ctx, bookingdomain.CargoQuery{SortBy: "weight"})
+
+		// Verify
+		var validationErr bookingdomain.DomainValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		cargoRepo.AssertNotCalled(t, "FindByQuery", mock.Anything)
+	})
+
 	t.Run("should fail with unauthorized context", func(t *testing.T) {
-		service, _, _, _ := setup()
+		service, _, _ := setup()
 
 		ctx := context.Background()
 
 		// Execute
-		_, err := service.ListAllCargo(ctx)
+		_, err := service.ListAllCargo(ctx, bookingdomain.CargoQuery{})
 
 		// Verify
 		assert.Error(t, err)
 	})
 
 	t.Run("should fail when repository fails", func(t *testing.T) {
-		service, cargoRepo, _, _ := setup()
+		service, cargoRepo, _ := setup()
 
 		// Setup mocks
-		cargoRepo.On("FindAll").Return([]bookingdomain.Cargo{}, errors.New("repository error"))
+		cargoRepo.On("FindByQuery", mock.Anything).Return(bookingdomain.CargoPage{}, errors.New("repository error"))
 
 		// Create context with valid claims
 		ctx := createContextWithClaims(t, []string{})
 
 		// Execute
-		_, err := service.ListAllCargo(ctx)
+		_, err := service.ListAllCargo(ctx, bookingdomain.CargoQuery{})
 
 		// Verify
 		assert.Error(t, err)
@@ -349,19 +505,19 @@ func TestBookingApplicationService_ListAllCargo(t *testing.T) {
 }
 
 func TestBookingApplicationService_UpdateCargoDelivery(t *testing.T) {
-	setup := func() (*BookingApplicationService, *MockCargoRepository, *MockRoutingService, *MockEventPublisher) {
+	setup := func() (*BookingApplicationService, *MockCargoRepository, *MockHandlingHistoryService) {
 		cargoRepo := &MockCargoRepository{}
 		routingService := &MockRoutingService{}
-		eventPublisher := &MockEventPublisher{}
+		handlingHistory := &MockHandlingHistoryService{}
 		logger := slog.Default()
 
-		service := NewBookingApplicationService(cargoRepo, routingService, eventPublisher, logger)
+		service := NewBookingApplicationService(cargoRepo, routingService, &MockVoyageScheduleService{}, handlingHistory, logger)
 
-		return service, cargoRepo, routingService, eventPublisher
+		return service, cargoRepo, handlingHistory
 	}
 
 	t.Run("should update cargo delivery successfully", func(t *testing.T) {
-		service, cargoRepo, _, eventPublisher := setup()
+		service, cargoRepo, handlingHistoryService := setup()
 
 		// Create test cargo and handling history
 		cargo := createTestCargo(t)
@@ -377,36 +533,397 @@ func TestBookingApplicationService_UpdateCargoDelivery(t *testing.T) {
 
 		// Setup mocks
 		cargoRepo.On("FindByTrackingId", trackingId).Return(cargo, nil)
+		handlingHistoryService.On("GetHandlingHistory", mock.Anything, trackingId).Return(handlingHistory, nil)
 		cargoRepo.On("Update", mock.AnythingOfType("bookingdomain.Cargo")).Return(nil)
-		eventPublisher.On("Publish", mock.Anything).Return(nil)
 
 		// Execute
 		ctx := context.Background()
-		err := service.UpdateCargoDelivery(ctx, trackingId, handlingHistory)
+		err := service.UpdateCargoDelivery(ctx, trackingId)
 
 		// Verify
 		require.NoError(t, err)
 		cargoRepo.AssertExpectations(t)
-		eventPublisher.AssertExpectations(t)
+		handlingHistoryService.AssertExpectations(t)
+	})
+
+	t.Run("should derive delivery from the latest event when reports arrive out of order", func(t *testing.T) {
+		service, cargoRepo, handlingHistoryService := setup()
+
+		cargo := createTestCargo(t)
+		trackingId := cargo.GetTrackingId()
+		now := time.Now()
+
+		// The earlier RECEIVE was registered after the LOAD
+		handlingHistory := []bookingdomain.HandlingEventSummary{
+			{Type: "LOAD", Location: "USNYC", VoyageNumber: "V001", Timestamp: now.Add(-time.Hour)},
+			{Type: "RECEIVE", Location: "USNYC", Timestamp: now.Add(-2 * time.Hour)},
+		}
+
+		// Setup mocks
+		cargoRepo.On("FindByTrackingId", trackingId).Return(cargo, nil)
+		handlingHistoryService.On("GetHandlingHistory", mock.Anything, trackingId).Return(handlingHistory, nil)
+		cargoRepo.On("Update", mock.MatchedBy(func(c bookingdomain.Cargo) bool {
+			return c.GetDelivery().TransportStatus == bookingdomain.TransportStatusOnboardCarrier
+		})).Return(nil)
+
+		// Execute
+		err := service.UpdateCargoDelivery(context.Background(), trackingId)
+
+		// Verify
+		require.NoError(t, err)
+		cargoRepo.AssertExpectations(t)
+	})
+
+	t.Run("should fail when handling history is unavailable", func(t *testing.T) {
+		service, cargoRepo, handlingHistoryService := setup()
+
+		cargo := createTestCargo(t)
+		trackingId := cargo.GetTrackingId()
+
+		// Setup mocks
+		cargoRepo.On("FindByTrackingId", trackingId).Return(cargo, nil)
+		handlingHistoryService.On("GetHandlingHistory", mock.Anything, trackingId).Return([]bookingdomain.HandlingEventSummary{}, errors.New("unavailable"))
+
+		// Execute
+		err := service.UpdateCargoDelivery(context.Background(), trackingId)
+
+		// Verify
+		assert.Error(t, err)
+		cargoRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("should retry when cargo was modified concurrently", func(t *testing.T) {
+		service, cargoRepo, handlingHistoryService := setup()
+
+		cargo := createTestCargo(t)
+		cargo.SetVersion(1)
+		trackingId := cargo.GetTrackingId()
+		conflict := bookingdomain.NewConcurrencyConflictError(trackingId, 1)
+
+		// Setup mocks
+		cargoRepo.On("FindByTrackingId", trackingId).Return(cargo, nil)
+		handlingHistoryService.On("GetHandlingHistory", mock.Anything, trackingId).Return([]bookingdomain.HandlingEventSummary{}, nil)
+		cargoRepo.On("Update", mock.AnythingOfType("bookingdomain.Cargo")).Return(conflict).Once()
+		cargoRepo.On("Update", mock.AnythingOfType("bookingdomain.Cargo")).Return(nil).Once()
+
+		// Execute
+		err := service.UpdateCargoDelivery(context.Background(), trackingId)
+
+		// Verify
+		require.NoError(t, err)
+		cargoRepo.AssertNumberOfCalls(t, "FindByTrackingId", 2)
+		cargoRepo.AssertNumberOfCalls(t, "Update", 2)
+	})
+
+	t.Run("should give up after repeated conflicts", func(t *testing.T) {
+		service, cargoRepo, handlingHistoryService := setup()
+
+		cargo := createTestCargo(t)
+		trackingId := cargo.GetTrackingId()
+		conflict := bookingdomain.NewConcurrencyConflictError(trackingId, 1)
+
+		// Setup mocks
+		cargoRepo.On("FindByTrackingId", trackingId).Return(cargo, nil)
+		handlingHistoryService.On("GetHandlingHistory", mock.Anything, trackingId).Return([]bookingdomain.HandlingEventSummary{}, nil)
+		cargoRepo.On("Update", mock.AnythingOfType("bookingdomain.Cargo")).Return(conflict)
+
+		// Execute
+		err := service.UpdateCargoDelivery(context.Background(), trackingId)
+
+		// Verify
+		var conflictErr bookingdomain.ConcurrencyConflictError
+		assert.ErrorAs(t, err, &conflictErr)
+		cargoRepo.AssertNumberOfCalls(t, "Update", maxDeliveryUpdateAttempts)
 	})
 
 	t.Run("should fail when cargo not found", func(t *testing.T) {
-		service, cargoRepo, _, _ := setup()
+		service, cargoRepo, _ := setup()
 
 		trackingId := bookingdomain.NewTrackingId()
-		handlingHistory := []bookingdomain.HandlingEventSummary{}
 
 		// Setup mocks
 		cargoRepo.On("FindByTrackingId", trackingId).Return(bookingdomain.Cargo{}, errors.New("not found"))
 
 		// Execute
 		ctx := context.Background()
-		err := service.UpdateCargoDelivery(ctx, trackingId, handlingHistory)
+		err := service.UpdateCargoDelivery(ctx, trackingId)
+
+		// Verify
+		assert.Error(t, err)
+		cargoRepo.AssertExpectations(t)
+	})
+}
+
+func TestBookingApplicationService_ReviewVoyageSchedule(t *testing.T) {
+	setup := func() (*BookingApplicationService, *MockCargoRepository, *MockVoyageScheduleService) {
+		cargoRepo := &MockCargoRepository{}
+		voyageSchedules := &MockVoyageScheduleService{}
+		logger := slog.Default()
+
+		service := NewBookingApplicationService(cargoRepo, &MockRoutingService{}, voyageSchedules, &MockHandlingHistoryService{}, logger)
+
+		return service, cargoRepo, voyageSchedules
+	}
+	routedCargo := func(t *testing.T) bookingdomain.Cargo {
+		cargo := createTestCargo(t)
+		require.NoError(t, cargo.AssignToRoute(createTestItinerary(t, cargo.GetRouteSpecification())))
+		return cargo
+	}
+	onVoyage := mock.MatchedBy(func(query bookingdomain.CargoQuery) bool {
+		return query.OnVoyage == "V001"
+	})
+
+	t.Run("should mark cargo misdirected that misses a connection", func(t *testing.T) {
+		service, cargoRepo, voyageSchedules := setup()
+
+		cargo := routedCargo(t)
+		trackingId := cargo.GetTrackingId()
+
+		// Setup mocks
+		cargoRepo.On("FindByQuery", onVoyage).Return(bookingdomain.CargoPage{Cargos: []bookingdomain.Cargo{cargo}}, nil)
+		cargoRepo.On("FindByTrackingId", trackingId).Return(cargo, nil)
+		voyageSchedules.On("FindLegSchedules", mock.Anything, mock.Anything).Return([]bookingdomain.LegSchedule{{Cancelled: true}}, nil)
+		cargoRepo.On("Update", mock.MatchedBy(func(c bookingdomain.Cargo) bool {
+			return c.GetDelivery().IsMisdirected()
+		})).Return(nil)
+
+		// Execute
+		err := service.ReviewVoyageSchedule(createContextWithClaims(t, []string{}), "V001")
+
+		// Verify
+		require.NoError(t, err)
+		cargoRepo.AssertExpectations(t)
+	})
+
+	t.Run("should leave cargo that still makes its connections", func(t *testing.T) {
+		service, cargoRepo, voyageSchedules := setup()
+
+		cargo := routedCargo(t)
+		leg := cargo.GetItinerary().Legs[0]
+
+		// Setup mocks
+		cargoRepo.On("FindByQuery", onVoyage).Return(bookingdomain.CargoPage{Cargos: []bookingdomain.Cargo{cargo}}, nil)
+		cargoRepo.On("FindByTrackingId", cargo.GetTrackingId()).Return(cargo, nil)
+		voyageSchedules.On("FindLegSchedules", mock.Anything, mock.Anything).Return([]bookingdomain.LegSchedule{
+			{LoadTime: leg.LoadTime.Add(time.Hour), UnloadTime: leg.UnloadTime.Add(time.Hour)},
+		}, nil)
+
+		// Execute
+		err := service.ReviewVoyageSchedule(createContextWithClaims(t, []string{}), "V001")
+
+		// Verify
+		require.NoError(t, err)
+		cargoRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("should review every page of cargo on the voyage", func(t *testing.T) {
+		service, cargoRepo, voyageSchedules := setup()
+
+		first, second := routedCargo(t), routedCargo(t)
+
+		// Setup mocks
+		cargoRepo.On("FindByQuery", mock.MatchedBy(func(query bookingdomain.CargoQuery) bool {
+			return query.OnVoyage == "V001" && query.Cursor == ""
+		})).Return(bookingdomain.CargoPage{Cargos: []bookingdomain.Cargo{first}, NextCursor: "next"}, nil)
+		cargoRepo.On("FindByQuery", mock.MatchedBy(func(query bookingdomain.CargoQuery) bool {
+			return query.OnVoyage == "V001" && query.Cursor == "next"
+		})).Return(bookingdomain.CargoPage{Cargos: []bookingdomain.Cargo{second}}, nil)
+		cargoRepo.On("FindByTrackingId", first.GetTrackingId()).Return(first, nil)
+		cargoRepo.On("FindByTrackingId", second.GetTrackingId()).Return(second, nil)
+		voyageSchedules.On("FindLegSchedules", mock.Anything, mock.Anything).Return([]bookingdomain.LegSchedule{{Cancelled: true}}, nil)
+		cargoRepo.On("Update", mock.AnythingOfType("bookingdomain.Cargo")).Return(nil)
+
+		// Execute
+		err := service.ReviewVoyageSchedule(createContextWithClaims(t, []string{}), "V001")
+
+		// Verify
+		require.NoError(t, err)
+		cargoRepo.AssertNumberOfCalls(t, "Update", 2)
+	})
+
+	t.Run("should fail with unauthorized context", func(t *testing.T) {
+		service, cargoRepo, _ := setup()
+
+		// Execute
+		err := service.ReviewVoyageSchedule(context.Background(), "V001")
+
+		// Verify
+		assert.Error(t, err)
+		cargoRepo.AssertNotCalled(t, "FindByQuery", mock.Anything)
+	})
+}
+
+func TestBookingApplicationService_ChangeRouteSpecification(t *testing.T) {
+	setup := func() (*BookingApplicationService, *MockCargoRepository, *MockRoutingService) {
+		cargoRepo := &MockCargoRepository{}
+		routingService := &MockRoutingService{}
+		logger := slog.Default()
+
+		service := NewBookingApplicationService(cargoRepo, routingService, &MockVoyageScheduleService{}, &MockHandlingHistoryService{}, logger)
+
+		return service, cargoRepo, routingService
+	}
+
+	t.Run("should change destination and keep arrival deadline", func(t *testing.T) {
+		service, cargoRepo, _ := setup()
+
+		cargo := createTestCargo(t)
+		cargo.SetVersion(1)
+		trackingId := cargo.GetTrackingId()
+		deadline := cargo.GetRouteSpecification().ArrivalDeadline
+
+		// Setup mocks
+		cargoRepo.On("FindByTrackingId", trackingId).Return(cargo, nil)
+		cargoRepo.On("Update", mock.MatchedBy(recordedEvent[bookingdomain.CargoRouteSpecificationChangedEvent])).Return(nil)
+
+		// Create context with valid claims
+		ctx := createContextWithClaims(t, []string{})
+
+		// Execute
+		updated, err := service.ChangeRouteSpecification(ctx, trackingId, "SEGOT", "", 1)
+
+		// Verify
+		require.NoError(t, err)
+		assert.Equal(t, 2, updated.GetVersion())
+		assert.Equal(t, "SEGOT", updated.GetRouteSpecification().Destination)
+		assert.True(t, deadline.Equal(updated.GetRouteSpecification().ArrivalDeadline))
+		cargoRepo.AssertExpectations(t)
+	})
+
+	t.Run("should fail with invalid arrival deadline", func(t *testing.T) {
+		service, cargoRepo, _ := setup()
+
+		cargo := createTestCargo(t)
+		trackingId := cargo.GetTrackingId()
+
+		// Setup mocks
+		cargoRepo.On("FindByTrackingId", trackingId).Return(cargo, nil)
+
+		// Create context with valid claims
+		ctx := createContextWithClaims(t, []string{})
+
+		// Execute
+		_, err := service.ChangeRouteSpecification(ctx, trackingId, "", "invalid-date", bookingprimary.AnyVersion)
+
+		// Verify
+		assert.Error(t, err)
+		cargoRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("should fail when nothing is changed", func(t *testing.T) {
+		service, _, _ := setup()
+
+		// Create context with valid claims
+		ctx := createContextWithClaims(t, []string{})
+
+		// Execute
+		_, err := service.ChangeRouteSpecification(ctx, bookingdomain.NewTrackingId(), "", "", bookingprimary.AnyVersion)
 
 		// Verify
 		assert.Error(t, err)
+	})
+
+	t.Run("should fail with unauthorized context", func(t *testing.T) {
+		service, _, _ := setup()
+
+		// Execute
+		_, err := service.ChangeRouteSpecification(context.Background(), bookingdomain.NewTrackingId(), "SEGOT", "", bookingprimary.AnyVersion)
+
+		// Verify
+		assert.Error(t, err)
+	})
+}
+
+func TestBookingApplicationService_CancelCargo(t *testing.T) {
+	setup := func() (*BookingApplicationService, *MockCargoRepository, *MockRoutingService) {
+		cargoRepo := &MockCargoRepository{}
+		routingService := &MockRoutingService{}
+		logger := slog.Default()
+
+		service := NewBookingApplicationService(cargoRepo, routingService, &MockVoyageScheduleService{}, &MockHandlingHistoryService{}, logger)
+
+		return service, cargoRepo, routingService
+	}
+
+	t.Run("should cancel cargo successfully", func(t *testing.T) {
+		service, cargoRepo, _ := setup()
+
+		cargo := createTestCargo(t)
+		trackingId := cargo.GetTrackingId()
+
+		// Setup mocks
+		cargoRepo.On("FindByTrackingId", trackingId).Return(cargo, nil)
+		cargoRepo.On("Update", mock.MatchedBy(func(c bookingdomain.Cargo) bool {
+			return c.GetDelivery().IsCancelled() && recordedEvent[bookingdomain.CargoCancelledEvent](c)
+		})).Return(nil)
+
+		// Create context with valid claims
+		ctx := createContextWithClaims(t, []string{})
+
+		// Execute
+		err := service.CancelCargo(ctx, trackingId, "customer request", bookingprimary.AnyVersion)
+
+		// Verify
+		require.NoError(t, err)
 		cargoRepo.AssertExpectations(t)
 	})
+
+	t.Run("should reject cancellation when cargo is not at the expected version", func(t *testing.T) {
+		service, cargoRepo, _ := setup()
+
+		cargo := createTestCargo(t)
+		cargo.SetVersion(3)
+		trackingId := cargo.GetTrackingId()
+
+		// Setup mocks
+		cargoRepo.On("FindByTrackingId", trackingId).Return(cargo, nil)
+
+		// Create context with valid claims
+		ctx := createContextWithClaims(t, []string{})
+
+		// Execute
+		err := service.CancelCargo(ctx, trackingId, "customer request", 2)
+
+		// Verify
+		var conflict bookingdomain.ConcurrencyConflictError
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, 2, conflict.ExpectedVersion)
+		cargoRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("should fail with unauthorized context", func(t *testing.T) {
+		service, _, _ := setup()
+
+		// Execute
+		err := service.CancelCargo(context.Background(), bookingdomain.NewTrackingId(), "customer request", bookingprimary.AnyVersion)
+
+		// Verify
+		assert.Error(t, err)
+	})
+
+	t.Run("should not update cargo that cannot be cancelled", func(t *testing.T) {
+		service, cargoRepo, _ := setup()
+
+		cargo := createTestCargo(t)
+		trackingId := cargo.GetTrackingId()
+		err := cargo.DeriveDeliveryProgress([]bookingdomain.HandlingEventSummary{
+			{Type: "LOAD", Location: "USNYC", VoyageNumber: "V001", Timestamp: time.Now()},
+		})
+		require.NoError(t, err)
+
+		// Setup mocks
+		cargoRepo.On("FindByTrackingId", trackingId).Return(cargo, nil)
+
+		// Create context with valid claims
+		ctx := createContextWithClaims(t, []string{})
+
+		// Execute
+		err = service.CancelCargo(ctx, trackingId, "customer request", bookingprimary.AnyVersion)
+
+		// Verify
+		assert.Error(t, err)
+		cargoRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
 }
 
 // Helper functions
@@ -0,0 +1,175 @@
+package bookingapplication
+
+import (
+	"context"
+	"go_hex/internal/booking/bookingdomain"
+	"go_hex/internal/booking/ports/bookingprimary"
+	"go_hex/internal/booking/ports/bookingsecondary"
+	"go_hex/internal/support/auth"
+	"log/slog"
+)
+
+// ReroutePolicy decides what happens to cargo that is found misdirected
+type ReroutePolicy string
+
+const (
+	// ReroutePolicyWorklist puts misdirected cargo on the reroute worklist for a planner to choose a route
+	ReroutePolicyWorklist ReroutePolicy = "worklist"
+
+	// ReroutePolicyAutoAssign assigns the best route candidate, using the worklist only when there is none
+	ReroutePolicyAutoAssign ReroutePolicy = "auto_assign"
+)
+
+// RerouteApplicationService implements the primary port for rerouting misdirected cargo
+type RerouteApplicationService struct {
+	cargoRepo      bookingsecondary.CargoRepository
+	routingService bookingsecondary.RoutingService
+	worklist       bookingsecondary.RerouteWorklist
+	policy         ReroutePolicy
+	criteria       bookingdomain.RankingCriteria
+	logger         *slog.Logger
+}
+
+// Ensure RerouteApplicationService implements the primary port
+var _ bookingprimary.RerouteService = (*RerouteApplicationService)(nil)
+
+// NewRerouteApplicationService creates a new RerouteApplicationService
+// The criteria rank the route candidates; with the auto-assign policy the first one is assigned.
+func NewRerouteApplicationService(
+	cargoRepo bookingsecondary.CargoRepository,
+	routingService bookingsecondary.RoutingService,
+	worklist bookingsecondary.RerouteWorklist,
+	policy ReroutePolicy,
+	criteria bookingdomain.RankingCriteria,
+	logger *slog.Logger,
+) *RerouteApplicationService {
+	return &RerouteApplicationService{
+		cargoRepo:      cargoRepo,
+		routingService: routingService,
+		worklist:       worklist,
+		policy:         policy,
+		criteria:       criteria,
+		logger:         logger,
+	}
+}
+
+// RerouteMisdirectedCargo requests route candidates from the port a misdirected cargo is in and applies the reroute policy
+func (s *RerouteApplicationService) RerouteMisdirectedCargo(ctx context.Context, trackingId bookingdomain.TrackingId) error {
+	// Check permissions
+	claims, err := auth.ExtractClaims(ctx)
+	if err != nil {
+		s.logger.Warn("Unauthorized cargo reroute attempt", "trackingId", trackingId, "error", err)
+		return err
+	}
+	if err := RequireBookingPermission(claims, auth.PermissionAssignRoute); err != nil {
+		s.logger.Warn("Unauthorized cargo reroute attempt", "trackingId", trackingId, "error", err)
+		return err
+	}
+
+	// Find cargo
+	cargo, err := s.cargoRepo.FindByTrackingId(trackingId)
+	if err != nil {
+		s.logger.Error("Cargo not found", "trackingId", trackingId, "error", err)
+		return err
+	}
+
+	// The event may be stale: the cargo has been handled or rerouted since
+	if !cargo.GetDelivery().IsMisdirected() {
+		s.logger.Debug("Cargo is no longer misdirected", "trackingId", trackingId)
+		return s.worklist.Remove(trackingId)
+	}
+
+	routeSpec, err := cargo.RerouteSpecification()
+	if err != nil {
+		// Cargo on board is rerouted once it is unloaded, which updates its delivery again
+		s.logger.Info("Misdirected cargo cannot be rerouted yet", "trackingId", trackingId, "reason", err)
+		return nil
+	}
+
+	s.logger.Info("Rerouting misdirected cargo",
+		"trackingId", trackingId,
+		"from", routeSpec.Origin,
+		"destination", routeSpec.Destination,
+		"policy", s.policy)
+
+	candidates, err := s.routingService.FindOptimalItineraries(ctx, routeSpec, s.criteria)
+	if err != nil {
+		s.logger.Error("Failed to find reroute candidates", "trackingId", trackingId, "error", err)
+		return err
+	}
+
+	if s.policy == ReroutePolicyAutoAssign && len(candidates) > 0 {
+		return s.assign(&cargo, candidates[0])
+	}
+
+	if err := s.worklist.Put(bookingdomain.NewRerouteTask(trackingId, routeSpec, candidates)); err != nil {
+		s.logger.Error("Failed to put cargo on reroute worklist", "trackingId", trackingId, "error", err)
+		return err
+	}
+
+	s.logger.Info("Misdirected cargo needs rerouting", "trackingId", trackingId, "candidates", len(candidates))
+	return nil
+}
+
+// ResolveReroute takes cargo off the reroute worklist once it has been rerouted or cancelled
+func (s *RerouteApplicationService) ResolveReroute(ctx context.Context, trackingId bookingdomain.TrackingId) error {
+	// Check permissions
+	claims, err := auth.ExtractClaims(ctx)
+	if err != nil {
+		s.logger.Warn("Unauthorized reroute resolution attempt", "trackingId", trackingId, "error", err)
+		return err
+	}
+	if err := RequireBookingPermission(claims, auth.PermissionAssignRoute); err != nil {
+		s.logger.Warn("Unauthorized reroute resolution attempt", "trackingId", trackingId, "error", err)
+		return err
+	}
+
+	return s.worklist.Remove(trackingId)
+}
+
+// ListRerouteWorklist retrieves the misdirected cargo waiting for a planner, oldest first
+func (s *RerouteApplicationService) ListRerouteWorklist(ctx context.Context) ([]bookingdomain.RerouteTask, error) {
+	// Check permissions
+	claims, err := auth.ExtractClaims(ctx)
+	if err != nil {
+		s.logger.Warn("Unauthorized reroute worklist attempt", "error", err)
+		return nil, err
+	}
+	if err := RequireBookingPermission(claims, auth.PermissionViewCargo); err != nil {
+		s.logger.Warn("Unauthorized reroute worklist attempt", "error", err)
+		return nil, err
+	}
+
+	tasks, err := s.worklist.FindAll()
+	if err != nil {
+		s.logger.Error("Failed to list reroute worklist", "error", err)
+		return nil, err
+	}
+
+	s.logger.Debug("Listed reroute worklist", "count", len(tasks))
+	return tasks, nil
+}
+
+// assign reroutes the cargo onto the itinerary and takes it off the worklist
+func (s *RerouteApplicationService) assign(cargo *bookingdomain.Cargo, itinerary bookingdomain.Itinerary) error {
+	trackingId := cargo.GetTrackingId()
+
+	if err := cargo.AssignToRoute(itinerary); err != nil {
+		s.logger.Error("Failed to assign reroute", "trackingId", trackingId, "error", err)
+		return err
+	}
+
+	// A concurrent change fails the update; the event is then delivered again and the cargo re-examined
+	if err := updateCargo(s.cargoRepo, cargo); err != nil {
+		s.logger.Error("Failed to update cargo", "trackingId", trackingId, "error", err)
+		return err
+	}
+
+	if err := s.worklist.Remove(trackingId); err != nil {
+		s.logger.Error("Failed to take cargo off reroute worklist", "trackingId", trackingId, "error", err)
+		return err
+	}
+
+	s.logger.Info("Misdirected cargo rerouted automatically", "trackingId", trackingId)
+	return nil
+}
@@ -0,0 +1,219 @@
+package bookingapplication
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go_hex/internal/booking/bookingdomain"
+	"log/slog"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockRerouteWorklist struct {
+	mock.Mock
+}
+
+func (m *MockRerouteWorklist) Put(task bookingdomain.RerouteTask) error {
+	args := m.Called(task)
+	return args.Error(0)
+}
+
+func (m *MockRerouteWorklist) Remove(trackingId bookingdomain.TrackingId) error {
+	args := m.Called(trackingId)
+	return args.Error(0)
+}
+
+func (m *MockRerouteWorklist) FindAll() ([]bookingdomain.RerouteTask, error) {
+	args := m.Called()
+	return args.Get(0).([]bookingdomain.RerouteTask), args.Error(1)
+}
+
+func TestRerouteApplicationService_RerouteMisdirectedCargo(t *testing.T) {
+	setup := func(policy ReroutePolicy) (*RerouteApplicationService, *MockCargoRepository, *MockRoutingService, *MockRerouteWorklist) {
+		cargoRepo := &MockCargoRepository{}
+		routingService := &MockRoutingService{}
+		worklist := &MockRerouteWorklist{}
+
+		service := NewRerouteApplicationService(cargoRepo, routingService, worklist, policy, bookingdomain.RankingCriteria{}, slog.Default())
+
+		return service, cargoRepo, routingService, worklist
+	}
+	rerouteSpec := func(cargo bookingdomain.Cargo) bookingdomain.RouteSpecification {
+		return bookingdomain.RouteSpecification{
+			Origin:            "NLRTM",
+			Destination:       "DEHAM",
+			ArrivalDeadline:   cargo.GetRouteSpecification().ArrivalDeadline,
+			EarliestDeparture: cargo.GetDelivery().LastEventTime,
+		}
+	}
+
+	t.Run("should put misdirected cargo on the worklist with its route candidates", func(t *testing.T) {
+		service, cargoRepo, routingService, worklist := setup(ReroutePolicyWorklist)
+		cargo := createMisdirectedCargo(t, true)
+		candidate := createTestItinerary(t, rerouteSpec(cargo))
+
+		// Setup mocks
+		cargoRepo.On("FindByTrackingId", cargo.GetTrackingId()).Return(cargo, nil)
+		routingService.On("FindOptimalItineraries", mock.Anything, rerouteSpec(cargo), bookingdomain.RankingCriteria{}).
+			Return([]bookingdomain.Itinerary{candidate}, nil)
+		worklist.On("Put", mock.MatchedBy(func(task bookingdomain.RerouteTask) bool {
+			return task.TrackingId == cargo.GetTrackingId() &&
+				task.RouteSpecification == rerouteSpec(cargo) &&
+				len(task.Candidates) == 1
+		})).Return(nil)
+
+		// Execute
+		err := service.RerouteMisdirectedCargo(createContextWithClaims(t, nil), cargo.GetTrackingId())
+
+		// Verify
+		require.NoError(t, err)
+		worklist.AssertExpectations(t)
+		cargoRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("should assign the best route candidate with the auto-assign policy", func(t *testing.T) {
+		service, cargoRepo, routingService, worklist := setup(ReroutePolicyAutoAssign)
+		cargo := createMisdirectedCargo(t, true)
+		best := createTestItinerary(t, rerouteSpec(cargo))
+
+		// Setup mocks
+		cargoRepo.On("FindByTrackingId", cargo.GetTrackingId()).Return(cargo, nil)
+		routingService.On("FindOptimalItineraries", mock.Anything, rerouteSpec(cargo), bookingdomain.RankingCriteria{}).
+			Return([]bookingdomain.Itinerary{best}, nil)
+		cargoRepo.On("Update", mock.MatchedBy(func(c bookingdomain.Cargo) bool {
+			return c.GetDelivery().RoutingStatus == bookingdomain.RoutingStatusRouted &&
+				c.GetItinerary().Legs[0].LoadLocation == "NLRTM" &&
+				recordedEvent[bookingdomain.CargoRoutedEvent](c)
+		})).Return(nil)
+		worklist.On("Remove", cargo.GetTrackingId()).Return(nil)
+
+		// Execute
+		err := service.RerouteMisdirectedCargo(createContextWithClaims(t, nil), cargo.GetTrackingId())
+
+		// Verify
+		require.NoError(t, err)
+		cargoRepo.AssertExpectations(t)
+		worklist.AssertExpectations(t)
+		worklist.AssertNotCalled(t, "Put", mock.Anything)
+	})
+
+	t.Run("should fall back to the worklist when no route reaches the destination", func(t *testing.T) {
+		service, cargoRepo, routingService, worklist := setup(ReroutePolicyAutoAssign)
+		cargo := createMisdirectedCargo(t, true)
+
+		// Setup mocks
+		cargoRepo.On("FindByTrackingId", cargo.GetTrackingId()).Return(cargo, nil)
+		routingService.On("FindOptimalItineraries", mock.Anything, rerouteSpec(cargo), bookingdomain.RankingCriteria{}).
+			Return([]bookingdomain.Itinerary{}, nil)
+		worklist.On("Put", mock.MatchedBy(func(task bookingdomain.RerouteTask) bool {
+			return len(task.Candidates) == 0
+		})).Return(nil)
+
+		// Execute
+		err := service.RerouteMisdirectedCargo(createContextWithClaims(t, nil), cargo.GetTrackingId())
+
+		// Verify
+		require.NoError(t, err)
+		worklist.AssertExpectations(t)
+		cargoRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("should wait for misdirected cargo on board a carrier to be unloaded", func(t *testing.T) {
+		service, cargoRepo, routingService, worklist := setup(ReroutePolicyWorklist)
+		cargo := createMisdirectedCargo(t, false)
+
+		// Setup mocks
+		cargoRepo.On("FindByTrackingId", cargo.GetTrackingId()).Return(cargo, nil)
+
+		// Execute
+		err := service.RerouteMisdirectedCargo(createContextWithClaims(t, nil), cargo.GetTrackingId())
+
+		// Verify
+		require.NoError(t, err)
+		routingService.AssertNotCalled(t, "FindOptimalItineraries", mock.Anything, mock.Anything, mock.Anything)
+		worklist.AssertNotCalled(t, "Put", mock.Anything)
+	})
+
+	t.Run("should take cargo that is no longer misdirected off the worklist", func(t *testing.T) {
+		service, cargoRepo, routingService, worklist := setup(ReroutePolicyWorklist)
+		cargo := createTestCargo(t)
+
+		// Setup mocks
+		cargoRepo.On("FindByTrackingId", cargo.GetTrackingId()).Return(cargo, nil)
+		worklist.On("Remove", cargo.GetTrackingId()).Return(nil)
+
+		// Execute
+		err := service.RerouteMisdirectedCargo(createContextWithClaims(t, nil), cargo.GetTrackingId())
+
+		// Verify
+		require.NoError(t, err)
+		worklist.AssertExpectations(t)
+		routingService.AssertNotCalled(t, "FindOptimalItineraries", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should fail with unauthorized context", func(t *testing.T) {
+		service, cargoRepo, _, _ := setup(ReroutePolicyWorklist)
+
+		// Execute
+		err := service.RerouteMisdirectedCargo(context.Background(), bookingdomain.NewTrackingId())
+
+		// Verify
+		assert.Error(t, err)
+		cargoRepo.AssertNotCalled(t, "FindByTrackingId", mock.Anything)
+	})
+}
+
+func TestRerouteApplicationService_ListRerouteWorklist(t *testing.T) {
+	t.Run("should list the worklist", func(t *testing.T) {
+		worklist := &MockRerouteWorklist{}
+		service := NewRerouteApplicationService(&MockCargoRepository{}, &MockRoutingService{}, worklist, ReroutePolicyWorklist, bookingdomain.RankingCriteria{}, slog.Default())
+		tasks := []bookingdomain.RerouteTask{bookingdomain.NewRerouteTask(bookingdomain.NewTrackingId(), bookingdomain.RouteSpecification{}, nil)}
+
+		// Setup mocks
+		worklist.On("FindAll").Return(tasks, nil)
+
+		// Execute
+		result, err := service.ListRerouteWorklist(createContextWithClaims(t, nil))
+
+		// Verify
+		require.NoError(t, err)
+		assert.Equal(t, tasks, result)
+	})
+
+	t.Run("should fail with unauthorized context", func(t *testing.T) {
+		worklist := &MockRerouteWorklist{}
+		service := NewRerouteApplicationService(&MockCargoRepository{}, &MockRoutingService{}, worklist, ReroutePolicyWorklist, bookingdomain.RankingCriteria{}, slog.Default())
+
+		// Execute
+		_, err := service.ListRerouteWorklist(context.Background())
+
+		// Verify
+		assert.Error(t, err)
+		worklist.AssertNotCalled(t, "FindAll")
+	})
+}
+
+// createMisdirectedCargo books cargo from USNYC to DEHAM that was loaded onto the wrong voyage
+// and, when unloaded, left in NLRTM
+func createMisdirectedCargo(t *testing.T, unloaded bool) bookingdomain.Cargo {
+	cargo := createTestCargo(t)
+	require.NoError(t, cargo.AssignToRoute(createTestItinerary(t, cargo.GetRouteSpecification())))
+
+	now := time.Now()
+	history := []bookingdomain.HandlingEventSummary{
+		{Type: "RECEIVE", Location: "USNYC", Timestamp: now.Add(-3 * time.Hour)},
+		{Type: "LOAD", Location: "USNYC", VoyageNumber: "V999", Timestamp: now.Add(-2 * time.Hour)},
+	}
+	if unloaded {
+		history = append(history, bookingdomain.HandlingEventSummary{Type: "UNLOAD", Location: "NLRTM", VoyageNumber: "V999", Timestamp: now.Add(-time.Hour)})
+	}
+	require.NoError(t, cargo.DeriveDeliveryProgress(history))
+	require.True(t, cargo.GetDelivery().IsMisdirected())
+
+	cargo.ClearEvents()
+	return cargo
+}
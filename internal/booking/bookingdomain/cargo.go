@@ -3,6 +3,9 @@ package bookingdomain
 import (
 	"go_hex/internal/support/basedomain"
 	"go_hex/internal/support/validation"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -13,6 +16,11 @@ type Cargo struct {
 
 	// The cargo's data and current state
 	Data CargoData `json:"data"`
+
+	// schedules are the current times of the itinerary's legs on their voyages, one per leg; nil until
+	// FollowVoyageSchedules is called, and again once the itinerary is replaced. They are not stored, as voyages may be
+	// rescheduled at any time.
+	schedules []LegSchedule
 }
 
 // CargoData represents the value object containing cargo's business data
@@ -20,6 +28,9 @@ type CargoData struct {
 	RouteSpecification RouteSpecification `json:"route_specification"`
 	Itinerary          *Itinerary         `json:"itinerary,omitempty"` // nil if not yet routed
 	Delivery           Delivery           `json:"delivery"`
+
+	// RoutedAt is when the current itinerary was assigned; zero while not routed, or when the time is not known
+	RoutedAt time.Time `json:"routed_at,omitempty"`
 }
 
 // NewCargo creates a new Cargo aggregate with the specified route specification
@@ -52,20 +63,30 @@ func NewCargo(origin, destination string, arrivalDeadline time.Time) (Cargo, err
 	return cargo, nil
 }
 
-// NewCargoFromExisting creates a cargo from existing data (for repository loading)
-func NewCargoFromExisting(trackingId TrackingId, routeSpec RouteSpecification, itinerary *Itinerary, delivery Delivery) (Cargo, error) {
+// NewCargoFromExisting creates a cargo from existing data (for repository loading); the booking and routing
+// times are those recorded when the cargo was booked and routed, and zero where they are not known
+func NewCargoFromExisting(trackingId TrackingId, routeSpec RouteSpecification, itinerary *Itinerary, delivery Delivery, bookedAt, routedAt time.Time) (Cargo, error) {
+	// The next expected activity and the estimated time of arrival follow from the stored state, so they are not persisted
+	delivery.NextExpectedActivity = delivery.nextExpectedActivity(itinerary)
+	delivery.EstimatedTimeOfArrival = delivery.estimatedTimeOfArrival(itinerary)
+
 	data := CargoData{
 		RouteSpecification: routeSpec,
 		Itinerary:          itinerary,
 		Delivery:           delivery,
+		RoutedAt:           routedAt,
 	}
 
 	if err := validation.Validate(data); err != nil {
 		return Cargo{}, NewDomainValidationError("cargo data validation failed", err)
 	}
 
+	// The entity was created when the cargo was booked, not when it is loaded
+	entity := basedomain.NewBaseEntity(trackingId)
+	entity.CreatedAt = bookedAt
+
 	return Cargo{
-		BaseEntity: basedomain.NewBaseEntity(trackingId),
+		BaseEntity: entity,
 		Data:       data,
 	}, nil
 }
@@ -75,7 +96,7 @@ func (c Cargo) GetTrackingId() TrackingId {
 	return c.Id
 }
 
-// GetRouteSpecification returns the customer's original routing requirement
+// GetRouteSpecification returns the customer's current routing requirement
 func (c Cargo) GetRouteSpecification() RouteSpecification {
 	return c.Data.RouteSpecification
 }
@@ -90,6 +111,16 @@ func (c Cargo) GetDelivery() Delivery {
 	return c.Data.Delivery
 }
 
+// GetBookedAt returns when the cargo was booked; zero when the time is not known
+func (c Cargo) GetBookedAt() time.Time {
+	return c.CreatedAt
+}
+
+// GetRoutedAt returns when the current itinerary was assigned; zero while not routed, or when the time is not known
+func (c Cargo) GetRoutedAt() time.Time {
+	return c.Data.RoutedAt
+}
+
 // IsRouted checks if the cargo has been assigned an itinerary
 func (c Cargo) IsRouted() bool {
 	return c.Data.Itinerary != nil
@@ -97,14 +128,29 @@ func (c Cargo) IsRouted() bool {
 
 // AssignToRoute assigns an itinerary to the cargo
 func (c *Cargo) AssignToRoute(itinerary Itinerary) error {
+	// Cancelled bookings are never shipped
+	if c.Data.Delivery.IsCancelled() {
+		return NewDomainValidationError("cannot assign route to cancelled cargo", nil)
+	}
+
 	// Cannot reassign route if already delivered
 	if c.Data.Delivery.IsDelivered() {
 		return NewDomainValidationError("cannot reassign route to already delivered cargo", nil)
 	}
 
-	// Validate that the itinerary satisfies the route specification
-	if !itinerary.SatisfiesSpecification(c.Data.RouteSpecification) {
-		return NewDomainValidationError("itinerary does not satisfy route specification", nil)
+	// Cargo under way is routed on from where it is, and the new legs are spliced onto the legs it has completed.
+	// Cargo that is on track may still be given a whole new itinerary from its origin.
+	if c.Data.Delivery.IsMisdirected() || !itinerary.SatisfiesSpecification(c.Data.RouteSpecification) {
+		routeSpec, err := c.RemainingRouteSpecification()
+		if err != nil {
+			return err
+		}
+
+		if !itinerary.SatisfiesSpecification(routeSpec) {
+			return NewDomainValidationError("itinerary does not satisfy route specification", nil)
+		}
+
+		itinerary = c.spliceOntoCompletedLegs(itinerary)
 	}
 
 	// Check if itinerary arrival deadline would be missed
@@ -113,6 +159,8 @@ func (c *Cargo) AssignToRoute(itinerary Itinerary) error {
 	}
 
 	c.Data.Itinerary = &itinerary
+	c.Data.RoutedAt = time.Now()
+	c.schedules = nil // The legs come from the current schedules
 
 	// Update routing status
 	newDelivery, err := NewDelivery(
@@ -125,8 +173,9 @@ func (c *Cargo) AssignToRoute(itinerary Itinerary) error {
 	if err != nil {
 		return err
 	}
+	newDelivery.LastEventTime = c.Data.Delivery.LastEventTime
 
-	c.Data.Delivery = newDelivery
+	c.setDelivery(newDelivery)
 
 	// Raise domain event for route assignment
 	c.AddEvent(NewCargoRoutedEvent(c.Id, itinerary))
@@ -134,15 +183,107 @@ func (c *Cargo) AssignToRoute(itinerary Itinerary) error {
 	return nil
 }
 
-// DeriveDeliveryProgress updates delivery status based on handling history
-// This is called when handling events are received
+// RemainingRouteSpecification returns what a route must satisfy from where the cargo is now. Until the cargo is
+// received that is the route specification itself; afterwards routes lead from the port it was last handled in,
+// departing no earlier than that handling. Cargo on board is routed on from where its current leg ends.
+func (c Cargo) RemainingRouteSpecification() (RouteSpecification, error) {
+	if !c.CanBeRerouted() {
+		return RouteSpecification{}, NewDomainValidationError("cannot route delivered, claimed or cancelled cargo", nil)
+	}
+
+	delivery := c.Data.Delivery
+	if !delivery.HasBeenReceived() {
+		return c.Data.RouteSpecification, nil
+	}
+
+	from, ready := delivery.LastKnownLocation, delivery.LastEventTime
+	if delivery.IsInTransit() {
+		leg, ok := c.currentLeg()
+		if !ok {
+			return RouteSpecification{}, NewDomainValidationError("cargo on board a voyage its itinerary does not plan can only be routed once it is in port", nil)
+		}
+		from, ready = leg.UnloadLocation, leg.UnloadTime
+	}
+
+	if from == c.Data.RouteSpecification.Destination {
+		return RouteSpecification{}, NewDomainValidationError("cargo is already at or bound for its destination", nil)
+	}
+
+	return RouteSpecification{
+		Origin:            from,
+		Destination:       c.Data.RouteSpecification.Destination,
+		ArrivalDeadline:   c.Data.RouteSpecification.ArrivalDeadline,
+		EarliestDeparture: ready,
+	}, nil
+}
+
+// RerouteSpecification returns what a new route for misdirected cargo must satisfy: to leave from the port
+// the cargo is in and reach its destination by the arrival deadline
+// Cargo on board a carrier cannot be rerouted until it is unloaded. Cargo that has not been received yet, misdirected
+// by a change of voyage schedules, is routed from its origin.
+func (c Cargo) RerouteSpecification() (RouteSpecification, error) {
+	delivery := c.Data.Delivery
+	if !delivery.IsMisdirected() {
+		return RouteSpecification{}, NewDomainValidationError("only misdirected cargo needs rerouting", nil)
+	}
+	if delivery.HasBeenReceived() && !delivery.IsAtPort() {
+		return RouteSpecification{}, NewDomainValidationError("misdirected cargo can only be rerouted once it is in port", nil)
+	}
+
+	// The deadline may have passed already; no route will then satisfy the specification
+	return c.RemainingRouteSpecification()
+}
+
+// currentLeg returns the itinerary leg the cargo is on board of
+func (c Cargo) currentLeg() (Leg, bool) {
+	if c.Data.Itinerary == nil {
+		return Leg{}, false
+	}
+	current := c.Data.Delivery.currentLeg(c.Data.Itinerary.Legs)
+	if current < 0 {
+		return Leg{}, false
+	}
+	return c.Data.Itinerary.Legs[current], true
+}
+
+// spliceOntoCompletedLegs prefixes an itinerary that starts where the cargo is with the legs of the current
+// itinerary up to the last one the cargo was handled on, so an itinerary calling at the port more than once keeps
+// every leg the cargo has travelled. Misdirected cargo left its itinerary, so none of those legs are kept.
+func (c Cargo) spliceOntoCompletedLegs(itinerary Itinerary) Itinerary {
+	if c.Data.Itinerary == nil || c.Data.Delivery.IsMisdirected() {
+		return itinerary
+	}
+
+	last := c.Data.Delivery.lastHandledLeg(c.Data.Itinerary.Legs)
+	if last < 0 || c.Data.Itinerary.Legs[last].UnloadLocation != itinerary.Legs[0].LoadLocation {
+		return itinerary
+	}
+
+	legs := make([]Leg, 0, last+1+len(itinerary.Legs))
+	legs = append(legs, c.Data.Itinerary.Legs[:last+1]...)
+	return Itinerary{Legs: append(legs, itinerary.Legs...)}
+}
+
+// DeriveDeliveryProgress updates delivery status based on the complete handling history
+// This is called when handling events are received. Events are replayed in completion-time
+// order, so a late report of an earlier event cannot overwrite a more recent state.
 func (c *Cargo) DeriveDeliveryProgress(handlingHistory []HandlingEventSummary) error {
 	if len(handlingHistory) == 0 {
 		return nil // No changes needed
 	}
 
-	// Get the most recent handling event
-	lastEvent := handlingHistory[len(handlingHistory)-1]
+	// Handling of a cancelled booking must not revive it
+	if c.Data.Delivery.IsCancelled() {
+		return NewDomainValidationError("cannot update delivery progress of cancelled cargo", nil)
+	}
+
+	// Get the most recent handling event by completion time
+	ordered := make([]HandlingEventSummary, len(handlingHistory))
+	copy(ordered, handlingHistory)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Timestamp.Before(ordered[j].Timestamp)
+	})
+	lastEvent := ordered[len(ordered)-1]
 
 	// Calculate new transport status based on the latest event
 	transportStatus := c.calculateTransportStatus(lastEvent)
@@ -161,11 +302,119 @@ func (c *Cargo) DeriveDeliveryProgress(handlingHistory []HandlingEventSummary) e
 	if err != nil {
 		return err
 	}
+	newDelivery.LastEventTime = lastEvent.Timestamp
+
+	// Cargo that can no longer make its connections on the current voyage schedules is off its itinerary as well
+	if newDelivery.IsOnTrack() && c.missesConnection(newDelivery) {
+		newDelivery.RoutingStatus = RoutingStatusMisdirected
+	}
 
-	c.Data.Delivery = newDelivery
+	c.setDelivery(newDelivery)
 
 	// Raise domain event for delivery progress update
-	c.AddEvent(NewCargoDeliveryUpdatedEvent(c.Id, newDelivery))
+	c.AddEvent(NewCargoDeliveryUpdatedEvent(c.Id, c.Data.Delivery, c.Data.RouteSpecification.ArrivalDeadline))
+
+	return nil
+}
+
+// ChangeDestination redirects the cargo to a new destination, keeping origin and arrival deadline
+func (c *Cargo) ChangeDestination(destination string) error {
+	routeSpec, err := NewRouteSpecification(c.Data.RouteSpecification.Origin, destination, c.Data.RouteSpecification.ArrivalDeadline)
+	if err != nil {
+		return err
+	}
+	return c.SpecifyNewRoute(routeSpec)
+}
+
+// ChangeArrivalDeadline moves the arrival deadline, keeping origin and destination
+func (c *Cargo) ChangeArrivalDeadline(arrivalDeadline time.Time) error {
+	routeSpec, err := NewRouteSpecification(c.Data.RouteSpecification.Origin, c.Data.RouteSpecification.Destination, arrivalDeadline)
+	if err != nil {
+		return err
+	}
+	return c.SpecifyNewRoute(routeSpec)
+}
+
+// SpecifyNewRoute replaces the route specification and re-evaluates the assigned itinerary against it
+// The cargo becomes misdirected when its current itinerary no longer satisfies the new specification.
+func (c *Cargo) SpecifyNewRoute(routeSpec RouteSpecification) error {
+	if !c.CanBeRerouted() {
+		return NewDomainValidationError("cannot change route specification of delivered, claimed or cancelled cargo", nil)
+	}
+
+	// The cargo is already booked from its origin
+	if routeSpec.Origin != c.Data.RouteSpecification.Origin {
+		return NewDomainValidationError("origin of booked cargo cannot be changed", nil)
+	}
+
+	if routeSpec.Equals(c.Data.RouteSpecification) {
+		return nil // No changes needed
+	}
+
+	previousSpec := c.Data.RouteSpecification
+
+	routingStatus := c.Data.Delivery.RoutingStatus
+	if c.Data.Itinerary != nil && !c.Data.Itinerary.SatisfiesSpecification(routeSpec) {
+		routingStatus = RoutingStatusMisdirected
+	}
+
+	// Unloading at the previous destination does not count for a new one
+	isUnloadedAtDest := c.Data.Delivery.IsUnloadedAtDest &&
+		routeSpec.Destination == previousSpec.Destination
+
+	newDelivery, err := NewDelivery(
+		c.Data.Delivery.TransportStatus,
+		routingStatus,
+		c.Data.Delivery.LastKnownLocation,
+		c.Data.Delivery.CurrentVoyage,
+		isUnloadedAtDest,
+	)
+	if err != nil {
+		return err
+	}
+	newDelivery.LastEventTime = c.Data.Delivery.LastEventTime
+
+	c.Data.RouteSpecification = routeSpec
+	c.setDelivery(newDelivery)
+
+	// Raise domain event for route specification change
+	c.AddEvent(NewCargoRouteSpecificationChangedEvent(c.Id, previousSpec, routeSpec, routingStatus))
+
+	return nil
+}
+
+// Cancel withdraws the booking before the cargo has been loaded onto a carrier
+func (c *Cargo) Cancel(reason string) error {
+	if c.Data.Delivery.IsCancelled() {
+		return NewDomainValidationError("cargo is already cancelled", nil)
+	}
+
+	if !c.CanBeCancelled() {
+		return NewDomainValidationError("cannot cancel cargo that has been loaded or claimed", nil)
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return NewDomainValidationError("cancellation reason is required", nil)
+	}
+
+	// Keep the last known whereabouts so the cargo can still be located for return
+	newDelivery, err := NewDelivery(
+		TransportStatusCancelled,
+		c.Data.Delivery.RoutingStatus,
+		c.Data.Delivery.LastKnownLocation,
+		c.Data.Delivery.CurrentVoyage,
+		c.Data.Delivery.IsUnloadedAtDest,
+	)
+	if err != nil {
+		return err
+	}
+	newDelivery.LastEventTime = c.Data.Delivery.LastEventTime
+
+	c.setDelivery(newDelivery)
+
+	// Raise domain event for cancellation
+	c.AddEvent(NewCargoCancelledEvent(c.Id, reason))
 
 	return nil
 }
@@ -192,8 +441,8 @@ func (c *Cargo) calculateRoutingStatus(lastEvent HandlingEventSummary) RoutingSt
 		return RoutingStatusNotRouted
 	}
 
-	// Check if the event location and voyage match the expected itinerary
-	if c.Data.Itinerary.IsOnTrack(lastEvent.Location, lastEvent.VoyageNumber) {
+	// Check if the itinerary plans the event
+	if c.Data.Itinerary.IsExpected(lastEvent) {
 		return RoutingStatusRouted
 	}
 
@@ -206,12 +455,133 @@ func (c *Cargo) isUnloadedAtDestination(lastEvent HandlingEventSummary) bool {
 		lastEvent.Location == c.Data.RouteSpecification.Destination
 }
 
+// setDelivery replaces the delivery status, deriving the next expected activity from the current itinerary and the
+// estimated time of arrival from its schedule
+func (c *Cargo) setDelivery(delivery Delivery) {
+	delivery.NextExpectedActivity = delivery.nextExpectedActivity(c.Data.Itinerary)
+	delivery.EstimatedTimeOfArrival = delivery.estimatedTimeOfArrival(c.scheduledItinerary())
+	c.Data.Delivery = delivery
+}
+
+// FollowVoyageSchedules re-estimates the time of arrival from the current schedules of the itinerary's voyages,
+// given for each leg in turn. A rescheduled leg is expected at its new times; a leg whose voyage no longer calls at
+// its ports keeps its planned times. The estimate follows the schedules until the itinerary is replaced.
+// Fails with DomainValidationError if the cargo is not routed or the schedules do not match its legs.
+func (c *Cargo) FollowVoyageSchedules(schedules []LegSchedule) error {
+	if c.Data.Itinerary == nil {
+		return NewDomainValidationError("cannot follow voyage schedules of cargo that is not routed", nil)
+	}
+	if len(schedules) != len(c.Data.Itinerary.Legs) {
+		return NewDomainValidationError("voyage schedules do not match the legs of the itinerary", nil)
+	}
+
+	c.schedules = schedules
+	c.Data.Delivery.EstimatedTimeOfArrival = c.Data.Delivery.estimatedTimeOfArrival(c.scheduledItinerary())
+	return nil
+}
+
+// ReviewVoyageSchedules marks cargo misdirected when the legs still ahead of it no longer connect on the voyage
+// schedules it follows, so that it is rerouted, and reports whether it did. Cargo that connects again is found
+// back on track by its next handling.
+func (c *Cargo) ReviewVoyageSchedules() (bool, error) {
+	if !c.Data.Delivery.IsOnTrack() || !c.missesConnection(c.Data.Delivery) {
+		return false, nil
+	}
+
+	newDelivery, err := NewDelivery(
+		c.Data.Delivery.TransportStatus,
+		RoutingStatusMisdirected,
+		c.Data.Delivery.LastKnownLocation,
+		c.Data.Delivery.CurrentVoyage,
+		c.Data.Delivery.IsUnloadedAtDest,
+	)
+	if err != nil {
+		return false, err
+	}
+	newDelivery.LastEventTime = c.Data.Delivery.LastEventTime
+
+	c.setDelivery(newDelivery)
+
+	// Raise domain event for the changed routing status
+	c.AddEvent(NewCargoDeliveryUpdatedEvent(c.Id, c.Data.Delivery, c.Data.RouteSpecification.ArrivalDeadline))
+
+	return true, nil
+}
+
+// scheduledItinerary returns the itinerary at the current times of its voyages when they are known, and as planned
+// otherwise; nil while the cargo is not routed
+func (c Cargo) scheduledItinerary() *Itinerary {
+	if c.Data.Itinerary == nil || c.schedules == nil {
+		return c.Data.Itinerary
+	}
+
+	legs := slices.Clone(c.Data.Itinerary.Legs)
+	for i, schedule := range c.schedules {
+		if !schedule.Cancelled {
+			legs[i].LoadTime, legs[i].UnloadTime = schedule.LoadTime, schedule.UnloadTime
+		}
+	}
+	return &Itinerary{Legs: legs}
+}
+
+// missesConnection reports whether the legs still ahead of cargo in the given delivery state no longer connect on the
+// voyage schedules it follows: the voyage of one of them no longer calls at its ports, or arrives after the voyage of
+// the next leg departs
+func (c Cargo) missesConnection(delivery Delivery) bool {
+	if c.schedules == nil {
+		return false
+	}
+
+	legs := c.scheduledItinerary().Legs
+	var ahead int
+	switch delivery.TransportStatus {
+	case TransportStatusNotReceived:
+		ahead = 0
+	case TransportStatusInPort:
+		if delivery.IsUnloadedAtDest {
+			return false
+		}
+		ahead = delivery.nextLeg(legs)
+	case TransportStatusOnboardCarrier:
+		ahead = delivery.currentLeg(legs)
+	default:
+		return false
+	}
+	if ahead < 0 {
+		return false
+	}
+
+	for i := ahead; i < len(legs); i++ {
+		if c.schedules[i].Cancelled {
+			return true
+		}
+		if i+1 < len(legs) && legs[i].UnloadTime.After(legs[i+1].LoadTime) {
+			return true
+		}
+	}
+	return false
+}
+
 // CanBeRerouted checks if cargo can be assigned a new route
 func (c Cargo) CanBeRerouted() bool {
 	return !c.Data.Delivery.IsDelivered() &&
+		!c.Data.Delivery.IsCancelled() &&
 		c.Data.Delivery.TransportStatus != TransportStatusClaimed
 }
 
+// CanBeCancelled checks if the cargo has not yet been loaded onto a carrier or claimed
+func (c Cargo) CanBeCancelled() bool {
+	switch c.Data.Delivery.TransportStatus {
+	case TransportStatusNotReceived:
+		return true
+	case TransportStatusInPort:
+		// Only a receipt at origin leaves the cargo in port without a voyage
+		return c.Data.Delivery.CurrentVoyage == ""
+	default:
+		return false
+	}
+}
+
 // IsReadyForPickup checks if cargo is ready for pickup at origin
 func (c Cargo) IsReadyForPickup() bool {
 	return c.IsRouted() &&
@@ -221,17 +591,41 @@ func (c Cargo) IsReadyForPickup() bool {
 
 // IsOverdue checks if cargo delivery is overdue
 func (c Cargo) IsOverdue() bool {
-	return time.Now().After(c.Data.RouteSpecification.ArrivalDeadline) &&
-		!c.Data.Delivery.IsDelivered()
+	return c.IsOverdueAt(time.Now())
 }
 
-// GetEstimatedTimeOfArrival returns the ETA based on current itinerary
-func (c Cargo) GetEstimatedTimeOfArrival() *time.Time {
-	if c.Data.Itinerary == nil {
-		return nil
+// IsOverdueAt checks if the cargo is past its arrival deadline at the given time without having been delivered
+func (c Cargo) IsOverdueAt(now time.Time) bool {
+	return now.After(c.Data.RouteSpecification.ArrivalDeadline) &&
+		!c.Data.Delivery.IsDelivered() &&
+		!c.Data.Delivery.IsCancelled()
+}
+
+// IsScheduledOnVoyage checks if the cargo is still to be delivered along an itinerary with a leg on the voyage
+func (c Cargo) IsScheduledOnVoyage(voyageNumber string) bool {
+	if c.Data.Itinerary == nil || c.Data.Delivery.IsDelivered() || c.Data.Delivery.IsCancelled() {
+		return false
 	}
-	eta := c.Data.Itinerary.FinalArrivalTime()
-	return &eta
+	return slices.ContainsFunc(c.Data.Itinerary.Legs, func(leg Leg) bool {
+		return leg.VoyageNumber == voyageNumber
+	})
+}
+
+// GetEstimatedTimeOfArrival returns the ETA from the handling progress along the itinerary (nil if none can be estimated)
+func (c Cargo) GetEstimatedTimeOfArrival() *time.Time {
+	return c.Data.Delivery.EstimatedTimeOfArrival
+}
+
+// GetArrivalSlack returns how long before its arrival deadline the cargo is estimated to arrive;
+// negative when it is estimated to be late, and false when no arrival can be estimated
+func (c Cargo) GetArrivalSlack() (time.Duration, bool) {
+	return c.Data.Delivery.arrivalSlack(c.Data.RouteSpecification.ArrivalDeadline)
+}
+
+// IsLate checks if the cargo is estimated to arrive after its arrival deadline
+func (c Cargo) IsLate() bool {
+	slack, ok := c.GetArrivalSlack()
+	return ok && slack < 0
 }
 
 // HandlingEventSummary represents key data from a handling event
@@ -0,0 +1,265 @@
+package bookingdomain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CargoSortField names the cargo attribute a cargo listing is ordered by
+type CargoSortField string
+
+const (
+	CargoSortByTrackingId      CargoSortField = "tracking_id"
+	CargoSortByArrivalDeadline CargoSortField = "arrival_deadline"
+	CargoSortByOrigin          CargoSortField = "origin"
+	CargoSortByDestination     CargoSortField = "destination"
+)
+
+// SortOrder is the direction of a sorted listing
+type SortOrder string
+
+const (
+	SortAscending  SortOrder = "asc"
+	SortDescending SortOrder = "desc"
+)
+
+const (
+	// DefaultCargoPageSize is the number of cargo returned when the query does not ask for a specific number
+	DefaultCargoPageSize = 50
+
+	// MaxCargoPageSize bounds the number of cargo returned in one page
+	MaxCargoPageSize = 200
+)
+
+// cursorTimeFormat is fixed-width so deadlines in cursors compare in the same order as the times they encode
+const cursorTimeFormat = "2006-01-02T15:04:05.000000000Z07:00"
+
+// CargoQuery selects a page of booked cargo. Empty filters match every cargo; the filters that are set must all match.
+// Cargo with equal sort values are ordered by tracking ID, so paging through a listing never skips or repeats one.
+type CargoQuery struct {
+	RoutingStatus   RoutingStatus
+	TransportStatus TransportStatus
+	Origin          string
+	Destination     string
+
+	// DeadlineFrom and DeadlineTo bound the arrival deadline inclusively; a zero time leaves that side open
+	DeadlineFrom time.Time
+	DeadlineTo   time.Time
+
+	// Overdue keeps only cargo past its arrival deadline at AsOf that is neither delivered nor cancelled
+	Overdue bool
+	AsOf    time.Time
+
+	// OnVoyage keeps only cargo that is neither delivered nor cancelled and has a leg on the voyage in its itinerary
+	OnVoyage string
+
+	SortBy CargoSortField
+	Order  SortOrder
+
+	// Cursor is the NextCursor of the previous page; empty starts at the first page
+	Cursor string
+	Limit  int
+}
+
+// CargoPage is one page of a cargo listing
+type CargoPage struct {
+	Cargos []Cargo
+
+	// NextCursor continues the listing after this page; empty when there are no more cargo
+	NextCursor string
+}
+
+// CargoCursor is the position of the last cargo of a page in the order of the query it was listed by
+type CargoCursor struct {
+	// SortValue is the value of the sort field; empty when sorting by tracking ID
+	SortValue string
+
+	// Deadline is the parsed SortValue when sorting by arrival deadline
+	Deadline time.Time
+
+	TrackingId string
+}
+
+// cursorData is the encoded form of a CargoCursor
+type cursorData struct {
+	SortBy     CargoSortField `json:"s"`
+	Order      SortOrder      `json:"o"`
+	SortValue  string         `json:"v,omitempty"`
+	TrackingId string         `json:"id"`
+}
+
+// Normalize validates the query and fills in the default sort order, page size and reference time
+func (q CargoQuery) Normalize() (CargoQuery, error) {
+	switch q.RoutingStatus {
+	case "", RoutingStatusNotRouted, RoutingStatusRouted, RoutingStatusMisdirected:
+	default:
+		return CargoQuery{}, NewDomainValidationError(fmt.Sprintf("unknown routing status %q", q.RoutingStatus), nil)
+	}
+
+	switch q.TransportStatus {
+	case "", TransportStatusNotReceived, TransportStatusInPort, TransportStatusOnboardCarrier,
+		TransportStatusClaimed, TransportStatusCancelled, TransportStatusUnknown:
+	default:
+		return CargoQuery{}, NewDomainValidationError(fmt.Sprintf("unknown transport status %q", q.TransportStatus), nil)
+	}
+
+	switch q.SortBy {
+	case "":
+		q.SortBy = CargoSortByTrackingId
+	case CargoSortByTrackingId, CargoSortByArrivalDeadline, CargoSortByOrigin, CargoSortByDestination:
+	default:
+		return CargoQuery{}, NewDomainValidationError(fmt.Sprintf("cannot sort cargo by %q", q.SortBy), nil)
+	}
+
+	switch q.Order {
+	case "":
+		q.Order = SortAscending
+	case SortAscending, SortDescending:
+	default:
+		return CargoQuery{}, NewDomainValidationError(fmt.Sprintf("unknown sort order %q", q.Order), nil)
+	}
+
+	if q.Limit < 0 || q.Limit > MaxCargoPageSize {
+		return CargoQuery{}, NewDomainValidationError(fmt.Sprintf("limit must be between 1 and %d", MaxCargoPageSize), nil)
+	}
+	if q.Limit == 0 {
+		q.Limit = DefaultCargoPageSize
+	}
+
+	if !q.DeadlineFrom.IsZero() && !q.DeadlineTo.IsZero() && q.DeadlineFrom.After(q.DeadlineTo) {
+		return CargoQuery{}, NewDomainValidationError("deadline range starts after it ends", nil)
+	}
+
+	if q.AsOf.IsZero() {
+		q.AsOf = time.Now()
+	}
+
+	if _, _, err := q.DecodeCursor(); err != nil {
+		return CargoQuery{}, err
+	}
+
+	return q, nil
+}
+
+// Matches reports whether the cargo passes every filter of the query
+func (q CargoQuery) Matches(cargo Cargo) bool {
+	routeSpec := cargo.GetRouteSpecification()
+	delivery := cargo.GetDelivery()
+
+	switch {
+	case q.RoutingStatus != "" && delivery.RoutingStatus != q.RoutingStatus:
+		return false
+	case q.TransportStatus != "" && delivery.TransportStatus != q.TransportStatus:
+		return false
+	case q.Origin != "" && routeSpec.Origin != q.Origin:
+		return false
+	case q.Destination != "" && routeSpec.Destination != q.Destination:
+		return false
+	case !q.DeadlineFrom.IsZero() && routeSpec.ArrivalDeadline.Before(q.DeadlineFrom):
+		return false
+	case !q.DeadlineTo.IsZero() && routeSpec.ArrivalDeadline.After(q.DeadlineTo):
+		return false
+	case q.Overdue && !cargo.IsOverdueAt(q.AsOf):
+		return false
+	case q.OnVoyage != "" && !cargo.IsScheduledOnVoyage(q.OnVoyage):
+		return false
+	}
+	return true
+}
+
+// Less reports whether cargo a comes before cargo b in the order of the query
+func (q CargoQuery) Less(a, b Cargo) bool {
+	return q.compare(q.SortValue(a), a.GetTrackingId().String(), q.SortValue(b), b.GetTrackingId().String()) < 0
+}
+
+// IsAfter reports whether the cargo comes after the cursor position in the order of the query
+func (q CargoQuery) IsAfter(cargo Cargo, cursor CargoCursor) bool {
+	return q.compare(q.SortValue(cargo), cargo.GetTrackingId().String(), cursor.SortValue, cursor.TrackingId) > 0
+}
+
+// compare orders two positions by sort value, then tracking ID, in the direction of the query
+func (q CargoQuery) compare(valueA, idA, valueB, idB string) int {
+	result := strings.Compare(valueA, valueB)
+	if result == 0 {
+		result = strings.Compare(idA, idB)
+	}
+	if q.Order == SortDescending {
+		return -result
+	}
+	return result
+}
+
+// SortValue returns the value of the query's sort field for the cargo, in a form that orders like the field
+func (q CargoQuery) SortValue(cargo Cargo) string {
+	routeSpec := cargo.GetRouteSpecification()
+	switch q.SortBy {
+	case CargoSortByArrivalDeadline:
+		return routeSpec.ArrivalDeadline.UTC().Format(cursorTimeFormat)
+	case CargoSortByOrigin:
+		return routeSpec.Origin
+	case CargoSortByDestination:
+		return routeSpec.Destination
+	default:
+		return ""
+	}
+}
+
+// direction returns the order of the query, ascending unless set
+func (q CargoQuery) direction() SortOrder {
+	if q.Order == "" {
+		return SortAscending
+	}
+	return q.Order
+}
+
+// CursorAfter returns the cursor continuing the listing after the cargo
+func (q CargoQuery) CursorAfter(cargo Cargo) string {
+	data, _ := json.Marshal(cursorData{
+		SortBy:     q.SortBy,
+		Order:      q.direction(),
+		SortValue:  q.SortValue(cargo),
+		TrackingId: cargo.GetTrackingId().String(),
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor reads the query's cursor; found is false when the listing starts at the first page
+func (q CargoQuery) DecodeCursor() (cursor CargoCursor, found bool, err error) {
+	if q.Cursor == "" {
+		return CargoCursor{}, false, nil
+	}
+
+	invalid := NewDomainValidationError("invalid cursor", nil)
+
+	raw, err := base64.RawURLEncoding.DecodeString(q.Cursor)
+	if err != nil {
+		return CargoCursor{}, false, invalid
+	}
+	var data cursorData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return CargoCursor{}, false, invalid
+	}
+	if _, err := TrackingIdFromString(data.TrackingId); err != nil {
+		return CargoCursor{}, false, invalid
+	}
+
+	sortBy := q.SortBy
+	if sortBy == "" {
+		sortBy = CargoSortByTrackingId
+	}
+	if data.SortBy != sortBy || data.Order != q.direction() {
+		return CargoCursor{}, false, NewDomainValidationError("cursor belongs to a listing with a different sort order", nil)
+	}
+
+	cursor = CargoCursor{SortValue: data.SortValue, TrackingId: data.TrackingId}
+	if sortBy == CargoSortByArrivalDeadline {
+		cursor.Deadline, err = time.Parse(cursorTimeFormat, data.SortValue)
+		if err != nil {
+			return CargoCursor{}, false, invalid
+		}
+	}
+	return cursor, true, nil
+}
@@ -0,0 +1,90 @@
+package bookingdomain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCargoQuery_Normalize(t *testing.T) {
+	t.Run("should fill in defaults", func(t *testing.T) {
+		query, err := CargoQuery{}.Normalize()
+
+		require.NoError(t, err)
+		assert.Equal(t, CargoSortByTrackingId, query.SortBy)
+		assert.Equal(t, SortAscending, query.Order)
+		assert.Equal(t, DefaultCargoPageSize, query.Limit)
+		assert.False(t, query.AsOf.IsZero())
+	})
+
+	t.Run("should reject invalid queries", func(t *testing.T) {
+		now := time.Now()
+		tests := map[string]CargoQuery{
+			"routing status":   {RoutingStatus: "LOST"},
+			"transport status": {TransportStatus: "FLYING"},
+			"sort field":       {SortBy: "weight"},
+			"sort order":       {Order: "sideways"},
+			"limit":            {Limit: MaxCargoPageSize + 1},
+			"deadline range":   {DeadlineFrom: now, DeadlineTo: now.Add(-time.Hour)},
+			"cursor":           {Cursor: "not-a-cursor"},
+		}
+		for name, query := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, err := query.Normalize()
+
+				var validationErr DomainValidationError
+				assert.ErrorAs(t, err, &validationErr)
+			})
+		}
+	})
+}
+
+func TestCargoQuery_Cursor(t *testing.T) {
+	t.Run("should continue after the cargo it was created for", func(t *testing.T) {
+		cargo, err := NewCargo("USNYC", "DEHAM", time.Now().Add(24*time.Hour))
+		require.NoError(t, err)
+		query := CargoQuery{SortBy: CargoSortByArrivalDeadline}
+
+		query.Cursor = query.CursorAfter(cargo)
+		cursor, found, err := query.DecodeCursor()
+
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, cargo.GetTrackingId().String(), cursor.TrackingId)
+		assert.True(t, cursor.Deadline.Equal(cargo.GetRouteSpecification().ArrivalDeadline))
+		assert.False(t, query.IsAfter(cargo, cursor))
+	})
+
+	t.Run("should reject cursor of another sort order", func(t *testing.T) {
+		cargo, err := NewCargo("USNYC", "DEHAM", time.Now().Add(24*time.Hour))
+		require.NoError(t, err)
+		cursor := CargoQuery{SortBy: CargoSortByOrigin}.CursorAfter(cargo)
+
+		_, _, err = CargoQuery{SortBy: CargoSortByDestination, Cursor: cursor}.DecodeCursor()
+
+		assert.ErrorContains(t, err, "different sort order")
+	})
+
+	t.Run("should reject cursor of the opposite direction", func(t *testing.T) {
+		cargo, err := NewCargo("USNYC", "DEHAM", time.Now().Add(24*time.Hour))
+		require.NoError(t, err)
+		cursor := CargoQuery{SortBy: CargoSortByOrigin, Order: SortDescending}.CursorAfter(cargo)
+
+		_, _, err = CargoQuery{SortBy: CargoSortByOrigin, Order: SortAscending, Cursor: cursor}.DecodeCursor()
+
+		assert.ErrorContains(t, err, "different sort order")
+	})
+}
+
+func TestCargoQuery_Matches(t *testing.T) {
+	t.Run("should match overdue cargo only after its deadline", func(t *testing.T) {
+		deadline := time.Now().Add(24 * time.Hour)
+		cargo, err := NewCargo("USNYC", "DEHAM", deadline)
+		require.NoError(t, err)
+
+		assert.False(t, CargoQuery{Overdue: true, AsOf: deadline.Add(-time.Minute)}.Matches(cargo))
+		assert.True(t, CargoQuery{Overdue: true, AsOf: deadline.Add(time.Minute)}.Matches(cargo))
+	})
+}
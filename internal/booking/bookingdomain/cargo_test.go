@@ -52,6 +52,7 @@ func TestCargo_AssignToRoute(t *testing.T) {
 	t.Run("should assign valid itinerary", func(t *testing.T) {
 		cargo := createTestCargo(t)
 		itinerary := createTestItinerary(t, cargo.GetRouteSpecification())
+		before := time.Now()
 
 		err := cargo.AssignToRoute(itinerary)
 
@@ -59,6 +60,7 @@ func TestCargo_AssignToRoute(t *testing.T) {
 		assert.True(t, cargo.IsRouted())
 		assert.Equal(t, RoutingStatusRouted, cargo.GetDelivery().RoutingStatus)
 		assert.NotNil(t, cargo.GetItinerary())
+		assert.False(t, cargo.GetRoutedAt().Before(before))
 	})
 
 	t.Run("should fail if itinerary doesn't satisfy specification", func(t *testing.T) {
@@ -141,6 +143,581 @@ func TestCargo_CanBeRerouted(t *testing.T) {
 	})
 }
 
+func TestCargo_RemainingRouteSpecification(t *testing.T) {
+	now := time.Now()
+	deadline := now.Add(30 * 24 * time.Hour)
+	setup := func(t *testing.T, history ...HandlingEventSummary) *Cargo {
+		cargo, err := NewCargo("USNYC", "SEGOT", deadline)
+		require.NoError(t, err)
+		first, err := NewLeg("V001", "USNYC", "DEHAM", now.Add(-2*time.Hour), now.Add(24*time.Hour))
+		require.NoError(t, err)
+		second, err := NewLeg("V002", "DEHAM", "SEGOT", now.Add(48*time.Hour), now.Add(72*time.Hour))
+		require.NoError(t, err)
+		require.NoError(t, cargo.AssignToRoute(Itinerary{Legs: []Leg{first, second}}))
+		if len(history) > 0 {
+			require.NoError(t, cargo.DeriveDeliveryProgress(history))
+		}
+		return &cargo
+	}
+	received := HandlingEventSummary{Type: "RECEIVE", Location: "USNYC", Timestamp: now.Add(-3 * time.Hour)}
+	loaded := HandlingEventSummary{Type: "LOAD", Location: "USNYC", VoyageNumber: "V001", Timestamp: now.Add(-2 * time.Hour)}
+	unloaded := HandlingEventSummary{Type: "UNLOAD", Location: "DEHAM", VoyageNumber: "V001", Timestamp: now.Add(-time.Hour)}
+
+	tests := map[string]struct {
+		history  []HandlingEventSummary
+		expected RouteSpecification
+	}{
+		"not received": {nil, RouteSpecification{Origin: "USNYC", Destination: "SEGOT", ArrivalDeadline: deadline}},
+		"received":     {[]HandlingEventSummary{received}, RouteSpecification{Origin: "USNYC", Destination: "SEGOT", ArrivalDeadline: deadline, EarliestDeparture: received.Timestamp}},
+		"on board":     {[]HandlingEventSummary{received, loaded}, RouteSpecification{Origin: "DEHAM", Destination: "SEGOT", ArrivalDeadline: deadline, EarliestDeparture: now.Add(24 * time.Hour)}},
+		"unloaded":     {[]HandlingEventSummary{received, loaded, unloaded}, RouteSpecification{Origin: "DEHAM", Destination: "SEGOT", ArrivalDeadline: deadline, EarliestDeparture: unloaded.Timestamp}},
+	}
+	for name, test := range tests {
+		t.Run("should route cargo "+name+" from where it is", func(t *testing.T) {
+			cargo := setup(t, test.history...)
+
+			routeSpec, err := cargo.RemainingRouteSpecification()
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, routeSpec)
+		})
+	}
+
+	t.Run("should not route cargo on board a voyage its itinerary does not plan", func(t *testing.T) {
+		cargo := setup(t, received, HandlingEventSummary{Type: "LOAD", Location: "USNYC", VoyageNumber: "V999", Timestamp: now.Add(-2 * time.Hour)})
+
+		_, err := cargo.RemainingRouteSpecification()
+
+		assert.ErrorContains(t, err, "once it is in port")
+	})
+
+	t.Run("should not route cargo that has been claimed", func(t *testing.T) {
+		cargo := setup(t, received, HandlingEventSummary{Type: "CLAIM", Location: "USNYC", Timestamp: now.Add(-time.Hour)})
+
+		_, err := cargo.RemainingRouteSpecification()
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should splice a route from the current port onto the completed legs", func(t *testing.T) {
+		cargo := setup(t, received, loaded, unloaded)
+		completed := cargo.GetItinerary().Legs[0]
+		onward, err := NewLeg("V003", "DEHAM", "SEGOT", now.Add(12*time.Hour), now.Add(36*time.Hour))
+		require.NoError(t, err)
+
+		err = cargo.AssignToRoute(Itinerary{Legs: []Leg{onward}})
+
+		require.NoError(t, err)
+		assert.Equal(t, []Leg{completed, onward}, cargo.GetItinerary().Legs)
+		assert.Equal(t, RoutingStatusRouted, cargo.GetDelivery().RoutingStatus)
+		assert.Equal(t, &HandlingActivity{Type: "LOAD", Location: "DEHAM", VoyageNumber: "V003"}, cargo.GetDelivery().NextExpectedActivity)
+	})
+
+	t.Run("should keep every travelled leg when the itinerary calls at the current port twice", func(t *testing.T) {
+		cargo := createTestCargo(t)
+		leg1 := createTestLeg(t, "V001", "USNYC", "DEHAM")
+		outbound := createTestLegAfter(t, leg1, "V002", "DEHAM", "NLRTM")
+		inbound := createTestLegAfter(t, outbound, "V003", "NLRTM", "DEHAM")
+		final := createTestLegAfter(t, inbound, "V004", "DEHAM", "SEGOT")
+		itinerary, err := NewItinerary([]Leg{leg1, outbound, inbound, final})
+		require.NoError(t, err)
+		require.NoError(t, cargo.AssignToRoute(itinerary))
+		require.NoError(t, cargo.DeriveDeliveryProgress([]HandlingEventSummary{
+			{Type: "UNLOAD", Location: "DEHAM", VoyageNumber: "V003", Timestamp: inbound.UnloadTime},
+		}))
+		onward := createTestLegAfter(t, inbound, "V005", "DEHAM", "SEGOT")
+
+		err = cargo.AssignToRoute(Itinerary{Legs: []Leg{onward}})
+
+		require.NoError(t, err)
+		assert.Equal(t, []Leg{leg1, outbound, inbound, onward}, cargo.GetItinerary().Legs)
+		assert.Equal(t, &HandlingActivity{Type: "LOAD", Location: "DEHAM", VoyageNumber: "V005"}, cargo.GetDelivery().NextExpectedActivity)
+	})
+
+	t.Run("should reject a route departing before the cargo is unloaded", func(t *testing.T) {
+		cargo := setup(t, received, loaded, unloaded)
+		tooEarly, err := NewLeg("V003", "DEHAM", "SEGOT", now.Add(-90*time.Minute), now.Add(36*time.Hour))
+		require.NoError(t, err)
+
+		err = cargo.AssignToRoute(Itinerary{Legs: []Leg{tooEarly}})
+
+		assert.ErrorContains(t, err, "itinerary does not satisfy route specification")
+	})
+}
+
+func TestCargo_RerouteSpecification(t *testing.T) {
+	setup := func(t *testing.T, history ...HandlingEventSummary) *Cargo {
+		cargo := createTestCargo(t)
+		require.NoError(t, cargo.AssignToRoute(createTestItinerary(t, cargo.GetRouteSpecification())))
+		require.NoError(t, cargo.DeriveDeliveryProgress(history))
+		return cargo
+	}
+	now := time.Now()
+	received := HandlingEventSummary{Type: "RECEIVE", Location: "USNYC", Timestamp: now.Add(-3 * time.Hour)}
+	wrongVoyage := HandlingEventSummary{Type: "LOAD", Location: "USNYC", VoyageNumber: "V999", Timestamp: now.Add(-2 * time.Hour)}
+	unloaded := HandlingEventSummary{Type: "UNLOAD", Location: "DEHAM", VoyageNumber: "V999", Timestamp: now.Add(-time.Hour)}
+
+	t.Run("should lead from the port misdirected cargo is in", func(t *testing.T) {
+		cargo := setup(t, received, wrongVoyage, unloaded)
+
+		routeSpec, err := cargo.RerouteSpecification()
+
+		require.NoError(t, err)
+		assert.Equal(t, "DEHAM", routeSpec.Origin)
+		assert.Equal(t, "SEGOT", routeSpec.Destination)
+		assert.Equal(t, cargo.GetRouteSpecification().ArrivalDeadline, routeSpec.ArrivalDeadline)
+	})
+
+	t.Run("should assign a route from the port misdirected cargo is in", func(t *testing.T) {
+		cargo := setup(t, received, wrongVoyage, unloaded)
+		routeSpec, err := cargo.RerouteSpecification()
+		require.NoError(t, err)
+
+		err = cargo.AssignToRoute(createTestItinerary(t, routeSpec))
+
+		require.NoError(t, err)
+		assert.Equal(t, RoutingStatusRouted, cargo.GetDelivery().RoutingStatus)
+		assert.Equal(t, "DEHAM", cargo.GetItinerary().Legs[0].LoadLocation)
+	})
+
+	t.Run("should not reroute misdirected cargo on board a carrier", func(t *testing.T) {
+		cargo := setup(t, received, wrongVoyage)
+
+		_, err := cargo.RerouteSpecification()
+
+		assert.ErrorContains(t, err, "once it is in port")
+	})
+
+	t.Run("should lead from the origin when misdirected cargo has not been received", func(t *testing.T) {
+		cargo := setup(t)
+		require.NoError(t, cargo.FollowVoyageSchedules([]LegSchedule{{Cancelled: true}}))
+		_, err := cargo.ReviewVoyageSchedules()
+		require.NoError(t, err)
+
+		routeSpec, err := cargo.RerouteSpecification()
+
+		require.NoError(t, err)
+		assert.Equal(t, cargo.GetRouteSpecification(), routeSpec)
+	})
+
+	t.Run("should not reroute cargo that follows its itinerary", func(t *testing.T) {
+		cargo := setup(t, received)
+
+		_, err := cargo.RerouteSpecification()
+
+		assert.ErrorContains(t, err, "only misdirected cargo")
+	})
+}
+
+func TestCargo_DeriveDeliveryProgress(t *testing.T) {
+	t.Run("should derive status from the most recent event by completion time", func(t *testing.T) {
+		cargo := createTestCargo(t)
+		require.NoError(t, cargo.AssignToRoute(createTestItinerary(t, cargo.GetRouteSpecification())))
+		now := time.Now()
+
+		// A late report of the RECEIVE arrives after the LOAD
+		err := cargo.DeriveDeliveryProgress([]HandlingEventSummary{
+			{Type: "LOAD", Location: "USNYC", VoyageNumber: "V001", Timestamp: now.Add(-time.Hour)},
+			{Type: "RECEIVE", Location: "USNYC", Timestamp: now.Add(-2 * time.Hour)},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, TransportStatusOnboardCarrier, cargo.GetDelivery().TransportStatus)
+		assert.Equal(t, "V001", cargo.GetDelivery().CurrentVoyage)
+		assert.Equal(t, RoutingStatusRouted, cargo.GetDelivery().RoutingStatus)
+	})
+
+	t.Run("should mark cargo unloaded at destination", func(t *testing.T) {
+		cargo := createTestCargo(t)
+		require.NoError(t, cargo.AssignToRoute(createTestItinerary(t, cargo.GetRouteSpecification())))
+		now := time.Now()
+
+		err := cargo.DeriveDeliveryProgress([]HandlingEventSummary{
+			{Type: "RECEIVE", Location: "USNYC", Timestamp: now.Add(-3 * time.Hour)},
+			{Type: "LOAD", Location: "USNYC", VoyageNumber: "V001", Timestamp: now.Add(-2 * time.Hour)},
+			{Type: "UNLOAD", Location: "SEGOT", VoyageNumber: "V001", Timestamp: now.Add(-time.Hour)},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, TransportStatusInPort, cargo.GetDelivery().TransportStatus)
+		assert.True(t, cargo.GetDelivery().IsUnloadedAtDest)
+	})
+}
+
+func TestCargo_ReviewVoyageSchedules(t *testing.T) {
+	leg1 := createTestLeg(t, "V001", "USNYC", "DEHAM")
+	leg2 := createTestLegAfter(t, leg1, "V002", "DEHAM", "SEGOT")
+	setup := func(t *testing.T) *Cargo {
+		cargo := createTestCargo(t)
+		itinerary, err := NewItinerary([]Leg{leg1, leg2})
+		require.NoError(t, err)
+		require.NoError(t, cargo.AssignToRoute(itinerary))
+		cargo.ClearEvents()
+		return cargo
+	}
+	onSchedule := LegSchedule{LoadTime: leg1.LoadTime, UnloadTime: leg1.UnloadTime}
+	delayed := LegSchedule{LoadTime: leg1.LoadTime.Add(6 * time.Hour), UnloadTime: leg1.UnloadTime.Add(6 * time.Hour)}
+	unloaded := HandlingEventSummary{Type: "UNLOAD", Location: "DEHAM", VoyageNumber: "V001", Timestamp: leg1.UnloadTime}
+
+	t.Run("should mark cargo misdirected when a voyage ahead no longer calls at its ports", func(t *testing.T) {
+		cargo := setup(t)
+		require.NoError(t, cargo.FollowVoyageSchedules([]LegSchedule{onSchedule, {Cancelled: true}}))
+
+		misdirected, err := cargo.ReviewVoyageSchedules()
+
+		require.NoError(t, err)
+		assert.True(t, misdirected)
+		assert.Equal(t, RoutingStatusMisdirected, cargo.GetDelivery().RoutingStatus)
+		require.Len(t, cargo.GetEvents(), 1)
+		assert.IsType(t, CargoDeliveryUpdatedEvent{}, cargo.GetEvents()[0])
+	})
+
+	t.Run("should mark cargo misdirected when it arrives after its next voyage departs", func(t *testing.T) {
+		cargo := setup(t)
+		require.NoError(t, cargo.FollowVoyageSchedules([]LegSchedule{delayed, {LoadTime: leg2.LoadTime, UnloadTime: leg2.UnloadTime}}))
+
+		misdirected, err := cargo.ReviewVoyageSchedules()
+
+		require.NoError(t, err)
+		assert.True(t, misdirected)
+		assert.Equal(t, RoutingStatusMisdirected, cargo.GetDelivery().RoutingStatus)
+	})
+
+	t.Run("should leave cargo on track while its connections hold", func(t *testing.T) {
+		cargo := setup(t)
+		require.NoError(t, cargo.FollowVoyageSchedules([]LegSchedule{
+			delayed,
+			{LoadTime: leg2.LoadTime.Add(6 * time.Hour), UnloadTime: leg2.UnloadTime.Add(6 * time.Hour)},
+		}))
+
+		misdirected, err := cargo.ReviewVoyageSchedules()
+
+		require.NoError(t, err)
+		assert.False(t, misdirected)
+		assert.Equal(t, RoutingStatusRouted, cargo.GetDelivery().RoutingStatus)
+		assert.Empty(t, cargo.GetEvents())
+	})
+
+	t.Run("should ignore the legs cargo has already completed", func(t *testing.T) {
+		cargo := setup(t)
+		require.NoError(t, cargo.DeriveDeliveryProgress([]HandlingEventSummary{unloaded}))
+		cargo.ClearEvents()
+		require.NoError(t, cargo.FollowVoyageSchedules([]LegSchedule{{Cancelled: true}, {LoadTime: leg2.LoadTime, UnloadTime: leg2.UnloadTime}}))
+
+		misdirected, err := cargo.ReviewVoyageSchedules()
+
+		require.NoError(t, err)
+		assert.False(t, misdirected)
+		assert.Equal(t, RoutingStatusRouted, cargo.GetDelivery().RoutingStatus)
+	})
+
+	t.Run("should mark cargo misdirected when handled on a schedule it can no longer follow", func(t *testing.T) {
+		cargo := setup(t)
+		require.NoError(t, cargo.FollowVoyageSchedules([]LegSchedule{delayed, {LoadTime: leg2.LoadTime, UnloadTime: leg2.UnloadTime}}))
+
+		err := cargo.DeriveDeliveryProgress([]HandlingEventSummary{
+			{Type: "RECEIVE", Location: "USNYC", Timestamp: leg1.LoadTime.Add(-time.Hour)},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, RoutingStatusMisdirected, cargo.GetDelivery().RoutingStatus)
+	})
+}
+
+func TestCargo_NextExpectedActivity(t *testing.T) {
+	setup := func(t *testing.T) *Cargo {
+		cargo := createTestCargo(t)
+		leg1 := createTestLeg(t, "V001", "USNYC", "DEHAM")
+		leg2 := createTestLegAfter(t, leg1, "V002", "DEHAM", "SEGOT")
+		itinerary, err := NewItinerary([]Leg{leg1, leg2})
+		require.NoError(t, err)
+		require.NoError(t, cargo.AssignToRoute(itinerary))
+		return cargo
+	}
+
+	t.Run("should expect nothing before the cargo is routed", func(t *testing.T) {
+		cargo := createTestCargo(t)
+
+		assert.Nil(t, cargo.GetDelivery().NextExpectedActivity)
+	})
+
+	t.Run("should expect receipt at origin once routed", func(t *testing.T) {
+		cargo := setup(t)
+
+		assert.Equal(t, &HandlingActivity{Type: "RECEIVE", Location: "USNYC"}, cargo.GetDelivery().NextExpectedActivity)
+	})
+
+	t.Run("should follow the itinerary as the cargo is handled", func(t *testing.T) {
+		now := time.Now()
+		history := []HandlingEventSummary{
+			{Type: "RECEIVE", Location: "USNYC", Timestamp: now.Add(-5 * time.Hour)},
+			{Type: "LOAD", Location: "USNYC", VoyageNumber: "V001", Timestamp: now.Add(-4 * time.Hour)},
+			{Type: "UNLOAD", Location: "DEHAM", VoyageNumber: "V001", Timestamp: now.Add(-3 * time.Hour)},
+			{Type: "LOAD", Location: "DEHAM", VoyageNumber: "V002", Timestamp: now.Add(-2 * time.Hour)},
+			{Type: "UNLOAD", Location: "SEGOT", VoyageNumber: "V002", Timestamp: now.Add(-time.Hour)},
+		}
+		expected := []*HandlingActivity{
+			{Type: "LOAD", Location: "USNYC", VoyageNumber: "V001"},
+			{Type: "UNLOAD", Location: "DEHAM", VoyageNumber: "V001"},
+			{Type: "LOAD", Location: "DEHAM", VoyageNumber: "V002"},
+			{Type: "UNLOAD", Location: "SEGOT", VoyageNumber: "V002"},
+			{Type: "CLAIM", Location: "SEGOT"},
+		}
+
+		cargo := setup(t)
+		for i := range history {
+			require.NoError(t, cargo.DeriveDeliveryProgress(history[:i+1]))
+
+			assert.Equal(t, RoutingStatusRouted, cargo.GetDelivery().RoutingStatus, history[i].Type)
+			assert.Equal(t, expected[i], cargo.GetDelivery().NextExpectedActivity, history[i].Type)
+		}
+	})
+
+	t.Run("should expect the leg after the one the cargo came off when the itinerary calls at a port twice", func(t *testing.T) {
+		cargo := createTestCargo(t)
+		leg1 := createTestLeg(t, "V001", "USNYC", "DEHAM")
+		outbound := createTestLegAfter(t, leg1, "V002", "DEHAM", "NLRTM")
+		inbound := createTestLegAfter(t, outbound, "V003", "NLRTM", "DEHAM")
+		final := createTestLegAfter(t, inbound, "V004", "DEHAM", "SEGOT")
+		itinerary, err := NewItinerary([]Leg{leg1, outbound, inbound, final})
+		require.NoError(t, err)
+		require.NoError(t, cargo.AssignToRoute(itinerary))
+
+		require.NoError(t, cargo.DeriveDeliveryProgress([]HandlingEventSummary{
+			{Type: "UNLOAD", Location: "DEHAM", VoyageNumber: "V003", Timestamp: inbound.UnloadTime},
+		}))
+
+		assert.Equal(t, &HandlingActivity{Type: "LOAD", Location: "DEHAM", VoyageNumber: "V004"}, cargo.GetDelivery().NextExpectedActivity)
+	})
+
+	t.Run("should expect nothing once the cargo is misdirected", func(t *testing.T) {
+		cargo := setup(t)
+
+		err := cargo.DeriveDeliveryProgress([]HandlingEventSummary{
+			{Type: "LOAD", Location: "USNYC", VoyageNumber: "V999", Timestamp: time.Now()},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, RoutingStatusMisdirected, cargo.GetDelivery().RoutingStatus)
+		assert.Nil(t, cargo.GetDelivery().NextExpectedActivity)
+	})
+
+	t.Run("should derive the activity of cargo loaded from storage", func(t *testing.T) {
+		cargo := setup(t)
+		require.NoError(t, cargo.DeriveDeliveryProgress([]HandlingEventSummary{
+			{Type: "LOAD", Location: "USNYC", VoyageNumber: "V001", Timestamp: time.Now()},
+		}))
+		stored := cargo.GetDelivery()
+		stored.NextExpectedActivity = nil
+
+		loaded, err := NewCargoFromExisting(cargo.GetTrackingId(), cargo.GetRouteSpecification(), cargo.GetItinerary(), stored, cargo.GetBookedAt(), cargo.GetRoutedAt())
+
+		require.NoError(t, err)
+		assert.Equal(t, &HandlingActivity{Type: "UNLOAD", Location: "DEHAM", VoyageNumber: "V001"}, loaded.GetDelivery().NextExpectedActivity)
+	})
+
+	t.Run("should keep the booking and routing times of cargo loaded from storage", func(t *testing.T) {
+		cargo := setup(t)
+		bookedAt := time.Date(2024, 1, 10, 8, 0, 0, 0, time.UTC)
+		routedAt := time.Date(2024, 1, 11, 9, 30, 0, 0, time.UTC)
+
+		loaded, err := NewCargoFromExisting(cargo.GetTrackingId(), cargo.GetRouteSpecification(), cargo.GetItinerary(), cargo.GetDelivery(), bookedAt, routedAt)
+
+		require.NoError(t, err)
+		assert.Equal(t, bookedAt, loaded.GetBookedAt())
+		assert.Equal(t, routedAt, loaded.GetRoutedAt())
+	})
+}
+
+func TestCargo_SpecifyNewRoute(t *testing.T) {
+	t.Run("should change destination of unrouted cargo", func(t *testing.T) {
+		cargo := createTestCargo(t)
+		cargo.ClearEvents()
+
+		err := cargo.ChangeDestination("DEHAM")
+
+		require.NoError(t, err)
+		assert.Equal(t, "DEHAM", cargo.GetRouteSpecification().Destination)
+		assert.Equal(t, RoutingStatusNotRouted, cargo.GetDelivery().RoutingStatus)
+		require.Len(t, cargo.GetEvents(), 1)
+		event, ok := cargo.GetEvents()[0].(CargoRouteSpecificationChangedEvent)
+		require.True(t, ok)
+		assert.Equal(t, "SEGOT", event.PreviousRouteSpecification.Destination)
+		assert.Equal(t, "DEHAM", event.RouteSpecification.Destination)
+	})
+
+	t.Run("should misdirect routed cargo when itinerary no longer reaches destination", func(t *testing.T) {
+		cargo := createTestCargo(t)
+		require.NoError(t, cargo.AssignToRoute(createTestItinerary(t, cargo.GetRouteSpecification())))
+
+		err := cargo.ChangeDestination("DEHAM")
+
+		require.NoError(t, err)
+		assert.Equal(t, RoutingStatusMisdirected, cargo.GetDelivery().RoutingStatus)
+	})
+
+	t.Run("should keep routed cargo on track when itinerary still meets new deadline", func(t *testing.T) {
+		cargo := createTestCargo(t)
+		itinerary := createTestItinerary(t, cargo.GetRouteSpecification())
+		require.NoError(t, cargo.AssignToRoute(itinerary))
+
+		err := cargo.ChangeArrivalDeadline(itinerary.FinalArrivalTime().Add(time.Hour))
+
+		require.NoError(t, err)
+		assert.Equal(t, RoutingStatusRouted, cargo.GetDelivery().RoutingStatus)
+	})
+
+	t.Run("should misdirect routed cargo when itinerary misses new deadline", func(t *testing.T) {
+		cargo := createTestCargo(t)
+		itinerary := createTestItinerary(t, cargo.GetRouteSpecification())
+		require.NoError(t, cargo.AssignToRoute(itinerary))
+
+		err := cargo.ChangeArrivalDeadline(itinerary.FinalArrivalTime().Add(-time.Hour))
+
+		require.NoError(t, err)
+		assert.Equal(t, RoutingStatusMisdirected, cargo.GetDelivery().RoutingStatus)
+	})
+
+	t.Run("should not raise event when specification is unchanged", func(t *testing.T) {
+		cargo := createTestCargo(t)
+		cargo.ClearEvents()
+
+		err := cargo.SpecifyNewRoute(cargo.GetRouteSpecification())
+
+		require.NoError(t, err)
+		assert.Empty(t, cargo.GetEvents())
+	})
+
+	t.Run("should fail when changing origin", func(t *testing.T) {
+		cargo := createTestCargo(t)
+		routeSpec, err := NewRouteSpecification("DEHAM", "SEGOT", cargo.GetRouteSpecification().ArrivalDeadline)
+		require.NoError(t, err)
+
+		err = cargo.SpecifyNewRoute(routeSpec)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "origin of booked cargo cannot be changed")
+	})
+
+	t.Run("should fail with past arrival deadline", func(t *testing.T) {
+		cargo := createTestCargo(t)
+
+		err := cargo.ChangeArrivalDeadline(time.Now().Add(-time.Hour))
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "arrival deadline must be in the future")
+	})
+
+	t.Run("should fail for claimed cargo", func(t *testing.T) {
+		cargo := createTestCargo(t)
+		claimedStatus, err := NewDelivery(TransportStatusClaimed, RoutingStatusRouted, "SEGOT", "", true)
+		require.NoError(t, err)
+		cargo.Data.Delivery = claimedStatus
+
+		err = cargo.ChangeDestination("DEHAM")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestCargo_Cancel(t *testing.T) {
+	t.Run("should cancel cargo that has not been received", func(t *testing.T) {
+		cargo := createTestCargo(t)
+		cargo.ClearEvents()
+
+		err := cargo.Cancel("customer request")
+
+		require.NoError(t, err)
+		assert.Equal(t, TransportStatusCancelled, cargo.GetDelivery().TransportStatus)
+		assert.True(t, cargo.GetDelivery().IsCancelled())
+		require.Len(t, cargo.GetEvents(), 1)
+		event, ok := cargo.GetEvents()[0].(CargoCancelledEvent)
+		require.True(t, ok)
+		assert.Equal(t, "customer request", event.Reason)
+	})
+
+	t.Run("should cancel cargo received at origin", func(t *testing.T) {
+		cargo := createTestCargo(t)
+		receivedStatus, err := NewDelivery(TransportStatusInPort, RoutingStatusNotRouted, "USNYC", "", false)
+		require.NoError(t, err)
+		cargo.Data.Delivery = receivedStatus
+
+		err = cargo.Cancel("customer request")
+
+		require.NoError(t, err)
+		assert.Equal(t, "USNYC", cargo.GetDelivery().LastKnownLocation)
+	})
+
+	t.Run("should fail for cargo onboard a carrier", func(t *testing.T) {
+		cargo := createTestCargo(t)
+		onboardStatus, err := NewDelivery(TransportStatusOnboardCarrier, RoutingStatusRouted, "USNYC", "V001", false)
+		require.NoError(t, err)
+		cargo.Data.Delivery = onboardStatus
+
+		err = cargo.Cancel("customer request")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot cancel cargo that has been loaded or claimed")
+	})
+
+	t.Run("should fail for cargo unloaded from a carrier", func(t *testing.T) {
+		cargo := createTestCargo(t)
+		unloadedStatus, err := NewDelivery(TransportStatusInPort, RoutingStatusRouted, "DEHAM", "V001", false)
+		require.NoError(t, err)
+		cargo.Data.Delivery = unloadedStatus
+
+		err = cargo.Cancel("customer request")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot cancel cargo that has been loaded or claimed")
+	})
+
+	t.Run("should fail for claimed cargo", func(t *testing.T) {
+		cargo := createTestCargo(t)
+		claimedStatus, err := NewDelivery(TransportStatusClaimed, RoutingStatusRouted, "SEGOT", "", true)
+		require.NoError(t, err)
+		cargo.Data.Delivery = claimedStatus
+
+		err = cargo.Cancel("customer request")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should fail without a reason", func(t *testing.T) {
+		cargo := createTestCargo(t)
+
+		err := cargo.Cancel("   ")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cancellation reason is required")
+	})
+
+	t.Run("should fail when already cancelled", func(t *testing.T) {
+		cargo := createTestCargo(t)
+		require.NoError(t, cargo.Cancel("customer request"))
+
+		err := cargo.Cancel("customer request")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cargo is already cancelled")
+	})
+
+	t.Run("should reject route assignment and handling after cancellation", func(t *testing.T) {
+		cargo := createTestCargo(t)
+		require.NoError(t, cargo.Cancel("customer request"))
+
+		err := cargo.AssignToRoute(createTestItinerary(t, cargo.GetRouteSpecification()))
+		assert.Error(t, err)
+
+		err = cargo.DeriveDeliveryProgress([]HandlingEventSummary{
+			{Type: "RECEIVE", Location: "USNYC", Timestamp: time.Now()},
+		})
+		assert.Error(t, err)
+		assert.Equal(t, TransportStatusCancelled, cargo.GetDelivery().TransportStatus)
+		assert.False(t, cargo.CanBeRerouted())
+	})
+}
+
 func TestCargo_IsReadyForPickup(t *testing.T) {
 	t.Run("should be ready for pickup when routed and not received", func(t *testing.T) {
 		cargo := createTestCargo(t)
@@ -190,6 +767,8 @@ func TestCargo_IsOverdue(t *testing.T) {
 			routeSpec,
 			nil,
 			NewInitialDelivery(),
+			time.Time{},
+			time.Time{},
 		)
 		require.NoError(t, err)
 
@@ -215,6 +794,8 @@ func TestCargo_IsOverdue(t *testing.T) {
 			routeSpec,
 			nil,
 			deliveredStatus,
+			time.Time{},
+			time.Time{},
 		)
 		require.NoError(t, err)
 
@@ -242,6 +823,175 @@ func TestCargo_GetEstimatedTimeOfArrival(t *testing.T) {
 
 		assert.Nil(t, eta)
 	})
+
+	leg1 := createTestLeg(t, "V001", "USNYC", "DEHAM")
+	leg2 := createTestLegAfter(t, leg1, "V002", "DEHAM", "SEGOT")
+	setup := func(t *testing.T) *Cargo {
+		cargo := createTestCargo(t)
+		itinerary, err := NewItinerary([]Leg{leg1, leg2})
+		require.NoError(t, err)
+		require.NoError(t, cargo.AssignToRoute(itinerary))
+		return cargo
+	}
+
+	tests := map[string]struct {
+		lastEvent HandlingEventSummary
+		expected  *time.Time
+	}{
+		"loaded in time": {
+			HandlingEventSummary{Type: "LOAD", Location: "USNYC", VoyageNumber: "V001", Timestamp: leg1.LoadTime.Add(-time.Hour)},
+			&leg2.UnloadTime,
+		},
+		"delay carried on to the next leg": {
+			HandlingEventSummary{Type: "LOAD", Location: "USNYC", VoyageNumber: "V001", Timestamp: leg1.LoadTime.Add(10 * time.Hour)},
+			ptr(leg2.UnloadTime.Add(8 * time.Hour)),
+		},
+		"delay absorbed by the transshipment": {
+			HandlingEventSummary{Type: "UNLOAD", Location: "DEHAM", VoyageNumber: "V001", Timestamp: leg1.UnloadTime.Add(time.Hour)},
+			&leg2.UnloadTime,
+		},
+		"unloaded at destination": {
+			HandlingEventSummary{Type: "UNLOAD", Location: "SEGOT", VoyageNumber: "V002", Timestamp: leg2.UnloadTime.Add(-3 * time.Hour)},
+			ptr(leg2.UnloadTime.Add(-3 * time.Hour)),
+		},
+		"misdirected": {
+			HandlingEventSummary{Type: "UNLOAD", Location: "CNSHA", VoyageNumber: "V001", Timestamp: leg1.UnloadTime},
+			nil,
+		},
+		"claimed": {
+			HandlingEventSummary{Type: "CLAIM", Location: "SEGOT", Timestamp: leg2.UnloadTime.Add(time.Hour)},
+			nil,
+		},
+	}
+	for name, test := range tests {
+		t.Run("should estimate from handling progress when "+name, func(t *testing.T) {
+			cargo := setup(t)
+
+			require.NoError(t, cargo.DeriveDeliveryProgress([]HandlingEventSummary{test.lastEvent}))
+
+			assert.Equal(t, test.expected, cargo.GetEstimatedTimeOfArrival())
+		})
+	}
+
+	t.Run("should continue from the leg the cargo came off when the itinerary calls at a port twice", func(t *testing.T) {
+		cargo := createTestCargo(t)
+		outbound := createTestLegAfter(t, leg1, "V002", "DEHAM", "NLRTM")
+		inbound := createTestLegAfter(t, outbound, "V003", "NLRTM", "DEHAM")
+		final := createTestLegAfter(t, inbound, "V004", "DEHAM", "SEGOT")
+		itinerary, err := NewItinerary([]Leg{leg1, outbound, inbound, final})
+		require.NoError(t, err)
+		require.NoError(t, cargo.AssignToRoute(itinerary))
+
+		require.NoError(t, cargo.DeriveDeliveryProgress([]HandlingEventSummary{
+			{Type: "UNLOAD", Location: "DEHAM", VoyageNumber: "V003", Timestamp: inbound.UnloadTime.Add(10 * time.Hour)},
+		}))
+
+		assert.Equal(t, ptr(final.UnloadTime.Add(8*time.Hour)), cargo.GetEstimatedTimeOfArrival())
+	})
+
+	rescheduled := func(t *testing.T, delay time.Duration) []LegSchedule {
+		return []LegSchedule{
+			{LoadTime: leg1.LoadTime, UnloadTime: leg1.UnloadTime},
+			{LoadTime: leg2.LoadTime.Add(delay), UnloadTime: leg2.UnloadTime.Add(delay)},
+		}
+	}
+
+	t.Run("should estimate from the current voyage schedules", func(t *testing.T) {
+		cargo := setup(t)
+
+		require.NoError(t, cargo.FollowVoyageSchedules(rescheduled(t, 6*time.Hour)))
+
+		assert.Equal(t, ptr(leg2.UnloadTime.Add(6*time.Hour)), cargo.GetEstimatedTimeOfArrival())
+	})
+
+	t.Run("should keep following the voyage schedules when the delivery is updated", func(t *testing.T) {
+		cargo := setup(t)
+		require.NoError(t, cargo.FollowVoyageSchedules(rescheduled(t, 6*time.Hour)))
+
+		require.NoError(t, cargo.DeriveDeliveryProgress([]HandlingEventSummary{
+			{Type: "UNLOAD", Location: "DEHAM", VoyageNumber: "V001", Timestamp: leg1.UnloadTime},
+		}))
+
+		assert.Equal(t, ptr(leg2.UnloadTime.Add(6*time.Hour)), cargo.GetEstimatedTimeOfArrival())
+	})
+
+	t.Run("should estimate from the planned times again once rerouted", func(t *testing.T) {
+		cargo := setup(t)
+		require.NoError(t, cargo.FollowVoyageSchedules(rescheduled(t, 6*time.Hour)))
+
+		itinerary, err := NewItinerary([]Leg{leg1, leg2})
+		require.NoError(t, err)
+		require.NoError(t, cargo.AssignToRoute(itinerary))
+
+		assert.Equal(t, &leg2.UnloadTime, cargo.GetEstimatedTimeOfArrival())
+	})
+
+	t.Run("should reject voyage schedules of other legs", func(t *testing.T) {
+		cargo := setup(t)
+		err := cargo.FollowVoyageSchedules(rescheduled(t, 6*time.Hour)[:1])
+
+		var validationErr DomainValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, &leg2.UnloadTime, cargo.GetEstimatedTimeOfArrival())
+	})
+
+	t.Run("should keep the planned times of a cancelled leg", func(t *testing.T) {
+		cargo := setup(t)
+
+		require.NoError(t, cargo.FollowVoyageSchedules([]LegSchedule{
+			{LoadTime: leg1.LoadTime.Add(time.Hour), UnloadTime: leg1.UnloadTime.Add(time.Hour)},
+			{Cancelled: true},
+		}))
+
+		assert.Equal(t, &leg2.UnloadTime, cargo.GetEstimatedTimeOfArrival())
+	})
+
+	t.Run("should report slack against the arrival deadline", func(t *testing.T) {
+		cargo := setup(t)
+		require.NoError(t, cargo.ChangeArrivalDeadline(leg2.UnloadTime.Add(4*time.Hour)))
+
+		slack, ok := cargo.GetArrivalSlack()
+
+		assert.True(t, ok)
+		assert.Equal(t, 4*time.Hour, slack)
+		assert.False(t, cargo.IsLate())
+	})
+
+	t.Run("should report late cargo in the delivery update", func(t *testing.T) {
+		cargo := setup(t)
+		require.NoError(t, cargo.ChangeArrivalDeadline(leg2.UnloadTime.Add(4*time.Hour)))
+		cargo.ClearEvents()
+
+		require.NoError(t, cargo.DeriveDeliveryProgress([]HandlingEventSummary{
+			{Type: "LOAD", Location: "USNYC", VoyageNumber: "V001", Timestamp: leg1.LoadTime.Add(10 * time.Hour)},
+		}))
+
+		slack, ok := cargo.GetArrivalSlack()
+		assert.True(t, ok)
+		assert.Equal(t, -4*time.Hour, slack)
+		assert.True(t, cargo.IsLate())
+
+		require.Len(t, cargo.GetEvents(), 1)
+		event, ok := cargo.GetEvents()[0].(CargoDeliveryUpdatedEvent)
+		require.True(t, ok)
+		assert.True(t, event.IsLate)
+		require.NotNil(t, event.ArrivalSlackSeconds)
+		assert.Equal(t, int64(-4*60*60), *event.ArrivalSlackSeconds)
+		assert.Equal(t, cargo.GetEstimatedTimeOfArrival(), event.Delivery.EstimatedTimeOfArrival)
+	})
+
+	t.Run("should not be late without an estimate", func(t *testing.T) {
+		cargo := createTestCargo(t)
+
+		_, ok := cargo.GetArrivalSlack()
+
+		assert.False(t, ok)
+		assert.False(t, cargo.IsLate())
+	})
+}
+
+func ptr[T any](v T) *T {
+	return &v
 }
 
 // Helper functions for tests
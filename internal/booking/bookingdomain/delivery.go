@@ -2,6 +2,7 @@ package bookingdomain
 
 import (
 	"go_hex/internal/support/validation"
+	"slices"
 	"time"
 )
 
@@ -13,6 +14,7 @@ const (
 	TransportStatusInPort         TransportStatus = "IN_PORT"
 	TransportStatusOnboardCarrier TransportStatus = "ONBOARD_CARRIER"
 	TransportStatusClaimed        TransportStatus = "CLAIMED"
+	TransportStatusCancelled      TransportStatus = "CANCELLED"
 	TransportStatusUnknown        TransportStatus = "UNKNOWN"
 )
 
@@ -34,6 +36,24 @@ type Delivery struct {
 	CurrentVoyage     string          `json:"current_voyage,omitempty"`
 	IsUnloadedAtDest  bool            `json:"is_unloaded_at_dest"`
 	CalculatedAt      time.Time       `json:"calculated_at" validate:"required"`
+
+	// LastEventTime is the completion time of the handling event this status was derived from; zero until handled
+	LastEventTime time.Time `json:"last_event_time"`
+
+	// NextExpectedActivity is the handling the itinerary plans next; nil when the cargo is not routed,
+	// misdirected, claimed, cancelled or in an unknown state
+	NextExpectedActivity *HandlingActivity `json:"next_expected_activity,omitempty"`
+
+	// EstimatedTimeOfArrival is when the cargo is expected at its destination given its progress so far;
+	// nil under the same conditions as NextExpectedActivity
+	EstimatedTimeOfArrival *time.Time `json:"estimated_time_of_arrival,omitempty"`
+}
+
+// HandlingActivity is a handling step a cargo is expected to go through
+type HandlingActivity struct {
+	Type         string `json:"type"`     // RECEIVE, LOAD, UNLOAD or CLAIM
+	Location     string `json:"location"` // UN/LOCODE
+	VoyageNumber string `json:"voyage_number,omitempty"`
 }
 
 // NewDelivery creates a new Delivery status with validation
@@ -71,6 +91,11 @@ func (d Delivery) IsDelivered() bool {
 	return d.TransportStatus == TransportStatusClaimed && d.IsUnloadedAtDest
 }
 
+// IsCancelled checks if the booking has been cancelled
+func (d Delivery) IsCancelled() bool {
+	return d.TransportStatus == TransportStatusCancelled
+}
+
 // IsOnTrack checks if the cargo is following its planned route
 func (d Delivery) IsOnTrack() bool {
 	return d.RoutingStatus == RoutingStatusRouted
@@ -100,3 +125,124 @@ func (d Delivery) HasBeenReceived() bool {
 func (d Delivery) CanBeClaimed() bool {
 	return d.IsUnloadedAtDest && d.TransportStatus == TransportStatusInPort
 }
+
+// nextExpectedActivity derives the next handling from the itinerary and the state the last handling event left.
+// Received or unloaded cargo is loaded onto the next leg of the itinerary, or claimed once unloaded at its destination;
+// cargo on board is unloaded where its current leg ends.
+func (d Delivery) nextExpectedActivity(itinerary *Itinerary) *HandlingActivity {
+	if itinerary == nil || len(itinerary.Legs) == 0 || d.RoutingStatus == RoutingStatusMisdirected {
+		return nil
+	}
+
+	switch d.TransportStatus {
+	case TransportStatusNotReceived:
+		return &HandlingActivity{Type: "RECEIVE", Location: itinerary.Legs[0].LoadLocation}
+	case TransportStatusInPort:
+		if d.IsUnloadedAtDest {
+			return &HandlingActivity{Type: "CLAIM", Location: d.LastKnownLocation}
+		}
+		if next := d.nextLeg(itinerary.Legs); next >= 0 {
+			leg := itinerary.Legs[next]
+			return &HandlingActivity{Type: "LOAD", Location: leg.LoadLocation, VoyageNumber: leg.VoyageNumber}
+		}
+	case TransportStatusOnboardCarrier:
+		if current := d.currentLeg(itinerary.Legs); current >= 0 {
+			leg := itinerary.Legs[current]
+			return &HandlingActivity{Type: "UNLOAD", Location: leg.UnloadLocation, VoyageNumber: leg.VoyageNumber}
+		}
+	}
+	return nil
+}
+
+// estimatedTimeOfArrival replays the rest of the itinerary from the last handling event.
+// A leg never departs before its planned load time, and a late departure delays the arrival of that leg
+// by as much, so a delay carries on to the following legs until a transshipment's slack absorbs it.
+// The itinerary given carries the times its voyages keep: their current schedules once the cargo follows them,
+// so a rescheduled arrival replaces the unload time planned for a leg.
+func (d Delivery) estimatedTimeOfArrival(itinerary *Itinerary) *time.Time {
+	if itinerary == nil || len(itinerary.Legs) == 0 || d.RoutingStatus == RoutingStatusMisdirected {
+		return nil
+	}
+
+	if d.TransportStatus == TransportStatusNotReceived {
+		eta := itinerary.FinalArrivalTime()
+		return &eta
+	}
+	if d.LastEventTime.IsZero() {
+		return nil // Handled before event times were recorded
+	}
+
+	legs := itinerary.Legs
+	var ready time.Time
+	switch d.TransportStatus {
+	case TransportStatusInPort:
+		if d.IsUnloadedAtDest {
+			eta := d.LastEventTime
+			return &eta
+		}
+		next := d.nextLeg(legs)
+		if next < 0 {
+			return nil
+		}
+		ready, legs = d.LastEventTime, legs[next:]
+	case TransportStatusOnboardCarrier:
+		current := d.currentLeg(legs)
+		if current < 0 {
+			return nil
+		}
+		ready, legs = legs[current].estimatedArrival(d.LastEventTime), legs[current+1:]
+	default:
+		return nil
+	}
+
+	for _, leg := range legs {
+		ready = leg.estimatedArrival(ready)
+	}
+	return &ready
+}
+
+// nextLeg returns the index of the leg cargo in port is loaded onto next, or -1. Cargo unloaded from a voyage
+// continues with the leg after the one it came off, so an itinerary calling at a port twice is followed in sequence;
+// cargo last handled without a voyage, as on receipt, continues with the first leg leaving its port.
+func (d Delivery) nextLeg(legs []Leg) int {
+	if d.CurrentVoyage == "" {
+		return slices.IndexFunc(legs, func(leg Leg) bool { return leg.LoadLocation == d.LastKnownLocation })
+	}
+
+	previous := d.lastHandledLeg(legs)
+	if previous < 0 || previous+1 == len(legs) || legs[previous+1].LoadLocation != d.LastKnownLocation {
+		return -1
+	}
+	return previous + 1
+}
+
+// currentLeg returns the index of the leg cargo on board is travelling, or -1
+func (d Delivery) currentLeg(legs []Leg) int {
+	return slices.IndexFunc(legs, func(leg Leg) bool {
+		return leg.VoyageNumber == d.CurrentVoyage && leg.LoadLocation == d.LastKnownLocation
+	})
+}
+
+// lastHandledLeg returns the index of the leg cargo was last loaded onto or unloaded from, or -1 when it has not
+// been handled on a leg of the itinerary, as after receipt. It is the cargo's position in the itinerary: the legs
+// up to it have been travelled, whichever ports they share with the legs after it.
+func (d Delivery) lastHandledLeg(legs []Leg) int {
+	switch {
+	case d.CurrentVoyage == "":
+		return -1
+	case d.IsInTransit():
+		return d.currentLeg(legs)
+	default:
+		return slices.IndexFunc(legs, func(leg Leg) bool {
+			return leg.VoyageNumber == d.CurrentVoyage && leg.UnloadLocation == d.LastKnownLocation
+		})
+	}
+}
+
+// arrivalSlack returns the time between the estimated time of arrival and the deadline, if there is an estimate
+func (d Delivery) arrivalSlack(arrivalDeadline time.Time) (time.Duration, bool) {
+	if d.EstimatedTimeOfArrival == nil {
+		return 0, false
+	}
+	return arrivalDeadline.Sub(*d.EstimatedTimeOfArrival), true
+}
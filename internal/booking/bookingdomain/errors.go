@@ -1,6 +1,10 @@
 package bookingdomain
 
-import "go_hex/internal/support/errors"
+import (
+	"fmt"
+
+	"go_hex/internal/support/errors"
+)
 
 // DomainValidationError represents booking domain validation failures
 type DomainValidationError struct {
@@ -13,3 +17,33 @@ func NewDomainValidationError(message string, cause error) DomainValidationError
 		BaseError: errors.NewBaseError(message, cause),
 	}
 }
+
+// ConcurrencyConflictError reports that a cargo was changed by someone else since the caller read it
+type ConcurrencyConflictError struct {
+	errors.BaseError
+	TrackingId      TrackingId
+	ExpectedVersion int
+}
+
+// NewConcurrencyConflictError creates a conflict error for a cargo that is no longer at expectedVersion
+func NewConcurrencyConflictError(trackingId TrackingId, expectedVersion int) ConcurrencyConflictError {
+	return ConcurrencyConflictError{
+		BaseError:       errors.NewBaseError(fmt.Sprintf("cargo %s was modified concurrently, expected version %d", trackingId.String(), expectedVersion), nil),
+		TrackingId:      trackingId,
+		ExpectedVersion: expectedVersion,
+	}
+}
+
+// CargoNotFoundError reports that no cargo with the given tracking ID has been booked
+type CargoNotFoundError struct {
+	errors.BaseError
+	TrackingId TrackingId
+}
+
+// NewCargoNotFoundError creates a new cargo not found error
+func NewCargoNotFoundError(trackingId TrackingId) CargoNotFoundError {
+	return CargoNotFoundError{
+		BaseError:  errors.NewBaseError(fmt.Sprintf("cargo with tracking ID %s not found", trackingId.String()), nil),
+		TrackingId: trackingId,
+	}
+}
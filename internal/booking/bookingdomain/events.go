@@ -58,18 +58,32 @@ func (e CargoRoutedEvent) OccurredAt() time.Time {
 
 // CargoDeliveryUpdatedEvent represents the domain event when cargo delivery status is updated
 type CargoDeliveryUpdatedEvent struct {
-	TrackingId TrackingId `json:"tracking_id"`
-	Delivery   Delivery   `json:"delivery"`
-	OccurredOn time.Time  `json:"occurred_on"`
+	TrackingId      TrackingId `json:"tracking_id"`
+	Delivery        Delivery   `json:"delivery"`
+	ArrivalDeadline time.Time  `json:"arrival_deadline"`
+
+	// IsLate and ArrivalSlackSeconds compare the estimated time of arrival with the arrival deadline;
+	// the slack is negative when the cargo is late and omitted when no arrival can be estimated
+	IsLate              bool   `json:"is_late"`
+	ArrivalSlackSeconds *int64 `json:"arrival_slack_seconds,omitempty"`
+
+	OccurredOn time.Time `json:"occurred_on"`
 }
 
 // NewCargoDeliveryUpdatedEvent creates a new CargoDeliveryUpdatedEvent
-func NewCargoDeliveryUpdatedEvent(trackingId TrackingId, delivery Delivery) CargoDeliveryUpdatedEvent {
-	return CargoDeliveryUpdatedEvent{
-		TrackingId: trackingId,
-		Delivery:   delivery,
-		OccurredOn: time.Now(),
+func NewCargoDeliveryUpdatedEvent(trackingId TrackingId, delivery Delivery, arrivalDeadline time.Time) CargoDeliveryUpdatedEvent {
+	event := CargoDeliveryUpdatedEvent{
+		TrackingId:      trackingId,
+		Delivery:        delivery,
+		ArrivalDeadline: arrivalDeadline,
+		OccurredOn:      time.Now(),
+	}
+	if slack, ok := delivery.arrivalSlack(arrivalDeadline); ok {
+		seconds := int64(slack / time.Second)
+		event.IsLate = slack < 0
+		event.ArrivalSlackSeconds = &seconds
 	}
+	return event
 }
 
 // EventName returns the name of this event
@@ -81,3 +95,59 @@ func (e CargoDeliveryUpdatedEvent) EventName() string {
 func (e CargoDeliveryUpdatedEvent) OccurredAt() time.Time {
 	return e.OccurredOn
 }
+
+// CargoCancelledEvent represents the domain event when a cargo booking is cancelled
+type CargoCancelledEvent struct {
+	TrackingId TrackingId `json:"tracking_id"`
+	Reason     string     `json:"reason"`
+	OccurredOn time.Time  `json:"occurred_on"`
+}
+
+// NewCargoCancelledEvent creates a new CargoCancelledEvent
+func NewCargoCancelledEvent(trackingId TrackingId, reason string) CargoCancelledEvent {
+	return CargoCancelledEvent{
+		TrackingId: trackingId,
+		Reason:     reason,
+		OccurredOn: time.Now(),
+	}
+}
+
+// EventName returns the name of this event
+func (e CargoCancelledEvent) EventName() string {
+	return "CargoCancelled"
+}
+
+// OccurredAt returns when this event occurred
+func (e CargoCancelledEvent) OccurredAt() time.Time {
+	return e.OccurredOn
+}
+
+// CargoRouteSpecificationChangedEvent represents the domain event when the customer changes a cargo's routing requirement
+type CargoRouteSpecificationChangedEvent struct {
+	TrackingId                 TrackingId         `json:"tracking_id"`
+	PreviousRouteSpecification RouteSpecification `json:"previous_route_specification"`
+	RouteSpecification         RouteSpecification `json:"route_specification"`
+	RoutingStatus              RoutingStatus      `json:"routing_status"`
+	OccurredOn                 time.Time          `json:"occurred_on"`
+}
+
+// NewCargoRouteSpecificationChangedEvent creates a new CargoRouteSpecificationChangedEvent
+func NewCargoRouteSpecificationChangedEvent(trackingId TrackingId, previous, current RouteSpecification, routingStatus RoutingStatus) CargoRouteSpecificationChangedEvent {
+	return CargoRouteSpecificationChangedEvent{
+		TrackingId:                 trackingId,
+		PreviousRouteSpecification: previous,
+		RouteSpecification:         current,
+		RoutingStatus:              routingStatus,
+		OccurredOn:                 time.Now(),
+	}
+}
+
+// EventName returns the name of this event
+func (e CargoRouteSpecificationChangedEvent) EventName() string {
+	return "CargoRouteSpecificationChanged"
+}
+
+// OccurredAt returns when this event occurred
+func (e CargoRouteSpecificationChangedEvent) OccurredAt() time.Time {
+	return e.OccurredOn
+}
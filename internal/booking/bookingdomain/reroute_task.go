@@ -0,0 +1,27 @@
+package bookingdomain
+
+import "time"
+
+// RerouteTask is misdirected cargo waiting for a planner to choose a new route
+// The candidates were found when the cargo was put on the worklist and are not kept up to date.
+type RerouteTask struct {
+	TrackingId TrackingId `json:"tracking_id"`
+
+	// RouteSpecification leads from the port the cargo is in to its destination
+	RouteSpecification RouteSpecification `json:"route_specification"`
+
+	// Candidates are ranked best first; empty when no route reaches the destination by the deadline
+	Candidates []Itinerary `json:"candidates"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewRerouteTask creates a worklist entry for a misdirected cargo with the routes found from where it is
+func NewRerouteTask(trackingId TrackingId, routeSpec RouteSpecification, candidates []Itinerary) RerouteTask {
+	return RerouteTask{
+		TrackingId:         trackingId,
+		RouteSpecification: routeSpec,
+		Candidates:         candidates,
+		CreatedAt:          time.Now(),
+	}
+}
@@ -2,14 +2,20 @@ package bookingdomain
 
 import (
 	"go_hex/internal/support/validation"
+	"slices"
 	"time"
 )
 
-// RouteSpecification defines the customer's immutable transportation requirement
+// RouteSpecification defines the customer's transportation requirement
+// It is a value object; changing the requirement replaces the whole specification.
 type RouteSpecification struct {
 	Origin          string    `json:"origin" validate:"required,min=3,max=5"`      // UN/LOCODE
 	Destination     string    `json:"destination" validate:"required,min=3,max=5"` // UN/LOCODE
 	ArrivalDeadline time.Time `json:"arrival_deadline" validate:"required"`        // Must arrive by this date
+
+	// EarliestDeparture keeps routes for cargo already under way from leaving before it is ready;
+	// the customer's specification leaves it zero
+	EarliestDeparture time.Time `json:"earliest_departure,omitzero"`
 }
 
 // NewRouteSpecification creates a new RouteSpecification with validation
@@ -36,6 +42,14 @@ func NewRouteSpecification(origin, destination string, arrivalDeadline time.Time
 	return spec, nil
 }
 
+// Equals checks if two route specifications describe the same requirement
+func (rs RouteSpecification) Equals(other RouteSpecification) bool {
+	return rs.Origin == other.Origin &&
+		rs.Destination == other.Destination &&
+		rs.ArrivalDeadline.Equal(other.ArrivalDeadline) &&
+		rs.EarliestDeparture.Equal(other.EarliestDeparture)
+}
+
 // Leg represents a single step in an itinerary
 type Leg struct {
 	VoyageNumber   string    `json:"voyage_number" validate:"required"`
@@ -71,6 +85,24 @@ func NewLeg(voyageNumber, loadLocation, unloadLocation string, loadTime, unloadT
 	return leg, nil
 }
 
+// estimatedArrival returns when the leg arrives if the cargo can board no earlier than ready
+func (l Leg) estimatedArrival(ready time.Time) time.Time {
+	if !ready.After(l.LoadTime) {
+		return l.UnloadTime
+	}
+	return l.UnloadTime.Add(ready.Sub(l.LoadTime))
+}
+
+// LegSchedule is when the voyage of an itinerary leg currently departs from the leg's load location and arrives at
+// its unload location
+type LegSchedule struct {
+	LoadTime   time.Time
+	UnloadTime time.Time
+
+	// Cancelled is set when the voyage no longer calls at the load and unload locations in turn; the times are unset
+	Cancelled bool
+}
+
 // Itinerary represents a planned shipping route consisting of one or more legs
 type Itinerary struct {
 	Legs []Leg `json:"legs" validate:"required,min=1,dive"`
@@ -124,6 +156,11 @@ func (i Itinerary) SatisfiesSpecification(spec RouteSpecification) bool {
 		return false
 	}
 
+	// Check the cargo is ready when the first leg departs
+	if firstLeg.LoadTime.Before(spec.EarliestDeparture) {
+		return false
+	}
+
 	return true
 }
 
@@ -143,13 +180,85 @@ func (i Itinerary) InitialDepartureTime() time.Time {
 	return i.Legs[0].LoadTime
 }
 
-// IsOnTrack checks if the given location and voyage are part of this itinerary
-func (i Itinerary) IsOnTrack(location, voyageNumber string) bool {
-	for _, leg := range i.Legs {
-		if leg.VoyageNumber == voyageNumber &&
-			(leg.LoadLocation == location || leg.UnloadLocation == location) {
-			return true
+// IsExpected checks if the itinerary plans a handling event: receipt at the origin, loading and unloading
+// on one of its legs, and claim at the final destination. Customs may happen anywhere along the way.
+func (i Itinerary) IsExpected(event HandlingEventSummary) bool {
+	if len(i.Legs) == 0 {
+		return false
+	}
+
+	switch event.Type {
+	case "RECEIVE":
+		return event.Location == i.Legs[0].LoadLocation
+	case "LOAD", "UNLOAD":
+		return slices.ContainsFunc(i.Legs, func(leg Leg) bool { return leg.handles(event) })
+	case "CLAIM":
+		return event.Location == i.Legs[len(i.Legs)-1].UnloadLocation
+	default:
+		return true
+	}
+}
+
+// HandlingPlacement tells where a handling event falls on an itinerary
+type HandlingPlacement struct {
+	// LegIndex is the leg the event loaded or unloaded the cargo for; -1 for any other event or one no leg plans
+	LegIndex int
+
+	// Expected reports whether the itinerary plans the event, as IsExpected does
+	Expected bool
+}
+
+// PlaceHandling places a cargo's handling events, given in the order they happened, on the itinerary's legs. The legs
+// are followed in sequence: a load or unload belongs to the first leg it matches from the leg the cargo was last
+// handled on, so an itinerary calling at a port twice places each call on its own leg. Handling matching only earlier
+// legs, as when cargo is sent back, belongs to the nearest of them.
+func (i Itinerary) PlaceHandling(events []HandlingEventSummary) []HandlingPlacement {
+	placements := make([]HandlingPlacement, len(events))
+	current := 0
+	for n, event := range events {
+		placements[n] = HandlingPlacement{LegIndex: -1, Expected: i.IsExpected(event)}
+
+		handledOn := func(leg Leg) bool { return leg.handles(event) }
+		if ahead := slices.IndexFunc(i.Legs[current:], handledOn); ahead >= 0 {
+			current += ahead
+		} else if behind := lastIndexFunc(i.Legs[:current], handledOn); behind >= 0 {
+			current = behind
+		} else {
+			continue
 		}
+		placements[n].LegIndex = current
+	}
+	return placements
+}
+
+// handles reports whether the event loads the cargo onto this leg or unloads it from this leg
+func (l Leg) handles(event HandlingEventSummary) bool {
+	if l.VoyageNumber != event.VoyageNumber {
+		return false
 	}
-	return false
+	switch event.Type {
+	case "LOAD":
+		return l.LoadLocation == event.Location
+	case "UNLOAD":
+		return l.UnloadLocation == event.Location
+	default:
+		return false
+	}
+}
+
+// lastIndexFunc returns the index of the last leg satisfying f, or -1
+func lastIndexFunc(legs []Leg, f func(Leg) bool) int {
+	for i := len(legs) - 1; i >= 0; i-- {
+		if f(legs[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// RankingCriteria expresses how route candidates should be ordered and trimmed
+// The strategy is interpreted by the routing context; an empty strategy uses its default.
+type RankingCriteria struct {
+	Strategy   string `json:"strategy,omitempty"`
+	MaxResults int    `json:"max_results,omitempty" validate:"gte=0"`
 }
@@ -251,45 +251,96 @@ func TestItinerary_InitialDepartureTime(t *testing.T) {
 	})
 }
 
-func TestItinerary_IsOnTrack(t *testing.T) {
-	t.Run("should be on track for matching voyage and location", func(t *testing.T) {
-		leg := createTestLeg(t, "V001", "USNYC", "SEGOT")
-		itinerary, err := NewItinerary([]Leg{leg})
-		require.NoError(t, err)
-
-		result := itinerary.IsOnTrack("USNYC", "V001")
-
-		assert.True(t, result)
-	})
-
-	t.Run("should be on track for unload location", func(t *testing.T) {
-		leg := createTestLeg(t, "V001", "USNYC", "SEGOT")
-		itinerary, err := NewItinerary([]Leg{leg})
-		require.NoError(t, err)
+func TestItinerary_IsExpected(t *testing.T) {
+	leg1 := createTestLeg(t, "V001", "USNYC", "DEHAM")
+	leg2 := createTestLegAfter(t, leg1, "V002", "DEHAM", "SEGOT")
+	itinerary, err := NewItinerary([]Leg{leg1, leg2})
+	require.NoError(t, err)
 
-		result := itinerary.IsOnTrack("SEGOT", "V001")
+	tests := map[string]struct {
+		event    HandlingEventSummary
+		expected bool
+	}{
+		"receipt at origin":           {HandlingEventSummary{Type: "RECEIVE", Location: "USNYC"}, true},
+		"receipt elsewhere":           {HandlingEventSummary{Type: "RECEIVE", Location: "DEHAM"}, false},
+		"load onto planned voyage":    {HandlingEventSummary{Type: "LOAD", Location: "DEHAM", VoyageNumber: "V002"}, true},
+		"load onto another voyage":    {HandlingEventSummary{Type: "LOAD", Location: "DEHAM", VoyageNumber: "V001"}, false},
+		"unload at end of leg":        {HandlingEventSummary{Type: "UNLOAD", Location: "DEHAM", VoyageNumber: "V001"}, true},
+		"unload before end of leg":    {HandlingEventSummary{Type: "UNLOAD", Location: "USNYC", VoyageNumber: "V001"}, false},
+		"claim at final destination":  {HandlingEventSummary{Type: "CLAIM", Location: "SEGOT"}, true},
+		"claim at transshipment port": {HandlingEventSummary{Type: "CLAIM", Location: "DEHAM"}, false},
+		"customs anywhere":            {HandlingEventSummary{Type: "CUSTOMS", Location: "DEHAM"}, true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, itinerary.IsExpected(test.event))
+		})
+	}
+}
 
-		assert.True(t, result)
+func TestItinerary_PlaceHandling(t *testing.T) {
+	// The cargo goes to DEHAM and back, and then to DEHAM again on the same voyage
+	leg1 := createTestLeg(t, "V001", "USNYC", "DEHAM")
+	leg2 := createTestLegAfter(t, leg1, "V002", "DEHAM", "USNYC")
+	leg3 := createTestLegAfter(t, leg2, "V001", "USNYC", "DEHAM")
+	itinerary := Itinerary{Legs: []Leg{leg1, leg2, leg3}}
+
+	t.Run("should follow the legs in sequence", func(t *testing.T) {
+		// Setup
+		events := []HandlingEventSummary{
+			{Type: "RECEIVE", Location: "USNYC"},
+			{Type: "LOAD", Location: "USNYC", VoyageNumber: "V001"},
+			{Type: "UNLOAD", Location: "DEHAM", VoyageNumber: "V001"},
+			{Type: "CUSTOMS", Location: "DEHAM"},
+			{Type: "LOAD", Location: "DEHAM", VoyageNumber: "V002"},
+			{Type: "UNLOAD", Location: "USNYC", VoyageNumber: "V002"},
+			{Type: "LOAD", Location: "USNYC", VoyageNumber: "V001"},
+			{Type: "UNLOAD", Location: "DEHAM", VoyageNumber: "V001"},
+		}
+
+		// Execute
+		placements := itinerary.PlaceHandling(events)
+
+		// Verify
+		assert.Equal(t, []HandlingPlacement{
+			{LegIndex: -1, Expected: true},
+			{LegIndex: 0, Expected: true},
+			{LegIndex: 0, Expected: true},
+			{LegIndex: -1, Expected: true},
+			{LegIndex: 1, Expected: true},
+			{LegIndex: 1, Expected: true},
+			{LegIndex: 2, Expected: true},
+			{LegIndex: 2, Expected: true},
+		}, placements)
 	})
 
-	t.Run("should not be on track for wrong voyage", func(t *testing.T) {
-		leg := createTestLeg(t, "V001", "USNYC", "SEGOT")
-		itinerary, err := NewItinerary([]Leg{leg})
-		require.NoError(t, err)
+	t.Run("should place handling on an earlier leg when the cargo is sent back", func(t *testing.T) {
+		// Setup
+		onward := Itinerary{Legs: []Leg{leg1, createTestLegAfter(t, leg1, "V002", "DEHAM", "SEGOT")}}
+		events := []HandlingEventSummary{
+			{Type: "LOAD", Location: "DEHAM", VoyageNumber: "V002"},
+			{Type: "UNLOAD", Location: "DEHAM", VoyageNumber: "V001"},
+		}
 
-		result := itinerary.IsOnTrack("USNYC", "WRONG")
+		// Execute
+		placements := onward.PlaceHandling(events)
 
-		assert.False(t, result)
+		// Verify
+		assert.Equal(t, []HandlingPlacement{{LegIndex: 1, Expected: true}, {LegIndex: 0, Expected: true}}, placements)
 	})
 
-	t.Run("should not be on track for wrong location", func(t *testing.T) {
-		leg := createTestLeg(t, "V001", "USNYC", "SEGOT")
-		itinerary, err := NewItinerary([]Leg{leg})
-		require.NoError(t, err)
+	t.Run("should not place handling the itinerary does not plan", func(t *testing.T) {
+		// Setup
+		events := []HandlingEventSummary{
+			{Type: "LOAD", Location: "USNYC", VoyageNumber: "V999"},
+			{Type: "CLAIM", Location: "USNYC"},
+		}
 
-		result := itinerary.IsOnTrack("WRONG", "V001")
+		// Execute
+		placements := itinerary.PlaceHandling(events)
 
-		assert.False(t, result)
+		// Verify
+		assert.Equal(t, []HandlingPlacement{{LegIndex: -1, Expected: false}, {LegIndex: -1, Expected: false}}, placements)
 	})
 }
 
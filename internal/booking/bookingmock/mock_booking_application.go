@@ -25,11 +25,12 @@ type MockBookingApplication struct {
 func NewMockBookingApplication(
 	cargoRepo bookingsecondary.CargoRepository,
 	routingService bookingsecondary.RoutingService,
-	eventPublisher bookingsecondary.EventPublisher,
+	voyageSchedules bookingsecondary.VoyageScheduleService,
+	handlingHistory bookingsecondary.HandlingHistoryService,
 	logger *slog.Logger,
 	seed int64,
 ) *MockBookingApplication {
-	realApp := bookingapplication.NewBookingApplicationService(cargoRepo, routingService, eventPublisher, logger)
+	realApp := bookingapplication.NewBookingApplicationService(cargoRepo, routingService, voyageSchedules, handlingHistory, logger)
 
 	return &MockBookingApplication{
 		BookingApplicationService: realApp,
@@ -66,7 +67,7 @@ func (m *MockBookingApplication) PopulateTestCargo(ctx context.Context, scenario
 
 		// If an itinerary is provided, assign it
 		if scenario.Itinerary != nil {
-			err = m.BookingApplicationService.AssignRouteToCargo(testCtx, cargo.GetTrackingId(), *scenario.Itinerary)
+			err = m.BookingApplicationService.AssignRouteToCargo(testCtx, cargo.GetTrackingId(), *scenario.Itinerary, bookingprimary.AnyVersion)
 			if err != nil {
 				m.logger.Warn("Failed to assign route to test cargo", "error", err, "trackingId", cargo.GetTrackingId())
 				// Continue with other cargo even if route assignment fails
@@ -5,13 +5,17 @@ import (
 	"go_hex/internal/booking/bookingdomain"
 )
 
+// AnyVersion skips the expected-version check of cargo modifications. Any other expectedVersion makes the
+// modification fail with bookingdomain.ConcurrencyConflictError unless the cargo is still at that version.
+const AnyVersion = 0
+
 // BookingService defines the primary port for cargo booking operations
 type BookingService interface {
 	// BookNewCargo initiates the creation of a new cargo based on customer's request
 	BookNewCargo(ctx context.Context, origin, destination string, arrivalDeadline string) (bookingdomain.Cargo, error)
 
 	// AssignRouteToCargo assigns a chosen itinerary to an existing cargo
-	AssignRouteToCargo(ctx context.Context, trackingId bookingdomain.TrackingId, itinerary bookingdomain.Itinerary) error
+	AssignRouteToCargo(ctx context.Context, trackingId bookingdomain.TrackingId, itinerary bookingdomain.Itinerary, expectedVersion int) error
 
 	// GetCargoDetails retrieves the full state of a cargo for tracking
 	GetCargoDetails(ctx context.Context, trackingId bookingdomain.TrackingId) (bookingdomain.Cargo, error)
@@ -19,11 +23,27 @@ type BookingService interface {
 	// ListUnroutedCargo gets all cargo that require route assignment
 	ListUnroutedCargo(ctx context.Context) ([]bookingdomain.Cargo, error)
 
-	// RequestRouteCandidates gets possible itineraries for a cargo
-	RequestRouteCandidates(ctx context.Context, trackingId bookingdomain.TrackingId) ([]bookingdomain.Itinerary, error)
+	// ListAllCargo retrieves one page of the booked cargo matching the query, whatever its routing state
+	// Fails with bookingdomain.DomainValidationError if the query or its cursor is invalid.
+	ListAllCargo(ctx context.Context, query bookingdomain.CargoQuery) (bookingdomain.CargoPage, error)
+
+	// RequestRouteCandidates gets possible itineraries for a cargo, ranked by the given criteria
+	RequestRouteCandidates(ctx context.Context, trackingId bookingdomain.TrackingId, criteria bookingdomain.RankingCriteria) ([]bookingdomain.Itinerary, error)
+
+	// ChangeRouteSpecification changes the destination and/or arrival deadline of a booked cargo
+	// Empty values keep the current destination or deadline.
+	ChangeRouteSpecification(ctx context.Context, trackingId bookingdomain.TrackingId, destination, arrivalDeadline string, expectedVersion int) (bookingdomain.Cargo, error)
+
+	// CancelCargo cancels a booking that has not yet been loaded or claimed
+	CancelCargo(ctx context.Context, trackingId bookingdomain.TrackingId, reason string, expectedVersion int) error
+
+	// UpdateCargoDelivery re-derives the delivery status of a cargo from its complete handling history
+	// Concurrent modifications are retried, so a racing route change does not lose the delivery update.
+	UpdateCargoDelivery(ctx context.Context, trackingId bookingdomain.TrackingId) error
 
-	// UpdateCargoDelivery updates the delivery status of a cargo
-	UpdateCargoDelivery(ctx context.Context, trackingId bookingdomain.TrackingId, handlingHistory []bookingdomain.HandlingEventSummary) error
+	// ReviewVoyageSchedule re-evaluates the cargo routed on a rescheduled voyage against the current voyage schedules
+	// Cargo that can no longer make its connections is marked misdirected, so that it is rerouted.
+	ReviewVoyageSchedule(ctx context.Context, voyageNumber string) error
 }
 
 // CargoTracker defines the primary port for cargo tracking queries
@@ -31,3 +51,17 @@ type CargoTracker interface {
 	// TrackCargo returns the current status of cargo by tracking ID
 	TrackCargo(ctx context.Context, trackingId bookingdomain.TrackingId) (bookingdomain.Cargo, error)
 }
+
+// RerouteService defines the primary port for rerouting misdirected cargo
+type RerouteService interface {
+	// RerouteMisdirectedCargo requests route candidates from the port a misdirected cargo is in and, depending on
+	// the reroute policy, assigns the best one or puts the cargo on the reroute worklist with the candidates
+	// Cargo that is no longer misdirected is taken off the worklist instead.
+	RerouteMisdirectedCargo(ctx context.Context, trackingId bookingdomain.TrackingId) error
+
+	// ResolveReroute takes cargo off the reroute worklist once it has been rerouted or cancelled
+	ResolveReroute(ctx context.Context, trackingId bookingdomain.TrackingId) error
+
+	// ListRerouteWorklist retrieves the misdirected cargo waiting for a planner, oldest first
+	ListRerouteWorklist(ctx context.Context) ([]bookingdomain.RerouteTask, error)
+}
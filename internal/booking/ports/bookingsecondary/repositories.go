@@ -3,35 +3,59 @@ package bookingsecondary
 import (
 	"context"
 	"go_hex/internal/booking/bookingdomain"
-	"go_hex/internal/support/basedomain"
 )
 
 // CargoRepository defines the secondary port for cargo persistence
 type CargoRepository interface {
-	// Store persists a cargo aggregate
+	// Store persists a new cargo aggregate as version GetVersion()+1 and records its pending domain events in the outbox atomically
+	// Fails with bookingdomain.ConcurrencyConflictError if the tracking ID is already stored.
 	Store(cargo bookingdomain.Cargo) error
 
 	// FindByTrackingId retrieves a cargo by its tracking ID
 	FindByTrackingId(trackingId bookingdomain.TrackingId) (bookingdomain.Cargo, error)
 
-	// FindUnrouted retrieves all cargo that don't have an itinerary assigned
+	// FindUnrouted retrieves all non-cancelled cargo that don't have an itinerary assigned
 	FindUnrouted() ([]bookingdomain.Cargo, error)
 
 	// FindAll retrieves all cargo (mainly for administrative purposes)
 	FindAll() ([]bookingdomain.Cargo, error)
 
-	// Update updates an existing cargo
+	// FindByQuery retrieves one page of the cargo matching the query, in the query's sort order
+	// Fails with bookingdomain.DomainValidationError if the query or its cursor is invalid.
+	FindByQuery(query bookingdomain.CargoQuery) (bookingdomain.CargoPage, error)
+
+	// Update persists an existing cargo as version GetVersion()+1 and records its pending domain events in the outbox atomically
+	// Fails with bookingdomain.ConcurrencyConflictError if the stored cargo is no longer at GetVersion().
 	Update(cargo bookingdomain.Cargo) error
 }
 
 // RoutingService defines the secondary port for route calculation
 type RoutingService interface {
-	// FindOptimalItineraries requests route candidates from the routing context
-	FindOptimalItineraries(ctx context.Context, routeSpec bookingdomain.RouteSpecification) ([]bookingdomain.Itinerary, error)
+	// FindOptimalItineraries requests ranked route candidates from the routing context
+	FindOptimalItineraries(ctx context.Context, routeSpec bookingdomain.RouteSpecification, criteria bookingdomain.RankingCriteria) ([]bookingdomain.Itinerary, error)
+}
+
+// VoyageScheduleService defines the secondary port for reading the current schedules of the voyages cargo is routed on
+type VoyageScheduleService interface {
+	// FindLegSchedules returns the current schedule of each leg of the itinerary on its voyage, in leg order. A leg is
+	// cancelled when its voyage no longer calls at its load and unload locations in turn.
+	FindLegSchedules(ctx context.Context, itinerary bookingdomain.Itinerary) ([]bookingdomain.LegSchedule, error)
 }
 
-// EventPublisher defines the secondary port for publishing domain events
-type EventPublisher interface {
-	// Publish publishes a domain event
-	Publish(event basedomain.DomainEvent) error
+// HandlingHistoryService defines the secondary port for reading a cargo's handling history
+type HandlingHistoryService interface {
+	// GetHandlingHistory retrieves every handling event registered for the cargo so far
+	GetHandlingHistory(ctx context.Context, trackingId bookingdomain.TrackingId) ([]bookingdomain.HandlingEventSummary, error)
+}
+
+// RerouteWorklist defines the secondary port for misdirected cargo waiting for a planner to reroute it
+type RerouteWorklist interface {
+	// Put adds a task, replacing any earlier task of the same cargo
+	Put(task bookingdomain.RerouteTask) error
+
+	// Remove takes the cargo off the worklist; removing cargo that is not on it is not an error
+	Remove(trackingId bookingdomain.TrackingId) error
+
+	// FindAll retrieves all tasks, oldest first
+	FindAll() ([]bookingdomain.RerouteTask, error)
 }
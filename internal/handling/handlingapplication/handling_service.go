@@ -2,8 +2,10 @@ package handlingapplication
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"time"
 
 	"go_hex/internal/handling/handlingdomain"
@@ -12,28 +14,63 @@ import (
 	"go_hex/internal/support/auth"
 )
 
+// SequencePolicy decides what happens to handling reports that break the cargo's handling sequence
+type SequencePolicy string
+
+const (
+	// SequencePolicyStrict rejects out-of-sequence reports
+	SequencePolicyStrict SequencePolicy = "strict"
+
+	// SequencePolicyLenient sets out-of-sequence reports aside in the quarantine for review
+	SequencePolicyLenient SequencePolicy = "lenient"
+
+	// sequencePolicyReviewed registers out-of-sequence reports; it applies to reports released from the quarantine
+	sequencePolicyReviewed SequencePolicy = "reviewed"
+)
+
+// maxRegistrationAttempts bounds how often a report is checked again after concurrent reports for the same cargo
+// changed its handling history
+const maxRegistrationAttempts = 3
+
+// handlingChunkSize is how many reports of a batch are checked against the same lookups and stored in one write
+const handlingChunkSize = 100
+
 // HandlingReportService implements the primary port for handling reports
 type HandlingReportService struct {
 	handlingEventRepo handlingsecondary.HandlingEventRepository
-	eventPublisher    handlingsecondary.EventPublisher
+	quarantine        handlingsecondary.HandlingQuarantine
+	cargoBookings     handlingsecondary.CargoBookingService
+	shippingNetwork   handlingsecondary.ShippingNetworkService
+	sequencePolicy    SequencePolicy
+	timeWindow        handlingdomain.TimeWindowPolicy
 	logger            *slog.Logger
 }
 
 // NewHandlingReportService creates a new handling report service instance
 func NewHandlingReportService(
 	handlingEventRepo handlingsecondary.HandlingEventRepository,
-	eventPublisher handlingsecondary.EventPublisher,
+	quarantine handlingsecondary.HandlingQuarantine,
+	cargoBookings handlingsecondary.CargoBookingService,
+	shippingNetwork handlingsecondary.ShippingNetworkService,
+	sequencePolicy SequencePolicy,
+	timeWindow handlingdomain.TimeWindowPolicy,
 	logger *slog.Logger,
 ) handlingprimary.HandlingReportService {
 	return &HandlingReportService{
 		handlingEventRepo: handlingEventRepo,
-		eventPublisher:    eventPublisher,
+		quarantine:        quarantine,
+		cargoBookings:     cargoBookings,
+		shippingNetwork:   shippingNetwork,
+		sequencePolicy:    sequencePolicy,
+		timeWindow:        timeWindow,
 		logger:            logger,
 	}
 }
 
-// SubmitHandlingReport processes a handling report from external systems
-func (h *HandlingReportService) SubmitHandlingReport(ctx context.Context, report handlingdomain.HandlingReport) error {
+// SubmitHandlingReport processes a handling report from external systems. The repository records the registered event
+// together with the handling event, for the outbox relay to publish. A report repeating one already registered, by
+// idempotency key or by describing the same handling, returns the original event, whose registration was recorded then.
+func (h *HandlingReportService) SubmitHandlingReport(ctx context.Context, report handlingdomain.HandlingReport) (handlingdomain.HandlingReceipt, error) {
 	h.logger.Info("Processing handling report",
 		"trackingId", report.TrackingId,
 		"eventType", report.EventType,
@@ -41,6 +78,291 @@ func (h *HandlingReportService) SubmitHandlingReport(ctx context.Context, report
 	)
 
 	// Check permissions
+	if err := h.authorizeSubmission(ctx); err != nil {
+		return handlingdomain.HandlingReceipt{}, err
+	}
+
+	handlingEvent, duplicate, err := h.registerReport(ctx, report, h.timeWindow, h.sequencePolicy)
+	if err != nil {
+		return handlingdomain.HandlingReceipt{}, err
+	}
+
+	receipt := handlingdomain.HandlingReceipt{
+		EventId:      handlingEvent.GetEventId(),
+		RegisteredAt: handlingEvent.GetRegistrationTime(),
+		Duplicate:    duplicate,
+	}
+	if duplicate {
+		h.logger.Info("Handling report was already registered", "trackingId", report.TrackingId, "eventId", receipt.EventId.String())
+		return receipt, nil
+	}
+
+	h.logger.Info("Handling report processed successfully", "trackingId", report.TrackingId)
+	return receipt, nil
+}
+
+// SubmitHandlingReports processes a batch of handling reports. A report that cannot be registered does not stop the
+// batch; its result says why. Each accepted report is stored with its registered event, so an accepted report is never
+// left unpublished; reports that repeat registered ones are marked duplicate.
+func (h *HandlingReportService) SubmitHandlingReports(ctx context.Context, reports []handlingdomain.HandlingReport) ([]handlingdomain.HandlingReportResult, error) {
+	batch, err := h.StartHandlingReportBatch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return batch.Submit(ctx, reports)
+}
+
+// BackfillHandlingReports processes a batch of historical handling reports like SubmitHandlingReports, but accepts
+// completion times older than the configured maximum age. It is reserved for callers allowed to backfill handling.
+func (h *HandlingReportService) BackfillHandlingReports(ctx context.Context, reports []handlingdomain.HandlingReport) ([]handlingdomain.HandlingReportResult, error) {
+	batch, err := h.StartHandlingBackfill(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return batch.Submit(ctx, reports)
+}
+
+// StartHandlingReportBatch checks that the caller may submit handling reports and returns the batch to submit them to
+func (h *HandlingReportService) StartHandlingReportBatch(ctx context.Context) (handlingprimary.HandlingReportBatch, error) {
+	h.logger.Info("Starting handling report batch")
+
+	// Check permissions
+	if err := h.authorizeSubmission(ctx); err != nil {
+		return nil, err
+	}
+
+	return &handlingReportBatch{service: h, window: h.timeWindow}, nil
+}
+
+// StartHandlingBackfill checks that the caller may backfill handling and returns the batch to submit the historical
+// reports to
+func (h *HandlingReportService) StartHandlingBackfill(ctx context.Context) (handlingprimary.HandlingReportBatch, error) {
+	h.logger.Info("Starting handling report backfill")
+
+	// Check permissions
+	claims, err := auth.ExtractClaims(ctx)
+	if err != nil {
+		h.logger.Warn("Unauthorized handling backfill attempt", "error", err)
+		return nil, fmt.Errorf("unauthorized handling backfill: %w", err)
+	}
+	if err := RequireHandlingPermission(claims, auth.PermissionBackfillHandling); err != nil {
+		h.logger.Warn("Unauthorized handling backfill attempt", "error", err, "userId", claims.UserID)
+		return nil, fmt.Errorf("unauthorized handling backfill: %w", err)
+	}
+
+	return &handlingReportBatch{service: h, window: h.timeWindow.WithoutMaxAge()}, nil
+}
+
+// ListQuarantinedReports retrieves the reports set aside for review, oldest first
+func (h *HandlingReportService) ListQuarantinedReports(ctx context.Context) ([]handlingdomain.QuarantinedReport, error) {
+	h.logger.Info("Retrieving quarantined handling reports")
+
+	// Check permissions
+	claims, err := auth.ExtractClaims(ctx)
+	if err != nil {
+		h.logger.Warn("Unauthorized handling quarantine access attempt", "error", err)
+		return nil, fmt.Errorf("unauthorized handling quarantine access: %w", err)
+	}
+	if err := RequireHandlingPermission(claims, auth.PermissionViewHandling); err != nil {
+		h.logger.Warn("Unauthorized handling quarantine access attempt", "error", err)
+		return nil, fmt.Errorf("unauthorized handling quarantine access: %w", err)
+	}
+
+	reports, err := h.quarantine.FindAll()
+	if err != nil {
+		h.logger.Error("Failed to find quarantined handling reports", "error", err)
+		return nil, fmt.Errorf("failed to find quarantined handling reports: %w", err)
+	}
+	return reports, nil
+}
+
+// ReleaseQuarantinedReport registers a quarantined report once it has been reviewed. The sequence violation it was
+// quarantined for no longer stops it, and neither does its age, since it waited in the quarantine; its references are
+// still checked. The report leaves the quarantine once it is registered, or found to be registered already.
+func (h *HandlingReportService) ReleaseQuarantinedReport(ctx context.Context, id string) (handlingdomain.HandlingReceipt, error) {
+	h.logger.Info("Releasing quarantined handling report", "quarantineId", id)
+
+	// Check permissions
+	claims, err := auth.ExtractClaims(ctx)
+	if err != nil {
+		h.logger.Warn("Unauthorized handling quarantine release attempt", "error", err)
+		return handlingdomain.HandlingReceipt{}, fmt.Errorf("unauthorized handling quarantine release: %w", err)
+	}
+	if err := RequireHandlingPermission(claims, auth.PermissionBackfillHandling); err != nil {
+		h.logger.Warn("Unauthorized handling quarantine release attempt", "error", err, "userId", claims.UserID)
+		return handlingdomain.HandlingReceipt{}, fmt.Errorf("unauthorized handling quarantine release: %w", err)
+	}
+
+	quarantined, err := h.quarantine.FindById(id)
+	if err != nil {
+		return handlingdomain.HandlingReceipt{}, err
+	}
+
+	handlingEvent, duplicate, err := h.registerReport(ctx, quarantined.Report, h.timeWindow.WithoutMaxAge(), sequencePolicyReviewed)
+	if err != nil {
+		return handlingdomain.HandlingReceipt{}, err
+	}
+
+	if err := h.quarantine.Remove(id); err != nil {
+		h.logger.Error("Failed to remove released handling report from quarantine", "error", err, "quarantineId", id)
+		return handlingdomain.HandlingReceipt{}, fmt.Errorf("failed to remove released handling report from quarantine: %w", err)
+	}
+
+	h.logger.Info("Quarantined handling report released", "quarantineId", id, "eventId", handlingEvent.GetEventId().String())
+	return handlingdomain.HandlingReceipt{
+		EventId:      handlingEvent.GetEventId(),
+		RegisteredAt: handlingEvent.GetRegistrationTime(),
+		Duplicate:    duplicate,
+	}, nil
+}
+
+// handlingReportBatch is a batch of handling reports whose caller has been authorized
+type handlingReportBatch struct {
+	service *HandlingReportService
+	window  handlingdomain.TimeWindowPolicy
+}
+
+// Submit registers each report whose completion time lies within the batch's window
+func (b *handlingReportBatch) Submit(ctx context.Context, reports []handlingdomain.HandlingReport) ([]handlingdomain.HandlingReportResult, error) {
+	return b.service.submitBatch(ctx, reports, b.window)
+}
+
+// submitBatch registers each report of a batch whose completion time lies within window, one chunk at a time
+func (h *HandlingReportService) submitBatch(ctx context.Context, reports []handlingdomain.HandlingReport, window handlingdomain.TimeWindowPolicy) ([]handlingdomain.HandlingReportResult, error) {
+	results := make([]handlingdomain.HandlingReportResult, 0, len(reports))
+	for offset := 0; offset < len(reports); offset += handlingChunkSize {
+		chunk := reports[offset:min(offset+handlingChunkSize, len(reports))]
+		results = append(results, h.submitChunk(ctx, chunk, offset, window)...)
+	}
+
+	accepted, duplicates := 0, 0
+	for _, result := range results {
+		switch result.Status {
+		case handlingdomain.HandlingReportAccepted:
+			accepted++
+		case handlingdomain.HandlingReportDuplicate:
+			duplicates++
+		}
+	}
+
+	h.logger.Info("Handling report batch processed", "reports", len(reports), "accepted", accepted, "duplicates", duplicates)
+	return results, nil
+}
+
+// submitChunk checks the reports of a chunk against the references and handling histories they share, looked up once
+// for the chunk, and stores the accepted ones together in one write. If that write fails, because concurrent reports
+// changed a history or for any other reason, the accepted reports are registered one by one instead. Result indexes
+// start at offset.
+func (h *HandlingReportService) submitChunk(ctx context.Context, reports []handlingdomain.HandlingReport, offset int, window handlingdomain.TimeWindowPolicy) []handlingdomain.HandlingReportResult {
+	chunk := newReportChunk(h.handlingEventRepo, h.cargoBookings, h.shippingNetwork)
+	results := make([]handlingdomain.HandlingReportResult, len(reports))
+	for i, report := range reports {
+		results[i] = handlingdomain.HandlingReportResult{
+			Index:      offset + i,
+			TrackingId: report.TrackingId,
+			Status:     handlingdomain.HandlingReportAccepted,
+		}
+		handlingEvent, duplicate, err := h.checkChunkReport(ctx, chunk, i, report, window)
+		setReportOutcome(&results[i], handlingEvent, duplicate, err)
+	}
+	if len(chunk.pending) == 0 {
+		return results
+	}
+
+	err := h.handlingEventRepo.AppendAll(chunk.pendingEvents(), chunk.historyLengths())
+	if err == nil {
+		h.logger.Info("Handling events stored successfully", "events", len(chunk.pending))
+		return results
+	}
+
+	h.logger.Warn("Failed to store handling report chunk, registering its reports one by one", "error", err, "reports", len(chunk.pending))
+	for _, pending := range chunk.pending {
+		handlingEvent, duplicate, err := h.registerEvent(pending.report, pending.event, h.sequencePolicy)
+		setReportOutcome(&results[pending.index], handlingEvent, duplicate, err)
+	}
+	// Reports repeating one of the chunk share its outcome
+	for i, original := range chunk.duplicateOf {
+		if results[original].Status == handlingdomain.HandlingReportAccepted || results[original].Status == handlingdomain.HandlingReportDuplicate {
+			results[i].EventId = results[original].EventId
+			continue
+		}
+		results[i].Status = results[original].Status
+		results[i].EventId = ""
+		results[i].Reason = results[original].Reason
+	}
+	return results
+}
+
+// checkChunkReport validates the i-th report of a chunk against the stored events and the events of the chunk's
+// reports accepted before it, and adds its event to the chunk's pending events. If the report repeats a stored event or
+// one of the chunk, it returns that event and duplicate set instead.
+func (h *HandlingReportService) checkChunkReport(ctx context.Context, chunk *reportChunk, i int, report handlingdomain.HandlingReport, window handlingdomain.TimeWindowPolicy) (event handlingdomain.HandlingEvent, duplicate bool, err error) {
+	handlingEvent, err := h.newReportEvent(report, window)
+	if err != nil {
+		return handlingdomain.HandlingEvent{}, false, err
+	}
+
+	// A report repeating the idempotency key of an earlier report of the chunk gets that report's event
+	if key := handlingEvent.GetIdempotencyKey(); key != "" {
+		if earlier, found := chunk.findByIdempotencyKey(key); found {
+			if !earlier.event.IsSameHandlingAs(handlingEvent) {
+				h.logger.Warn("Rejected handling report reusing an idempotency key", "trackingId", report.TrackingId, "idempotencyKey", key)
+				return handlingdomain.HandlingEvent{}, false, fmt.Errorf("handling report rejected: %w", handlingdomain.NewIdempotencyKeyConflictError(key))
+			}
+			chunk.duplicateOf[i] = earlier.index
+			return earlier.event, true, nil
+		}
+	}
+	if original, found, err := h.findByIdempotencyKey(handlingEvent); err != nil || found {
+		return original, found, err
+	}
+
+	if err := h.verifyReferences(ctx, report, chunk.references, chunk.references); err != nil {
+		return handlingdomain.HandlingEvent{}, false, err
+	}
+
+	history, err := chunk.history(report.TrackingId)
+	if err != nil {
+		h.logger.Error("Failed to load handling history", "error", err, "trackingId", report.TrackingId)
+		return handlingdomain.HandlingEvent{}, false, fmt.Errorf("failed to load handling history: %w", err)
+	}
+	if original, found := history.FindSameHandling(handlingEvent); found {
+		if earlier, pending := chunk.findPending(original.GetEventId()); pending {
+			chunk.duplicateOf[i] = earlier.index
+		}
+		return original, true, nil
+	}
+	if err := h.checkSequence(report, history, handlingEvent, h.sequencePolicy); err != nil {
+		return handlingdomain.HandlingEvent{}, false, err
+	}
+
+	chunk.add(i, report, handlingEvent)
+	return handlingEvent, false, nil
+}
+
+// setReportOutcome records on result how registering its report turned out
+func setReportOutcome(result *handlingdomain.HandlingReportResult, handlingEvent handlingdomain.HandlingEvent, duplicate bool, err error) {
+	if err != nil {
+		result.Status = handlingdomain.HandlingReportRejected
+		var quarantined handlingdomain.ReportQuarantinedError
+		if errors.As(err, &quarantined) {
+			result.Status = handlingdomain.HandlingReportQuarantined
+		}
+		result.EventId = ""
+		result.Reason = err.Error()
+		return
+	}
+
+	result.Status = handlingdomain.HandlingReportAccepted
+	if duplicate {
+		result.Status = handlingdomain.HandlingReportDuplicate
+	}
+	result.EventId = handlingEvent.GetEventId().String()
+	result.Reason = ""
+}
+
+// authorizeSubmission checks that the caller may submit handling reports
+func (h *HandlingReportService) authorizeSubmission(ctx context.Context) error {
 	claims, err := auth.ExtractClaims(ctx)
 	if err != nil {
 		h.logger.Warn("Unauthorized handling submission attempt", "error", err)
@@ -50,19 +372,46 @@ func (h *HandlingReportService) SubmitHandlingReport(ctx context.Context, report
 		h.logger.Warn("Unauthorized handling submission attempt", "error", err)
 		return fmt.Errorf("unauthorized handling submission: %w", err)
 	}
+	return nil
+}
+
+// registerReport validates a report, whose completion time must lie within window, and stores it as a handling event
+// with its domain events; policy decides what happens if it breaks the handling sequence. If the report repeats one
+// already registered, it returns the stored event and duplicate set instead.
+func (h *HandlingReportService) registerReport(ctx context.Context, report handlingdomain.HandlingReport, window handlingdomain.TimeWindowPolicy, policy SequencePolicy) (event handlingdomain.HandlingEvent, duplicate bool, err error) {
+	handlingEvent, err := h.newReportEvent(report, window)
+	if err != nil {
+		return handlingdomain.HandlingEvent{}, false, err
+	}
 
+	// A retry with the same idempotency key gets the event registered the first time
+	if original, found, err := h.findByIdempotencyKey(handlingEvent); err != nil || found {
+		return original, found, err
+	}
+
+	// Check the cargo, location and voyage against the booking and routing contexts
+	if err := h.verifyReferences(ctx, report, h.cargoBookings, h.shippingNetwork); err != nil {
+		return handlingdomain.HandlingEvent{}, false, err
+	}
+
+	return h.registerEvent(report, handlingEvent, policy)
+}
+
+// newReportEvent creates the handling event a report describes, provided its completion time lies within window
+func (h *HandlingReportService) newReportEvent(report handlingdomain.HandlingReport, window handlingdomain.TimeWindowPolicy) (handlingdomain.HandlingEvent, error) {
 	// Parse completion time
 	completionTime, err := time.Parse(time.RFC3339, report.CompletionTime)
 	if err != nil {
 		h.logger.Error("Invalid completion time format", "error", err, "completionTime", report.CompletionTime)
-		return fmt.Errorf("invalid completion time format: %w", err)
+		return handlingdomain.HandlingEvent{}, fmt.Errorf("invalid completion time format: %w", err)
 	}
 
 	// Convert string event type to domain event type
 	eventType := handlingdomain.HandlingEventType(report.EventType)
 
 	// Create handling event domain object
-	handlingEvent, err := handlingdomain.NewHandlingEvent(
+	handlingEvent, err := handlingdomain.NewHandlingEventWithinWindow(
+		window,
 		report.TrackingId,
 		eventType,
 		report.Location,
@@ -71,30 +420,187 @@ func (h *HandlingReportService) SubmitHandlingReport(ctx context.Context, report
 	)
 	if err != nil {
 		h.logger.Error("Failed to create handling event", "error", err, "trackingId", report.TrackingId)
-		return fmt.Errorf("failed to create handling event: %w", err)
+		return handlingdomain.HandlingEvent{}, fmt.Errorf("failed to create handling event: %w", err)
+	}
+	return handlingEvent.WithIdempotencyKey(report.IdempotencyKey), nil
+}
+
+// registerEvent checks the handling event of a report with verified references against the cargo's handling history
+// and stores it. Reports for the same cargo registered concurrently are checked again against the history the other
+// one left.
+func (h *HandlingReportService) registerEvent(report handlingdomain.HandlingReport, handlingEvent handlingdomain.HandlingEvent, policy SequencePolicy) (event handlingdomain.HandlingEvent, duplicate bool, err error) {
+	for attempt := 1; ; attempt++ {
+		event, duplicate, err = h.appendToHistory(report, handlingEvent, policy)
+		var changed handlingdomain.HandlingHistoryChangedError
+		if !errors.As(err, &changed) || attempt == maxRegistrationAttempts {
+			return event, duplicate, err
+		}
+		h.logger.Info("Handling history changed while registering report, checking again", "trackingId", report.TrackingId, "attempt", attempt)
 	}
+}
 
-	// Store the handling event
-	if err := h.handlingEventRepo.Store(handlingEvent); err != nil {
+// appendToHistory checks handlingEvent against the cargo's stored handling history and stores it, provided the history
+// is unchanged by then. If the history already records the handling, it returns the stored event and duplicate set.
+func (h *HandlingReportService) appendToHistory(report handlingdomain.HandlingReport, handlingEvent handlingdomain.HandlingEvent, policy SequencePolicy) (event handlingdomain.HandlingEvent, duplicate bool, err error) {
+	events, err := h.handlingEventRepo.FindByTrackingId(report.TrackingId)
+	if err != nil {
+		h.logger.Error("Failed to load handling history", "error", err, "trackingId", report.TrackingId)
+		return handlingdomain.HandlingEvent{}, false, fmt.Errorf("failed to load handling history: %w", err)
+	}
+
+	history, err := handlingdomain.NewHandlingHistory(report.TrackingId, events)
+	if err != nil {
+		return handlingdomain.HandlingEvent{}, false, fmt.Errorf("failed to create handling history: %w", err)
+	}
+
+	// A report of handling that is already recorded is a retry without a key
+	if original, found := history.FindSameHandling(handlingEvent); found {
+		return original, true, nil
+	}
+
+	// Check the event against what already happened to the cargo
+	if err := h.checkSequence(report, history, handlingEvent, policy); err != nil {
+		return handlingdomain.HandlingEvent{}, false, err
+	}
+
+	// Store the handling event, unless events were stored for the cargo since its history was loaded
+	if err := h.handlingEventRepo.Append(handlingEvent, len(events)); err != nil {
+		var changed handlingdomain.HandlingHistoryChangedError
+		if errors.As(err, &changed) {
+			return handlingdomain.HandlingEvent{}, false, err
+		}
+		// A concurrent retry, with the same key or of the same handling, may have been stored first
+		if original, found := h.findStoredRetry(handlingEvent); found {
+			return original, true, nil
+		}
 		h.logger.Error("Failed to store handling event", "error", err, "eventId", handlingEvent.Id.String())
-		return fmt.Errorf("failed to store handling event: %w", err)
+		return handlingdomain.HandlingEvent{}, false, fmt.Errorf("failed to store handling event: %w", err)
 	}
 
 	h.logger.Info("Handling event stored successfully", "eventId", handlingEvent.Id.String())
+	return handlingEvent, false, nil
+}
 
-	// Publish domain events
-	for _, event := range handlingEvent.GetEvents() {
-		if err := h.eventPublisher.Publish(event); err != nil {
-			h.logger.Error("Failed to publish handling event", "error", err, "eventType", fmt.Sprintf("%T", event))
-			return fmt.Errorf("failed to publish handling event: %w", err)
-		}
-		h.logger.Debug("Published domain event", "eventType", fmt.Sprintf("%T", event))
+// findByIdempotencyKey returns the stored event sharing handlingEvent's idempotency key, rejecting the report if that
+// event records different handling. It finds nothing for events without a key.
+func (h *HandlingReportService) findByIdempotencyKey(handlingEvent handlingdomain.HandlingEvent) (handlingdomain.HandlingEvent, bool, error) {
+	key := handlingEvent.GetIdempotencyKey()
+	if key == "" {
+		return handlingdomain.HandlingEvent{}, false, nil
+	}
+
+	original, found, err := h.handlingEventRepo.FindByIdempotencyKey(key)
+	if err != nil {
+		h.logger.Error("Failed to look up idempotency key", "error", err, "idempotencyKey", key)
+		return handlingdomain.HandlingEvent{}, false, fmt.Errorf("failed to look up idempotency key: %w", err)
+	}
+	if !found {
+		return handlingdomain.HandlingEvent{}, false, nil
+	}
+
+	if !original.IsSameHandlingAs(handlingEvent) {
+		h.logger.Warn("Rejected handling report reusing an idempotency key", "trackingId", handlingEvent.GetTrackingId(), "idempotencyKey", key)
+		return handlingdomain.HandlingEvent{}, false, fmt.Errorf("handling report rejected: %w", handlingdomain.NewIdempotencyKeyConflictError(key))
+	}
+	return original, true, nil
+}
+
+// findStoredRetry looks for an event stored since handlingEvent was checked that makes it a duplicate: one with its
+// idempotency key, or one recording the same handling
+func (h *HandlingReportService) findStoredRetry(handlingEvent handlingdomain.HandlingEvent) (handlingdomain.HandlingEvent, bool) {
+	if original, found, err := h.findByIdempotencyKey(handlingEvent); err == nil && found {
+		return original, true
+	}
+
+	events, err := h.handlingEventRepo.FindByTrackingId(handlingEvent.GetTrackingId())
+	if err != nil {
+		return handlingdomain.HandlingEvent{}, false
+	}
+	history, err := handlingdomain.NewHandlingHistory(handlingEvent.GetTrackingId(), events)
+	if err != nil {
+		return handlingdomain.HandlingEvent{}, false
+	}
+	return history.FindSameHandling(handlingEvent)
+}
+
+// verifyReferences rejects reports for cargo that is not booked, unknown locations and voyages not calling at the
+// location, as told by cargoBookings and shippingNetwork
+func (h *HandlingReportService) verifyReferences(ctx context.Context, report handlingdomain.HandlingReport, cargoBookings handlingsecondary.CargoBookingService, shippingNetwork handlingsecondary.ShippingNetworkService) error {
+	booked, err := cargoBookings.IsCargoBooked(ctx, report.TrackingId)
+	if err != nil {
+		h.logger.Error("Failed to check cargo booking", "error", err, "trackingId", report.TrackingId)
+		return fmt.Errorf("failed to check cargo booking: %w", err)
+	}
+	if !booked {
+		return h.rejectReference(report, fmt.Sprintf("cargo %s is not booked", report.TrackingId))
+	}
+
+	known, err := shippingNetwork.IsKnownLocation(ctx, report.Location)
+	if err != nil {
+		h.logger.Error("Failed to check handling location", "error", err, "location", report.Location)
+		return fmt.Errorf("failed to check handling location: %w", err)
+	}
+	if !known {
+		return h.rejectReference(report, fmt.Sprintf("location %s is unknown", report.Location))
+	}
+
+	if report.VoyageNumber == "" {
+		return nil
+	}
+
+	portCalls, found, err := shippingNetwork.FindVoyagePortCalls(ctx, report.VoyageNumber)
+	if err != nil {
+		h.logger.Error("Failed to check handling voyage", "error", err, "voyageNumber", report.VoyageNumber)
+		return fmt.Errorf("failed to check handling voyage: %w", err)
+	}
+	if !found {
+		return h.rejectReference(report, fmt.Sprintf("voyage %s is unknown", report.VoyageNumber))
+	}
+	if !slices.Contains(portCalls, report.Location) {
+		return h.rejectReference(report, fmt.Sprintf("voyage %s does not call at %s", report.VoyageNumber, report.Location))
 	}
 
-	h.logger.Info("Handling report processed successfully", "trackingId", report.TrackingId)
 	return nil
 }
 
+// rejectReference logs and returns an unknown reference error for report
+func (h *HandlingReportService) rejectReference(report handlingdomain.HandlingReport, reason string) error {
+	h.logger.Warn("Rejected handling report with unknown reference", "trackingId", report.TrackingId, "eventType", report.EventType, "reason", reason)
+	return fmt.Errorf("handling report rejected: %w", handlingdomain.NewUnknownReferenceError(reason))
+}
+
+// checkSequence validates handlingEvent against the cargo's stored history and applies policy to violations
+func (h *HandlingReportService) checkSequence(report handlingdomain.HandlingReport, history handlingdomain.HandlingHistory, handlingEvent handlingdomain.HandlingEvent, policy SequencePolicy) error {
+	violation := history.ValidateNewEvent(handlingEvent)
+	if violation == nil {
+		return nil
+	}
+
+	var sequenceErr handlingdomain.HandlingSequenceError
+	if !errors.As(violation, &sequenceErr) {
+		return fmt.Errorf("failed to validate handling sequence: %w", violation)
+	}
+
+	if policy == sequencePolicyReviewed {
+		h.logger.Warn("Registering reviewed out-of-sequence handling report", "trackingId", report.TrackingId, "eventType", report.EventType, "reason", violation)
+		return nil
+	}
+
+	if policy != SequencePolicyLenient {
+		h.logger.Warn("Rejected out-of-sequence handling report", "trackingId", report.TrackingId, "eventType", report.EventType, "reason", violation)
+		return fmt.Errorf("handling report rejected: %w", violation)
+	}
+
+	quarantined := handlingdomain.NewQuarantinedReport(report, violation.Error())
+	if err := h.quarantine.Add(quarantined); err != nil {
+		h.logger.Error("Failed to quarantine handling report", "error", err, "trackingId", report.TrackingId)
+		return fmt.Errorf("failed to quarantine handling report: %w", err)
+	}
+
+	h.logger.Warn("Quarantined out-of-sequence handling report", "trackingId", report.TrackingId, "eventType", report.EventType, "quarantineId", quarantined.Id, "reason", violation)
+	return handlingdomain.NewReportQuarantinedError(quarantined, violation)
+}
+
 // HandlingEventQueryService implements the primary port for querying handling events
 type HandlingEventQueryService struct {
 	handlingEventRepo handlingsecondary.HandlingEventRepository
@@ -168,6 +674,34 @@ func (h *HandlingEventQueryService) ListAllHandlingEvents(ctx context.Context) (
 	return events, nil
 }
 
+// ListHandlingEvents retrieves one page of the handling events matching the query, by completion time and then event ID
+func (h *HandlingEventQueryService) ListHandlingEvents(ctx context.Context, query handlingdomain.HandlingEventQuery) (handlingdomain.HandlingEventPage, error) {
+	// Check permissions
+	claims, err := auth.ExtractClaims(ctx)
+	if err != nil {
+		h.logger.Warn("Unauthorized handling events access attempt", "error", err)
+		return handlingdomain.HandlingEventPage{}, fmt.Errorf("unauthorized handling events access: %w", err)
+	}
+	if err := RequireHandlingPermission(claims, auth.PermissionViewHandling); err != nil {
+		h.logger.Warn("Unauthorized handling events access attempt", "error", err)
+		return handlingdomain.HandlingEventPage{}, fmt.Errorf("unauthorized handling events access: %w", err)
+	}
+
+	query, err = query.Normalize()
+	if err != nil {
+		return handlingdomain.HandlingEventPage{}, err
+	}
+
+	page, err := h.handlingEventRepo.FindByQuery(query)
+	if err != nil {
+		h.logger.Error("Failed to list handling events", "error", err)
+		return handlingdomain.HandlingEventPage{}, fmt.Errorf("failed to list handling events: %w", err)
+	}
+
+	h.logger.Debug("Listed handling events", "eventCount", len(page.Events), "more", page.NextCursor != "")
+	return page, nil
+}
+
 // GetHandlingEvent retrieves a specific handling event by ID
 func (h *HandlingEventQueryService) GetHandlingEvent(ctx context.Context, eventId handlingdomain.HandlingEventId) (handlingdomain.HandlingEvent, error) {
 	h.logger.Info("Retrieving handling event by ID", "eventId", eventId.String())
@@ -11,7 +11,6 @@ import (
 	"go_hex/internal/handling/handlingdomain"
 	"go_hex/internal/handling/ports/handlingprimary"
 	"go_hex/internal/support/auth"
-	"go_hex/internal/support/basedomain"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -28,6 +27,16 @@ func (m *MockHandlingEventRepository) Store(event handlingdomain.HandlingEvent)
 	return args.Error(0)
 }
 
+func (m *MockHandlingEventRepository) Append(event handlingdomain.HandlingEvent, historyLength int) error {
+	args := m.Called(event, historyLength)
+	return args.Error(0)
+}
+
+func (m *MockHandlingEventRepository) AppendAll(events []handlingdomain.HandlingEvent, historyLengths map[string]int) error {
+	args := m.Called(events, historyLengths)
+	return args.Error(0)
+}
+
 func (m *MockHandlingEventRepository) FindByTrackingId(trackingId string) ([]handlingdomain.HandlingEvent, error) {
 	args := m.Called(trackingId)
 	return args.Get(0).([]handlingdomain.HandlingEvent), args.Error(1)
@@ -38,40 +47,113 @@ func (m *MockHandlingEventRepository) FindAll() ([]handlingdomain.HandlingEvent,
 	return args.Get(0).([]handlingdomain.HandlingEvent), args.Error(1)
 }
 
+func (m *MockHandlingEventRepository) FindByQuery(query handlingdomain.HandlingEventQuery) (handlingdomain.HandlingEventPage, error) {
+	args := m.Called(query)
+	return args.Get(0).(handlingdomain.HandlingEventPage), args.Error(1)
+}
+
+func (m *MockHandlingEventRepository) FindByIdempotencyKey(key string) (handlingdomain.HandlingEvent, bool, error) {
+	args := m.Called(key)
+	return args.Get(0).(handlingdomain.HandlingEvent), args.Bool(1), args.Error(2)
+}
+
 func (m *MockHandlingEventRepository) FindById(id handlingdomain.HandlingEventId) (handlingdomain.HandlingEvent, error) {
 	args := m.Called(id)
 	return args.Get(0).(handlingdomain.HandlingEvent), args.Error(1)
 }
 
-type MockHandlingEventPublisher struct {
+type MockHandlingQuarantine struct {
 	mock.Mock
 }
 
-func (m *MockHandlingEventPublisher) Publish(event basedomain.DomainEvent) error {
-	args := m.Called(event)
+func (m *MockHandlingQuarantine) Add(report handlingdomain.QuarantinedReport) error {
+	args := m.Called(report)
+	return args.Error(0)
+}
+
+func (m *MockHandlingQuarantine) FindAll() ([]handlingdomain.QuarantinedReport, error) {
+	args := m.Called()
+	return args.Get(0).([]handlingdomain.QuarantinedReport), args.Error(1)
+}
+
+func (m *MockHandlingQuarantine) FindById(id string) (handlingdomain.QuarantinedReport, error) {
+	args := m.Called(id)
+	return args.Get(0).(handlingdomain.QuarantinedReport), args.Error(1)
+}
+
+func (m *MockHandlingQuarantine) Remove(id string) error {
+	args := m.Called(id)
 	return args.Error(0)
 }
 
+type MockCargoBookingService struct {
+	mock.Mock
+}
+
+func (m *MockCargoBookingService) IsCargoBooked(ctx context.Context, trackingId string) (bool, error) {
+	args := m.Called(ctx, trackingId)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockShippingNetworkService struct {
+	mock.Mock
+}
+
+func (m *MockShippingNetworkService) IsKnownLocation(ctx context.Context, unLocode string) (bool, error) {
+	args := m.Called(ctx, unLocode)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockShippingNetworkService) FindVoyagePortCalls(ctx context.Context, voyageNumber string) ([]string, bool, error) {
+	args := m.Called(ctx, voyageNumber)
+	return args.Get(0).([]string), args.Bool(1), args.Error(2)
+}
+
 func TestHandlingReportService_SubmitHandlingReport(t *testing.T) {
-	setup := func() (handlingprimary.HandlingReportService, *MockHandlingEventRepository, *MockHandlingEventPublisher) {
+	setupWithReferences := func(policy SequencePolicy, bookings *MockCargoBookingService, network *MockShippingNetworkService) (handlingprimary.HandlingReportService, *MockHandlingEventRepository, *MockHandlingQuarantine) {
 		repo := &MockHandlingEventRepository{}
-		publisher := &MockHandlingEventPublisher{}
+		quarantine := &MockHandlingQuarantine{}
 
 		jsonHandler := slog.NewJSONHandler(os.Stdout, nil)
 
 		logger := slog.New(jsonHandler)
 
-		service := NewHandlingReportService(repo, publisher, logger)
+		service := NewHandlingReportService(repo, quarantine, bookings, network, policy, handlingdomain.DefaultTimeWindowPolicy(), logger)
+
+		return service, repo, quarantine
+	}
+
+	// knownReferences accepts cargo TEST123 and voyage V001 sailing from USNYC to DEHAM
+	knownReferences := func() (*MockCargoBookingService, *MockShippingNetworkService) {
+		bookings := &MockCargoBookingService{}
+		bookings.On("IsCargoBooked", mock.Anything, "TEST123").Return(true, nil).Maybe()
+
+		network := &MockShippingNetworkService{}
+		network.On("IsKnownLocation", mock.Anything, mock.Anything).Return(true, nil).Maybe()
+		network.On("FindVoyagePortCalls", mock.Anything, "V001").Return([]string{"USNYC", "DEHAM"}, true, nil).Maybe()
+
+		return bookings, network
+	}
 
-		return service, repo, publisher
+	setupWithPolicy := func(policy SequencePolicy) (handlingprimary.HandlingReportService, *MockHandlingEventRepository, *MockHandlingQuarantine) {
+		bookings, network := knownReferences()
+		return setupWithReferences(policy, bookings, network)
+	}
+
+	setup := func() (handlingprimary.HandlingReportService, *MockHandlingEventRepository) {
+		service, repo, _ := setupWithPolicy(SequencePolicyStrict)
+		return service, repo
 	}
 
 	t.Run("should submit handling report successfully", func(t *testing.T) {
-		service, repo, publisher := setup()
+		service, repo := setup()
 
-		// Setup mocks
-		repo.On("Store", mock.AnythingOfType("handlingdomain.HandlingEvent")).Return(nil)
-		publisher.On("Publish", mock.Anything).Return(nil)
+		// Setup mocks: the registered event is stored with the handling event, for the outbox
+		repo.On("FindByTrackingId", "TEST123").Return([]handlingdomain.HandlingEvent{}, nil)
+		repo.On("Append", mock.MatchedBy(func(event handlingdomain.HandlingEvent) bool {
+			events := event.GetEvents()
+			return len(events) == 1 && events[0].EventName() == handlingdomain.HandlingEventRegisteredEvent{}.EventName()
+		}), mock.Anything).Return(nil)
 
 		// Create context with valid claims
 		ctx := createContextWithClaims(t, []string{})
@@ -86,16 +168,15 @@ func TestHandlingReportService_SubmitHandlingReport(t *testing.T) {
 		}
 
 		// Execute
-		err := service.SubmitHandlingReport(ctx, report)
+		_, err := service.SubmitHandlingReport(ctx, report)
 
 		// Verify
 		require.NoError(t, err)
 		repo.AssertExpectations(t)
-		publisher.AssertExpectations(t)
 	})
 
 	t.Run("should fail with unauthorized context", func(t *testing.T) {
-		service, _, _ := setup()
+		service, _ := setup()
 
 		// Create context without proper claims
 		ctx := context.Background()
@@ -109,7 +190,7 @@ func TestHandlingReportService_SubmitHandlingReport(t *testing.T) {
 		}
 
 		// Execute
-		err := service.SubmitHandlingReport(ctx, report)
+		_, err := service.SubmitHandlingReport(ctx, report)
 
 		// Verify
 		assert.Error(t, err)
@@ -117,7 +198,7 @@ func TestHandlingReportService_SubmitHandlingReport(t *testing.T) {
 	})
 
 	t.Run("should fail with invalid completion time format", func(t *testing.T) {
-		service, _, _ := setup()
+		service, _ := setup()
 
 		// Create context with valid claims
 		ctx := createContextWithClaims(t, []string{})
@@ -131,7 +212,7 @@ func TestHandlingReportService_SubmitHandlingReport(t *testing.T) {
 		}
 
 		// Execute
-		err := service.SubmitHandlingReport(ctx, report)
+		_, err := service.SubmitHandlingReport(ctx, report)
 
 		// Verify
 		assert.Error(t, err)
@@ -139,10 +220,11 @@ func TestHandlingReportService_SubmitHandlingReport(t *testing.T) {
 	})
 
 	t.Run("should fail when repository store fails", func(t *testing.T) {
-		service, repo, _ := setup()
+		service, repo := setup()
 
 		// Setup mocks
-		repo.On("Store", mock.AnythingOfType("handlingdomain.HandlingEvent")).Return(errors.New("storage error"))
+		repo.On("FindByTrackingId", "TEST123").Return([]handlingdomain.HandlingEvent{}, nil)
+		repo.On("Append", mock.AnythingOfType("handlingdomain.HandlingEvent"), mock.Anything).Return(errors.New("storage error"))
 
 		// Create context with valid claims
 		ctx := createContextWithClaims(t, []string{})
@@ -156,7 +238,7 @@ func TestHandlingReportService_SubmitHandlingReport(t *testing.T) {
 		}
 
 		// Execute
-		err := service.SubmitHandlingReport(ctx, report)
+		_, err := service.SubmitHandlingReport(ctx, report)
 
 		// Verify
 		assert.Error(t, err)
@@ -165,7 +247,7 @@ func TestHandlingReportService_SubmitHandlingReport(t *testing.T) {
 	})
 
 	t.Run("should fail with invalid event type", func(t *testing.T) {
-		service, _, _ := setup()
+		service, _ := setup()
 
 		// Create context with valid claims
 		ctx := createContextWithClaims(t, []string{})
@@ -179,7 +261,7 @@ func TestHandlingReportService_SubmitHandlingReport(t *testing.T) {
 		}
 
 		// Execute
-		err := service.SubmitHandlingReport(ctx, report)
+		_, err := service.SubmitHandlingReport(ctx, report)
 
 		// Verify
 		assert.Error(t, err)
@@ -187,7 +269,7 @@ func TestHandlingReportService_SubmitHandlingReport(t *testing.T) {
 	})
 
 	t.Run("should fail when LOAD event missing voyage number", func(t *testing.T) {
-		service, _, _ := setup()
+		service, _ := setup()
 
 		// Create context with valid claims
 		ctx := createContextWithClaims(t, []string{})
@@ -201,12 +283,761 @@ func TestHandlingReportService_SubmitHandlingReport(t *testing.T) {
 		}
 
 		// Execute
-		err := service.SubmitHandlingReport(ctx, report)
+		_, err := service.SubmitHandlingReport(ctx, report)
 
 		// Verify
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to create handling event")
 	})
+
+	t.Run("should reject LOAD before RECEIVE under strict policy", func(t *testing.T) {
+		service, repo := setup()
+
+		// Setup mocks
+		repo.On("FindByTrackingId", "TEST123").Return([]handlingdomain.HandlingEvent{}, nil)
+
+		ctx := createContextWithClaims(t, []string{})
+
+		report := handlingdomain.HandlingReport{
+			TrackingId:     "TEST123",
+			EventType:      "LOAD",
+			Location:       "USNYC",
+			VoyageNumber:   "V001",
+			CompletionTime: time.Now().Add(-time.Hour).Format(time.RFC3339),
+		}
+
+		// Execute
+		_, err := service.SubmitHandlingReport(ctx, report)
+
+		// Verify
+		var sequenceErr handlingdomain.HandlingSequenceError
+		require.ErrorAs(t, err, &sequenceErr)
+		assert.Contains(t, err.Error(), "first handling event must be RECEIVE")
+		repo.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject second CLAIM under strict policy", func(t *testing.T) {
+		service, repo := setup()
+
+		// Setup mocks
+		history := createTestHandlingEvents(t)
+		claim, err := handlingdomain.NewHandlingEvent("TEST123", handlingdomain.HandlingEventTypeClaim, "DEHAM", "", time.Now().Add(-30*time.Minute))
+		require.NoError(t, err)
+		repo.On("FindByTrackingId", "TEST123").Return(append(history, claim), nil)
+
+		ctx := createContextWithClaims(t, []string{})
+
+		report := handlingdomain.HandlingReport{
+			TrackingId:     "TEST123",
+			EventType:      "CLAIM",
+			Location:       "DEHAM",
+			CompletionTime: time.Now().Add(-10 * time.Minute).Format(time.RFC3339),
+		}
+
+		// Execute
+		_, err = service.SubmitHandlingReport(ctx, report)
+
+		// Verify
+		var sequenceErr handlingdomain.HandlingSequenceError
+		require.ErrorAs(t, err, &sequenceErr)
+		assert.Contains(t, err.Error(), "cargo has already been claimed")
+		repo.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should accept late report that fits the sequence", func(t *testing.T) {
+		service, repo := setup()
+
+		// Setup mocks
+		repo.On("FindByTrackingId", "TEST123").Return(createTestHandlingEvents(t), nil)
+		repo.On("Append", mock.AnythingOfType("handlingdomain.HandlingEvent"), mock.Anything).Return(nil)
+
+		ctx := createContextWithClaims(t, []string{})
+
+		// Customs clearance completed before the cargo was loaded but reported afterwards
+		report := handlingdomain.HandlingReport{
+			TrackingId:     "TEST123",
+			EventType:      "CUSTOMS",
+			Location:       "USNYC",
+			CompletionTime: time.Now().Add(-150 * time.Minute).Format(time.RFC3339),
+		}
+
+		// Execute
+		_, err := service.SubmitHandlingReport(ctx, report)
+
+		// Verify
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("should quarantine out-of-sequence report under lenient policy", func(t *testing.T) {
+		service, repo, quarantine := setupWithPolicy(SequencePolicyLenient)
+
+		// Setup mocks
+		repo.On("FindByTrackingId", "TEST123").Return([]handlingdomain.HandlingEvent{}, nil)
+		quarantine.On("Add", mock.MatchedBy(func(q handlingdomain.QuarantinedReport) bool {
+			return q.Report.EventType == "CLAIM" && q.Reason == "first handling event must be RECEIVE"
+		}), mock.Anything).Return(nil)
+
+		ctx := createContextWithClaims(t, []string{})
+
+		report := handlingdomain.HandlingReport{
+			TrackingId:     "TEST123",
+			EventType:      "CLAIM",
+			Location:       "DEHAM",
+			CompletionTime: time.Now().Add(-time.Hour).Format(time.RFC3339),
+		}
+
+		// Execute
+		_, err := service.SubmitHandlingReport(ctx, report)
+
+		// Verify
+		var quarantinedErr handlingdomain.ReportQuarantinedError
+		require.ErrorAs(t, err, &quarantinedErr)
+		quarantine.AssertExpectations(t)
+		repo.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+	})
+
+	loadReport := func(location, voyageNumber string) handlingdomain.HandlingReport {
+		return handlingdomain.HandlingReport{
+			TrackingId:     "TEST123",
+			EventType:      "LOAD",
+			Location:       location,
+			VoyageNumber:   voyageNumber,
+			CompletionTime: time.Now().Add(-time.Hour).Format(time.RFC3339),
+		}
+	}
+
+	t.Run("should reject report for cargo that is not booked", func(t *testing.T) {
+		bookings := &MockCargoBookingService{}
+		bookings.On("IsCargoBooked", mock.Anything, "TEST123").Return(false, nil)
+		_, network := knownReferences()
+		service, repo, _ := setupWithReferences(SequencePolicyLenient, bookings, network)
+
+		// Execute
+		_, err := service.SubmitHandlingReport(createContextWithClaims(t, []string{}), loadReport("USNYC", "V001"))
+
+		// Verify
+		var referenceErr handlingdomain.UnknownReferenceError
+		require.ErrorAs(t, err, &referenceErr)
+		assert.Contains(t, err.Error(), "cargo TEST123 is not booked")
+		repo.AssertNotCalled(t, "FindByTrackingId", mock.Anything)
+		repo.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject report at unknown location", func(t *testing.T) {
+		bookings, _ := knownReferences()
+		network := &MockShippingNetworkService{}
+		network.On("IsKnownLocation", mock.Anything, "XXXXX").Return(false, nil)
+		service, repo, _ := setupWithReferences(SequencePolicyStrict, bookings, network)
+
+		// Execute
+		_, err := service.SubmitHandlingReport(createContextWithClaims(t, []string{}), loadReport("XXXXX", "V001"))
+
+		// Verify
+		var referenceErr handlingdomain.UnknownReferenceError
+		require.ErrorAs(t, err, &referenceErr)
+		assert.Contains(t, err.Error(), "location XXXXX is unknown")
+		repo.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject report for unknown voyage", func(t *testing.T) {
+		bookings, network := knownReferences()
+		network.On("FindVoyagePortCalls", mock.Anything, "V999").Return([]string(nil), false, nil)
+		service, repo, _ := setupWithReferences(SequencePolicyStrict, bookings, network)
+
+		// Execute
+		_, err := service.SubmitHandlingReport(createContextWithClaims(t, []string{}), loadReport("USNYC", "V999"))
+
+		// Verify
+		var referenceErr handlingdomain.UnknownReferenceError
+		require.ErrorAs(t, err, &referenceErr)
+		assert.Contains(t, err.Error(), "voyage V999 is unknown")
+		repo.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject report for voyage not calling at location", func(t *testing.T) {
+		service, repo, _ := setupWithPolicy(SequencePolicyStrict)
+
+		// Execute
+		_, err := service.SubmitHandlingReport(createContextWithClaims(t, []string{}), loadReport("SEGOT", "V001"))
+
+		// Verify
+		var referenceErr handlingdomain.UnknownReferenceError
+		require.ErrorAs(t, err, &referenceErr)
+		assert.Contains(t, err.Error(), "voyage V001 does not call at SEGOT")
+		repo.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should fail when cargo booking cannot be checked", func(t *testing.T) {
+		bookings := &MockCargoBookingService{}
+		bookings.On("IsCargoBooked", mock.Anything, "TEST123").Return(false, errors.New("booking unavailable"))
+		_, network := knownReferences()
+		service, repo, _ := setupWithReferences(SequencePolicyStrict, bookings, network)
+
+		// Execute
+		_, err := service.SubmitHandlingReport(createContextWithClaims(t, []string{}), loadReport("USNYC", "V001"))
+
+		// Verify
+		var referenceErr handlingdomain.UnknownReferenceError
+		assert.False(t, errors.As(err, &referenceErr))
+		assert.Contains(t, err.Error(), "failed to check cargo booking")
+		repo.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+	})
+
+	receiveReport := func(completionTime time.Time, idempotencyKey string) handlingdomain.HandlingReport {
+		return handlingdomain.HandlingReport{
+			TrackingId:     "TEST123",
+			EventType:      "RECEIVE",
+			Location:       "USNYC",
+			CompletionTime: completionTime.Format(time.RFC3339),
+			IdempotencyKey: idempotencyKey,
+		}
+	}
+
+	t.Run("should store idempotency key with new event", func(t *testing.T) {
+		service, repo := setup()
+
+		// Setup mocks
+		repo.On("FindByIdempotencyKey", "scan-1").Return(handlingdomain.HandlingEvent{}, false, nil)
+		repo.On("FindByTrackingId", "TEST123").Return([]handlingdomain.HandlingEvent{}, nil)
+		repo.On("Append", mock.MatchedBy(func(event handlingdomain.HandlingEvent) bool {
+			return event.GetIdempotencyKey() == "scan-1"
+		}), mock.Anything).Return(nil)
+
+		// Execute
+		receipt, err := service.SubmitHandlingReport(createContextWithClaims(t, []string{}), receiveReport(time.Now().Add(-time.Hour), "scan-1"))
+
+		// Verify
+		require.NoError(t, err)
+		assert.False(t, receipt.Duplicate)
+		assert.NotEqual(t, handlingdomain.HandlingEventId{}, receipt.EventId)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("should return original event when idempotency key is replayed", func(t *testing.T) {
+		service, repo := setup()
+
+		// Setup mocks
+		completionTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+		original, err := handlingdomain.NewHandlingEvent("TEST123", handlingdomain.HandlingEventTypeReceive, "USNYC", "", completionTime)
+		require.NoError(t, err)
+		repo.On("FindByIdempotencyKey", "scan-1").Return(original.WithIdempotencyKey("scan-1"), true, nil)
+
+		// Execute
+		receipt, err := service.SubmitHandlingReport(createContextWithClaims(t, []string{}), receiveReport(completionTime, "scan-1"))
+
+		// Verify
+		require.NoError(t, err)
+		assert.True(t, receipt.Duplicate)
+		assert.Equal(t, original.GetEventId(), receipt.EventId)
+		repo.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return original event when the same handling is reported again", func(t *testing.T) {
+		service, repo := setup()
+
+		// Setup mocks
+		completionTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+		original, err := handlingdomain.NewHandlingEvent("TEST123", handlingdomain.HandlingEventTypeReceive, "USNYC", "", completionTime)
+		require.NoError(t, err)
+		repo.On("FindByTrackingId", "TEST123").Return([]handlingdomain.HandlingEvent{original}, nil)
+
+		// Execute
+		receipt, err := service.SubmitHandlingReport(createContextWithClaims(t, []string{}), receiveReport(completionTime, ""))
+
+		// Verify
+		require.NoError(t, err)
+		assert.True(t, receipt.Duplicate)
+		assert.Equal(t, original.GetEventId(), receipt.EventId)
+		repo.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject idempotency key reused for a different report", func(t *testing.T) {
+		service, repo := setup()
+
+		// Setup mocks
+		original, err := handlingdomain.NewHandlingEvent("TEST123", handlingdomain.HandlingEventTypeReceive, "USNYC", "", time.Now().Add(-2*time.Hour))
+		require.NoError(t, err)
+		repo.On("FindByIdempotencyKey", "scan-1").Return(original.WithIdempotencyKey("scan-1"), true, nil)
+
+		// Execute
+		_, err = service.SubmitHandlingReport(createContextWithClaims(t, []string{}), receiveReport(time.Now().Add(-time.Hour), "scan-1"))
+
+		// Verify
+		var conflictErr handlingdomain.IdempotencyKeyConflictError
+		require.ErrorAs(t, err, &conflictErr)
+		assert.Equal(t, "scan-1", conflictErr.IdempotencyKey)
+		repo.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return event stored by a concurrent retry with the same key", func(t *testing.T) {
+		service, repo := setup()
+
+		// Setup mocks
+		completionTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+		concurrent, err := handlingdomain.NewHandlingEvent("TEST123", handlingdomain.HandlingEventTypeReceive, "USNYC", "", completionTime)
+		require.NoError(t, err)
+		repo.On("FindByIdempotencyKey", "scan-1").Return(handlingdomain.HandlingEvent{}, false, nil).Once()
+		repo.On("FindByIdempotencyKey", "scan-1").Return(concurrent.WithIdempotencyKey("scan-1"), true, nil).Once()
+		repo.On("FindByTrackingId", "TEST123").Return([]handlingdomain.HandlingEvent{}, nil)
+		repo.On("Append", mock.Anything, mock.Anything).Return(errors.New("duplicate key value violates unique constraint"))
+
+		// Execute
+		receipt, err := service.SubmitHandlingReport(createContextWithClaims(t, []string{}), receiveReport(completionTime, "scan-1"))
+
+		// Verify
+		require.NoError(t, err)
+		assert.True(t, receipt.Duplicate)
+		assert.Equal(t, concurrent.GetEventId(), receipt.EventId)
+	})
+
+	t.Run("should return event stored by a concurrent report of the same handling", func(t *testing.T) {
+		service, repo := setup()
+
+		// Setup mocks
+		completionTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+		concurrent, err := handlingdomain.NewHandlingEvent("TEST123", handlingdomain.HandlingEventTypeReceive, "USNYC", "", completionTime)
+		require.NoError(t, err)
+		repo.On("FindByTrackingId", "TEST123").Return([]handlingdomain.HandlingEvent{}, nil).Once()
+		repo.On("FindByTrackingId", "TEST123").Return([]handlingdomain.HandlingEvent{concurrent}, nil).Once()
+		repo.On("Append", mock.Anything, mock.Anything).Return(errors.New("UNIQUE constraint failed: handling_events.tracking_id"))
+
+		// Execute
+		receipt, err := service.SubmitHandlingReport(createContextWithClaims(t, []string{}), receiveReport(completionTime, ""))
+
+		// Verify
+		require.NoError(t, err)
+		assert.True(t, receipt.Duplicate)
+		assert.Equal(t, concurrent.GetEventId(), receipt.EventId)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("should check the report again when the cargo's history changed before it was stored", func(t *testing.T) {
+		service, repo := setup()
+
+		// Setup mocks
+		completionTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+		concurrent, err := handlingdomain.NewHandlingEvent("TEST123", handlingdomain.HandlingEventTypeReceive, "USNYC", "", completionTime)
+		require.NoError(t, err)
+		repo.On("FindByTrackingId", "TEST123").Return([]handlingdomain.HandlingEvent{}, nil).Once()
+		repo.On("FindByTrackingId", "TEST123").Return([]handlingdomain.HandlingEvent{concurrent}, nil).Once()
+		repo.On("Append", mock.Anything, 0).Return(handlingdomain.NewHandlingHistoryChangedError("TEST123")).Once()
+
+		// Execute
+		receipt, err := service.SubmitHandlingReport(createContextWithClaims(t, []string{}), receiveReport(completionTime, ""))
+
+		// Verify
+		require.NoError(t, err)
+		assert.True(t, receipt.Duplicate)
+		assert.Equal(t, concurrent.GetEventId(), receipt.EventId)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("should give up when the cargo's history keeps changing", func(t *testing.T) {
+		service, repo := setup()
+
+		// Setup mocks
+		repo.On("FindByTrackingId", "TEST123").Return([]handlingdomain.HandlingEvent{}, nil)
+		repo.On("Append", mock.Anything, 0).Return(handlingdomain.NewHandlingHistoryChangedError("TEST123"))
+
+		// Execute
+		_, err := service.SubmitHandlingReport(createContextWithClaims(t, []string{}), receiveReport(time.Now().Add(-time.Hour), ""))
+
+		// Verify
+		var changed handlingdomain.HandlingHistoryChangedError
+		assert.ErrorAs(t, err, &changed)
+		repo.AssertNumberOfCalls(t, "Append", maxRegistrationAttempts)
+	})
+}
+
+func TestHandlingReportService_SubmitHandlingReports(t *testing.T) {
+	setup := func(policy SequencePolicy) (handlingprimary.HandlingReportService, *MockHandlingEventRepository, *MockHandlingQuarantine) {
+		repo := &MockHandlingEventRepository{}
+		quarantine := &MockHandlingQuarantine{}
+
+		bookings := &MockCargoBookingService{}
+		bookings.On("IsCargoBooked", mock.Anything, "TEST123").Return(true, nil).Maybe()
+		bookings.On("IsCargoBooked", mock.Anything, mock.Anything).Return(false, nil).Maybe()
+
+		network := &MockShippingNetworkService{}
+		network.On("IsKnownLocation", mock.Anything, mock.Anything).Return(true, nil).Maybe()
+		network.On("FindVoyagePortCalls", mock.Anything, "V001").Return([]string{"USNYC", "DEHAM"}, true, nil).Maybe()
+
+		logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		service := NewHandlingReportService(repo, quarantine, bookings, network, policy, handlingdomain.DefaultTimeWindowPolicy(), logger)
+
+		return service, repo, quarantine
+	}
+
+	report := func(trackingId, eventType, location, voyageNumber string, completionTime time.Time) handlingdomain.HandlingReport {
+		return handlingdomain.HandlingReport{
+			TrackingId:     trackingId,
+			EventType:      eventType,
+			Location:       location,
+			VoyageNumber:   voyageNumber,
+			CompletionTime: completionTime.Format(time.RFC3339),
+		}
+	}
+
+	t.Run("should register valid reports and reject the others independently", func(t *testing.T) {
+		// Setup
+		service, repo, _ := setup(SequencePolicyStrict)
+		baseTime := time.Now().Add(-3 * time.Hour)
+		repo.On("FindByTrackingId", "TEST123").Return([]handlingdomain.HandlingEvent{}, nil)
+		repo.On("AppendAll", mock.MatchedBy(func(events []handlingdomain.HandlingEvent) bool {
+			return len(events) == 2
+		}), map[string]int{"TEST123": 0}).Return(nil)
+
+		reports := []handlingdomain.HandlingReport{
+			report("TEST123", "RECEIVE", "USNYC", "", baseTime),
+			report("OTHER", "RECEIVE", "USNYC", "", baseTime),
+			report("TEST123", "LOAD", "USNYC", "V001", time.Now().Add(-30*time.Minute)),
+			{TrackingId: "TEST123", EventType: "UNLOAD", Location: "DEHAM", VoyageNumber: "V001", CompletionTime: "yesterday"},
+		}
+
+		// Execute
+		results, err := service.SubmitHandlingReports(createContextWithClaims(t, []string{}), reports)
+
+		// Verify
+		require.NoError(t, err)
+		require.Len(t, results, 4)
+		assert.Equal(t, handlingdomain.HandlingReportAccepted, results[0].Status)
+		assert.Equal(t, handlingdomain.HandlingReportRejected, results[1].Status)
+		assert.Contains(t, results[1].Reason, "cargo OTHER is not booked")
+		assert.Equal(t, handlingdomain.HandlingReportAccepted, results[2].Status)
+		assert.Equal(t, handlingdomain.HandlingReportRejected, results[3].Status)
+		assert.Contains(t, results[3].Reason, "invalid completion time format")
+		for i, result := range results {
+			assert.Equal(t, i, result.Index)
+		}
+		repo.AssertNumberOfCalls(t, "AppendAll", 1)
+		repo.AssertNumberOfCalls(t, "FindByTrackingId", 1)
+	})
+
+	t.Run("should mark a report repeating an earlier one of the batch as duplicate", func(t *testing.T) {
+		// Setup
+		service, repo, _ := setup(SequencePolicyStrict)
+		received := report("TEST123", "RECEIVE", "USNYC", "", time.Now().Add(-time.Hour))
+		received.IdempotencyKey = "scan-42"
+		repo.On("FindByIdempotencyKey", "scan-42").Return(handlingdomain.HandlingEvent{}, false, nil)
+		repo.On("FindByTrackingId", "TEST123").Return([]handlingdomain.HandlingEvent{}, nil)
+		repo.On("AppendAll", mock.MatchedBy(func(events []handlingdomain.HandlingEvent) bool {
+			return len(events) == 1
+		}), map[string]int{"TEST123": 0}).Return(nil)
+
+		// Execute
+		results, err := service.SubmitHandlingReports(createContextWithClaims(t, []string{}), []handlingdomain.HandlingReport{received, received})
+
+		// Verify
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, handlingdomain.HandlingReportAccepted, results[0].Status)
+		assert.Equal(t, handlingdomain.HandlingReportDuplicate, results[1].Status)
+		assert.Equal(t, results[0].EventId, results[1].EventId)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("should register the reports one by one when their chunk cannot be stored", func(t *testing.T) {
+		// Setup
+		service, repo, _ := setup(SequencePolicyStrict)
+		received := report("TEST123", "RECEIVE", "USNYC", "", time.Now().Add(-2*time.Hour))
+		repo.On("FindByTrackingId", "TEST123").Return([]handlingdomain.HandlingEvent{}, nil)
+		repo.On("AppendAll", mock.Anything, mock.Anything).Return(handlingdomain.NewHandlingHistoryChangedError("TEST123"))
+		repo.On("Append", mock.AnythingOfType("handlingdomain.HandlingEvent"), 0).Return(nil)
+
+		// Execute
+		results, err := service.SubmitHandlingReports(createContextWithClaims(t, []string{}), []handlingdomain.HandlingReport{received, received})
+
+		// Verify
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, handlingdomain.HandlingReportAccepted, results[0].Status)
+		assert.Equal(t, handlingdomain.HandlingReportDuplicate, results[1].Status)
+		assert.NotEmpty(t, results[0].EventId)
+		assert.Equal(t, results[0].EventId, results[1].EventId)
+		repo.AssertNumberOfCalls(t, "AppendAll", 1)
+	})
+
+	t.Run("should look up each cargo, location and voyage once and store each chunk in one write", func(t *testing.T) {
+		// Setup
+		received, err := handlingdomain.NewHandlingEvent("TEST123", handlingdomain.HandlingEventTypeReceive, "USNYC", "", time.Now().Add(-4*time.Hour))
+		require.NoError(t, err)
+		repo := &MockHandlingEventRepository{}
+		repo.On("FindByTrackingId", "TEST123").Return([]handlingdomain.HandlingEvent{received}, nil)
+		repo.On("AppendAll", mock.Anything, mock.Anything).Return(nil)
+		bookings := &MockCargoBookingService{}
+		bookings.On("IsCargoBooked", mock.Anything, mock.Anything).Return(true, nil)
+		network := &MockShippingNetworkService{}
+		network.On("IsKnownLocation", mock.Anything, "USNYC").Return(true, nil)
+		logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		service := NewHandlingReportService(repo, &MockHandlingQuarantine{}, bookings, network, SequencePolicyStrict, handlingdomain.DefaultTimeWindowPolicy(), logger)
+
+		// The received cargo is inspected by customs again and again
+		reports := make([]handlingdomain.HandlingReport, handlingChunkSize+1)
+		for i := range reports {
+			reports[i] = report("TEST123", "CUSTOMS", "USNYC", "", time.Now().Add(-time.Duration(len(reports)-i)*time.Minute))
+		}
+
+		// Execute
+		results, err := service.SubmitHandlingReports(createContextWithClaims(t, []string{}), reports)
+
+		// Verify
+		require.NoError(t, err)
+		require.Len(t, results, handlingChunkSize+1)
+		for i, result := range results {
+			assert.Equal(t, i, result.Index)
+			assert.Equal(t, handlingdomain.HandlingReportAccepted, result.Status, result.Reason)
+		}
+		repo.AssertNumberOfCalls(t, "AppendAll", 2)
+		bookings.AssertNumberOfCalls(t, "IsCargoBooked", 2)
+		network.AssertNumberOfCalls(t, "IsKnownLocation", 2)
+		repo.AssertNumberOfCalls(t, "FindByTrackingId", 2)
+	})
+
+	t.Run("should mark repeated reports as duplicates without publishing them", func(t *testing.T) {
+		// Setup
+		service, repo, _ := setup(SequencePolicyStrict)
+		completionTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+		original, err := handlingdomain.NewHandlingEvent("TEST123", handlingdomain.HandlingEventTypeReceive, "USNYC", "", completionTime)
+		require.NoError(t, err)
+		repo.On("FindByTrackingId", "TEST123").Return([]handlingdomain.HandlingEvent{original}, nil)
+
+		reports := []handlingdomain.HandlingReport{
+			report("TEST123", "RECEIVE", "USNYC", "", completionTime),
+		}
+
+		// Execute
+		results, err := service.SubmitHandlingReports(createContextWithClaims(t, []string{}), reports)
+
+		// Verify
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, handlingdomain.HandlingReportDuplicate, results[0].Status)
+		assert.Equal(t, original.GetEventId().String(), results[0].EventId)
+		repo.AssertNotCalled(t, "AppendAll", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should report quarantined reports under lenient policy", func(t *testing.T) {
+		// Setup
+		service, repo, quarantine := setup(SequencePolicyLenient)
+		repo.On("FindByTrackingId", "TEST123").Return([]handlingdomain.HandlingEvent{}, nil)
+		quarantine.On("Add", mock.Anything).Return(nil)
+
+		reports := []handlingdomain.HandlingReport{
+			report("TEST123", "CLAIM", "DEHAM", "", time.Now().Add(-time.Hour)),
+		}
+
+		// Execute
+		results, err := service.SubmitHandlingReports(createContextWithClaims(t, []string{}), reports)
+
+		// Verify
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, handlingdomain.HandlingReportQuarantined, results[0].Status)
+		assert.Contains(t, results[0].Reason, "first handling event must be RECEIVE")
+		quarantine.AssertExpectations(t)
+	})
+
+	t.Run("should fail with unauthorized context", func(t *testing.T) {
+		// Setup
+		service, repo, _ := setup(SequencePolicyStrict)
+		reports := []handlingdomain.HandlingReport{
+			report("TEST123", "RECEIVE", "USNYC", "", time.Now().Add(-time.Hour)),
+		}
+
+		// Execute
+		results, err := service.SubmitHandlingReports(context.Background(), reports)
+
+		// Verify
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unauthorized handling submission")
+		assert.Nil(t, results)
+		repo.AssertNotCalled(t, "AppendAll", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandlingReportService_BackfillHandlingReports(t *testing.T) {
+	setup := func(window handlingdomain.TimeWindowPolicy) (handlingprimary.HandlingReportService, *MockHandlingEventRepository) {
+		repo := &MockHandlingEventRepository{}
+
+		bookings := &MockCargoBookingService{}
+		bookings.On("IsCargoBooked", mock.Anything, "TEST123").Return(true, nil).Maybe()
+
+		network := &MockShippingNetworkService{}
+		network.On("IsKnownLocation", mock.Anything, mock.Anything).Return(true, nil).Maybe()
+
+		logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		service := NewHandlingReportService(repo, &MockHandlingQuarantine{}, bookings, network, SequencePolicyStrict, window, logger)
+
+		return service, repo
+	}
+
+	window := handlingdomain.TimeWindowPolicy{MaxAge: 7 * 24 * time.Hour, MaxFutureSkew: time.Minute}
+	oldReport := handlingdomain.HandlingReport{
+		TrackingId:     "TEST123",
+		EventType:      "RECEIVE",
+		Location:       "USNYC",
+		CompletionTime: time.Now().AddDate(0, 0, -90).Format(time.RFC3339),
+	}
+
+	t.Run("should register reports older than the maximum age", func(t *testing.T) {
+		// Setup
+		service, repo := setup(window)
+		repo.On("FindByTrackingId", "TEST123").Return([]handlingdomain.HandlingEvent{}, nil)
+		repo.On("AppendAll", mock.Anything, map[string]int{"TEST123": 0}).Return(nil)
+
+		// Execute
+		results, err := service.BackfillHandlingReports(createContextWithClaims(t, []string{}), []handlingdomain.HandlingReport{oldReport})
+
+		// Verify
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, handlingdomain.HandlingReportAccepted, results[0].Status)
+		repo.AssertNumberOfCalls(t, "AppendAll", 1)
+	})
+
+	t.Run("should reject the same reports when submitted normally", func(t *testing.T) {
+		// Setup
+		service, repo := setup(window)
+
+		// Execute
+		results, err := service.SubmitHandlingReports(createContextWithClaims(t, []string{}), []handlingdomain.HandlingReport{oldReport})
+
+		// Verify
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, handlingdomain.HandlingReportRejected, results[0].Status)
+		assert.Contains(t, results[0].Reason, "completion time cannot be more than 7 days in the past")
+		repo.AssertNotCalled(t, "AppendAll", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should still reject reports beyond the allowed clock skew", func(t *testing.T) {
+		// Setup
+		service, repo := setup(window)
+		future := oldReport
+		future.CompletionTime = time.Now().Add(time.Hour).Format(time.RFC3339)
+
+		// Execute
+		results, err := service.BackfillHandlingReports(createContextWithClaims(t, []string{}), []handlingdomain.HandlingReport{future})
+
+		// Verify
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, handlingdomain.HandlingReportRejected, results[0].Status)
+		assert.Contains(t, results[0].Reason, "completion time cannot be more than 1m0s in the future")
+		repo.AssertNotCalled(t, "AppendAll", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should require the backfill permission", func(t *testing.T) {
+		// Setup
+		service, repo := setup(window)
+		claims, err := auth.NewClaims("terminal", "terminal", "", []string{string(auth.RoleUser)}, nil)
+		require.NoError(t, err)
+		ctx := context.WithValue(context.Background(), auth.ClaimsContextKey, claims)
+
+		// Execute
+		results, err := service.BackfillHandlingReports(ctx, []handlingdomain.HandlingReport{oldReport})
+
+		// Verify
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unauthorized handling backfill")
+		assert.Nil(t, results)
+		repo.AssertNotCalled(t, "AppendAll", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandlingReportService_ReleaseQuarantinedReport(t *testing.T) {
+	setup := func() (handlingprimary.HandlingReportService, *MockHandlingEventRepository, *MockHandlingQuarantine) {
+		repo := &MockHandlingEventRepository{}
+		quarantine := &MockHandlingQuarantine{}
+
+		bookings := &MockCargoBookingService{}
+		bookings.On("IsCargoBooked", mock.Anything, "TEST123").Return(true, nil).Maybe()
+
+		network := &MockShippingNetworkService{}
+		network.On("IsKnownLocation", mock.Anything, mock.Anything).Return(true, nil).Maybe()
+		network.On("FindVoyagePortCalls", mock.Anything, "V001").Return([]string{"USNYC", "DEHAM"}, true, nil).Maybe()
+
+		window := handlingdomain.TimeWindowPolicy{MaxAge: 7 * 24 * time.Hour, MaxFutureSkew: time.Minute}
+		logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		service := NewHandlingReportService(repo, quarantine, bookings, network, SequencePolicyLenient, window, logger)
+
+		return service, repo, quarantine
+	}
+
+	// An unload without a load, held back for review for longer than reports may be old
+	quarantined := handlingdomain.NewQuarantinedReport(handlingdomain.HandlingReport{
+		TrackingId:     "TEST123",
+		EventType:      "UNLOAD",
+		Location:       "DEHAM",
+		VoyageNumber:   "V001",
+		CompletionTime: time.Now().AddDate(0, 0, -10).Format(time.RFC3339),
+	}, "cannot unload cargo that is not loaded")
+
+	t.Run("should register released report despite its sequence violation and remove it from quarantine", func(t *testing.T) {
+		// Setup
+		service, repo, quarantine := setup()
+		receive, err := handlingdomain.NewHandlingEventWithinWindow(handlingdomain.TimeWindowPolicy{}, "TEST123", handlingdomain.HandlingEventTypeReceive, "USNYC", "", time.Now().AddDate(0, 0, -12))
+		require.NoError(t, err)
+		quarantine.On("FindById", quarantined.Id).Return(quarantined, nil)
+		quarantine.On("Remove", quarantined.Id).Return(nil)
+		repo.On("FindByTrackingId", "TEST123").Return([]handlingdomain.HandlingEvent{receive}, nil)
+		repo.On("Append", mock.MatchedBy(func(event handlingdomain.HandlingEvent) bool {
+			return event.GetEventType() == handlingdomain.HandlingEventTypeUnload
+		}), mock.Anything).Return(nil)
+
+		// Execute
+		receipt, err := service.ReleaseQuarantinedReport(createContextWithClaims(t, []string{}), quarantined.Id)
+
+		// Verify
+		require.NoError(t, err)
+		assert.False(t, receipt.Duplicate)
+		repo.AssertExpectations(t)
+		quarantine.AssertExpectations(t)
+	})
+
+	t.Run("should keep report in quarantine when it cannot be registered", func(t *testing.T) {
+		// Setup
+		service, repo, quarantine := setup()
+		quarantine.On("FindById", quarantined.Id).Return(quarantined, nil)
+		repo.On("FindByTrackingId", "TEST123").Return([]handlingdomain.HandlingEvent(nil), errors.New("storage unavailable"))
+
+		// Execute
+		_, err := service.ReleaseQuarantinedReport(createContextWithClaims(t, []string{}), quarantined.Id)
+
+		// Verify
+		require.Error(t, err)
+		quarantine.AssertNotCalled(t, "Remove", mock.Anything)
+	})
+
+	t.Run("should return not found error for unknown report", func(t *testing.T) {
+		// Setup
+		service, _, quarantine := setup()
+		quarantine.On("FindById", "unknown").Return(handlingdomain.QuarantinedReport{}, handlingdomain.NewQuarantinedReportNotFoundError("unknown"))
+
+		// Execute
+		_, err := service.ReleaseQuarantinedReport(createContextWithClaims(t, []string{}), "unknown")
+
+		// Verify
+		var notFound handlingdomain.QuarantinedReportNotFoundError
+		assert.ErrorAs(t, err, &notFound)
+	})
+
+	t.Run("should require the backfill permission", func(t *testing.T) {
+		// Setup
+		service, _, quarantine := setup()
+		claims, err := auth.NewClaims("terminal", "terminal", "", []string{string(auth.RoleUser)}, nil)
+		require.NoError(t, err)
+		ctx := context.WithValue(context.Background(), auth.ClaimsContextKey, claims)
+
+		// Execute
+		_, err = service.ReleaseQuarantinedReport(ctx, quarantined.Id)
+
+		// Verify
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unauthorized handling quarantine release")
+		quarantine.AssertNotCalled(t, "FindById", mock.Anything)
+	})
 }
 
 func TestHandlingEventQueryService_GetHandlingHistory(t *testing.T) {
@@ -344,6 +1175,76 @@ func TestHandlingEventQueryService_ListAllHandlingEvents(t *testing.T) {
 	})
 }
 
+func TestHandlingEventQueryService_ListHandlingEvents(t *testing.T) {
+	setup := func() (handlingprimary.HandlingEventQueryService, *MockHandlingEventRepository) {
+		repo := &MockHandlingEventRepository{}
+		logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		return NewHandlingEventQueryService(repo, logger), repo
+	}
+
+	t.Run("should list a page of handling events with the default page size", func(t *testing.T) {
+		service, repo := setup()
+
+		// Setup mocks
+		events := createTestHandlingEvents(t)
+		repo.On("FindByQuery", handlingdomain.HandlingEventQuery{
+			Location: "USNYC",
+			Limit:    handlingdomain.DefaultHandlingEventPageSize,
+		}).Return(handlingdomain.HandlingEventPage{Events: events, NextCursor: "next"}, nil)
+
+		ctx := createContextWithClaims(t, []string{})
+
+		// Execute
+		result, err := service.ListHandlingEvents(ctx, handlingdomain.HandlingEventQuery{Location: "USNYC"})
+
+		// Verify
+		require.NoError(t, err)
+		assert.Len(t, result.Events, len(events))
+		assert.Equal(t, "next", result.NextCursor)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("should reject invalid query", func(t *testing.T) {
+		service, repo := setup()
+
+		ctx := createContextWithClaims(t, []string{})
+
+		// Execute
+		_, err := service.ListHandlingEvents(ctx, handlingdomain.HandlingEventQuery{EventType: "SCAN"})
+
+		// Verify
+		var validationErr handlingdomain.DomainValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		repo.AssertNotCalled(t, "FindByQuery", mock.Anything)
+	})
+
+	t.Run("should fail with unauthorized context", func(t *testing.T) {
+		service, _ := setup()
+
+		// Execute
+		_, err := service.ListHandlingEvents(context.Background(), handlingdomain.HandlingEventQuery{})
+
+		// Verify
+		assert.ErrorContains(t, err, "unauthorized")
+	})
+
+	t.Run("should fail when repository fails", func(t *testing.T) {
+		service, repo := setup()
+
+		// Setup mocks
+		repo.On("FindByQuery", mock.Anything).Return(handlingdomain.HandlingEventPage{}, errors.New("repository error"))
+
+		ctx := createContextWithClaims(t, []string{})
+
+		// Execute
+		_, err := service.ListHandlingEvents(ctx, handlingdomain.HandlingEventQuery{})
+
+		// Verify
+		assert.ErrorContains(t, err, "failed to list handling events")
+		repo.AssertExpectations(t)
+	})
+}
+
 func TestHandlingEventQueryService_GetHandlingEvent(t *testing.T) {
 	setup := func() (handlingprimary.HandlingEventQueryService, *MockHandlingEventRepository) {
 		repo := &MockHandlingEventRepository{}
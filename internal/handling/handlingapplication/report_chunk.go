@@ -0,0 +1,182 @@
+package handlingapplication
+
+import (
+	"context"
+
+	"go_hex/internal/handling/handlingdomain"
+	"go_hex/internal/handling/ports/handlingsecondary"
+)
+
+// reportChunk holds what the reports of one chunk of a batch are checked against: the references and handling
+// histories they share, each looked up once, and the events of the reports accepted so far, to be stored together
+type reportChunk struct {
+	handlingEventRepo handlingsecondary.HandlingEventRepository
+	references        *referenceCache
+
+	// histories holds the handling history of each cargo: its stored events followed by the chunk's pending ones
+	histories map[string]*chunkHistory
+
+	// pending holds the events of the accepted reports, in report order
+	pending []pendingReport
+
+	// duplicateOf maps the index of each report repeating a pending one to the index of that report
+	duplicateOf map[int]int
+}
+
+// chunkHistory is a cargo's handling history as a chunk sees it
+type chunkHistory struct {
+	stored int
+	events []handlingdomain.HandlingEvent
+}
+
+// pendingReport is an accepted report of a chunk whose event is not stored yet
+type pendingReport struct {
+	index  int
+	report handlingdomain.HandlingReport
+	event  handlingdomain.HandlingEvent
+}
+
+// newReportChunk creates an empty chunk looking up histories and references through the given ports
+func newReportChunk(
+	handlingEventRepo handlingsecondary.HandlingEventRepository,
+	cargoBookings handlingsecondary.CargoBookingService,
+	shippingNetwork handlingsecondary.ShippingNetworkService,
+) *reportChunk {
+	return &reportChunk{
+		handlingEventRepo: handlingEventRepo,
+		references:        newReferenceCache(cargoBookings, shippingNetwork),
+		histories:         make(map[string]*chunkHistory),
+		duplicateOf:       make(map[int]int),
+	}
+}
+
+// history returns the cargo's handling history including the chunk's pending events, loading its stored events the
+// first time
+func (c *reportChunk) history(trackingId string) (handlingdomain.HandlingHistory, error) {
+	cargo, loaded := c.histories[trackingId]
+	if !loaded {
+		events, err := c.handlingEventRepo.FindByTrackingId(trackingId)
+		if err != nil {
+			return handlingdomain.HandlingHistory{}, err
+		}
+		cargo = &chunkHistory{stored: len(events), events: events}
+		c.histories[trackingId] = cargo
+	}
+	return handlingdomain.NewHandlingHistory(trackingId, cargo.events)
+}
+
+// add accepts the event of the i-th report, whose cargo's history must have been loaded
+func (c *reportChunk) add(i int, report handlingdomain.HandlingReport, event handlingdomain.HandlingEvent) {
+	c.pending = append(c.pending, pendingReport{index: i, report: report, event: event})
+	cargo := c.histories[report.TrackingId]
+	cargo.events = append(cargo.events, event)
+}
+
+// findByIdempotencyKey returns the pending report submitted with an idempotency key
+func (c *reportChunk) findByIdempotencyKey(key string) (pendingReport, bool) {
+	for _, pending := range c.pending {
+		if pending.event.GetIdempotencyKey() == key {
+			return pending, true
+		}
+	}
+	return pendingReport{}, false
+}
+
+// findPending returns the pending report whose event has the given ID
+func (c *reportChunk) findPending(eventId handlingdomain.HandlingEventId) (pendingReport, bool) {
+	for _, pending := range c.pending {
+		if pending.event.GetEventId() == eventId {
+			return pending, true
+		}
+	}
+	return pendingReport{}, false
+}
+
+// pendingEvents returns the events of the accepted reports
+func (c *reportChunk) pendingEvents() []handlingdomain.HandlingEvent {
+	events := make([]handlingdomain.HandlingEvent, len(c.pending))
+	for i, pending := range c.pending {
+		events[i] = pending.event
+	}
+	return events
+}
+
+// historyLengths returns the number of stored events the pending events of each cargo were checked against
+func (c *reportChunk) historyLengths() map[string]int {
+	lengths := make(map[string]int)
+	for _, pending := range c.pending {
+		lengths[pending.report.TrackingId] = c.histories[pending.report.TrackingId].stored
+	}
+	return lengths
+}
+
+// voyagePortCalls is the answer to a voyage lookup
+type voyagePortCalls struct {
+	portCalls []string
+	found     bool
+}
+
+// referenceCache answers the booking and routing lookups of a chunk, asking the other contexts about each cargo,
+// location and voyage once. Failed lookups are not kept, so they are asked again.
+type referenceCache struct {
+	cargoBookings   handlingsecondary.CargoBookingService
+	shippingNetwork handlingsecondary.ShippingNetworkService
+	booked          map[string]bool
+	known           map[string]bool
+	voyages         map[string]voyagePortCalls
+}
+
+// newReferenceCache creates an empty cache in front of the given ports
+func newReferenceCache(cargoBookings handlingsecondary.CargoBookingService, shippingNetwork handlingsecondary.ShippingNetworkService) *referenceCache {
+	return &referenceCache{
+		cargoBookings:   cargoBookings,
+		shippingNetwork: shippingNetwork,
+		booked:          make(map[string]bool),
+		known:           make(map[string]bool),
+		voyages:         make(map[string]voyagePortCalls),
+	}
+}
+
+// IsCargoBooked reports whether cargo with the tracking ID has been booked and not cancelled
+func (c *referenceCache) IsCargoBooked(ctx context.Context, trackingId string) (bool, error) {
+	if booked, cached := c.booked[trackingId]; cached {
+		return booked, nil
+	}
+	booked, err := c.cargoBookings.IsCargoBooked(ctx, trackingId)
+	if err != nil {
+		return false, err
+	}
+	c.booked[trackingId] = booked
+	return booked, nil
+}
+
+// IsKnownLocation reports whether the UN/LOCODE belongs to a location of the shipping network
+func (c *referenceCache) IsKnownLocation(ctx context.Context, unLocode string) (bool, error) {
+	if known, cached := c.known[unLocode]; cached {
+		return known, nil
+	}
+	known, err := c.shippingNetwork.IsKnownLocation(ctx, unLocode)
+	if err != nil {
+		return false, err
+	}
+	c.known[unLocode] = known
+	return known, nil
+}
+
+// FindVoyagePortCalls returns the UN/LOCODEs a voyage calls at, in schedule order; found is false for unknown voyages
+func (c *referenceCache) FindVoyagePortCalls(ctx context.Context, voyageNumber string) ([]string, bool, error) {
+	if voyage, cached := c.voyages[voyageNumber]; cached {
+		return voyage.portCalls, voyage.found, nil
+	}
+	portCalls, found, err := c.shippingNetwork.FindVoyagePortCalls(ctx, voyageNumber)
+	if err != nil {
+		return nil, false, err
+	}
+	c.voyages[voyageNumber] = voyagePortCalls{portCalls: portCalls, found: found}
+	return portCalls, found, nil
+}
+
+var (
+	_ handlingsecondary.CargoBookingService    = (*referenceCache)(nil)
+	_ handlingsecondary.ShippingNetworkService = (*referenceCache)(nil)
+)
@@ -1,6 +1,8 @@
 package handlingdomain
 
 import (
+	"fmt"
+
 	"go_hex/internal/support/errors"
 )
 
@@ -15,3 +17,84 @@ func NewDomainValidationError(message string, cause error) DomainValidationError
 		BaseError: errors.NewBaseError(message, cause),
 	}
 }
+
+// HandlingSequenceError reports a handling event that does not fit the cargo's handling history
+type HandlingSequenceError struct {
+	errors.BaseError
+}
+
+// NewHandlingSequenceError creates a new handling sequence error
+func NewHandlingSequenceError(message string) HandlingSequenceError {
+	return HandlingSequenceError{
+		BaseError: errors.NewBaseError(message, nil),
+	}
+}
+
+// HandlingHistoryChangedError reports that events of a cargo were stored since its handling history was read, so a new
+// event checked against that history has to be checked again
+type HandlingHistoryChangedError struct {
+	errors.BaseError
+	TrackingId string
+}
+
+// NewHandlingHistoryChangedError creates a new handling history changed error
+func NewHandlingHistoryChangedError(trackingId string) HandlingHistoryChangedError {
+	return HandlingHistoryChangedError{
+		BaseError:  errors.NewBaseError(fmt.Sprintf("handling history of cargo %s changed concurrently", trackingId), nil),
+		TrackingId: trackingId,
+	}
+}
+
+// ReportQuarantinedError reports that a handling report was set aside for review instead of being registered
+type ReportQuarantinedError struct {
+	errors.BaseError
+	Quarantined QuarantinedReport
+}
+
+// NewReportQuarantinedError creates a quarantine error for the report quarantined because of cause
+func NewReportQuarantinedError(quarantined QuarantinedReport, cause error) ReportQuarantinedError {
+	return ReportQuarantinedError{
+		BaseError:   errors.NewBaseError("handling report quarantined", cause),
+		Quarantined: quarantined,
+	}
+}
+
+// QuarantinedReportNotFoundError reports that no report with the given ID is held in the quarantine
+type QuarantinedReportNotFoundError struct {
+	errors.BaseError
+	Id string
+}
+
+// NewQuarantinedReportNotFoundError creates a new quarantined report not found error
+func NewQuarantinedReportNotFoundError(id string) QuarantinedReportNotFoundError {
+	return QuarantinedReportNotFoundError{
+		BaseError: errors.NewBaseError(fmt.Sprintf("quarantined report %s not found", id), nil),
+		Id:        id,
+	}
+}
+
+// UnknownReferenceError reports a handling report naming cargo, a location or a voyage that is not known to the other contexts
+type UnknownReferenceError struct {
+	errors.BaseError
+}
+
+// NewUnknownReferenceError creates a new unknown reference error
+func NewUnknownReferenceError(message string) UnknownReferenceError {
+	return UnknownReferenceError{
+		BaseError: errors.NewBaseError(message, nil),
+	}
+}
+
+// IdempotencyKeyConflictError reports an idempotency key reused for a report that differs from the one first sent with it
+type IdempotencyKeyConflictError struct {
+	errors.BaseError
+	IdempotencyKey string
+}
+
+// NewIdempotencyKeyConflictError creates a new idempotency key conflict error
+func NewIdempotencyKeyConflictError(idempotencyKey string) IdempotencyKeyConflictError {
+	return IdempotencyKeyConflictError{
+		BaseError:      errors.NewBaseError(fmt.Sprintf("idempotency key %s was already used for a different handling report", idempotencyKey), nil),
+		IdempotencyKey: idempotencyKey,
+	}
+}
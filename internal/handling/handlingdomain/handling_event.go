@@ -1,8 +1,10 @@
 package handlingdomain
 
 import (
+	"fmt"
 	"go_hex/internal/support/basedomain"
 	"go_hex/internal/support/validation"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -63,15 +65,30 @@ type HandlingEventData struct {
 	VoyageNumber     string            `json:"voyage_number,omitempty"`               // Optional for some event types
 	CompletionTime   time.Time         `json:"completion_time" validate:"required"`   // When the physical event occurred
 	RegistrationTime time.Time         `json:"registration_time" validate:"required"` // When the event was recorded in the system
+
+	// IdempotencyKey is the key the submitter sent to make retries safe, if any
+	IdempotencyKey string `json:"idempotency_key,omitempty" validate:"max=255"`
 }
 
-// NewHandlingEvent creates a new HandlingEvent with validation
+// NewHandlingEvent creates a new HandlingEvent with validation, accepting completion times within the default time window
 func NewHandlingEvent(
 	trackingId string,
 	eventType HandlingEventType,
 	location string,
 	voyageNumber string,
 	completionTime time.Time,
+) (HandlingEvent, error) {
+	return NewHandlingEventWithinWindow(DefaultTimeWindowPolicy(), trackingId, eventType, location, voyageNumber, completionTime)
+}
+
+// NewHandlingEventWithinWindow creates a new HandlingEvent with validation, accepting completion times within window
+func NewHandlingEventWithinWindow(
+	window TimeWindowPolicy,
+	trackingId string,
+	eventType HandlingEventType,
+	location string,
+	voyageNumber string,
+	completionTime time.Time,
 ) (HandlingEvent, error) {
 	eventId := NewHandlingEventId()
 
@@ -104,15 +121,9 @@ func NewHandlingEvent(
 		return HandlingEvent{}, NewDomainValidationError("invalid event type", nil)
 	}
 
-	// Validate business rules
-	if completionTime.After(time.Now()) {
-		return HandlingEvent{}, NewDomainValidationError("completion time cannot be in the future", nil)
-	}
-
-	// Validate completion time is not too far in the past (e.g., more than 30 days)
-	thirtyDaysAgo := time.Now().AddDate(0, 0, -30)
-	if completionTime.Before(thirtyDaysAgo) {
-		return HandlingEvent{}, NewDomainValidationError("completion time cannot be more than 30 days in the past", nil)
+	// Validate completion time lies within the accepted window
+	if err := window.Check(completionTime, data.RegistrationTime); err != nil {
+		return HandlingEvent{}, err
 	}
 
 	// Validate voyage number is required for LOAD and UNLOAD events
@@ -145,6 +156,59 @@ func NewHandlingEvent(
 	return event, nil
 }
 
+// NewHandlingEventFromExisting creates a handling event from existing data (for repository loading). The event was
+// created when it was registered and never changes, so the entity keeps the stored registration time rather than the
+// time it is loaded at.
+func NewHandlingEventFromExisting(
+	eventId HandlingEventId,
+	trackingId string,
+	eventType HandlingEventType,
+	location string,
+	voyageNumber string,
+	completionTime time.Time,
+	registrationTime time.Time,
+	idempotencyKey string,
+) (HandlingEvent, error) {
+	data := HandlingEventData{
+		EventId:          eventId,
+		TrackingId:       trackingId,
+		EventType:        eventType,
+		Location:         location,
+		VoyageNumber:     voyageNumber,
+		CompletionTime:   completionTime,
+		RegistrationTime: registrationTime,
+		IdempotencyKey:   idempotencyKey,
+	}
+
+	if err := validation.Validate(data); err != nil {
+		return HandlingEvent{}, NewDomainValidationError("handling event data validation failed", err)
+	}
+
+	return HandlingEvent{
+		BaseEntity: basedomain.BaseEntity[HandlingEventId]{
+			Id:        eventId,
+			CreatedAt: registrationTime,
+			UpdatedAt: registrationTime,
+		},
+		Data: data,
+	}, nil
+}
+
+// WithIdempotencyKey returns a copy of the event carrying the submitter's idempotency key
+func (h HandlingEvent) WithIdempotencyKey(key string) HandlingEvent {
+	h.Data.IdempotencyKey = key
+	return h
+}
+
+// IsSameHandlingAs reports whether other records the same physical handling: same cargo, type, location, voyage and completion time
+func (h HandlingEvent) IsSameHandlingAs(other HandlingEvent) bool {
+	return h.Data.TrackingId == other.Data.TrackingId &&
+		h.Data.EventType == other.Data.EventType &&
+		h.Data.Location == other.Data.Location &&
+		h.Data.VoyageNumber == other.Data.VoyageNumber &&
+		h.Data.CompletionTime.Equal(other.Data.CompletionTime)
+}
+
 // GetEventId returns the event's identifier
 func (h HandlingEvent) GetEventId() HandlingEventId {
 	return h.Data.EventId
@@ -180,17 +244,28 @@ func (h HandlingEvent) GetRegistrationTime() time.Time {
 	return h.Data.RegistrationTime
 }
 
+// GetIdempotencyKey returns the idempotency key the event was submitted with, if any
+func (h HandlingEvent) GetIdempotencyKey() string {
+	return h.Data.IdempotencyKey
+}
+
 // HandlingHistory represents an unmodifiable, ordered collection of handling events
 type HandlingHistory struct {
 	TrackingId string          `json:"tracking_id" validate:"required"`
 	Events     []HandlingEvent `json:"events" validate:"dive"`
 }
 
-// NewHandlingHistory creates a new HandlingHistory
+// NewHandlingHistory creates a new HandlingHistory with the events ordered by completion time. Events completed at
+// the same time are ordered by registration time, then event ID, so the history does not depend on the order the
+// events were loaded in.
 func NewHandlingHistory(trackingId string, events []HandlingEvent) (HandlingHistory, error) {
+	ordered := make([]HandlingEvent, len(events))
+	copy(ordered, events)
+	SortHandlingEvents(ordered)
+
 	history := HandlingHistory{
 		TrackingId: trackingId,
-		Events:     events,
+		Events:     ordered,
 	}
 
 	if err := validation.Validate(history); err != nil {
@@ -200,6 +275,16 @@ func NewHandlingHistory(trackingId string, events []HandlingEvent) (HandlingHist
 	return history, nil
 }
 
+// FindSameHandling returns the recorded event for the same physical handling as event, if there is one
+func (h HandlingHistory) FindSameHandling(event HandlingEvent) (HandlingEvent, bool) {
+	for _, recorded := range h.Events {
+		if recorded.IsSameHandlingAs(event) {
+			return recorded, true
+		}
+	}
+	return HandlingEvent{}, false
+}
+
 // GetMostRecentEvent returns the most recent handling event, or nil if no events
 func (h HandlingHistory) GetMostRecentEvent() *HandlingEvent {
 	if len(h.Events) == 0 {
@@ -233,46 +318,142 @@ func (h HandlingHistory) GetLastEventAtLocation(location string) *HandlingEvent
 	return nil
 }
 
+// WithEvent returns a new history containing event, placed in the same order NewHandlingHistory uses
+func (h HandlingHistory) WithEvent(event HandlingEvent) HandlingHistory {
+	events := make([]HandlingEvent, 0, len(h.Events)+1)
+	events = append(events, h.Events...)
+	events = append(events, event)
+	SortHandlingEvents(events)
+	return HandlingHistory{TrackingId: h.TrackingId, Events: events}
+}
+
+// SortHandlingEvents orders events as in a handling history: by completion time, then registration time, then event ID
+func SortHandlingEvents(events []HandlingEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return handledBefore(events[i], events[j])
+	})
+}
+
+// handledBefore reports whether a comes before b in a handling history
+func handledBefore(a, b HandlingEvent) bool {
+	if result := a.GetCompletionTime().Compare(b.GetCompletionTime()); result != 0 {
+		return result < 0
+	}
+	if result := a.GetRegistrationTime().Compare(b.GetRegistrationTime()); result != 0 {
+		return result < 0
+	}
+	return a.GetEventId().String() < b.GetEventId().String()
+}
+
+// ValidateNewEvent checks that event can be registered without breaking the handling sequence. Only the event and
+// the events right after it are checked, so a violation elsewhere in the history, such as one released from the
+// quarantine, does not block new events. Customs events do not move the cargo, so they are checked along with the
+// first event after them that does.
+func (h HandlingHistory) ValidateNewEvent(event HandlingEvent) error {
+	at := sort.Search(len(h.Events), func(i int) bool {
+		return handledBefore(event, h.Events[i])
+	})
+
+	var sequence handlingSequence
+	for _, earlier := range h.Events[:at] {
+		sequence.advance(earlier)
+	}
+
+	if err := sequence.check(event); err != nil {
+		return err
+	}
+	sequence.advance(event)
+
+	for _, later := range h.Events[at:] {
+		if err := sequence.check(later); err != nil {
+			return err
+		}
+		if later.GetEventType() != HandlingEventTypeCustoms {
+			break
+		}
+		sequence.advance(later)
+	}
+
+	return nil
+}
+
 // IsValidSequence validates that the events form a logical sequence
 func (h HandlingHistory) IsValidSequence() error {
-	if len(h.Events) == 0 {
-		return nil
+	var sequence handlingSequence
+	for _, event := range h.Events {
+		if err := sequence.check(event); err != nil {
+			return err
+		}
+		sequence.advance(event)
 	}
 
+	return nil
+}
+
+// handlingSequence tracks where the events handled so far leave the cargo
+type handlingSequence struct {
+	last          *HandlingEvent
+	onBoardVoyage string // Voyage the cargo is currently loaded on
+	unloaded      bool
+}
+
+// check reports whether event may be handled next
+func (s handlingSequence) check(event HandlingEvent) error {
 	// First event must be RECEIVE
-	if h.Events[0].GetEventType() != HandlingEventTypeReceive {
-		return NewDomainValidationError("first handling event must be RECEIVE", nil)
+	if s.last == nil {
+		if event.GetEventType() != HandlingEventTypeReceive {
+			return NewHandlingSequenceError("first handling event must be RECEIVE")
+		}
+		return nil
 	}
 
 	// Check chronological order
-	for i := 1; i < len(h.Events); i++ {
-		prevEvent := h.Events[i-1]
-		currentEvent := h.Events[i]
+	if event.GetCompletionTime().Before(s.last.GetCompletionTime()) {
+		return NewHandlingSequenceError("events must be in chronological order")
+	}
 
-		if currentEvent.GetCompletionTime().Before(prevEvent.GetCompletionTime()) {
-			return NewDomainValidationError("events must be in chronological order", nil)
-		}
+	// Business rule: Nothing happens to cargo after it has been claimed
+	if s.last.GetEventType() == HandlingEventTypeClaim {
+		return NewHandlingSequenceError("cargo has already been claimed")
+	}
 
+	switch event.GetEventType() {
+	case HandlingEventTypeReceive:
+		return NewHandlingSequenceError("cargo has already been received")
+	case HandlingEventTypeLoad:
+		if s.onBoardVoyage != "" {
+			return NewHandlingSequenceError(fmt.Sprintf("cargo is already loaded on voyage %s", s.onBoardVoyage))
+		}
+	case HandlingEventTypeUnload:
+		if s.onBoardVoyage == "" {
+			return NewHandlingSequenceError("cannot unload cargo that is not loaded")
+		}
+		if s.onBoardVoyage != event.GetVoyageNumber() {
+			return NewHandlingSequenceError(fmt.Sprintf("cannot unload cargo from voyage %s, it is loaded on voyage %s", event.GetVoyageNumber(), s.onBoardVoyage))
+		}
+	case HandlingEventTypeClaim:
 		// Business rule: Can't CLAIM before final UNLOAD
-		if currentEvent.GetEventType() == HandlingEventTypeClaim {
-			lastUnload := h.getLastUnloadEvent()
-			if lastUnload == nil {
-				return NewDomainValidationError("cannot claim cargo before unloading", nil)
-			}
+		if !s.unloaded {
+			return NewHandlingSequenceError("cannot claim cargo before unloading")
+		}
+		if s.onBoardVoyage != "" {
+			return NewHandlingSequenceError(fmt.Sprintf("cannot claim cargo while it is loaded on voyage %s", s.onBoardVoyage))
 		}
 	}
 
 	return nil
 }
 
-// getLastUnloadEvent returns the most recent UNLOAD event
-func (h HandlingHistory) getLastUnloadEvent() *HandlingEvent {
-	for i := len(h.Events) - 1; i >= 0; i-- {
-		if h.Events[i].GetEventType() == HandlingEventTypeUnload {
-			return &h.Events[i]
-		}
+// advance records that event was handled, whether or not it was in sequence
+func (s *handlingSequence) advance(event HandlingEvent) {
+	s.last = &event
+	switch event.GetEventType() {
+	case HandlingEventTypeLoad:
+		s.onBoardVoyage = event.GetVoyageNumber()
+	case HandlingEventTypeUnload:
+		s.onBoardVoyage = ""
+		s.unloaded = true
 	}
-	return nil
 }
 
 // IsCompleted checks if the cargo handling is completed (claimed)
@@ -0,0 +1,153 @@
+package handlingdomain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultHandlingEventPageSize is the number of events returned when the query does not ask for a specific number
+	DefaultHandlingEventPageSize = 100
+
+	// MaxHandlingEventPageSize bounds the number of events returned in one page
+	MaxHandlingEventPageSize = 1000
+)
+
+// HandlingEventQuery selects a page of handling events, ordered by completion time and then event ID.
+// Empty filters match every event; the filters that are set must all match. All filters compare for equality or
+// against a half-open time range, so SQL adapters can answer them from an index on the filtered column and
+// completion time.
+type HandlingEventQuery struct {
+	TrackingId   string
+	EventType    HandlingEventType
+	Location     string
+	VoyageNumber string
+
+	// CompletedFrom is the earliest completion time included; a zero time leaves the range open
+	CompletedFrom time.Time
+
+	// CompletedBefore is the completion time from which events are excluded; a zero time leaves the range open
+	CompletedBefore time.Time
+
+	// Cursor is the NextCursor of the previous page; empty starts at the first page
+	Cursor string
+	Limit  int
+}
+
+// HandlingEventPage is one page of a handling event listing
+type HandlingEventPage struct {
+	Events []HandlingEvent
+
+	// NextCursor continues the listing after this page; empty when there are no more events
+	NextCursor string
+}
+
+// HandlingEventCursor is the position of the last event of a page
+type HandlingEventCursor struct {
+	CompletionTime time.Time
+	EventId        string
+}
+
+// handlingEventCursorData is the encoded form of a HandlingEventCursor
+type handlingEventCursorData struct {
+	CompletionTime time.Time `json:"t"`
+	EventId        string    `json:"id"`
+}
+
+// Normalize validates the query and fills in the default page size
+func (q HandlingEventQuery) Normalize() (HandlingEventQuery, error) {
+	switch q.EventType {
+	case "", HandlingEventTypeReceive, HandlingEventTypeLoad, HandlingEventTypeUnload, HandlingEventTypeClaim, HandlingEventTypeCustoms:
+	default:
+		return HandlingEventQuery{}, NewDomainValidationError(fmt.Sprintf("unknown event type %q", q.EventType), nil)
+	}
+
+	if q.Limit < 0 || q.Limit > MaxHandlingEventPageSize {
+		return HandlingEventQuery{}, NewDomainValidationError(fmt.Sprintf("limit must be between 1 and %d", MaxHandlingEventPageSize), nil)
+	}
+	if q.Limit == 0 {
+		q.Limit = DefaultHandlingEventPageSize
+	}
+
+	if !q.CompletedFrom.IsZero() && !q.CompletedBefore.IsZero() && !q.CompletedFrom.Before(q.CompletedBefore) {
+		return HandlingEventQuery{}, NewDomainValidationError("completion time range is empty", nil)
+	}
+
+	if _, _, err := q.DecodeCursor(); err != nil {
+		return HandlingEventQuery{}, err
+	}
+
+	return q, nil
+}
+
+// Matches reports whether the event passes every filter of the query
+func (q HandlingEventQuery) Matches(event HandlingEvent) bool {
+	switch {
+	case q.TrackingId != "" && event.GetTrackingId() != q.TrackingId:
+		return false
+	case q.EventType != "" && event.GetEventType() != q.EventType:
+		return false
+	case q.Location != "" && event.GetLocation() != q.Location:
+		return false
+	case q.VoyageNumber != "" && event.GetVoyageNumber() != q.VoyageNumber:
+		return false
+	case !q.CompletedFrom.IsZero() && event.GetCompletionTime().Before(q.CompletedFrom):
+		return false
+	case !q.CompletedBefore.IsZero() && !event.GetCompletionTime().Before(q.CompletedBefore):
+		return false
+	}
+	return true
+}
+
+// Less reports whether event a comes before event b in the listing order
+func (q HandlingEventQuery) Less(a, b HandlingEvent) bool {
+	return compareListingPosition(a.GetCompletionTime(), a.GetEventId().String(), b.GetCompletionTime(), b.GetEventId().String()) < 0
+}
+
+// IsAfter reports whether the event comes after the cursor position in the listing order
+func (q HandlingEventQuery) IsAfter(event HandlingEvent, cursor HandlingEventCursor) bool {
+	return compareListingPosition(event.GetCompletionTime(), event.GetEventId().String(), cursor.CompletionTime, cursor.EventId) > 0
+}
+
+// compareListingPosition orders two events by completion time, then event ID
+func compareListingPosition(timeA time.Time, idA string, timeB time.Time, idB string) int {
+	if result := timeA.Compare(timeB); result != 0 {
+		return result
+	}
+	return strings.Compare(idA, idB)
+}
+
+// CursorAfter returns the cursor continuing the listing after the event
+func (q HandlingEventQuery) CursorAfter(event HandlingEvent) string {
+	data, _ := json.Marshal(handlingEventCursorData{
+		CompletionTime: event.GetCompletionTime().UTC(),
+		EventId:        event.GetEventId().String(),
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor reads the query's cursor; found is false when the listing starts at the first page
+func (q HandlingEventQuery) DecodeCursor() (cursor HandlingEventCursor, found bool, err error) {
+	if q.Cursor == "" {
+		return HandlingEventCursor{}, false, nil
+	}
+
+	invalid := NewDomainValidationError("invalid cursor", nil)
+
+	raw, err := base64.RawURLEncoding.DecodeString(q.Cursor)
+	if err != nil {
+		return HandlingEventCursor{}, false, invalid
+	}
+	var data handlingEventCursorData
+	if err := json.Unmarshal(raw, &data); err != nil || data.CompletionTime.IsZero() {
+		return HandlingEventCursor{}, false, invalid
+	}
+	if _, err := HandlingEventIdFromString(data.EventId); err != nil {
+		return HandlingEventCursor{}, false, invalid
+	}
+
+	return HandlingEventCursor{CompletionTime: data.CompletionTime, EventId: data.EventId}, true, nil
+}
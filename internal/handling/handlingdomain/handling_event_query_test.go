@@ -0,0 +1,64 @@
+package handlingdomain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlingEventQuery_Normalize(t *testing.T) {
+	t.Run("should fill in the default page size", func(t *testing.T) {
+		query, err := HandlingEventQuery{}.Normalize()
+
+		require.NoError(t, err)
+		assert.Equal(t, DefaultHandlingEventPageSize, query.Limit)
+	})
+
+	t.Run("should reject invalid queries", func(t *testing.T) {
+		now := time.Now()
+		tests := map[string]HandlingEventQuery{
+			"event type":            {EventType: "SCAN"},
+			"limit":                 {Limit: MaxHandlingEventPageSize + 1},
+			"completion time range": {CompletedFrom: now, CompletedBefore: now},
+			"cursor":                {Cursor: "not-a-cursor"},
+		}
+		for name, query := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, err := query.Normalize()
+
+				var validationErr DomainValidationError
+				assert.ErrorAs(t, err, &validationErr)
+			})
+		}
+	})
+}
+
+func TestHandlingEventQuery_Cursor(t *testing.T) {
+	t.Run("should continue after the event it was created for", func(t *testing.T) {
+		event, err := NewHandlingEvent("TEST123", HandlingEventTypeReceive, "USNYC", "", time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		query := HandlingEventQuery{}
+
+		query.Cursor = query.CursorAfter(event)
+		cursor, found, err := query.DecodeCursor()
+
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, event.GetEventId().String(), cursor.EventId)
+		assert.True(t, cursor.CompletionTime.Equal(event.GetCompletionTime()))
+		assert.False(t, query.IsAfter(event, cursor))
+	})
+}
+
+func TestHandlingEventQuery_Matches(t *testing.T) {
+	t.Run("should include the start and exclude the end of the completion time range", func(t *testing.T) {
+		completionTime := time.Now().Add(-time.Hour)
+		event, err := NewHandlingEvent("TEST123", HandlingEventTypeReceive, "USNYC", "", completionTime)
+		require.NoError(t, err)
+
+		assert.True(t, HandlingEventQuery{CompletedFrom: completionTime}.Matches(event))
+		assert.False(t, HandlingEventQuery{CompletedBefore: completionTime}.Matches(event))
+	})
+}
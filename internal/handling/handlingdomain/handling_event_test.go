@@ -37,7 +37,7 @@ func TestNewHandlingEvent(t *testing.T) {
 		_, err := NewHandlingEvent(trackingId, eventType, location, voyageNumber, completionTime)
 
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "completion time cannot be in the future")
+		assert.Contains(t, err.Error(), "completion time cannot be more than 5m0s in the future")
 	})
 
 	t.Run("should fail when completion time is too far in the past", func(t *testing.T) {
@@ -53,6 +53,15 @@ func TestNewHandlingEvent(t *testing.T) {
 		assert.Contains(t, err.Error(), "completion time cannot be more than 30 days in the past")
 	})
 
+	t.Run("should accept completion time allowed by the time window", func(t *testing.T) {
+		completionTime := time.Now().AddDate(-1, 0, 0)
+
+		event, err := NewHandlingEventWithinWindow(DefaultTimeWindowPolicy().WithoutMaxAge(), "test-tracking-id", HandlingEventTypeReceive, "USNYC", "", completionTime)
+
+		require.NoError(t, err)
+		assert.Equal(t, completionTime, event.GetCompletionTime())
+	})
+
 	t.Run("should require voyage number for LOAD events", func(t *testing.T) {
 		trackingId := "test-tracking-id"
 		eventType := HandlingEventTypeLoad
@@ -119,6 +128,40 @@ func TestNewHandlingEvent(t *testing.T) {
 	})
 }
 
+func TestNewHandlingEventFromExisting(t *testing.T) {
+	t.Run("should restore event without raising domain events", func(t *testing.T) {
+		eventId := NewHandlingEventId()
+		completionTime := time.Now().AddDate(0, 0, -90) // Older than a new report may be
+		registrationTime := completionTime.Add(time.Hour)
+
+		event, err := NewHandlingEventFromExisting(eventId, "test-tracking-id", HandlingEventTypeLoad, "USNYC", "V001", completionTime, registrationTime, "scan-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, eventId, event.GetEventId())
+		assert.Equal(t, completionTime, event.GetCompletionTime())
+		assert.Equal(t, registrationTime, event.GetRegistrationTime())
+		assert.Equal(t, "scan-1", event.GetIdempotencyKey())
+		assert.Empty(t, event.GetEvents())
+	})
+
+	t.Run("should keep the registration time as creation time rather than the load time", func(t *testing.T) {
+		registrationTime := time.Now().Add(-48 * time.Hour)
+
+		event, err := NewHandlingEventFromExisting(NewHandlingEventId(), "test-tracking-id", HandlingEventTypeReceive, "USNYC", "", registrationTime.Add(-time.Hour), registrationTime, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, registrationTime, event.CreatedAt)
+		assert.Equal(t, registrationTime, event.UpdatedAt)
+	})
+
+	t.Run("should fail when required data is missing", func(t *testing.T) {
+		_, err := NewHandlingEventFromExisting(NewHandlingEventId(), "", HandlingEventTypeReceive, "USNYC", "", time.Now(), time.Now(), "")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "handling event data validation failed")
+	})
+}
+
 func TestNewHandlingHistory(t *testing.T) {
 	t.Run("should create valid handling history", func(t *testing.T) {
 		trackingId := "test-tracking-id"
@@ -142,6 +185,52 @@ func TestNewHandlingHistory(t *testing.T) {
 		assert.Equal(t, trackingId, history.TrackingId)
 		assert.Len(t, history.Events, 0)
 	})
+
+	t.Run("should order events by completion time", func(t *testing.T) {
+		events := createTestHandlingEventsWithClaim(t)
+		shuffled := []HandlingEvent{events[2], events[0], events[3], events[1]}
+
+		history, err := NewHandlingHistory("TEST123", shuffled)
+		require.NoError(t, err)
+
+		assert.Equal(t, events, history.Events)
+		assert.Equal(t, events[3].GetEventId(), history.GetMostRecentEvent().GetEventId())
+		assert.NoError(t, history.IsValidSequence())
+		assert.Equal(t, events[2], shuffled[0], "the caller's slice must not be reordered")
+	})
+
+	t.Run("should order events completed at the same time by registration time", func(t *testing.T) {
+		completionTime := time.Now().Add(-time.Hour)
+		registered := completionTime.Add(time.Minute)
+		customs, err := NewHandlingEventFromExisting(NewHandlingEventId(), "TEST123", HandlingEventTypeCustoms, "USNYC", "", completionTime, registered, "")
+		require.NoError(t, err)
+		load, err := NewHandlingEventFromExisting(NewHandlingEventId(), "TEST123", HandlingEventTypeLoad, "USNYC", "V001", completionTime, registered.Add(time.Second), "")
+		require.NoError(t, err)
+
+		history, err := NewHandlingHistory("TEST123", []HandlingEvent{load, customs})
+		require.NoError(t, err)
+
+		assert.Equal(t, []HandlingEvent{customs, load}, history.Events)
+	})
+
+	t.Run("should order events registered at the same time by event ID", func(t *testing.T) {
+		completionTime := time.Now().Add(-time.Hour)
+		first, err := NewHandlingEventFromExisting(NewHandlingEventId(), "TEST123", HandlingEventTypeCustoms, "USNYC", "", completionTime, completionTime, "")
+		require.NoError(t, err)
+		second, err := NewHandlingEventFromExisting(NewHandlingEventId(), "TEST123", HandlingEventTypeCustoms, "USNYC", "", completionTime, completionTime, "")
+		require.NoError(t, err)
+		if second.GetEventId().String() < first.GetEventId().String() {
+			first, second = second, first
+		}
+
+		forward, err := NewHandlingHistory("TEST123", []HandlingEvent{first, second})
+		require.NoError(t, err)
+		backward, err := NewHandlingHistory("TEST123", []HandlingEvent{second, first})
+		require.NoError(t, err)
+
+		assert.Equal(t, forward.Events, backward.Events)
+		assert.Equal(t, first.GetEventId(), forward.Events[0].GetEventId())
+	})
 }
 
 func TestHandlingHistory_GetMostRecentEvent(t *testing.T) {
@@ -282,12 +371,10 @@ func TestHandlingHistory_IsValidSequence(t *testing.T) {
 			Data:       event2Data,
 		}
 
-		events := []HandlingEvent{event1, event2}
+		// NewHandlingHistory would sort the events, so build the history directly
+		history := HandlingHistory{TrackingId: trackingId, Events: []HandlingEvent{event1, event2}}
 
-		history, err := NewHandlingHistory(trackingId, events)
-		require.NoError(t, err)
-
-		err = history.IsValidSequence()
+		err := history.IsValidSequence()
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "events must be in chronological order")
@@ -308,6 +395,64 @@ func TestHandlingHistory_IsValidSequence(t *testing.T) {
 		assert.Contains(t, err.Error(), "cannot claim cargo before unloading")
 	})
 
+	t.Run("should fail if cargo is received twice", func(t *testing.T) {
+		event1 := createTestHandlingEvent(t, HandlingEventTypeReceive, "USNYC", "")
+		event2 := createTestHandlingEventAfter(t, event1, HandlingEventTypeReceive, "USNYC", "")
+
+		history, err := NewHandlingHistory("test-tracking-id", []HandlingEvent{event1, event2})
+		require.NoError(t, err)
+
+		err = history.IsValidSequence()
+
+		var sequenceErr HandlingSequenceError
+		assert.ErrorAs(t, err, &sequenceErr)
+		assert.Contains(t, err.Error(), "cargo has already been received")
+	})
+
+	t.Run("should fail if cargo is loaded while on board", func(t *testing.T) {
+		event1 := createTestHandlingEvent(t, HandlingEventTypeReceive, "USNYC", "")
+		event2 := createTestHandlingEventAfter(t, event1, HandlingEventTypeLoad, "USNYC", "V001")
+		event3 := createTestHandlingEventAfter(t, event2, HandlingEventTypeLoad, "USNYC", "V002")
+
+		history, err := NewHandlingHistory("test-tracking-id", []HandlingEvent{event1, event2, event3})
+		require.NoError(t, err)
+
+		err = history.IsValidSequence()
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cargo is already loaded on voyage V001")
+	})
+
+	t.Run("should fail if cargo is unloaded from a different voyage", func(t *testing.T) {
+		event1 := createTestHandlingEvent(t, HandlingEventTypeReceive, "USNYC", "")
+		event2 := createTestHandlingEventAfter(t, event1, HandlingEventTypeLoad, "USNYC", "V001")
+		event3 := createTestHandlingEventAfter(t, event2, HandlingEventTypeUnload, "SEGOT", "V002")
+
+		history, err := NewHandlingHistory("test-tracking-id", []HandlingEvent{event1, event2, event3})
+		require.NoError(t, err)
+
+		err = history.IsValidSequence()
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot unload cargo from voyage V002, it is loaded on voyage V001")
+	})
+
+	t.Run("should fail if anything happens after CLAIM", func(t *testing.T) {
+		event1 := createTestHandlingEvent(t, HandlingEventTypeReceive, "USNYC", "")
+		event2 := createTestHandlingEventAfter(t, event1, HandlingEventTypeLoad, "USNYC", "V001")
+		event3 := createTestHandlingEventAfter(t, event2, HandlingEventTypeUnload, "SEGOT", "V001")
+		event4 := createTestHandlingEventAfter(t, event3, HandlingEventTypeClaim, "SEGOT", "")
+		event5 := createTestHandlingEventAfter(t, event4, HandlingEventTypeClaim, "SEGOT", "")
+
+		history, err := NewHandlingHistory("test-tracking-id", []HandlingEvent{event1, event2, event3, event4, event5})
+		require.NoError(t, err)
+
+		err = history.IsValidSequence()
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cargo has already been claimed")
+	})
+
 	t.Run("should validate empty history", func(t *testing.T) {
 		trackingId := "test-tracking-id"
 		events := []HandlingEvent{}
@@ -321,6 +466,63 @@ func TestHandlingHistory_IsValidSequence(t *testing.T) {
 	})
 }
 
+func TestHandlingHistory_ValidateNewEvent(t *testing.T) {
+	t.Run("should place late event by completion time", func(t *testing.T) {
+		receive := createTestHandlingEvent(t, HandlingEventTypeReceive, "USNYC", "")
+		load := createTestHandlingEventAfter(t, receive, HandlingEventTypeLoad, "USNYC", "V001")
+		customs, err := NewHandlingEvent("test-tracking-id", HandlingEventTypeCustoms, "USNYC", "", receive.GetCompletionTime().Add(time.Minute))
+		require.NoError(t, err)
+
+		history, err := NewHandlingHistory("test-tracking-id", []HandlingEvent{receive, load})
+		require.NoError(t, err)
+
+		assert.NoError(t, history.ValidateNewEvent(customs))
+		assert.Equal(t, []HandlingEvent{receive, customs, load}, history.WithEvent(customs).Events)
+		assert.Len(t, history.Events, 2)
+	})
+
+	t.Run("should reject event that breaks the sequence", func(t *testing.T) {
+		receive := createTestHandlingEvent(t, HandlingEventTypeReceive, "USNYC", "")
+		unload := createTestHandlingEventAfter(t, receive, HandlingEventTypeUnload, "SEGOT", "V001")
+
+		history, err := NewHandlingHistory("test-tracking-id", []HandlingEvent{receive})
+		require.NoError(t, err)
+
+		err = history.ValidateNewEvent(unload)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot unload cargo that is not loaded")
+	})
+
+	t.Run("should reject late event that breaks the event after it", func(t *testing.T) {
+		receive := createTestHandlingEvent(t, HandlingEventTypeReceive, "USNYC", "")
+		customs := createTestHandlingEventAfter(t, receive, HandlingEventTypeCustoms, "USNYC", "")
+		load := createTestHandlingEventAfter(t, customs, HandlingEventTypeLoad, "USNYC", "V002")
+		lateLoad, err := NewHandlingEvent("test-tracking-id", HandlingEventTypeLoad, "USNYC", "V001", receive.GetCompletionTime().Add(time.Second))
+		require.NoError(t, err)
+
+		history, err := NewHandlingHistory("test-tracking-id", []HandlingEvent{receive, customs, load})
+		require.NoError(t, err)
+
+		err = history.ValidateNewEvent(lateLoad)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cargo is already loaded on voyage V001")
+	})
+
+	t.Run("should accept event despite a violation elsewhere in the history", func(t *testing.T) {
+		receive := createTestHandlingEvent(t, HandlingEventTypeReceive, "USNYC", "")
+		strayUnload := createTestHandlingEventAfter(t, receive, HandlingEventTypeUnload, "USNYC", "V001")
+		load := createTestHandlingEventAfter(t, strayUnload, HandlingEventTypeLoad, "USNYC", "V002")
+
+		history, err := NewHandlingHistory("test-tracking-id", []HandlingEvent{receive, strayUnload})
+		require.NoError(t, err)
+		require.Error(t, history.IsValidSequence())
+
+		assert.NoError(t, history.ValidateNewEvent(load))
+	})
+}
+
 // Helper functions for tests
 
 func createTestHandlingEvent(t *testing.T, eventType HandlingEventType, location, voyageNumber string) HandlingEvent {
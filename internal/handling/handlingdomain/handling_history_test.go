@@ -54,6 +54,36 @@ func TestHandlingHistory_IsReceived(t *testing.T) {
 	})
 }
 
+func TestHandlingHistory_FindSameHandling(t *testing.T) {
+	completionTime := time.Now().Add(-time.Hour)
+	loaded, err := NewHandlingEvent("TEST123", HandlingEventTypeLoad, "USNYC", "V001", completionTime)
+	require.NoError(t, err)
+	history, err := NewHandlingHistory("TEST123", []HandlingEvent{loaded})
+	require.NoError(t, err)
+
+	t.Run("should find recorded event for the same handling", func(t *testing.T) {
+		report, err := NewHandlingEvent("TEST123", HandlingEventTypeLoad, "USNYC", "V001", completionTime.UTC())
+		require.NoError(t, err)
+
+		found, ok := history.FindSameHandling(report)
+
+		assert.True(t, ok)
+		assert.Equal(t, loaded.GetEventId(), found.GetEventId())
+	})
+
+	t.Run("should not match handling on another voyage or at another time", func(t *testing.T) {
+		otherVoyage, err := NewHandlingEvent("TEST123", HandlingEventTypeLoad, "USNYC", "V002", completionTime)
+		require.NoError(t, err)
+		otherTime, err := NewHandlingEvent("TEST123", HandlingEventTypeLoad, "USNYC", "V001", completionTime.Add(time.Minute))
+		require.NoError(t, err)
+
+		_, ok := history.FindSameHandling(otherVoyage)
+		assert.False(t, ok)
+		_, ok = history.FindSameHandling(otherTime)
+		assert.False(t, ok)
+	})
+}
+
 func TestHandlingHistory_GetCurrentLocation(t *testing.T) {
 	t.Run("should return location of most recent event", func(t *testing.T) {
 		events := createTestHandlingEventsWithMultipleLocations(t)
@@ -1,5 +1,11 @@
 package handlingdomain
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
 // HandlingReport represents raw data from external systems (Data Transfer Object)
 // This is the Anti-Corruption Layer input format
 type HandlingReport struct {
@@ -8,4 +14,60 @@ type HandlingReport struct {
 	Location       string `json:"location" validate:"required"`
 	VoyageNumber   string `json:"voyage_number,omitempty"`
 	CompletionTime string `json:"completion_time" validate:"required"` // RFC3339 format
+
+	// IdempotencyKey is optional; reports retried with the same key are registered once
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+}
+
+// HandlingReceipt tells the submitter which handling event a report was registered as
+type HandlingReceipt struct {
+	EventId      HandlingEventId
+	RegisteredAt time.Time
+
+	// Duplicate is set when the report repeated one registered before; EventId is then the original event
+	Duplicate bool
+}
+
+// QuarantinedReport is a handling report held back for review because it broke the handling sequence
+type QuarantinedReport struct {
+	Id            string         `json:"id"`
+	Report        HandlingReport `json:"report"`
+	Reason        string         `json:"reason"`
+	QuarantinedAt time.Time      `json:"quarantined_at"`
+}
+
+// NewQuarantinedReport sets report aside for the reason given, under a generated ID
+func NewQuarantinedReport(report HandlingReport, reason string) QuarantinedReport {
+	return QuarantinedReport{
+		Id:            uuid.NewString(),
+		Report:        report,
+		Reason:        reason,
+		QuarantinedAt: time.Now(),
+	}
+}
+
+// HandlingReportStatus is the outcome of a single report of a batch submission
+type HandlingReportStatus string
+
+const (
+	// HandlingReportAccepted means the report was registered as a handling event
+	HandlingReportAccepted HandlingReportStatus = "accepted"
+
+	// HandlingReportRejected means the report was not registered
+	HandlingReportRejected HandlingReportStatus = "rejected"
+
+	// HandlingReportQuarantined means the report was set aside for review
+	HandlingReportQuarantined HandlingReportStatus = "quarantined"
+
+	// HandlingReportDuplicate means the report repeated one that was already registered
+	HandlingReportDuplicate HandlingReportStatus = "duplicate"
+)
+
+// HandlingReportResult tells a batch submitter what happened to the report at Index
+type HandlingReportResult struct {
+	Index      int                  `json:"index"`
+	TrackingId string               `json:"tracking_id"`
+	Status     HandlingReportStatus `json:"status"`
+	EventId    string               `json:"event_id,omitempty"`
+	Reason     string               `json:"reason,omitempty"`
 }
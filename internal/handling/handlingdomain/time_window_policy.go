@@ -0,0 +1,54 @@
+package handlingdomain
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeWindowPolicy bounds the completion time a handling event may be registered with, relative to now
+type TimeWindowPolicy struct {
+	// MaxAge is how long ago the handling may have been completed; zero means there is no limit
+	MaxAge time.Duration
+
+	// MaxFutureSkew tolerates clocks at terminals running ahead of ours
+	MaxFutureSkew time.Duration
+}
+
+// DefaultTimeWindowPolicy accepts handling completed within the last 30 days and up to 5 minutes ahead
+func DefaultTimeWindowPolicy() TimeWindowPolicy {
+	return TimeWindowPolicy{
+		MaxAge:        30 * 24 * time.Hour,
+		MaxFutureSkew: 5 * time.Minute,
+	}
+}
+
+// WithoutMaxAge returns a copy of the policy that accepts handling completed at any time in the past, for backfills
+func (p TimeWindowPolicy) WithoutMaxAge() TimeWindowPolicy {
+	p.MaxAge = 0
+	return p
+}
+
+// Check returns a validation error if completionTime lies outside the window around now
+func (p TimeWindowPolicy) Check(completionTime, now time.Time) error {
+	if completionTime.After(now.Add(p.MaxFutureSkew)) {
+		if p.MaxFutureSkew == 0 {
+			return NewDomainValidationError("completion time cannot be in the future", nil)
+		}
+		return NewDomainValidationError(fmt.Sprintf("completion time cannot be more than %s in the future", formatWindow(p.MaxFutureSkew)), nil)
+	}
+
+	if p.MaxAge > 0 && completionTime.Before(now.Add(-p.MaxAge)) {
+		return NewDomainValidationError(fmt.Sprintf("completion time cannot be more than %s in the past", formatWindow(p.MaxAge)), nil)
+	}
+
+	return nil
+}
+
+// formatWindow writes whole days as days and anything else as a duration
+func formatWindow(d time.Duration) string {
+	const day = 24 * time.Hour
+	if d >= day && d%day == 0 {
+		return fmt.Sprintf("%d days", d/day)
+	}
+	return d.String()
+}
@@ -0,0 +1,41 @@
+package handlingdomain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeWindowPolicy_Check(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	policy := TimeWindowPolicy{MaxAge: 7 * 24 * time.Hour, MaxFutureSkew: 30 * time.Second}
+
+	t.Run("should accept completion times inside the window", func(t *testing.T) {
+		assert.NoError(t, policy.Check(now, now))
+		assert.NoError(t, policy.Check(now.Add(30*time.Second), now))
+		assert.NoError(t, policy.Check(now.Add(-7*24*time.Hour), now))
+	})
+
+	t.Run("should reject completion time beyond the allowed skew", func(t *testing.T) {
+		err := policy.Check(now.Add(31*time.Second), now)
+
+		assert.EqualError(t, err, "completion time cannot be more than 30s in the future")
+	})
+
+	t.Run("should reject any future completion time without skew", func(t *testing.T) {
+		err := TimeWindowPolicy{MaxAge: time.Hour}.Check(now.Add(time.Second), now)
+
+		assert.EqualError(t, err, "completion time cannot be in the future")
+	})
+
+	t.Run("should reject completion time older than the maximum age", func(t *testing.T) {
+		err := policy.Check(now.Add(-7*24*time.Hour-time.Second), now)
+
+		assert.EqualError(t, err, "completion time cannot be more than 7 days in the past")
+	})
+
+	t.Run("should accept any past completion time without maximum age", func(t *testing.T) {
+		assert.NoError(t, policy.WithoutMaxAge().Check(now.AddDate(-5, 0, 0), now))
+	})
+}
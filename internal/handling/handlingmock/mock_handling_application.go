@@ -25,11 +25,23 @@ type MockHandlingApplication struct {
 // NewMockHandlingApplication creates a mock handling application with embedded real application service
 func NewMockHandlingApplication(
 	handlingEventRepo handlingsecondary.HandlingEventRepository,
-	eventPublisher handlingsecondary.EventPublisher,
+	quarantine handlingsecondary.HandlingQuarantine,
+	cargoBookings handlingsecondary.CargoBookingService,
+	shippingNetwork handlingsecondary.ShippingNetworkService,
+	sequencePolicy handlingapplication.SequencePolicy,
+	timeWindow handlingdomain.TimeWindowPolicy,
 	logger *slog.Logger,
 	seed int64,
 ) *MockHandlingApplication {
-	realApp := handlingapplication.NewHandlingReportService(handlingEventRepo, eventPublisher, logger)
+	realApp := handlingapplication.NewHandlingReportService(
+		handlingEventRepo,
+		quarantine,
+		cargoBookings,
+		shippingNetwork,
+		sequencePolicy,
+		timeWindow,
+		logger,
+	)
 
 	return &MockHandlingApplication{
 		HandlingReportService: realApp.(*handlingapplication.HandlingReportService),
@@ -49,11 +61,11 @@ func (m *MockHandlingApplication) PopulateTestHandlingEvents(ctx context.Context
 	var events []handlingdomain.HandlingEvent
 
 	for _, scenario := range scenarios {
+		// Start 72-96 hours ago and move forward so the sequence stays in chronological order
+		completionTime := time.Now().Add(-time.Duration(72+m.random.Intn(24)) * time.Hour)
+
 		for _, eventSpec := range scenario.EventSequence {
-			// Generate completion time relative to current date
-			// Events should be in the past (24+ hours ago to ensure validity)
-			hoursAgo := 24 + m.random.Intn(72) // 24-96 hours ago
-			completionTime := time.Now().Add(-time.Duration(hoursAgo) * time.Hour)
+			completionTime = completionTime.Add(time.Duration(1+m.random.Intn(6)) * time.Hour) // 1-6 hours after the previous event
 
 			// Create handling report for submission
 			report := handlingdomain.HandlingReport{
@@ -65,7 +77,7 @@ func (m *MockHandlingApplication) PopulateTestHandlingEvents(ctx context.Context
 			}
 
 			// Use the real application service to submit the handling report
-			err := m.HandlingReportService.SubmitHandlingReport(testCtx, report)
+			_, err := m.HandlingReportService.SubmitHandlingReport(testCtx, report)
 			if err != nil {
 				m.logger.Error("Failed to submit test handling report", "error", err,
 					"trackingId", scenario.TrackingId, "eventType", eventSpec.EventType)
@@ -98,12 +110,12 @@ func (m *MockHandlingApplication) PopulateTestHandlingEvents(ctx context.Context
 	return events, nil
 }
 
-// GenerateHandlingScenarios creates realistic handling event sequences for cargo
-func (m *MockHandlingApplication) GenerateHandlingScenarios(trackingIds []string, locations []string) []TestHandlingScenario {
-	m.logger.Info("Generating handling scenarios", "trackingIds", len(trackingIds), "locations", len(locations))
+// GenerateHandlingScenarios creates realistic handling event sequences for cargo travelling on the given voyages
+func (m *MockHandlingApplication) GenerateHandlingScenarios(trackingIds []string, voyages []TestVoyageRoute) []TestHandlingScenario {
+	m.logger.Info("Generating handling scenarios", "trackingIds", len(trackingIds), "voyages", len(voyages))
 
-	if len(locations) < 2 {
-		m.logger.Warn("Not enough locations to generate handling scenarios", "locations", len(locations))
+	if len(voyages) == 0 {
+		m.logger.Warn("No voyages to generate handling scenarios from")
 		return nil
 	}
 
@@ -111,7 +123,7 @@ func (m *MockHandlingApplication) GenerateHandlingScenarios(trackingIds []string
 
 	for _, trackingId := range trackingIds {
 		// Generate a realistic sequence of handling events
-		eventSequence := m.generateEventSequence(locations)
+		eventSequence := m.generateEventSequence(voyages)
 
 		scenario := TestHandlingScenario{
 			TrackingId:    trackingId,
@@ -125,15 +137,17 @@ func (m *MockHandlingApplication) GenerateHandlingScenarios(trackingIds []string
 	return scenarios
 }
 
-// generateEventSequence creates a realistic sequence of handling events
-func (m *MockHandlingApplication) generateEventSequence(locations []string) []TestHandlingEventSpec {
+// generateEventSequence creates a realistic sequence of handling events along connecting voyages
+func (m *MockHandlingApplication) generateEventSequence(voyages []TestVoyageRoute) []TestHandlingEventSpec {
 	var events []TestHandlingEventSpec
 
-	// Always start with RECEIVE
-	originLocation := locations[m.random.Intn(len(locations))]
+	// Always start with RECEIVE at a port the first voyage departs from
+	voyage := voyages[m.random.Intn(len(voyages))]
+	loadIdx := m.random.Intn(len(voyage.PortCalls) - 1)
+	location := voyage.PortCalls[loadIdx]
 	events = append(events, TestHandlingEventSpec{
 		EventType:    handlingdomain.HandlingEventTypeReceive,
-		Location:     originLocation,
+		Location:     location,
 		VoyageNumber: "", // No voyage for RECEIVE
 	})
 
@@ -141,42 +155,67 @@ func (m *MockHandlingApplication) generateEventSequence(locations []string) []Te
 	loadUnloadPairs := 1 + m.random.Intn(3) // 1-3 pairs
 
 	for i := 0; i < loadUnloadPairs; i++ {
-		// LOAD event
-		loadLocation := locations[m.random.Intn(len(locations))]
-		voyageNumber := fmt.Sprintf("V%d", 1000+m.random.Intn(9000))
-
-		events = append(events, TestHandlingEventSpec{
-			EventType:    handlingdomain.HandlingEventTypeLoad,
-			Location:     loadLocation,
-			VoyageNumber: voyageNumber,
-		})
-
-		// UNLOAD event (different location)
-		unloadLocation := locations[m.random.Intn(len(locations))]
-		for unloadLocation == loadLocation && len(locations) > 1 {
-			unloadLocation = locations[m.random.Intn(len(locations))]
+		// UNLOAD at a later port call of the same voyage
+		unloadIdx := loadIdx + 1 + m.random.Intn(len(voyage.PortCalls)-loadIdx-1)
+
+		events = append(events,
+			TestHandlingEventSpec{
+				EventType:    handlingdomain.HandlingEventTypeLoad,
+				Location:     voyage.PortCalls[loadIdx],
+				VoyageNumber: voyage.VoyageNumber,
+			},
+			TestHandlingEventSpec{
+				EventType:    handlingdomain.HandlingEventTypeUnload,
+				Location:     voyage.PortCalls[unloadIdx],
+				VoyageNumber: voyage.VoyageNumber,
+			},
+		)
+		location = voyage.PortCalls[unloadIdx]
+
+		// Continue on a voyage departing from where the cargo was unloaded, if there is one
+		next, nextLoadIdx, found := m.findDeparture(voyages, location)
+		if !found {
+			break
 		}
-
-		events = append(events, TestHandlingEventSpec{
-			EventType:    handlingdomain.HandlingEventTypeUnload,
-			Location:     unloadLocation,
-			VoyageNumber: voyageNumber,
-		})
+		voyage, loadIdx = next, nextLoadIdx
 	}
 
 	// End with CLAIM if this is a complete journey
 	if m.random.Float32() < 0.7 { // 70% chance of completed delivery
-		finalLocation := events[len(events)-1].Location // Use last unload location
 		events = append(events, TestHandlingEventSpec{
 			EventType:    handlingdomain.HandlingEventTypeClaim,
-			Location:     finalLocation,
-			VoyageNumber: "", // No voyage for CLAIM
+			Location:     location, // Use last unload location
+			VoyageNumber: "",       // No voyage for CLAIM
 		})
 	}
 
 	return events
 }
 
+// findDeparture picks a random voyage departing from location and the index of that port call
+func (m *MockHandlingApplication) findDeparture(voyages []TestVoyageRoute, location string) (TestVoyageRoute, int, bool) {
+	type departure struct {
+		voyage  TestVoyageRoute
+		callIdx int
+	}
+
+	var departures []departure
+	for _, voyage := range voyages {
+		for i, portCall := range voyage.PortCalls[:len(voyage.PortCalls)-1] {
+			if portCall == location {
+				departures = append(departures, departure{voyage: voyage, callIdx: i})
+			}
+		}
+	}
+
+	if len(departures) == 0 {
+		return TestVoyageRoute{}, 0, false
+	}
+
+	chosen := departures[m.random.Intn(len(departures))]
+	return chosen.voyage, chosen.callIdx, true
+}
+
 // createTestContext creates an authenticated context for test operations
 func (m *MockHandlingApplication) createTestContext(ctx context.Context) context.Context {
 	// Create test claims with admin permissions
@@ -197,6 +236,12 @@ type TestHandlingScenario struct {
 	EventSequence []TestHandlingEventSpec
 }
 
+// TestVoyageRoute lists the locations a voyage calls at, in schedule order
+type TestVoyageRoute struct {
+	VoyageNumber string
+	PortCalls    []string
+}
+
 // TestHandlingEventSpec defines the specification for creating a test handling event
 type TestHandlingEventSpec struct {
 	EventType    handlingdomain.HandlingEventType
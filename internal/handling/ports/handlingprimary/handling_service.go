@@ -7,8 +7,37 @@ import (
 
 // HandlingReportService defines the primary port for receiving handling reports from external systems
 type HandlingReportService interface {
-	// SubmitHandlingReport receives a report from an external system about a handling event
-	SubmitHandlingReport(ctx context.Context, report handlingdomain.HandlingReport) error
+	// SubmitHandlingReport receives a report from an external system about a handling event and says which event it was
+	// registered as; resubmitting a registered report returns the original event marked as a duplicate
+	SubmitHandlingReport(ctx context.Context, report handlingdomain.HandlingReport) (handlingdomain.HandlingReceipt, error)
+
+	// SubmitHandlingReports validates and registers each report of a batch independently and returns one result per report
+	SubmitHandlingReports(ctx context.Context, reports []handlingdomain.HandlingReport) ([]handlingdomain.HandlingReportResult, error)
+
+	// BackfillHandlingReports registers a batch of historical reports regardless of how long ago they were completed;
+	// it requires the permission to backfill handling
+	BackfillHandlingReports(ctx context.Context, reports []handlingdomain.HandlingReport) ([]handlingdomain.HandlingReportResult, error)
+
+	// StartHandlingReportBatch authorizes the caller once for a batch whose reports are then submitted in parts, like
+	// SubmitHandlingReports
+	StartHandlingReportBatch(ctx context.Context) (HandlingReportBatch, error)
+
+	// StartHandlingBackfill authorizes the caller once for a backfill whose reports are then submitted in parts, like
+	// BackfillHandlingReports
+	StartHandlingBackfill(ctx context.Context) (HandlingReportBatch, error)
+
+	// ListQuarantinedReports retrieves the reports set aside for review, oldest first
+	ListQuarantinedReports(ctx context.Context) ([]handlingdomain.QuarantinedReport, error)
+
+	// ReleaseQuarantinedReport registers a reviewed report despite the sequence violation it was quarantined for and
+	// takes it out of the quarantine; it requires the permission to backfill handling
+	ReleaseQuarantinedReport(ctx context.Context, id string) (handlingdomain.HandlingReceipt, error)
+}
+
+// HandlingReportBatch registers the reports of a batch that was authorized when it was started
+type HandlingReportBatch interface {
+	// Submit validates and registers each report independently and returns one result per report, indexed within reports
+	Submit(ctx context.Context, reports []handlingdomain.HandlingReport) ([]handlingdomain.HandlingReportResult, error)
 }
 
 // HandlingEventQueryService defines the primary port for querying handling events
@@ -21,4 +50,8 @@ type HandlingEventQueryService interface {
 
 	// ListAllHandlingEvents retrieves all handling events from the repository
 	ListAllHandlingEvents(ctx context.Context) ([]handlingdomain.HandlingEvent, error)
+
+	// ListHandlingEvents retrieves one page of the handling events matching the query, by completion time and then event ID
+	// Fails with handlingdomain.DomainValidationError if the query or its cursor is invalid.
+	ListHandlingEvents(ctx context.Context, query handlingdomain.HandlingEventQuery) (handlingdomain.HandlingEventPage, error)
 }
@@ -1,27 +1,69 @@
 package handlingsecondary
 
 import (
+	"context"
+
 	"go_hex/internal/handling/handlingdomain"
-	"go_hex/internal/support/basedomain"
 )
 
 // HandlingEventRepository defines the secondary port for handling event persistence
 type HandlingEventRepository interface {
-	// Store persists a handling event
+	// Store persists a handling event and records its pending domain events for delivery in the same write. It fails
+	// if another event already has the same idempotency key or records the same handling.
 	Store(event handlingdomain.HandlingEvent) error
 
+	// Append stores a new handling event like Store, provided the cargo's stored history still holds historyLength
+	// events, the history the event was checked against. Fails with handlingdomain.HandlingHistoryChangedError if
+	// events of the cargo were stored since, so concurrent reports for one cargo are checked one after another.
+	Append(event handlingdomain.HandlingEvent, historyLength int) error
+
+	// AppendAll stores new handling events like Append in one write, all of them or none. historyLengths holds, per
+	// tracking ID, the length of the stored history the cargo's events were checked against.
+	AppendAll(events []handlingdomain.HandlingEvent, historyLengths map[string]int) error
+
 	// FindById retrieves a handling event by its ID
 	FindById(eventId handlingdomain.HandlingEventId) (handlingdomain.HandlingEvent, error)
 
+	// FindByIdempotencyKey retrieves the handling event submitted with an idempotency key; found is false if there is none
+	FindByIdempotencyKey(key string) (event handlingdomain.HandlingEvent, found bool, err error)
+
 	// FindByTrackingId retrieves all handling events for a specific cargo
 	FindByTrackingId(trackingId string) ([]handlingdomain.HandlingEvent, error)
 
 	// FindAll retrieves all handling events (mainly for administrative purposes)
 	FindAll() ([]handlingdomain.HandlingEvent, error)
+
+	// FindByQuery retrieves one page of the handling events matching the query, by completion time and then event ID
+	// Fails with handlingdomain.DomainValidationError if the query or its cursor is invalid.
+	FindByQuery(query handlingdomain.HandlingEventQuery) (handlingdomain.HandlingEventPage, error)
 }
 
-// EventPublisher defines the secondary port for publishing domain events
-type EventPublisher interface {
-	// Publish publishes a domain event
-	Publish(event basedomain.DomainEvent) error
+// HandlingQuarantine defines the secondary port for keeping handling reports that were not registered
+type HandlingQuarantine interface {
+	// Add keeps a quarantined report for later review
+	Add(report handlingdomain.QuarantinedReport) error
+
+	// FindAll retrieves all quarantined reports, oldest first
+	FindAll() ([]handlingdomain.QuarantinedReport, error)
+
+	// FindById retrieves a quarantined report; fails with handlingdomain.QuarantinedReportNotFoundError if there is none
+	FindById(id string) (handlingdomain.QuarantinedReport, error)
+
+	// Remove takes a report out of the quarantine; fails with handlingdomain.QuarantinedReportNotFoundError if there is none
+	Remove(id string) error
+}
+
+// CargoBookingService defines the secondary port for checking handled cargo against the booking context
+type CargoBookingService interface {
+	// IsCargoBooked reports whether cargo with the tracking ID has been booked and not cancelled
+	IsCargoBooked(ctx context.Context, trackingId string) (bool, error)
+}
+
+// ShippingNetworkService defines the secondary port for checking handling locations and voyages against the routing context
+type ShippingNetworkService interface {
+	// IsKnownLocation reports whether the UN/LOCODE belongs to a location of the shipping network
+	IsKnownLocation(ctx context.Context, unLocode string) (bool, error)
+
+	// FindVoyagePortCalls returns the UN/LOCODEs a voyage calls at, in schedule order; found is false for unknown voyages
+	FindVoyagePortCalls(ctx context.Context, voyageNumber string) (portCalls []string, found bool, err error)
 }
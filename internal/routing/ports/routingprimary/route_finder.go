@@ -7,8 +7,14 @@ import (
 
 // RouteFinder defines the primary port for route calculation services
 type RouteFinder interface {
-	// FindOptimalItineraries finds the best routes that satisfy the given specification
-	FindOptimalItineraries(ctx context.Context, routeSpec routingdomain.RouteSpecification) ([]routingdomain.Itinerary, error)
+	// FindOptimalItineraries finds the best routes that satisfy the given specification, ranked by the given criteria
+	FindOptimalItineraries(ctx context.Context, routeSpec routingdomain.RouteSpecification, criteria routingdomain.RankingCriteria) ([]routingdomain.Itinerary, error)
 	ListAllVoyages(ctx context.Context) ([]routingdomain.Voyage, error)
 	ListAllLocations(ctx context.Context) ([]routingdomain.Location, error)
+
+	// GetVoyage retrieves a voyage by its number, failing with routingdomain.VoyageNotFoundError if it does not exist
+	GetVoyage(ctx context.Context, voyageNumber routingdomain.VoyageNumber) (routingdomain.Voyage, error)
+
+	// GetLocation retrieves a location by its UN/LOCODE, failing with routingdomain.LocationNotFoundError if it does not exist
+	GetLocation(ctx context.Context, unLocode routingdomain.UnLocode) (routingdomain.Location, error)
 }
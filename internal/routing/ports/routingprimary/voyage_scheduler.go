@@ -0,0 +1,18 @@
+package routingprimary
+
+import (
+	"context"
+	"go_hex/internal/routing/routingdomain"
+)
+
+// VoyageScheduler defines the primary port for managing voyage schedules
+type VoyageScheduler interface {
+	// CreateVoyage schedules a new voyage with the given carrier movements under a generated voyage number
+	CreateVoyage(ctx context.Context, movements []routingdomain.CarrierMovement) (routingdomain.Voyage, error)
+
+	// RescheduleVoyage replaces the schedule of a voyage, failing with routingdomain.VoyageNotFoundError if it does not exist
+	RescheduleVoyage(ctx context.Context, voyageNumber routingdomain.VoyageNumber, movements []routingdomain.CarrierMovement) (routingdomain.Voyage, error)
+
+	// DeleteVoyage removes a voyage from the schedule, failing with routingdomain.VoyageNotFoundError if it does not exist
+	DeleteVoyage(ctx context.Context, voyageNumber routingdomain.VoyageNumber) error
+}
@@ -1,6 +1,7 @@
 package routingsecondary
 
 import (
+	"context"
 	"go_hex/internal/routing/routingdomain"
 )
 
@@ -17,6 +18,10 @@ type VoyageRepository interface {
 
 	// FindVoyagesConnecting finds voyages that connect two locations
 	FindVoyagesConnecting(origin, destination routingdomain.UnLocode) ([]routingdomain.Voyage, error)
+
+	// Delete removes a voyage and its schedule, failing with routingdomain.VoyageNotFoundError if it does not exist and
+	// with routingdomain.VoyageInUseError if cargo that is neither delivered nor cancelled is routed on it
+	Delete(voyageNumber routingdomain.VoyageNumber) error
 }
 
 // LocationRepository defines the secondary port for location persistence
@@ -30,3 +35,9 @@ type LocationRepository interface {
 	// FindAll retrieves all locations
 	FindAll() ([]routingdomain.Location, error)
 }
+
+// CargoItineraryService defines the secondary port for telling the booking context about voyages cargo is routed on
+type CargoItineraryService interface {
+	// ReviewItineraries has the cargo routed on a rescheduled voyage re-evaluated against its new schedule
+	ReviewItineraries(ctx context.Context, voyageNumber routingdomain.VoyageNumber) error
+}
@@ -0,0 +1,90 @@
+package routingapplication
+
+import (
+	"go_hex/internal/routing/routingdomain"
+	"sort"
+	"time"
+)
+
+// DefaultMaxResults limits the candidates returned when the caller does not ask for a specific number
+const DefaultMaxResults = 10
+
+// costFunction scores a route candidate; candidates with a lower cost rank first
+type costFunction func(candidate routeCandidate) int64
+
+// costStrategies maps each ranking strategy to its cost function.
+// New strategies only need an entry here to become selectable.
+var costStrategies = map[routingdomain.RankingStrategy]costFunction{
+	routingdomain.RankingStrategyEarliestArrival: func(c routeCandidate) int64 {
+		return c.finalArrivalTime().UnixNano()
+	},
+	routingdomain.RankingStrategyFewestLegs: func(c routeCandidate) int64 {
+		return int64(len(c.legs))
+	},
+	routingdomain.RankingStrategyShortestTransit: func(c routeCandidate) int64 {
+		return int64(c.transitTime())
+	},
+	routingdomain.RankingStrategyMinimumIdle: func(c routeCandidate) int64 {
+		return int64(c.idleTime())
+	},
+}
+
+// resolveCostFunction returns the cost function for the strategy, defaulting to earliest arrival
+func resolveCostFunction(strategy routingdomain.RankingStrategy) (costFunction, error) {
+	if strategy == "" {
+		strategy = routingdomain.RankingStrategyEarliestArrival
+	}
+
+	cost, ok := costStrategies[strategy]
+	if !ok {
+		return nil, routingdomain.NewDomainValidationError("unknown ranking strategy: "+string(strategy), nil)
+	}
+	return cost, nil
+}
+
+// rankCandidates orders candidates by cost and keeps at most maxResults of them.
+// Ties are broken by earliest arrival and then by fewer legs so results are deterministic.
+func rankCandidates(candidates []routeCandidate, cost costFunction, maxResults int) []routeCandidate {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		costI, costJ := cost(candidates[i]), cost(candidates[j])
+		if costI != costJ {
+			return costI < costJ
+		}
+
+		arrivalI, arrivalJ := candidates[i].finalArrivalTime(), candidates[j].finalArrivalTime()
+		if !arrivalI.Equal(arrivalJ) {
+			return arrivalI.Before(arrivalJ)
+		}
+
+		return len(candidates[i].legs) < len(candidates[j].legs)
+	})
+
+	if len(candidates) > maxResults {
+		candidates = candidates[:maxResults]
+	}
+	return candidates
+}
+
+// initialDepartureTime returns when the cargo is loaded on the first leg
+func (c routeCandidate) initialDepartureTime() time.Time {
+	return c.legs[0].loadTime
+}
+
+// finalArrivalTime returns when the cargo is unloaded at the destination
+func (c routeCandidate) finalArrivalTime() time.Time {
+	return c.legs[len(c.legs)-1].unloadTime
+}
+
+// transitTime returns the time from first load to final unload
+func (c routeCandidate) transitTime() time.Duration {
+	return c.finalArrivalTime().Sub(c.initialDepartureTime())
+}
+
+// idleTime returns the total time spent waiting in port between legs
+func (c routeCandidate) idleTime() time.Duration {
+	var idle time.Duration
+	for i := 1; i < len(c.legs); i++ {
+		idle += c.legs[i].loadTime.Sub(c.legs[i-1].unloadTime)
+	}
+	return idle
+}
@@ -0,0 +1,178 @@
+package routingapplication
+
+import (
+	"go_hex/internal/routing/routingdomain"
+	"time"
+)
+
+const (
+	// DefaultMaxLegs bounds the route search when no explicit limit is configured.
+	// Feeder services commonly make bookings three or four legs long.
+	DefaultMaxLegs = 4
+
+	// MinTransshipmentTime is the minimum time cargo needs in port to move between voyages
+	MinTransshipmentTime = 2 * time.Hour
+
+	// MaxRouteCandidates bounds the itineraries one search collects for ranking, so a dense voyage network
+	// cannot make a search run away
+	MaxRouteCandidates = 500
+)
+
+// routeCandidate represents an internal route candidate
+type routeCandidate struct {
+	legs []routeLeg
+}
+
+// routeLeg represents an internal route leg
+// A leg covers one or more consecutive carrier movements of the same voyage,
+// so cargo staying onboard through intermediate calls is not transshipped.
+type routeLeg struct {
+	voyageNumber   routingdomain.VoyageNumber
+	loadLocation   routingdomain.UnLocode
+	unloadLocation routingdomain.UnLocode
+	loadTime       time.Time
+	unloadTime     time.Time
+}
+
+// routeSearch performs a time-dependent depth-first search over voyage schedules.
+// Each step boards a voyage at the current location and rides it to one of its later calls;
+// boarding a different voyage requires the transshipment window to have elapsed.
+// A partial route is not extended when a route explored before it reached the same port at least as well, and the
+// search stops once it has collected MaxRouteCandidates itineraries.
+type routeSearch struct {
+	voyages     []routingdomain.Voyage
+	destination routingdomain.UnLocode
+	deadline    time.Time
+	maxLegs     int
+	candidates  []routeCandidate
+
+	// reached holds the partial routes extended from each port so far
+	reached map[routingdomain.UnLocode][]routeLabel
+}
+
+// routeLabel summarizes a partial route by what its extensions are ranked on
+type routeLabel struct {
+	legs       int
+	departure  time.Time
+	arrival    time.Time
+	idleBefore time.Duration
+}
+
+// dominates reports whether every extension of the route labelled other ranks no better, by any strategy, than the
+// same extension of this route: this route arrives no later, has no more legs and departs no earlier, and it idled
+// less by at least the time its earlier arrival then waits in port.
+func (l routeLabel) dominates(other routeLabel) bool {
+	return l.legs <= other.legs &&
+		!l.arrival.After(other.arrival) &&
+		!l.departure.Before(other.departure) &&
+		l.idleBefore-other.idleBefore <= l.arrival.Sub(other.arrival)
+}
+
+// findRoutes returns every itinerary of at most maxLegs legs that departs no earlier than readyAt
+// and reaches the destination by the deadline
+func findRoutes(voyages []routingdomain.Voyage, origin, destination routingdomain.UnLocode, readyAt, deadline time.Time, maxLegs int) []routeCandidate {
+	search := &routeSearch{
+		voyages:     voyages,
+		destination: destination,
+		deadline:    deadline,
+		maxLegs:     maxLegs,
+		reached:     make(map[routingdomain.UnLocode][]routeLabel),
+	}
+
+	visited := map[routingdomain.UnLocode]bool{origin: true}
+	search.explore(origin, readyAt, nil, visited)
+
+	return search.candidates
+}
+
+// explore extends the partial route from location, departing no earlier than readyAt
+func (s *routeSearch) explore(location routingdomain.UnLocode, readyAt time.Time, legs []routeLeg, visited map[routingdomain.UnLocode]bool) {
+	// Nothing departing after readyAt can arrive by a deadline that has already passed
+	if len(legs) == s.maxLegs || readyAt.After(s.deadline) || s.full() {
+		return
+	}
+
+	for _, voyage := range s.voyages {
+		voyageNumber := voyage.GetVoyageNumber()
+
+		// Re-boarding the voyage just left would duplicate staying onboard
+		if len(legs) > 0 && legs[len(legs)-1].voyageNumber == voyageNumber {
+			continue
+		}
+
+		movements := voyage.GetSchedule().Movements
+		for i, boarding := range movements {
+			if boarding.DepartureLocation != location || boarding.DepartureTime.Before(readyAt) {
+				continue
+			}
+
+			// Ride the voyage through each subsequent call
+			for j := i; j < len(movements); j++ {
+				alighting := movements[j]
+
+				// Arrival times only increase along a schedule, so later calls are also too late
+				if alighting.ArrivalTime.After(s.deadline) {
+					break
+				}
+
+				unloadLocation := alighting.ArrivalLocation
+				if visited[unloadLocation] {
+					continue
+				}
+
+				leg := routeLeg{
+					voyageNumber:   voyageNumber,
+					loadLocation:   location,
+					unloadLocation: unloadLocation,
+					loadTime:       boarding.DepartureTime,
+					unloadTime:     alighting.ArrivalTime,
+				}
+
+				route := make([]routeLeg, len(legs), len(legs)+1)
+				copy(route, legs)
+				route = append(route, leg)
+
+				if unloadLocation == s.destination {
+					s.candidates = append(s.candidates, routeCandidate{legs: route})
+					if s.full() {
+						return
+					}
+					continue
+				}
+
+				if !s.reach(unloadLocation, route) {
+					continue
+				}
+
+				visited[unloadLocation] = true
+				s.explore(unloadLocation, alighting.ArrivalTime.Add(MinTransshipmentTime), route, visited)
+				delete(visited, unloadLocation)
+			}
+		}
+	}
+}
+
+// full reports whether the search has collected as many candidates as it may
+func (s *routeSearch) full() bool {
+	return len(s.candidates) >= MaxRouteCandidates
+}
+
+// reach records that the route reached location and reports whether it is worth extending, because no route that
+// reached location before dominates it
+func (s *routeSearch) reach(location routingdomain.UnLocode, route []routeLeg) bool {
+	candidate := routeCandidate{legs: route}
+	label := routeLabel{
+		legs:       len(route),
+		departure:  candidate.initialDepartureTime(),
+		arrival:    candidate.finalArrivalTime(),
+		idleBefore: candidate.idleTime(),
+	}
+	for _, earlier := range s.reached[location] {
+		if earlier.dominates(label) {
+			return false
+		}
+	}
+
+	s.reached[location] = append(s.reached[location], label)
+	return true
+}
@@ -14,6 +14,7 @@ import (
 type RoutingApplicationService struct {
 	voyageRepo   routingsecondary.VoyageRepository
 	locationRepo routingsecondary.LocationRepository
+	maxLegs      int
 	logger       *slog.Logger
 }
 
@@ -24,17 +25,23 @@ var _ routingprimary.RouteFinder = (*RoutingApplicationService)(nil)
 func NewRoutingApplicationService(
 	voyageRepo routingsecondary.VoyageRepository,
 	locationRepo routingsecondary.LocationRepository,
+	maxLegs int,
 	logger *slog.Logger,
 ) *RoutingApplicationService {
+	if maxLegs < 1 {
+		maxLegs = DefaultMaxLegs
+	}
+
 	return &RoutingApplicationService{
 		voyageRepo:   voyageRepo,
 		locationRepo: locationRepo,
+		maxLegs:      maxLegs,
 		logger:       logger,
 	}
 }
 
-// FindOptimalItineraries finds the best routes that satisfy the given specification
-func (s *RoutingApplicationService) FindOptimalItineraries(ctx context.Context, routeSpec routingdomain.RouteSpecification) ([]routingdomain.Itinerary, error) {
+// FindOptimalItineraries finds the best routes that satisfy the given specification, ranked by the given criteria
+func (s *RoutingApplicationService) FindOptimalItineraries(ctx context.Context, routeSpec routingdomain.RouteSpecification, criteria routingdomain.RankingCriteria) ([]routingdomain.Itinerary, error) {
 	// Check permissions
 	claims, err := auth.ExtractClaims(ctx)
 	if err != nil {
@@ -49,7 +56,27 @@ func (s *RoutingApplicationService) FindOptimalItineraries(ctx context.Context,
 	s.logger.Info("Finding optimal itineraries",
 		"origin", routeSpec.Origin,
 		"destination", routeSpec.Destination,
-		"deadline", routeSpec.ArrivalDeadline)
+		"deadline", routeSpec.ArrivalDeadline,
+		"earliestDeparture", routeSpec.EarliestDeparture,
+		"strategy", criteria.Strategy,
+		"maxResults", criteria.MaxResults)
+
+	// Resolve ranking criteria before doing any search work
+	cost, err := resolveCostFunction(criteria.Strategy)
+	if err != nil {
+		s.logger.Error("Invalid ranking strategy", "error", err)
+		return nil, err
+	}
+
+	if criteria.MaxResults < 0 {
+		s.logger.Error("Invalid max results", "maxResults", criteria.MaxResults)
+		return nil, routingdomain.NewDomainValidationError("max results cannot be negative", nil)
+	}
+
+	maxResults := criteria.MaxResults
+	if maxResults == 0 {
+		maxResults = DefaultMaxResults
+	}
 
 	// Parse arrival deadline
 	arrivalDeadline, err := time.Parse(time.RFC3339, routeSpec.ArrivalDeadline)
@@ -58,6 +85,16 @@ func (s *RoutingApplicationService) FindOptimalItineraries(ctx context.Context,
 		return nil, routingdomain.NewDomainValidationError("invalid arrival deadline format, expected RFC3339", err)
 	}
 
+	// Parse earliest departure; cargo ready straight away cannot leave on a voyage that has already departed
+	earliestDeparture := time.Now()
+	if routeSpec.EarliestDeparture != "" {
+		earliestDeparture, err = time.Parse(time.RFC3339, routeSpec.EarliestDeparture)
+		if err != nil {
+			s.logger.Error("Invalid earliest departure format", "error", err)
+			return nil, routingdomain.NewDomainValidationError("invalid earliest departure format, expected RFC3339", err)
+		}
+	}
+
 	// Convert external route spec to internal format
 	origin, err := routingdomain.NewUnLocode(routeSpec.Origin)
 	if err != nil {
@@ -78,8 +115,9 @@ func (s *RoutingApplicationService) FindOptimalItineraries(ctx context.Context,
 		return nil, routingdomain.NewDomainValidationError("failed to retrieve voyages", err)
 	}
 
-	// Find route candidates using simplified algorithm
-	candidates := s.findRoutes(allVoyages, origin, destination, arrivalDeadline)
+	// Search the voyage network for itineraries of up to maxLegs legs
+	candidates := findRoutes(allVoyages, origin, destination, earliestDeparture, arrivalDeadline, s.maxLegs)
+	candidates = rankCandidates(candidates, cost, maxResults)
 
 	// Convert internal candidates to external format
 	itineraries := s.convertToExternalFormat(candidates)
@@ -88,147 +126,6 @@ func (s *RoutingApplicationService) FindOptimalItineraries(ctx context.Context,
 	return itineraries, nil
 }
 
-// findRoutes implements a simplified routing algorithm
-func (s *RoutingApplicationService) findRoutes(voyages []routingdomain.Voyage, origin, destination routingdomain.UnLocode, deadline time.Time) []routeCandidate {
-	var candidates []routeCandidate
-
-	// Find direct routes
-	directRoutes := s.findDirectRoutes(voyages, origin, destination, deadline)
-	candidates = append(candidates, directRoutes...)
-
-	// Find routes with one connection
-	oneConnectionRoutes := s.findOneConnectionRoutes(voyages, origin, destination, deadline)
-	candidates = append(candidates, oneConnectionRoutes...)
-
-	return candidates
-}
-
-// routeCandidate represents an internal route candidate
-type routeCandidate struct {
-	legs []routeLeg
-}
-
-// routeLeg represents an internal route leg
-type routeLeg struct {
-	voyageNumber   routingdomain.VoyageNumber
-	loadLocation   routingdomain.UnLocode
-	unloadLocation routingdomain.UnLocode
-	loadTime       time.Time
-	unloadTime     time.Time
-}
-
-// findDirectRoutes finds routes that can be completed with a single voyage
-func (s *RoutingApplicationService) findDirectRoutes(voyages []routingdomain.Voyage, origin, destination routingdomain.UnLocode, deadline time.Time) []routeCandidate {
-	var candidates []routeCandidate
-
-	for _, voyage := range voyages {
-		schedule := voyage.GetSchedule()
-
-		// Check if this voyage connects origin to destination
-		for _, movement := range schedule.Movements {
-			if movement.DepartureLocation == origin &&
-				movement.ArrivalLocation == destination &&
-				(movement.ArrivalTime.Before(deadline) || movement.ArrivalTime.Equal(deadline)) {
-
-				leg := routeLeg{
-					voyageNumber:   voyage.GetVoyageNumber(),
-					loadLocation:   movement.DepartureLocation,
-					unloadLocation: movement.ArrivalLocation,
-					loadTime:       movement.DepartureTime,
-					unloadTime:     movement.ArrivalTime,
-				}
-
-				candidate := routeCandidate{
-					legs: []routeLeg{leg},
-				}
-				candidates = append(candidates, candidate)
-			}
-		}
-	}
-
-	return candidates
-}
-
-// findOneConnectionRoutes finds routes that require exactly one connection
-func (s *RoutingApplicationService) findOneConnectionRoutes(voyages []routingdomain.Voyage, origin, destination routingdomain.UnLocode, deadline time.Time) []routeCandidate {
-	var candidates []routeCandidate
-
-	// Find all intermediate locations
-	intermediateLocations := s.findIntermediateLocations(voyages, origin, destination)
-
-	for _, intermediate := range intermediateLocations {
-		// Find first leg: origin to intermediate
-		firstLegs := s.findLegsConnecting(voyages, origin, intermediate)
-
-		// Find second leg: intermediate to destination
-		secondLegs := s.findLegsConnecting(voyages, intermediate, destination)
-
-		// Combine compatible legs
-		for _, firstLeg := range firstLegs {
-			for _, secondLeg := range secondLegs {
-				// Check transshipment time and deadline
-				if secondLeg.loadTime.After(firstLeg.unloadTime.Add(2*time.Hour)) &&
-					(secondLeg.unloadTime.Before(deadline) || secondLeg.unloadTime.Equal(deadline)) {
-
-					candidate := routeCandidate{
-						legs: []routeLeg{firstLeg, secondLeg},
-					}
-					candidates = append(candidates, candidate)
-				}
-			}
-		}
-	}
-
-	return candidates
-}
-
-// findIntermediateLocations finds potential connection points
-func (s *RoutingApplicationService) findIntermediateLocations(voyages []routingdomain.Voyage, origin, destination routingdomain.UnLocode) []routingdomain.UnLocode {
-	locationSet := make(map[routingdomain.UnLocode]bool)
-
-	for _, voyage := range voyages {
-		schedule := voyage.GetSchedule()
-		for _, movement := range schedule.Movements {
-			if movement.DepartureLocation != origin && movement.DepartureLocation != destination {
-				locationSet[movement.DepartureLocation] = true
-			}
-			if movement.ArrivalLocation != origin && movement.ArrivalLocation != destination {
-				locationSet[movement.ArrivalLocation] = true
-			}
-		}
-	}
-
-	var intermediates []routingdomain.UnLocode
-	for location := range locationSet {
-		intermediates = append(intermediates, location)
-	}
-
-	return intermediates
-}
-
-// findLegsConnecting finds voyage legs that connect two locations
-func (s *RoutingApplicationService) findLegsConnecting(voyages []routingdomain.Voyage, origin, destination routingdomain.UnLocode) []routeLeg {
-	var legs []routeLeg
-
-	for _, voyage := range voyages {
-		schedule := voyage.GetSchedule()
-		for _, movement := range schedule.Movements {
-			if movement.DepartureLocation == origin && movement.ArrivalLocation == destination {
-				leg := routeLeg{
-					voyageNumber:   voyage.GetVoyageNumber(),
-					loadLocation:   movement.DepartureLocation,
-					unloadLocation: movement.ArrivalLocation,
-					loadTime:       movement.DepartureTime,
-					unloadTime:     movement.ArrivalTime,
-				}
-				legs = append(legs, leg)
-			}
-		}
-	}
-
-	return legs
-}
-
 // convertToExternalFormat converts internal route candidates to external itinerary format
 func (s *RoutingApplicationService) convertToExternalFormat(candidates []routeCandidate) []routingdomain.Itinerary {
 	var itineraries []routingdomain.Itinerary
@@ -307,3 +204,47 @@ func (s *RoutingApplicationService) ListAllLocations(ctx context.Context) ([]rou
 	s.logger.Info("Retrieved all locations", "count", len(allLocations))
 	return allLocations, nil
 }
+
+// GetVoyage retrieves a voyage by its number
+func (s *RoutingApplicationService) GetVoyage(ctx context.Context, voyageNumber routingdomain.VoyageNumber) (routingdomain.Voyage, error) {
+	// Check permissions
+	claims, err := auth.ExtractClaims(ctx)
+	if err != nil {
+		s.logger.Warn("Unauthorized voyage view attempt", "error", err)
+		return routingdomain.Voyage{}, err
+	}
+	if err := RequireRoutingPermission(claims, auth.PermissionViewVoyages); err != nil {
+		s.logger.Warn("Unauthorized voyage view attempt", "error", err)
+		return routingdomain.Voyage{}, err
+	}
+
+	voyage, err := s.voyageRepo.FindByVoyageNumber(voyageNumber)
+	if err != nil {
+		s.logger.Debug("Voyage not retrieved", "voyageNumber", voyageNumber.String(), "error", err)
+		return routingdomain.Voyage{}, err
+	}
+
+	return voyage, nil
+}
+
+// GetLocation retrieves a location by its UN/LOCODE
+func (s *RoutingApplicationService) GetLocation(ctx context.Context, unLocode routingdomain.UnLocode) (routingdomain.Location, error) {
+	// Check permissions
+	claims, err := auth.ExtractClaims(ctx)
+	if err != nil {
+		s.logger.Warn("Unauthorized location view attempt", "error", err)
+		return routingdomain.Location{}, err
+	}
+	if err := RequireRoutingPermission(claims, auth.PermissionViewLocations); err != nil {
+		s.logger.Warn("Unauthorized location view attempt", "error", err)
+		return routingdomain.Location{}, err
+	}
+
+	location, err := s.locationRepo.FindByUnLocode(unLocode)
+	if err != nil {
+		s.logger.Debug("Location not retrieved", "unLocode", unLocode.String(), "error", err)
+		return routingdomain.Location{}, err
+	}
+
+	return location, nil
+}
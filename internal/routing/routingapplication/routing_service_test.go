@@ -45,6 +45,11 @@ func (m *MockVoyageRepository) FindVoyagesConnecting(origin, destination routing
 	return args.Get(0).([]routingdomain.Voyage), args.Error(1)
 }
 
+func (m *MockVoyageRepository) Delete(voyageNumber routingdomain.VoyageNumber) error {
+	args := m.Called(voyageNumber)
+	return args.Error(0)
+}
+
 type MockLocationRepository struct {
 	mock.Mock
 }
@@ -70,7 +75,7 @@ func TestRoutingApplicationService_FindOptimalItineraries(t *testing.T) {
 		locationRepo := &MockLocationRepository{}
 		logger := slog.Default()
 
-		service := NewRoutingApplicationService(voyageRepo, locationRepo, logger)
+		service := NewRoutingApplicationService(voyageRepo, locationRepo, DefaultMaxLegs, logger)
 
 		return service, voyageRepo, locationRepo
 	}
@@ -95,7 +100,7 @@ func TestRoutingApplicationService_FindOptimalItineraries(t *testing.T) {
 		}
 
 		// Execute
-		itineraries, err := service.FindOptimalItineraries(ctx, routeSpec)
+		itineraries, err := service.FindOptimalItineraries(ctx, routeSpec, routingdomain.RankingCriteria{})
 
 		// Verify
 		require.NoError(t, err)
@@ -116,7 +121,7 @@ func TestRoutingApplicationService_FindOptimalItineraries(t *testing.T) {
 		}
 
 		// Execute
-		_, err := service.FindOptimalItineraries(ctx, routeSpec)
+		_, err := service.FindOptimalItineraries(ctx, routeSpec, routingdomain.RankingCriteria{})
 
 		// Verify
 		assert.Error(t, err)
@@ -135,7 +140,7 @@ func TestRoutingApplicationService_FindOptimalItineraries(t *testing.T) {
 		}
 
 		// Execute
-		_, err := service.FindOptimalItineraries(ctx, routeSpec)
+		_, err := service.FindOptimalItineraries(ctx, routeSpec, routingdomain.RankingCriteria{})
 
 		// Verify
 		assert.Error(t, err)
@@ -155,7 +160,7 @@ func TestRoutingApplicationService_FindOptimalItineraries(t *testing.T) {
 		}
 
 		// Execute
-		_, err := service.FindOptimalItineraries(ctx, routeSpec)
+		_, err := service.FindOptimalItineraries(ctx, routeSpec, routingdomain.RankingCriteria{})
 
 		// Verify
 		assert.Error(t, err)
@@ -175,7 +180,7 @@ func TestRoutingApplicationService_FindOptimalItineraries(t *testing.T) {
 		}
 
 		// Execute
-		_, err := service.FindOptimalItineraries(ctx, routeSpec)
+		_, err := service.FindOptimalItineraries(ctx, routeSpec, routingdomain.RankingCriteria{})
 
 		// Verify
 		assert.Error(t, err)
@@ -198,7 +203,7 @@ func TestRoutingApplicationService_FindOptimalItineraries(t *testing.T) {
 		}
 
 		// Execute
-		_, err := service.FindOptimalItineraries(ctx, routeSpec)
+		_, err := service.FindOptimalItineraries(ctx, routeSpec, routingdomain.RankingCriteria{})
 
 		// Verify
 		assert.Error(t, err)
@@ -222,7 +227,7 @@ func TestRoutingApplicationService_FindOptimalItineraries(t *testing.T) {
 		}
 
 		// Execute
-		itineraries, err := service.FindOptimalItineraries(ctx, routeSpec)
+		itineraries, err := service.FindOptimalItineraries(ctx, routeSpec, routingdomain.RankingCriteria{})
 
 		// Verify
 		require.NoError(t, err)
@@ -231,6 +236,296 @@ func TestRoutingApplicationService_FindOptimalItineraries(t *testing.T) {
 	})
 }
 
+func TestRoutingApplicationService_MultiLegRouting(t *testing.T) {
+	setup := func(maxLegs int, voyages []routingdomain.Voyage) *RoutingApplicationService {
+		voyageRepo := &MockVoyageRepository{}
+		locationRepo := &MockLocationRepository{}
+		voyageRepo.On("FindAll").Return(voyages, nil)
+
+		return NewRoutingApplicationService(voyageRepo, locationRepo, maxLegs, slog.Default())
+	}
+
+	baseTime := time.Now().Add(time.Hour)
+	routeSpec := func(origin, destination string, deadline time.Time) routingdomain.RouteSpecification {
+		return routingdomain.RouteSpecification{
+			Origin:          origin,
+			Destination:     destination,
+			ArrivalDeadline: deadline.Format(time.RFC3339),
+		}
+	}
+
+	t.Run("should find itineraries requiring multiple connections", func(t *testing.T) {
+		service := setup(DefaultMaxLegs, []routingdomain.Voyage{
+			createTestVoyage(t, baseTime, "USNYC", "DEHAM"),
+			createTestVoyage(t, baseTime.Add(30*time.Hour), "DEHAM", "SEGOT"),
+			createTestVoyage(t, baseTime.Add(60*time.Hour), "SEGOT", "FIHEL"),
+		})
+
+		ctx := createContextWithClaims(t, []string{})
+		itineraries, err := service.FindOptimalItineraries(ctx, routeSpec("USNYC", "FIHEL", baseTime.Add(10*24*time.Hour)), routingdomain.RankingCriteria{})
+
+		require.NoError(t, err)
+		require.Len(t, itineraries, 1)
+		require.Len(t, itineraries[0].Legs, 3)
+		assert.Equal(t, "USNYC", itineraries[0].Legs[0].LoadLocation)
+		assert.Equal(t, "DEHAM", itineraries[0].Legs[1].LoadLocation)
+		assert.Equal(t, "SEGOT", itineraries[0].Legs[2].LoadLocation)
+		assert.Equal(t, "FIHEL", itineraries[0].Legs[2].UnloadLocation)
+	})
+
+	t.Run("should not exceed the configured number of legs", func(t *testing.T) {
+		service := setup(2, []routingdomain.Voyage{
+			createTestVoyage(t, baseTime, "USNYC", "DEHAM"),
+			createTestVoyage(t, baseTime.Add(30*time.Hour), "DEHAM", "SEGOT"),
+			createTestVoyage(t, baseTime.Add(60*time.Hour), "SEGOT", "FIHEL"),
+		})
+
+		ctx := createContextWithClaims(t, []string{})
+		itineraries, err := service.FindOptimalItineraries(ctx, routeSpec("USNYC", "FIHEL", baseTime.Add(10*24*time.Hour)), routingdomain.RankingCriteria{})
+
+		require.NoError(t, err)
+		assert.Empty(t, itineraries)
+	})
+
+	t.Run("should require the transshipment window between voyages", func(t *testing.T) {
+		// Second voyage departs one hour after the first arrives
+		service := setup(DefaultMaxLegs, []routingdomain.Voyage{
+			createTestVoyage(t, baseTime, "USNYC", "DEHAM"),
+			createTestVoyage(t, baseTime.Add(25*time.Hour), "DEHAM", "SEGOT"),
+		})
+
+		ctx := createContextWithClaims(t, []string{})
+		itineraries, err := service.FindOptimalItineraries(ctx, routeSpec("USNYC", "SEGOT", baseTime.Add(10*24*time.Hour)), routingdomain.RankingCriteria{})
+
+		require.NoError(t, err)
+		assert.Empty(t, itineraries)
+	})
+
+	t.Run("should keep cargo onboard through intermediate calls", func(t *testing.T) {
+		service := setup(DefaultMaxLegs, []routingdomain.Voyage{
+			createTestVoyage(t, baseTime, "USNYC", "DEHAM", "SEGOT"),
+		})
+
+		ctx := createContextWithClaims(t, []string{})
+		itineraries, err := service.FindOptimalItineraries(ctx, routeSpec("USNYC", "SEGOT", baseTime.Add(10*24*time.Hour)), routingdomain.RankingCriteria{})
+
+		require.NoError(t, err)
+		require.Len(t, itineraries, 1)
+		require.Len(t, itineraries[0].Legs, 1)
+		assert.Equal(t, "USNYC", itineraries[0].Legs[0].LoadLocation)
+		assert.Equal(t, "SEGOT", itineraries[0].Legs[0].UnloadLocation)
+	})
+
+	t.Run("should exclude itineraries arriving after the deadline", func(t *testing.T) {
+		service := setup(DefaultMaxLegs, []routingdomain.Voyage{
+			createTestVoyage(t, baseTime, "USNYC", "DEHAM"),
+			createTestVoyage(t, baseTime.Add(30*time.Hour), "DEHAM", "SEGOT"),
+		})
+
+		ctx := createContextWithClaims(t, []string{})
+		itineraries, err := service.FindOptimalItineraries(ctx, routeSpec("USNYC", "SEGOT", baseTime.Add(40*time.Hour)), routingdomain.RankingCriteria{})
+
+		require.NoError(t, err)
+		assert.Empty(t, itineraries)
+	})
+
+	t.Run("should not depart before the earliest departure", func(t *testing.T) {
+		service := setup(DefaultMaxLegs, []routingdomain.Voyage{
+			createTestVoyage(t, baseTime, "USNYC", "DEHAM"),
+			createTestVoyage(t, baseTime.Add(48*time.Hour), "USNYC", "DEHAM"),
+		})
+		spec := routeSpec("USNYC", "DEHAM", baseTime.Add(10*24*time.Hour))
+		spec.EarliestDeparture = baseTime.Add(time.Hour).Format(time.RFC3339)
+
+		ctx := createContextWithClaims(t, []string{})
+		itineraries, err := service.FindOptimalItineraries(ctx, spec, routingdomain.RankingCriteria{})
+
+		require.NoError(t, err)
+		require.Len(t, itineraries, 1)
+		assert.Equal(t, baseTime.Add(48*time.Hour).Format(time.RFC3339), itineraries[0].Legs[0].LoadTime)
+	})
+
+	t.Run("should not depart before now without an earliest departure", func(t *testing.T) {
+		departed := createTestVoyage(t, time.Now().Add(-time.Hour), "USNYC", "DEHAM")
+		service := setup(DefaultMaxLegs, []routingdomain.Voyage{
+			departed,
+			createTestVoyage(t, baseTime, "USNYC", "DEHAM"),
+		})
+
+		ctx := createContextWithClaims(t, []string{})
+		itineraries, err := service.FindOptimalItineraries(ctx, routeSpec("USNYC", "DEHAM", baseTime.Add(10*24*time.Hour)), routingdomain.RankingCriteria{})
+
+		require.NoError(t, err)
+		require.Len(t, itineraries, 1)
+		assert.NotEqual(t, departed.GetVoyageNumber().String(), itineraries[0].Legs[0].VoyageNumber)
+	})
+
+	t.Run("should not extend a route that reaches a port no better than one found before", func(t *testing.T) {
+		// Via GBLON the cargo reaches DEHAM two hours later, having idled two hours longer and taken one more leg
+		service := setup(DefaultMaxLegs, []routingdomain.Voyage{
+			createTestVoyage(t, baseTime, "USNYC", "DEHAM"),
+			createTestVoyageWithDuration(t, baseTime, 12*time.Hour, "USNYC", "GBLON"),
+			createTestVoyageWithDuration(t, baseTime.Add(14*time.Hour), 12*time.Hour, "GBLON", "DEHAM"),
+			createTestVoyage(t, baseTime.Add(30*time.Hour), "DEHAM", "SEGOT"),
+		})
+
+		ctx := createContextWithClaims(t, []string{})
+		itineraries, err := service.FindOptimalItineraries(ctx, routeSpec("USNYC", "SEGOT", baseTime.Add(10*24*time.Hour)), routingdomain.RankingCriteria{})
+
+		require.NoError(t, err)
+		require.Len(t, itineraries, 1)
+		assert.Len(t, itineraries[0].Legs, 2)
+	})
+
+	t.Run("should stop collecting candidates at the limit", func(t *testing.T) {
+		voyages := make([]routingdomain.Voyage, 0, MaxRouteCandidates+1)
+		for i := 0; i <= MaxRouteCandidates; i++ {
+			voyages = append(voyages, createTestVoyage(t, baseTime.Add(time.Duration(i)*time.Minute), "USNYC", "DEHAM"))
+		}
+		service := setup(DefaultMaxLegs, voyages)
+
+		ctx := createContextWithClaims(t, []string{})
+		itineraries, err := service.FindOptimalItineraries(ctx, routeSpec("USNYC", "DEHAM", baseTime.Add(10*24*time.Hour)),
+			routingdomain.RankingCriteria{MaxResults: 2 * MaxRouteCandidates})
+
+		require.NoError(t, err)
+		assert.Len(t, itineraries, MaxRouteCandidates)
+	})
+
+	t.Run("should fail with invalid earliest departure format", func(t *testing.T) {
+		service := setup(DefaultMaxLegs, nil)
+		spec := routeSpec("USNYC", "DEHAM", baseTime.Add(10*24*time.Hour))
+		spec.EarliestDeparture = "tomorrow"
+
+		ctx := createContextWithClaims(t, []string{})
+		_, err := service.FindOptimalItineraries(ctx, spec, routingdomain.RankingCriteria{})
+
+		assert.ErrorContains(t, err, "invalid earliest departure format")
+	})
+}
+
+func TestRoutingApplicationService_RankedItineraries(t *testing.T) {
+	baseTime := time.Now().Add(time.Hour)
+
+	// Three ways from USNYC to SEGOT:
+	//   direct:   one slow voyage arriving at +100h
+	//   fast:     two legs with a short connection, arriving at +54h
+	//   patient:  two legs leaving late with a minimal connection, arriving at +80h
+	direct := createTestVoyageWithDuration(t, baseTime, 100*time.Hour, "USNYC", "SEGOT")
+	fastFirst := createTestVoyageWithDuration(t, baseTime, 24*time.Hour, "USNYC", "DEHAM")
+	fastSecond := createTestVoyageWithDuration(t, baseTime.Add(30*time.Hour), 24*time.Hour, "DEHAM", "SEGOT")
+	patientFirst := createTestVoyageWithDuration(t, baseTime.Add(44*time.Hour), 12*time.Hour, "USNYC", "GBLON")
+	patientSecond := createTestVoyageWithDuration(t, baseTime.Add(58*time.Hour), 22*time.Hour, "GBLON", "SEGOT")
+
+	voyages := []routingdomain.Voyage{direct, fastFirst, fastSecond, patientFirst, patientSecond}
+
+	setup := func() *RoutingApplicationService {
+		voyageRepo := &MockVoyageRepository{}
+		locationRepo := &MockLocationRepository{}
+		voyageRepo.On("FindAll").Return(voyages, nil)
+
+		return NewRoutingApplicationService(voyageRepo, locationRepo, DefaultMaxLegs, slog.Default())
+	}
+
+	routeSpec := routingdomain.RouteSpecification{
+		Origin:          "USNYC",
+		Destination:     "SEGOT",
+		ArrivalDeadline: baseTime.Add(10 * 24 * time.Hour).Format(time.RFC3339),
+	}
+
+	firstVoyage := func(itinerary routingdomain.Itinerary) string {
+		return itinerary.Legs[0].VoyageNumber
+	}
+
+	t.Run("should rank by earliest arrival by default", func(t *testing.T) {
+		service := setup()
+		ctx := createContextWithClaims(t, []string{})
+
+		itineraries, err := service.FindOptimalItineraries(ctx, routeSpec, routingdomain.RankingCriteria{})
+
+		require.NoError(t, err)
+		require.Len(t, itineraries, 3)
+		assert.Equal(t, fastFirst.GetVoyageNumber().String(), firstVoyage(itineraries[0]))
+		assert.Equal(t, patientFirst.GetVoyageNumber().String(), firstVoyage(itineraries[1]))
+		assert.Equal(t, direct.GetVoyageNumber().String(), firstVoyage(itineraries[2]))
+	})
+
+	t.Run("should rank by fewest legs", func(t *testing.T) {
+		service := setup()
+		ctx := createContextWithClaims(t, []string{})
+
+		itineraries, err := service.FindOptimalItineraries(ctx, routeSpec, routingdomain.RankingCriteria{
+			Strategy: routingdomain.RankingStrategyFewestLegs,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, itineraries, 3)
+		assert.Equal(t, direct.GetVoyageNumber().String(), firstVoyage(itineraries[0]))
+		assert.Equal(t, fastFirst.GetVoyageNumber().String(), firstVoyage(itineraries[1]))
+	})
+
+	t.Run("should rank by shortest transit time", func(t *testing.T) {
+		service := setup()
+		ctx := createContextWithClaims(t, []string{})
+
+		itineraries, err := service.FindOptimalItineraries(ctx, routeSpec, routingdomain.RankingCriteria{
+			Strategy: routingdomain.RankingStrategyShortestTransit,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, itineraries, 3)
+		assert.Equal(t, patientFirst.GetVoyageNumber().String(), firstVoyage(itineraries[0]))
+		assert.Equal(t, fastFirst.GetVoyageNumber().String(), firstVoyage(itineraries[1]))
+		assert.Equal(t, direct.GetVoyageNumber().String(), firstVoyage(itineraries[2]))
+	})
+
+	t.Run("should rank by minimum idle time in port", func(t *testing.T) {
+		service := setup()
+		ctx := createContextWithClaims(t, []string{})
+
+		itineraries, err := service.FindOptimalItineraries(ctx, routeSpec, routingdomain.RankingCriteria{
+			Strategy: routingdomain.RankingStrategyMinimumIdle,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, itineraries, 3)
+		assert.Equal(t, direct.GetVoyageNumber().String(), firstVoyage(itineraries[0]))
+		assert.Equal(t, patientFirst.GetVoyageNumber().String(), firstVoyage(itineraries[1]))
+		assert.Equal(t, fastFirst.GetVoyageNumber().String(), firstVoyage(itineraries[2]))
+	})
+
+	t.Run("should limit the number of results", func(t *testing.T) {
+		service := setup()
+		ctx := createContextWithClaims(t, []string{})
+
+		itineraries, err := service.FindOptimalItineraries(ctx, routeSpec, routingdomain.RankingCriteria{MaxResults: 1})
+
+		require.NoError(t, err)
+		require.Len(t, itineraries, 1)
+		assert.Equal(t, fastFirst.GetVoyageNumber().String(), firstVoyage(itineraries[0]))
+	})
+
+	t.Run("should fail with unknown ranking strategy", func(t *testing.T) {
+		service := setup()
+		ctx := createContextWithClaims(t, []string{})
+
+		_, err := service.FindOptimalItineraries(ctx, routeSpec, routingdomain.RankingCriteria{Strategy: "cheapest"})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown ranking strategy")
+	})
+
+	t.Run("should fail with negative max results", func(t *testing.T) {
+		service := setup()
+		ctx := createContextWithClaims(t, []string{})
+
+		_, err := service.FindOptimalItineraries(ctx, routeSpec, routingdomain.RankingCriteria{MaxResults: -1})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "max results cannot be negative")
+	})
+}
+
 // Helper functions
 
 func createContextWithClaims(t *testing.T, permissions []string) context.Context {
@@ -247,6 +542,14 @@ func createContextWithClaims(t *testing.T, permissions []string) context.Context
 	return context.WithValue(context.Background(), auth.ClaimsContextKey, claims)
 }
 
+// createContextWithRoles creates a context with the role-based default claims of the given roles
+func createContextWithRoles(t *testing.T, roles ...string) context.Context {
+	claims, err := auth.NewClaims("test-user", "testuser", "test@example.com", roles, nil)
+	require.NoError(t, err)
+
+	return context.WithValue(context.Background(), auth.ClaimsContextKey, claims)
+}
+
 func createTestVoyages(t *testing.T) []routingdomain.Voyage {
 	// Create test UN/LOCODEs
 	usnyc, err := routingdomain.NewUnLocode("USNYC")
@@ -282,3 +585,32 @@ func createTestVoyages(t *testing.T) []routingdomain.Voyage {
 
 	return []routingdomain.Voyage{voyage1, voyage2}
 }
+
+// createTestVoyage builds a voyage calling at the given ports, with 24h sailings and 4h port stays
+func createTestVoyage(t *testing.T, departure time.Time, codes ...string) routingdomain.Voyage {
+	return createTestVoyageWithDuration(t, departure, 24*time.Hour, codes...)
+}
+
+// createTestVoyageWithDuration builds a voyage calling at the given ports, with fixed sailing times and 4h port stays
+func createTestVoyageWithDuration(t *testing.T, departure time.Time, sailing time.Duration, codes ...string) routingdomain.Voyage {
+	var movements []routingdomain.CarrierMovement
+	current := departure
+
+	for i := 0; i < len(codes)-1; i++ {
+		from, err := routingdomain.NewUnLocode(codes[i])
+		require.NoError(t, err)
+		to, err := routingdomain.NewUnLocode(codes[i+1])
+		require.NoError(t, err)
+
+		movement, err := routingdomain.NewCarrierMovement(from, to, current, current.Add(sailing))
+		require.NoError(t, err)
+		movements = append(movements, movement)
+
+		current = current.Add(sailing + 4*time.Hour)
+	}
+
+	voyage, err := routingdomain.NewVoyage(movements)
+	require.NoError(t, err)
+
+	return voyage
+}
@@ -0,0 +1,133 @@
+package routingapplication
+
+import (
+	"context"
+	"fmt"
+	"go_hex/internal/routing/ports/routingprimary"
+	"go_hex/internal/routing/ports/routingsecondary"
+	"go_hex/internal/routing/routingdomain"
+	"go_hex/internal/support/auth"
+	"log/slog"
+)
+
+// VoyageSchedulingService implements the primary port for managing voyage schedules
+// Cargo routed on a rescheduled voyage is re-evaluated by the booking context, which reroutes the cargo that can no
+// longer make its connections; a voyage that cargo is routed on cannot be deleted.
+type VoyageSchedulingService struct {
+	voyageRepo       routingsecondary.VoyageRepository
+	cargoItineraries routingsecondary.CargoItineraryService
+	logger           *slog.Logger
+}
+
+// Ensure VoyageSchedulingService implements the primary port
+var _ routingprimary.VoyageScheduler = (*VoyageSchedulingService)(nil)
+
+// NewVoyageSchedulingService creates a new VoyageSchedulingService
+func NewVoyageSchedulingService(
+	voyageRepo routingsecondary.VoyageRepository,
+	cargoItineraries routingsecondary.CargoItineraryService,
+	logger *slog.Logger,
+) *VoyageSchedulingService {
+	return &VoyageSchedulingService{
+		voyageRepo:       voyageRepo,
+		cargoItineraries: cargoItineraries,
+		logger:           logger,
+	}
+}
+
+// CreateVoyage schedules a new voyage with the given carrier movements
+func (s *VoyageSchedulingService) CreateVoyage(ctx context.Context, movements []routingdomain.CarrierMovement) (routingdomain.Voyage, error) {
+	// Check permissions
+	claims, err := auth.ExtractClaims(ctx)
+	if err != nil {
+		s.logger.Warn("Unauthorized voyage creation attempt", "error", err)
+		return routingdomain.Voyage{}, err
+	}
+	if err := RequireRoutingPermission(claims, auth.PermissionManageSchedules); err != nil {
+		s.logger.Warn("Unauthorized voyage creation attempt", "error", err)
+		return routingdomain.Voyage{}, err
+	}
+
+	// Create voyage through the domain to ensure the schedule is connected
+	voyage, err := routingdomain.NewVoyage(movements)
+	if err != nil {
+		s.logger.Error("Invalid voyage schedule", "error", err)
+		return routingdomain.Voyage{}, err
+	}
+
+	if err := s.voyageRepo.Store(voyage); err != nil {
+		s.logger.Error("Failed to store voyage", "voyageNumber", voyage.GetVoyageNumber().String(), "error", err)
+		return routingdomain.Voyage{}, err
+	}
+
+	s.logger.Info("Voyage created",
+		"voyageNumber", voyage.GetVoyageNumber().String(),
+		"departureLocation", voyage.GetDepartureLocation().String(),
+		"arrivalLocation", voyage.GetArrivalLocation().String(),
+		"movements", len(movements))
+	return voyage, nil
+}
+
+// RescheduleVoyage replaces the schedule of an existing voyage
+func (s *VoyageSchedulingService) RescheduleVoyage(ctx context.Context, voyageNumber routingdomain.VoyageNumber, movements []routingdomain.CarrierMovement) (routingdomain.Voyage, error) {
+	// Check permissions
+	claims, err := auth.ExtractClaims(ctx)
+	if err != nil {
+		s.logger.Warn("Unauthorized voyage reschedule attempt", "voyageNumber", voyageNumber.String(), "error", err)
+		return routingdomain.Voyage{}, err
+	}
+	if err := RequireRoutingPermission(claims, auth.PermissionManageSchedules); err != nil {
+		s.logger.Warn("Unauthorized voyage reschedule attempt", "voyageNumber", voyageNumber.String(), "error", err)
+		return routingdomain.Voyage{}, err
+	}
+
+	// Store upserts, so make sure the voyage exists before replacing its schedule
+	if _, err := s.voyageRepo.FindByVoyageNumber(voyageNumber); err != nil {
+		s.logger.Debug("Voyage not retrieved", "voyageNumber", voyageNumber.String(), "error", err)
+		return routingdomain.Voyage{}, err
+	}
+
+	voyage, err := routingdomain.NewVoyageFromExisting(voyageNumber, movements)
+	if err != nil {
+		s.logger.Error("Invalid voyage schedule", "voyageNumber", voyageNumber.String(), "error", err)
+		return routingdomain.Voyage{}, err
+	}
+
+	if err := s.voyageRepo.Store(voyage); err != nil {
+		s.logger.Error("Failed to store voyage", "voyageNumber", voyageNumber.String(), "error", err)
+		return routingdomain.Voyage{}, err
+	}
+
+	s.logger.Info("Voyage rescheduled", "voyageNumber", voyageNumber.String(), "movements", len(movements))
+
+	// The new schedule is stored, so repeating the reschedule reviews the cargo again if this review fails
+	if err := s.cargoItineraries.ReviewItineraries(ctx, voyageNumber); err != nil {
+		s.logger.Error("Failed to review cargo routed on voyage", "voyageNumber", voyageNumber.String(), "error", err)
+		return routingdomain.Voyage{}, fmt.Errorf("voyage %s rescheduled, but its cargo was not reviewed: %w", voyageNumber.String(), err)
+	}
+
+	return voyage, nil
+}
+
+// DeleteVoyage removes a voyage from the schedule
+func (s *VoyageSchedulingService) DeleteVoyage(ctx context.Context, voyageNumber routingdomain.VoyageNumber) error {
+	// Check permissions
+	claims, err := auth.ExtractClaims(ctx)
+	if err != nil {
+		s.logger.Warn("Unauthorized voyage deletion attempt", "voyageNumber", voyageNumber.String(), "error", err)
+		return err
+	}
+	if err := RequireRoutingPermission(claims, auth.PermissionManageSchedules); err != nil {
+		s.logger.Warn("Unauthorized voyage deletion attempt", "voyageNumber", voyageNumber.String(), "error", err)
+		return err
+	}
+
+	// The repository checks for routed cargo as it deletes, so no cargo is routed on the voyage in between
+	if err := s.voyageRepo.Delete(voyageNumber); err != nil {
+		s.logger.Error("Failed to delete voyage", "voyageNumber", voyageNumber.String(), "error", err)
+		return err
+	}
+
+	s.logger.Info("Voyage deleted", "voyageNumber", voyageNumber.String())
+	return nil
+}
@@ -0,0 +1,221 @@
+package routingapplication
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"go_hex/internal/routing/routingdomain"
+	"go_hex/internal/support/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockCargoItineraryService struct {
+	mock.Mock
+}
+
+func (m *MockCargoItineraryService) ReviewItineraries(ctx context.Context, voyageNumber routingdomain.VoyageNumber) error {
+	args := m.Called(ctx, voyageNumber)
+	return args.Error(0)
+}
+
+func TestVoyageSchedulingService_CreateVoyage(t *testing.T) {
+	setup := func() (*VoyageSchedulingService, *MockVoyageRepository, *MockCargoItineraryService) {
+		voyageRepo := &MockVoyageRepository{}
+		cargoItineraries := &MockCargoItineraryService{}
+		service := NewVoyageSchedulingService(voyageRepo, cargoItineraries, slog.Default())
+		return service, voyageRepo, cargoItineraries
+	}
+	movements := createTestVoyage(t, time.Now().Add(time.Hour), "USNYC", "NLRTM", "DEHAM").GetSchedule().Movements
+
+	t.Run("should create voyage with a generated voyage number", func(t *testing.T) {
+		service, voyageRepo, _ := setup()
+
+		// Setup mocks
+		voyageRepo.On("Store", mock.MatchedBy(func(voyage routingdomain.Voyage) bool {
+			return voyage.GetSchedule().Movements[0] == movements[0] && len(voyage.GetSchedule().Movements) == 2
+		})).Return(nil)
+
+		// Execute
+		voyage, err := service.CreateVoyage(createContextWithClaims(t, nil), movements)
+
+		// Verify
+		require.NoError(t, err)
+		assert.NoError(t, voyage.GetVoyageNumber().Validate())
+		assert.Equal(t, "USNYC", voyage.GetDepartureLocation().String())
+		assert.Equal(t, "DEHAM", voyage.GetArrivalLocation().String())
+		voyageRepo.AssertExpectations(t)
+	})
+
+	t.Run("should fail with disconnected movements", func(t *testing.T) {
+		service, voyageRepo, _ := setup()
+		disconnected := []routingdomain.CarrierMovement{movements[1], movements[0]}
+
+		// Execute
+		_, err := service.CreateVoyage(createContextWithClaims(t, nil), disconnected)
+
+		// Verify
+		var validationErr routingdomain.DomainValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		voyageRepo.AssertNotCalled(t, "Store", mock.Anything)
+	})
+
+	t.Run("should fail without permission to manage schedules", func(t *testing.T) {
+		service, voyageRepo, _ := setup()
+
+		// Execute
+		_, err := service.CreateVoyage(createContextWithRoles(t, "user"), movements)
+
+		// Verify
+		var authErr auth.AuthorizationError
+		assert.ErrorAs(t, err, &authErr)
+		voyageRepo.AssertNotCalled(t, "Store", mock.Anything)
+	})
+}
+
+func TestVoyageSchedulingService_RescheduleVoyage(t *testing.T) {
+	setup := func() (*VoyageSchedulingService, *MockVoyageRepository, *MockCargoItineraryService) {
+		voyageRepo := &MockVoyageRepository{}
+		cargoItineraries := &MockCargoItineraryService{}
+		service := NewVoyageSchedulingService(voyageRepo, cargoItineraries, slog.Default())
+		return service, voyageRepo, cargoItineraries
+	}
+	existing := createTestVoyage(t, time.Now().Add(time.Hour), "USNYC", "NLRTM", "DEHAM")
+	movements := createTestVoyage(t, time.Now().Add(2*time.Hour), "USNYC", "DEHAM").GetSchedule().Movements
+
+	t.Run("should replace the schedule of the voyage", func(t *testing.T) {
+		service, voyageRepo, cargoItineraries := setup()
+
+		// Setup mocks
+		voyageRepo.On("FindByVoyageNumber", existing.GetVoyageNumber()).Return(existing, nil)
+		voyageRepo.On("Store", mock.MatchedBy(func(voyage routingdomain.Voyage) bool {
+			return voyage.GetVoyageNumber() == existing.GetVoyageNumber() && len(voyage.GetSchedule().Movements) == 1
+		})).Return(nil)
+		cargoItineraries.On("ReviewItineraries", mock.Anything, existing.GetVoyageNumber()).Return(nil)
+
+		// Execute
+		voyage, err := service.RescheduleVoyage(createContextWithClaims(t, nil), existing.GetVoyageNumber(), movements)
+
+		// Verify
+		require.NoError(t, err)
+		assert.Equal(t, existing.GetVoyageNumber(), voyage.GetVoyageNumber())
+		assert.Equal(t, movements, voyage.GetSchedule().Movements)
+		voyageRepo.AssertExpectations(t)
+		cargoItineraries.AssertExpectations(t)
+	})
+
+	t.Run("should fail when the cargo routed on the voyage cannot be reviewed", func(t *testing.T) {
+		service, voyageRepo, cargoItineraries := setup()
+
+		// Setup mocks
+		voyageRepo.On("FindByVoyageNumber", existing.GetVoyageNumber()).Return(existing, nil)
+		voyageRepo.On("Store", mock.Anything).Return(nil)
+		cargoItineraries.On("ReviewItineraries", mock.Anything, existing.GetVoyageNumber()).Return(errors.New("booking unavailable"))
+
+		// Execute
+		_, err := service.RescheduleVoyage(createContextWithClaims(t, nil), existing.GetVoyageNumber(), movements)
+
+		// Verify
+		assert.ErrorContains(t, err, "booking unavailable")
+		voyageRepo.AssertCalled(t, "Store", mock.Anything)
+	})
+
+	t.Run("should fail for unknown voyage", func(t *testing.T) {
+		service, voyageRepo, _ := setup()
+		unknown := routingdomain.NewVoyageNumber()
+
+		// Setup mocks
+		voyageRepo.On("FindByVoyageNumber", unknown).Return(routingdomain.Voyage{}, routingdomain.NewVoyageNotFoundError(unknown))
+
+		// Execute
+		_, err := service.RescheduleVoyage(createContextWithClaims(t, nil), unknown, movements)
+
+		// Verify
+		var notFound routingdomain.VoyageNotFoundError
+		assert.ErrorAs(t, err, &notFound)
+		voyageRepo.AssertNotCalled(t, "Store", mock.Anything)
+	})
+
+	t.Run("should fail without permission to manage schedules", func(t *testing.T) {
+		service, voyageRepo, _ := setup()
+
+		// Execute
+		_, err := service.RescheduleVoyage(createContextWithRoles(t, "user"), existing.GetVoyageNumber(), movements)
+
+		// Verify
+		var authErr auth.AuthorizationError
+		assert.ErrorAs(t, err, &authErr)
+		voyageRepo.AssertNotCalled(t, "FindByVoyageNumber", mock.Anything)
+	})
+}
+
+func TestVoyageSchedulingService_DeleteVoyage(t *testing.T) {
+	setup := func() (*VoyageSchedulingService, *MockVoyageRepository, *MockCargoItineraryService) {
+		voyageRepo := &MockVoyageRepository{}
+		cargoItineraries := &MockCargoItineraryService{}
+		service := NewVoyageSchedulingService(voyageRepo, cargoItineraries, slog.Default())
+		return service, voyageRepo, cargoItineraries
+	}
+
+	t.Run("should delete the voyage", func(t *testing.T) {
+		service, voyageRepo, _ := setup()
+		voyageNumber := routingdomain.NewVoyageNumber()
+
+		// Setup mocks
+		voyageRepo.On("Delete", voyageNumber).Return(nil)
+
+		// Execute
+		err := service.DeleteVoyage(createContextWithClaims(t, nil), voyageNumber)
+
+		// Verify
+		require.NoError(t, err)
+		voyageRepo.AssertExpectations(t)
+	})
+
+	t.Run("should fail for unknown voyage", func(t *testing.T) {
+		service, voyageRepo, _ := setup()
+		unknown := routingdomain.NewVoyageNumber()
+
+		// Setup mocks
+		voyageRepo.On("Delete", unknown).Return(routingdomain.NewVoyageNotFoundError(unknown))
+
+		// Execute
+		err := service.DeleteVoyage(createContextWithClaims(t, nil), unknown)
+
+		// Verify
+		var notFound routingdomain.VoyageNotFoundError
+		assert.ErrorAs(t, err, &notFound)
+	})
+
+	t.Run("should fail while cargo is routed on the voyage", func(t *testing.T) {
+		service, voyageRepo, cargoItineraries := setup()
+		voyageNumber := routingdomain.NewVoyageNumber()
+
+		// Setup mocks
+		voyageRepo.On("Delete", voyageNumber).Return(routingdomain.NewVoyageInUseError(voyageNumber))
+
+		// Execute
+		err := service.DeleteVoyage(createContextWithClaims(t, nil), voyageNumber)
+
+		// Verify
+		var inUse routingdomain.VoyageInUseError
+		assert.ErrorAs(t, err, &inUse)
+		cargoItineraries.AssertNotCalled(t, "ReviewItineraries", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should fail with unauthorized context", func(t *testing.T) {
+		service, voyageRepo, _ := setup()
+
+		// Execute
+		err := service.DeleteVoyage(context.Background(), routingdomain.NewVoyageNumber())
+
+		// Verify
+		assert.Error(t, err)
+		voyageRepo.AssertNotCalled(t, "Delete", mock.Anything)
+	})
+}
@@ -1,6 +1,8 @@
 package routingdomain
 
 import (
+	"fmt"
+
 	"go_hex/internal/support/errors"
 )
 
@@ -15,3 +17,45 @@ func NewDomainValidationError(message string, cause error) DomainValidationError
 		BaseError: errors.NewBaseError(message, cause),
 	}
 }
+
+// VoyageNotFoundError reports that no voyage with the given number exists
+type VoyageNotFoundError struct {
+	errors.BaseError
+	VoyageNumber VoyageNumber
+}
+
+// NewVoyageNotFoundError creates a new voyage not found error
+func NewVoyageNotFoundError(voyageNumber VoyageNumber) VoyageNotFoundError {
+	return VoyageNotFoundError{
+		BaseError:    errors.NewBaseError(fmt.Sprintf("voyage with number %s not found", voyageNumber.String()), nil),
+		VoyageNumber: voyageNumber,
+	}
+}
+
+// VoyageInUseError reports that cargo still to be delivered is routed on a voyage, so it cannot be deleted
+type VoyageInUseError struct {
+	errors.BaseError
+	VoyageNumber VoyageNumber
+}
+
+// NewVoyageInUseError creates a new voyage in use error
+func NewVoyageInUseError(voyageNumber VoyageNumber) VoyageInUseError {
+	return VoyageInUseError{
+		BaseError:    errors.NewBaseError(fmt.Sprintf("voyage with number %s is in the itinerary of cargo still to be delivered", voyageNumber.String()), nil),
+		VoyageNumber: voyageNumber,
+	}
+}
+
+// LocationNotFoundError reports that no location with the given UN/LOCODE exists
+type LocationNotFoundError struct {
+	errors.BaseError
+	UnLocode UnLocode
+}
+
+// NewLocationNotFoundError creates a new location not found error
+func NewLocationNotFoundError(unLocode UnLocode) LocationNotFoundError {
+	return LocationNotFoundError{
+		BaseError: errors.NewBaseError(fmt.Sprintf("location with UN/LOCODE %s not found", unLocode.String()), nil),
+		UnLocode:  unLocode,
+	}
+}
@@ -6,6 +6,10 @@ type RouteSpecification struct {
 	Origin          string `json:"origin"`           // UN/LOCODE
 	Destination     string `json:"destination"`      // UN/LOCODE
 	ArrivalDeadline string `json:"arrival_deadline"` // RFC3339 format
+
+	// EarliestDeparture optionally keeps the first leg from departing before it, in RFC3339 format; without it the
+	// first leg departs no earlier than the search
+	EarliestDeparture string `json:"earliest_departure,omitempty"`
 }
 
 // Leg represents a single step in a route for external contexts
@@ -21,3 +25,20 @@ type Leg struct {
 type Itinerary struct {
 	Legs []Leg `json:"legs"`
 }
+
+// RankingStrategy selects the cost function used to order route candidates
+type RankingStrategy string
+
+const (
+	RankingStrategyEarliestArrival RankingStrategy = "earliest_arrival"
+	RankingStrategyFewestLegs      RankingStrategy = "fewest_legs"
+	RankingStrategyShortestTransit RankingStrategy = "shortest_transit"
+	RankingStrategyMinimumIdle     RankingStrategy = "minimum_idle"
+)
+
+// RankingCriteria controls how route candidates are ordered and how many are returned
+// A zero value ranks by earliest arrival and applies the default result limit.
+type RankingCriteria struct {
+	Strategy   RankingStrategy `json:"strategy,omitempty"`
+	MaxResults int             `json:"max_results,omitempty"`
+}
@@ -138,6 +138,27 @@ func NewVoyage(movements []CarrierMovement) (Voyage, error) {
 	}, nil
 }
 
+// NewVoyageFromExisting creates a voyage from existing data (for repository loading)
+func NewVoyageFromExisting(voyageNumber VoyageNumber, movements []CarrierMovement) (Voyage, error) {
+	schedule, err := NewSchedule(movements)
+	if err != nil {
+		return Voyage{}, err
+	}
+
+	data := VoyageData{
+		Schedule: schedule,
+	}
+
+	if err := validation.Validate(data); err != nil {
+		return Voyage{}, NewDomainValidationError("voyage data validation failed", err)
+	}
+
+	return Voyage{
+		BaseEntity: basedomain.NewBaseEntity(voyageNumber),
+		Data:       data,
+	}, nil
+}
+
 // GetVoyageNumber returns the voyage's number
 func (v Voyage) GetVoyageNumber() VoyageNumber {
 	return v.Id
@@ -165,6 +165,26 @@ func TestNewVoyage(t *testing.T) {
 	})
 }
 
+func TestNewVoyageFromExisting(t *testing.T) {
+	t.Run("should keep the given voyage number", func(t *testing.T) {
+		movements := createTestMovements(t)
+		voyageNumber := NewVoyageNumber()
+
+		voyage, err := NewVoyageFromExisting(voyageNumber, movements)
+
+		require.NoError(t, err)
+		assert.Equal(t, voyageNumber, voyage.GetVoyageNumber())
+		assert.Len(t, voyage.Data.Schedule.Movements, 2)
+	})
+
+	t.Run("should fail with invalid movements", func(t *testing.T) {
+		_, err := NewVoyageFromExisting(NewVoyageNumber(), []CarrierMovement{})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "schedule must contain at least one movement")
+	})
+}
+
 func TestVoyage_Methods(t *testing.T) {
 	movements := createTestMovements(t)
 	voyage, err := NewVoyage(movements)
@@ -26,10 +26,11 @@ type MockRoutingApplication struct {
 func NewMockRoutingApplication(
 	voyageRepo routingsecondary.VoyageRepository,
 	locationRepo routingsecondary.LocationRepository,
+	maxLegs int,
 	logger *slog.Logger,
 	seed int64,
 ) *MockRoutingApplication {
-	realApp := routingapplication.NewRoutingApplicationService(voyageRepo, locationRepo, logger)
+	realApp := routingapplication.NewRoutingApplicationService(voyageRepo, locationRepo, maxLegs, logger)
 
 	return &MockRoutingApplication{
 		RoutingApplicationService: realApp,
@@ -73,6 +73,20 @@ func NewClaimsWithDomainOverrides(
 	return claims, nil
 }
 
+// WithServiceClaims returns a context authenticated as an internal service that calls a bounded context on the
+// system's behalf, holding exactly the given domain permissions and none derived from roles
+func WithServiceClaims(ctx context.Context, serviceID string, booking BookingClaims, routing RoutingClaims, handling HandlingClaims) context.Context {
+	claims := &Claims{
+		UserID:         serviceID,
+		Username:       serviceID,
+		Metadata:       make(map[string]string),
+		BookingClaims:  &booking,
+		RoutingClaims:  &routing,
+		HandlingClaims: &handling,
+	}
+	return context.WithValue(ctx, ClaimsContextKey, claims)
+}
+
 // initializeDefaultDomainClaims sets up default domain-specific claims based on user roles
 func (c *Claims) initializeDefaultDomainClaims() {
 	isAdmin := c.HasRole(string(RoleAdmin))
@@ -89,15 +103,17 @@ func (c *Claims) initializeDefaultDomainClaims() {
 
 	// Default routing claims based on role
 	c.RoutingClaims = &RoutingClaims{
-		CanPlanRoutes:    isAdmin || isUser,
-		CanViewVoyages:   isAdmin || isUser || isReadOnly,
-		CanViewLocations: isAdmin || isUser || isReadOnly,
+		CanPlanRoutes:      isAdmin || isUser,
+		CanViewVoyages:     isAdmin || isUser || isReadOnly,
+		CanViewLocations:   isAdmin || isUser || isReadOnly,
+		CanManageSchedules: isAdmin,
 	}
 
 	// Default handling claims based on role
 	c.HandlingClaims = &HandlingClaims{
-		CanSubmitHandling: isAdmin || isUser,
-		CanViewHandling:   isAdmin || isUser || isReadOnly,
+		CanSubmitHandling:   isAdmin || isUser,
+		CanViewHandling:     isAdmin || isUser || isReadOnly,
+		CanBackfillHandling: isAdmin,
 	}
 }
 
@@ -186,18 +202,20 @@ func (bc *BookingClaims) HasPermission(permission BookingPermission) bool {
 
 // RoutingClaims represents domain-specific claims for the routing context
 type RoutingClaims struct {
-	CanPlanRoutes    bool `json:"can_plan_routes"`
-	CanViewVoyages   bool `json:"can_view_voyages"`
-	CanViewLocations bool `json:"can_view_locations"`
+	CanPlanRoutes      bool `json:"can_plan_routes"`
+	CanViewVoyages     bool `json:"can_view_voyages"`
+	CanViewLocations   bool `json:"can_view_locations"`
+	CanManageSchedules bool `json:"can_manage_schedules"`
 }
 
 // RoutingPermission represents permissions specific to the routing domain
 type RoutingPermission string
 
 const (
-	PermissionPlanRoutes    RoutingPermission = "plan_routes"
-	PermissionViewVoyages   RoutingPermission = "view_voyages"
-	PermissionViewLocations RoutingPermission = "view_locations"
+	PermissionPlanRoutes      RoutingPermission = "plan_routes"
+	PermissionViewVoyages     RoutingPermission = "view_voyages"
+	PermissionViewLocations   RoutingPermission = "view_locations"
+	PermissionManageSchedules RoutingPermission = "manage_schedules"
 )
 
 // HasPermission checks if the routing claims include a specific permission
@@ -209,6 +227,8 @@ func (rc *RoutingClaims) HasPermission(permission RoutingPermission) bool {
 		return rc.CanViewVoyages
 	case PermissionViewLocations:
 		return rc.CanViewLocations
+	case PermissionManageSchedules:
+		return rc.CanManageSchedules
 	default:
 		return false
 	}
@@ -216,16 +236,18 @@ func (rc *RoutingClaims) HasPermission(permission RoutingPermission) bool {
 
 // HandlingClaims represents domain-specific claims for the handling context
 type HandlingClaims struct {
-	CanSubmitHandling bool `json:"can_submit_handling"`
-	CanViewHandling   bool `json:"can_view_handling"`
+	CanSubmitHandling   bool `json:"can_submit_handling"`
+	CanViewHandling     bool `json:"can_view_handling"`
+	CanBackfillHandling bool `json:"can_backfill_handling"`
 }
 
 // HandlingPermission represents permissions specific to the handling domain
 type HandlingPermission string
 
 const (
-	PermissionSubmitHandling HandlingPermission = "submit_handling"
-	PermissionViewHandling   HandlingPermission = "view_handling"
+	PermissionSubmitHandling   HandlingPermission = "submit_handling"
+	PermissionViewHandling     HandlingPermission = "view_handling"
+	PermissionBackfillHandling HandlingPermission = "backfill_handling"
 )
 
 // HasPermission checks if the handling claims include a specific permission
@@ -235,6 +257,8 @@ func (hc *HandlingClaims) HasPermission(permission HandlingPermission) bool {
 		return hc.CanSubmitHandling
 	case PermissionViewHandling:
 		return hc.CanViewHandling
+	case PermissionBackfillHandling:
+		return hc.CanBackfillHandling
 	default:
 		return false
 	}
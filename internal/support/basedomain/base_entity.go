@@ -21,6 +21,9 @@ type BaseEntity[T EntityID] struct {
 	CreatedAt time.Time `json:"created_at" validate:"required"`
 	UpdatedAt time.Time `json:"updated_at" validate:"required,gtefield=CreatedAt"`
 
+	// Version is the persisted revision the entity was loaded at; zero until first stored
+	Version int `json:"version"`
+
 	// Event queue for domain events (not serialized)
 	events []DomainEvent `json:"-"`
 }
@@ -38,6 +41,15 @@ func (b *BaseEntity[T]) ID() T {
 	return b.Id
 }
 
+func (b *BaseEntity[T]) GetVersion() int {
+	return b.Version
+}
+
+// SetVersion records the persisted revision of the entity; used by repositories and after a successful save
+func (b *BaseEntity[T]) SetVersion(version int) {
+	b.Version = version
+}
+
 func (b *BaseEntity[T]) Touch() {
 	b.UpdatedAt = time.Now()
 }
@@ -5,15 +5,21 @@ import (
 	"go_hex/internal/support/validation"
 	"os"
 	"strconv"
+	"time"
 )
 
 // Config holds application configuration.
 type Config struct {
-	Port        int       `json:"port" validate:"required,min=1,max=65535"`
-	Environment string    `json:"environment" validate:"required,environment"`
-	LogLevel    string    `json:"log_level" validate:"required,log_level"`
-	Mode        string    `json:"mode" validate:"required,mode"`
-	JWT         JWTConfig `json:"jwt"`
+	Port        int            `json:"port" validate:"required,min=1,max=65535"`
+	Environment string         `json:"environment" validate:"required,environment"`
+	LogLevel    string         `json:"log_level" validate:"required,log_level"`
+	Mode        string         `json:"mode" validate:"required,mode"`
+	JWT         JWTConfig      `json:"jwt"`
+	Routing     RoutingConfig  `json:"routing"`
+	Storage     StorageConfig  `json:"storage"`
+	EventBus    EventBusConfig `json:"event_bus"`
+	Handling    HandlingConfig `json:"handling"`
+	Booking     BookingConfig  `json:"booking"`
 }
 
 // JWTConfig holds JWT-specific configuration.
@@ -23,6 +29,37 @@ type JWTConfig struct {
 	Audience  string `json:"audience" validate:"required"`
 }
 
+// RoutingConfig holds route search configuration.
+type RoutingConfig struct {
+	MaxLegs int `json:"max_legs" validate:"required,min=1,max=10"`
+}
+
+// StorageConfig holds repository persistence configuration.
+type StorageConfig struct {
+	Driver      string `json:"driver" validate:"required,storage"`
+	PostgresDSN string `json:"-" validate:"required_if=Driver postgres"`
+	SQLitePath  string `json:"sqlite_path" validate:"required_if=Driver sqlite"`
+}
+
+// EventBusConfig holds domain event delivery configuration.
+type EventBusConfig struct {
+	Mode      string `json:"mode" validate:"required,event_bus_mode"`
+	QueueSize int    `json:"queue_size" validate:"required,min=1"`
+}
+
+// HandlingConfig holds handling report processing configuration.
+type HandlingConfig struct {
+	SequencePolicy string        `json:"sequence_policy" validate:"required,sequence_policy"`
+	MaxEventAge    time.Duration `json:"max_event_age" validate:"required,min=0"`
+	MaxFutureSkew  time.Duration `json:"max_future_skew" validate:"min=0"`
+}
+
+// BookingConfig holds cargo booking configuration.
+type BookingConfig struct {
+	ReroutePolicy   string `json:"reroute_policy" validate:"required,reroute_policy"`
+	RerouteStrategy string `json:"reroute_strategy" validate:"omitempty,oneof=earliest_arrival fewest_legs shortest_transit minimum_idle"`
+}
+
 // New creates configuration from environment variables with validation.
 func New() (*Config, error) {
 	config := &Config{
@@ -35,6 +72,24 @@ func New() (*Config, error) {
 			Issuer:    "go-hex-service",
 			Audience:  "go-hex-api",
 		},
+		Routing: RoutingConfig{
+			MaxLegs: 4,
+		},
+		Storage: StorageConfig{
+			Driver: "memory",
+		},
+		EventBus: EventBusConfig{
+			Mode:      "sync",
+			QueueSize: 256,
+		},
+		Handling: HandlingConfig{
+			SequencePolicy: "strict",
+			MaxEventAge:    30 * 24 * time.Hour,
+			MaxFutureSkew:  5 * time.Minute,
+		},
+		Booking: BookingConfig{
+			ReroutePolicy: "worklist",
+		},
 	}
 
 	if portStr := os.Getenv("PORT"); portStr != "" {
@@ -70,6 +125,66 @@ func New() (*Config, error) {
 		config.JWT.Audience = jwtAudience
 	}
 
+	if maxLegsStr := os.Getenv("ROUTING_MAX_LEGS"); maxLegsStr != "" {
+		if n, err := strconv.Atoi(maxLegsStr); err != nil {
+			return nil, fmt.Errorf("invalid ROUTING_MAX_LEGS value: %w", err)
+		} else {
+			config.Routing.MaxLegs = n
+		}
+	}
+
+	if storage := os.Getenv("STORAGE"); storage != "" {
+		config.Storage.Driver = storage
+	}
+
+	if dsn := os.Getenv("POSTGRES_DSN"); dsn != "" {
+		config.Storage.PostgresDSN = dsn
+	}
+
+	if sqlitePath := os.Getenv("SQLITE_PATH"); sqlitePath != "" {
+		config.Storage.SQLitePath = sqlitePath
+	}
+
+	if mode := os.Getenv("EVENT_BUS_MODE"); mode != "" {
+		config.EventBus.Mode = mode
+	}
+
+	if queueSizeStr := os.Getenv("EVENT_BUS_QUEUE_SIZE"); queueSizeStr != "" {
+		if n, err := strconv.Atoi(queueSizeStr); err != nil {
+			return nil, fmt.Errorf("invalid EVENT_BUS_QUEUE_SIZE value: %w", err)
+		} else {
+			config.EventBus.QueueSize = n
+		}
+	}
+
+	if policy := os.Getenv("HANDLING_SEQUENCE_POLICY"); policy != "" {
+		config.Handling.SequencePolicy = policy
+	}
+
+	if maxAgeStr := os.Getenv("HANDLING_MAX_EVENT_AGE"); maxAgeStr != "" {
+		if d, err := time.ParseDuration(maxAgeStr); err != nil {
+			return nil, fmt.Errorf("invalid HANDLING_MAX_EVENT_AGE value: %w", err)
+		} else {
+			config.Handling.MaxEventAge = d
+		}
+	}
+
+	if skewStr := os.Getenv("HANDLING_MAX_FUTURE_SKEW"); skewStr != "" {
+		if d, err := time.ParseDuration(skewStr); err != nil {
+			return nil, fmt.Errorf("invalid HANDLING_MAX_FUTURE_SKEW value: %w", err)
+		} else {
+			config.Handling.MaxFutureSkew = d
+		}
+	}
+
+	if policy := os.Getenv("BOOKING_REROUTE_POLICY"); policy != "" {
+		config.Booking.ReroutePolicy = policy
+	}
+
+	if strategy := os.Getenv("BOOKING_REROUTE_STRATEGY"); strategy != "" {
+		config.Booking.RerouteStrategy = strategy
+	}
+
 	// Annotation-based validation handles all validation rules
 	if err := validation.Validate(config); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
@@ -93,3 +208,15 @@ func (c *Config) IsMockMode() bool {
 func (c *Config) IsLiveMode() bool {
 	return c.Mode == "live"
 }
+
+func (c *Config) UsesPostgres() bool {
+	return c.Storage.Driver == "postgres"
+}
+
+func (c *Config) UsesSQLite() bool {
+	return c.Storage.Driver == "sqlite"
+}
+
+func (c *Config) UsesAsyncEventBus() bool {
+	return c.EventBus.Mode == "async"
+}
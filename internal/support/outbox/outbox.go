@@ -0,0 +1,62 @@
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go_hex/internal/support/basedomain"
+
+	"github.com/google/uuid"
+)
+
+// Message is a domain event recorded in the outbox together with its aggregate, awaiting delivery
+type Message struct {
+	Id         string    `json:"id"`
+	EventName  string    `json:"event_name"`
+	Payload    []byte    `json:"payload"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Attempts   int       `json:"attempts"`
+	LastError  string    `json:"last_error,omitempty"`
+}
+
+// NewMessage serializes a domain event into an outbox message
+func NewMessage(event basedomain.DomainEvent) (Message, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to encode event %s: %w", event.EventName(), err)
+	}
+
+	return Message{
+		Id:         uuid.New().String(),
+		EventName:  event.EventName(),
+		Payload:    payload,
+		OccurredAt: event.OccurredAt(),
+	}, nil
+}
+
+// NewMessages serializes all pending events of an aggregate, in the order they were raised
+func NewMessages(events []basedomain.DomainEvent) ([]Message, error) {
+	messages := make([]Message, 0, len(events))
+	for _, event := range events {
+		message, err := NewMessage(event)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+// Store defines the secondary port the relay uses to read and settle outbox messages
+type Store interface {
+	// FindPending retrieves undispatched messages of the named events with fewer than maxAttempts failed deliveries,
+	// oldest first
+	FindPending(eventNames []string, maxAttempts, limit int) ([]Message, error)
+
+	// MarkDispatched records that a message was delivered so it is not relayed again
+	MarkDispatched(id string) error
+
+	// MarkFailed records a failed delivery attempt for a message
+	MarkFailed(id string, reason string) error
+}
@@ -0,0 +1,53 @@
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"go_hex/internal/support/basedomain"
+)
+
+// Registry maps event names to their concrete types so stored payloads can be turned back into domain events
+type Registry struct {
+	decoders map[string]func(payload []byte) (basedomain.DomainEvent, error)
+}
+
+// NewRegistry creates an empty event registry
+func NewRegistry() *Registry {
+	return &Registry{
+		decoders: make(map[string]func(payload []byte) (basedomain.DomainEvent, error)),
+	}
+}
+
+// Register makes events of type T decodable under the name T reports
+func Register[T basedomain.DomainEvent](r *Registry) {
+	var zero T
+	r.decoders[zero.EventName()] = func(payload []byte) (basedomain.DomainEvent, error) {
+		var event T
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return nil, err
+		}
+		return event, nil
+	}
+}
+
+// EventNames lists the registered event names in sorted order
+func (r *Registry) EventNames() []string {
+	return slices.Sorted(maps.Keys(r.decoders))
+}
+
+// Decode restores the domain event carried by a message
+func (r *Registry) Decode(message Message) (basedomain.DomainEvent, error) {
+	decode, exists := r.decoders[message.EventName]
+	if !exists {
+		return nil, fmt.Errorf("no event type registered for %s", message.EventName)
+	}
+
+	event, err := decode(message.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode event %s: %w", message.EventName, err)
+	}
+	return event, nil
+}
@@ -0,0 +1,121 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go_hex/internal/support/basedomain"
+)
+
+// RelayConfig controls how often and how persistently the relay delivers messages
+type RelayConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+}
+
+// DefaultRelayConfig returns the relay settings used by the application
+func DefaultRelayConfig() RelayConfig {
+	return RelayConfig{
+		PollInterval: time.Second,
+		BatchSize:    100,
+		MaxAttempts:  10,
+	}
+}
+
+// Relay delivers outbox messages to an event publisher, giving at-least-once delivery. It relays only the events of
+// its registry, leaving other messages pending for a relay that knows them.
+type Relay struct {
+	store     Store
+	publisher basedomain.EventPublisher
+	registry  *Registry
+	config    RelayConfig
+	logger    *slog.Logger
+}
+
+// NewRelay creates a relay reading from store and publishing to publisher
+func NewRelay(store Store, publisher basedomain.EventPublisher, registry *Registry, config RelayConfig, logger *slog.Logger) *Relay {
+	return &Relay{
+		store:     store,
+		publisher: publisher,
+		registry:  registry,
+		config:    config,
+		logger:    logger,
+	}
+}
+
+// Run dispatches pending messages every poll interval until ctx is cancelled
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		r.DispatchPending()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchPending makes one delivery attempt for each pending message and returns how many were delivered
+func (r *Relay) DispatchPending() int {
+	messages, err := r.store.FindPending(r.registry.EventNames(), r.config.MaxAttempts, r.config.BatchSize)
+	if err != nil {
+		r.logger.Error("Failed to read outbox", "error", err)
+		return 0
+	}
+
+	dispatched := 0
+	for _, message := range messages {
+		if err := r.dispatch(message); err != nil {
+			r.recordFailure(message, err)
+			continue
+		}
+		dispatched++
+	}
+	return dispatched
+}
+
+// dispatch publishes a single message and marks it delivered
+func (r *Relay) dispatch(message Message) error {
+	event, err := r.registry.Decode(message)
+	if err != nil {
+		return err
+	}
+
+	if err := r.publisher.Publish(event); err != nil {
+		return err
+	}
+
+	// A failure here means the message is delivered again later, which at-least-once consumers tolerate
+	if err := r.store.MarkDispatched(message.Id); err != nil {
+		r.logger.Error("Failed to mark outbox message dispatched", "messageId", message.Id, "eventName", message.EventName, "error", err)
+	}
+	return nil
+}
+
+// recordFailure stores a failed attempt so the message is retried on a later pass
+func (r *Relay) recordFailure(message Message, cause error) {
+	attempts := message.Attempts + 1
+	if attempts >= r.config.MaxAttempts {
+		r.logger.Error("Giving up on outbox message",
+			"messageId", message.Id,
+			"eventName", message.EventName,
+			"attempts", attempts,
+			"error", cause)
+	} else {
+		r.logger.Warn("Outbox message delivery failed, will retry",
+			"messageId", message.Id,
+			"eventName", message.EventName,
+			"attempts", attempts,
+			"error", cause)
+	}
+
+	if err := r.store.MarkFailed(message.Id, cause.Error()); err != nil {
+		r.logger.Error("Failed to record outbox delivery failure", "messageId", message.Id, "error", err)
+	}
+}
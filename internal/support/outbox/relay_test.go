@@ -0,0 +1,147 @@
+package outbox
+
+import (
+	"errors"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"go_hex/internal/support/basedomain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testEvent struct {
+	Name string    `json:"name"`
+	At   time.Time `json:"at"`
+}
+
+func (e testEvent) EventName() string     { return "TestEvent" }
+func (e testEvent) OccurredAt() time.Time { return e.At }
+
+// fakeStore keeps messages in memory with the same settling rules as the real stores
+type fakeStore struct {
+	messages   []Message
+	dispatched map[string]bool
+}
+
+func newFakeStore(messages ...Message) *fakeStore {
+	return &fakeStore{messages: messages, dispatched: make(map[string]bool)}
+}
+
+func (s *fakeStore) FindPending(eventNames []string, maxAttempts, limit int) ([]Message, error) {
+	var pending []Message
+	for _, message := range s.messages {
+		if len(pending) < limit && !s.dispatched[message.Id] && message.Attempts < maxAttempts && slices.Contains(eventNames, message.EventName) {
+			pending = append(pending, message)
+		}
+	}
+	return pending, nil
+}
+
+func (s *fakeStore) MarkDispatched(id string) error {
+	s.dispatched[id] = true
+	return nil
+}
+
+func (s *fakeStore) MarkFailed(id string, reason string) error {
+	for i := range s.messages {
+		if s.messages[i].Id == id {
+			s.messages[i].Attempts++
+			s.messages[i].LastError = reason
+		}
+	}
+	return nil
+}
+
+// flakyPublisher fails the first failures calls, then records what it publishes
+type flakyPublisher struct {
+	failures  int
+	published []basedomain.DomainEvent
+}
+
+func (p *flakyPublisher) Publish(event basedomain.DomainEvent) error {
+	if p.failures > 0 {
+		p.failures--
+		return errors.New("subscriber unavailable")
+	}
+	p.published = append(p.published, event)
+	return nil
+}
+
+func TestRelay_DispatchPending(t *testing.T) {
+	setup := func(t *testing.T, failures int, maxAttempts int) (*Relay, *fakeStore, *flakyPublisher, testEvent) {
+		event := testEvent{Name: "first", At: time.Now().UTC().Truncate(time.Second)}
+		message, err := NewMessage(event)
+		require.NoError(t, err)
+
+		registry := NewRegistry()
+		Register[testEvent](registry)
+
+		store := newFakeStore(message)
+		publisher := &flakyPublisher{failures: failures}
+		config := RelayConfig{PollInterval: time.Millisecond, BatchSize: 10, MaxAttempts: maxAttempts}
+		return NewRelay(store, publisher, registry, config, slog.Default()), store, publisher, event
+	}
+
+	t.Run("should publish decoded event and mark it dispatched", func(t *testing.T) {
+		// Setup
+		relay, store, publisher, event := setup(t, 0, 3)
+
+		// Execute
+		dispatched := relay.DispatchPending()
+
+		// Verify
+		assert.Equal(t, 1, dispatched)
+		assert.Equal(t, []basedomain.DomainEvent{event}, publisher.published)
+		pending, _ := store.FindPending([]string{"TestEvent"}, 3, 10)
+		assert.Empty(t, pending)
+	})
+
+	t.Run("should retry failed delivery on a later pass", func(t *testing.T) {
+		// Setup
+		relay, store, publisher, event := setup(t, 1, 3)
+
+		// Execute
+		first := relay.DispatchPending()
+		second := relay.DispatchPending()
+
+		// Verify
+		assert.Equal(t, 0, first)
+		assert.Equal(t, 1, second)
+		assert.Equal(t, []basedomain.DomainEvent{event}, publisher.published)
+		assert.Equal(t, 1, store.messages[0].Attempts)
+		assert.Equal(t, "subscriber unavailable", store.messages[0].LastError)
+	})
+
+	t.Run("should give up after max attempts", func(t *testing.T) {
+		// Setup
+		relay, store, publisher, _ := setup(t, 5, 2)
+
+		// Execute
+		for i := 0; i < 4; i++ {
+			relay.DispatchPending()
+		}
+
+		// Verify
+		assert.Empty(t, publisher.published)
+		assert.Equal(t, 2, store.messages[0].Attempts)
+	})
+
+	t.Run("should leave messages of unregistered events pending", func(t *testing.T) {
+		// Setup
+		relay, store, publisher, _ := setup(t, 0, 3)
+		store.messages[0].EventName = "UnknownEvent"
+
+		// Execute
+		dispatched := relay.DispatchPending()
+
+		// Verify
+		assert.Equal(t, 0, dispatched)
+		assert.Empty(t, publisher.published)
+		assert.False(t, store.dispatched[store.messages[0].Id])
+		assert.Zero(t, store.messages[0].Attempts)
+	})
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"go_hex/internal/adapters/driving/httpadapter"
 	"go_hex/internal/support/config"
@@ -13,13 +14,18 @@ import (
 	"time"
 )
 
+// ShutdownHook releases a background component once the server has stopped accepting requests
+type ShutdownHook func(ctx context.Context) error
+
 type HTTPServer struct {
-	server  *http.Server
-	config  *config.Config
-	handler *httpadapter.Handler
+	server        *http.Server
+	config        *config.Config
+	handler       *httpadapter.Handler
+	shutdownHooks []ShutdownHook
 }
 
-func New(cfg *config.Config, handler *httpadapter.Handler, _ interface{}) *HTTPServer {
+// New creates the server; shutdownHooks run in order after in-flight requests complete, sharing the shutdown timeout
+func New(cfg *config.Config, handler *httpadapter.Handler, shutdownHooks ...ShutdownHook) *HTTPServer {
 	mux := http.NewServeMux()
 	httpadapter.RegisterRoutes(mux, handler)
 
@@ -29,9 +35,10 @@ func New(cfg *config.Config, handler *httpadapter.Handler, _ interface{}) *HTTPS
 	}
 
 	return &HTTPServer{
-		server:  server,
-		config:  cfg,
-		handler: handler,
+		server:        server,
+		config:        cfg,
+		handler:       handler,
+		shutdownHooks: shutdownHooks,
 	}
 }
 
@@ -59,6 +66,17 @@ func (s *HTTPServer) Start() error {
 		return err
 	}
 
+	var hookErrs []error
+	for _, hook := range s.shutdownHooks {
+		if err := hook(ctx); err != nil {
+			logger.Error("Shutdown hook failed", "error", err)
+			hookErrs = append(hookErrs, err)
+		}
+	}
+	if len(hookErrs) > 0 {
+		return errors.Join(hookErrs...)
+	}
+
 	logger.Info("Server exited")
 	return nil
 }
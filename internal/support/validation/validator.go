@@ -45,6 +45,10 @@ func New() *Validator {
 	validate.RegisterValidation("environment", validateEnvironment)
 	validate.RegisterValidation("log_level", validateLogLevel)
 	validate.RegisterValidation("mode", validateMode)
+	validate.RegisterValidation("storage", validateStorage)
+	validate.RegisterValidation("event_bus_mode", validateEventBusMode)
+	validate.RegisterValidation("sequence_policy", validateSequencePolicy)
+	validate.RegisterValidation("reroute_policy", validateReroutePolicy)
 	validate.RegisterValidation("phone_number", validatePhoneNumber)
 	validate.RegisterValidation("postal_code", validatePostalCode)
 	validate.RegisterValidation("currency", validateCurrency)
@@ -187,6 +191,33 @@ func validateMode(fl validator.FieldLevel) bool {
 	return false
 }
 
+func validateStorage(fl validator.FieldLevel) bool {
+	storage := fl.Field().String()
+	validStorages := []string{"memory", "postgres", "sqlite"}
+
+	for _, validStorage := range validStorages {
+		if storage == validStorage {
+			return true
+		}
+	}
+	return false
+}
+
+func validateEventBusMode(fl validator.FieldLevel) bool {
+	mode := fl.Field().String()
+	return mode == "sync" || mode == "async"
+}
+
+func validateSequencePolicy(fl validator.FieldLevel) bool {
+	policy := fl.Field().String()
+	return policy == "strict" || policy == "lenient"
+}
+
+func validateReroutePolicy(fl validator.FieldLevel) bool {
+	policy := fl.Field().String()
+	return policy == "worklist" || policy == "auto_assign"
+}
+
 func validatePhoneNumber(fl validator.FieldLevel) bool {
 	phone := strings.TrimSpace(fl.Field().String())
 	if phone == "" {
@@ -0,0 +1,14 @@
+package trackingprimary
+
+import (
+	"context"
+	"go_hex/internal/tracking/trackingdomain"
+)
+
+// CargoTimelineService defines the primary port for the tracking view of a cargo
+type CargoTimelineService interface {
+	// GetCargoTimeline combines the booking, itinerary and handling history of a cargo into its timeline
+	// Fails with trackingdomain.CargoNotFoundError if the cargo is not booked. The caller needs the permissions to
+	// view both the cargo and its handling.
+	GetCargoTimeline(ctx context.Context, trackingId string) (trackingdomain.CargoTimeline, error)
+}
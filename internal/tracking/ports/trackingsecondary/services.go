@@ -0,0 +1,23 @@
+package trackingsecondary
+
+import (
+	"context"
+	"go_hex/internal/tracking/trackingdomain"
+)
+
+// CargoBookingService defines the secondary port for reading bookings from the booking context
+type CargoBookingService interface {
+	// GetBookedCargo retrieves the booking and assigned itinerary of a cargo
+	// Fails with trackingdomain.CargoNotFoundError if the cargo is not booked.
+	GetBookedCargo(ctx context.Context, trackingId string) (trackingdomain.BookedCargo, error)
+
+	// PlaceHandledEvents places a cargo's handling events, ordered by trackingdomain.SortHandledEvents, on its itinerary
+	// as the booking context does: the leg each loaded or unloaded the cargo for, and whether the itinerary plans it
+	PlaceHandledEvents(cargo trackingdomain.BookedCargo, events []trackingdomain.HandledEvent) []trackingdomain.TimelineEvent
+}
+
+// HandlingHistoryService defines the secondary port for reading handling history from the handling context
+type HandlingHistoryService interface {
+	// GetHandlingHistory retrieves the handling events registered for a cargo
+	GetHandlingHistory(ctx context.Context, trackingId string) ([]trackingdomain.HandledEvent, error)
+}
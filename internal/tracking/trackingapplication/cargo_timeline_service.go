@@ -0,0 +1,71 @@
+package trackingapplication
+
+import (
+	"context"
+	"fmt"
+	"go_hex/internal/support/auth"
+	"go_hex/internal/tracking/ports/trackingprimary"
+	"go_hex/internal/tracking/ports/trackingsecondary"
+	"go_hex/internal/tracking/trackingdomain"
+	"log/slog"
+	"time"
+)
+
+// CargoTimelineService implements the primary port for cargo timelines
+// It checks that the caller may track cargo, and the booking and handling contexts check the caller's claims again
+// when it reads from them.
+type CargoTimelineService struct {
+	bookingService  trackingsecondary.CargoBookingService
+	handlingHistory trackingsecondary.HandlingHistoryService
+	logger          *slog.Logger
+}
+
+// Ensure CargoTimelineService implements the primary port
+var _ trackingprimary.CargoTimelineService = (*CargoTimelineService)(nil)
+
+// NewCargoTimelineService creates a new CargoTimelineService
+func NewCargoTimelineService(
+	bookingService trackingsecondary.CargoBookingService,
+	handlingHistory trackingsecondary.HandlingHistoryService,
+	logger *slog.Logger,
+) *CargoTimelineService {
+	return &CargoTimelineService{
+		bookingService:  bookingService,
+		handlingHistory: handlingHistory,
+		logger:          logger,
+	}
+}
+
+// GetCargoTimeline combines the booking, itinerary and handling history of a cargo into its timeline
+func (s *CargoTimelineService) GetCargoTimeline(ctx context.Context, trackingId string) (trackingdomain.CargoTimeline, error) {
+	s.logger.Debug("Building cargo timeline", "trackingId", trackingId)
+
+	// Check permissions
+	claims, err := auth.ExtractClaims(ctx)
+	if err != nil {
+		s.logger.Warn("Unauthorized cargo timeline access attempt", "trackingId", trackingId, "error", err)
+		return trackingdomain.CargoTimeline{}, fmt.Errorf("unauthorized cargo timeline access: %w", err)
+	}
+	if err := RequireTrackingPermission(claims); err != nil {
+		s.logger.Warn("Unauthorized cargo timeline access attempt", "trackingId", trackingId, "error", err)
+		return trackingdomain.CargoTimeline{}, fmt.Errorf("unauthorized cargo timeline access: %w", err)
+	}
+
+	cargo, err := s.bookingService.GetBookedCargo(ctx, trackingId)
+	if err != nil {
+		s.logger.Warn("Failed to load booked cargo", "trackingId", trackingId, "error", err)
+		return trackingdomain.CargoTimeline{}, fmt.Errorf("failed to load cargo %s: %w", trackingId, err)
+	}
+
+	events, err := s.handlingHistory.GetHandlingHistory(ctx, trackingId)
+	if err != nil {
+		s.logger.Warn("Failed to load handling history", "trackingId", trackingId, "error", err)
+		return trackingdomain.CargoTimeline{}, fmt.Errorf("failed to load handling history of cargo %s: %w", trackingId, err)
+	}
+
+	// The booking context places the events on the itinerary, so the timeline judges them as it does
+	trackingdomain.SortHandledEvents(events)
+	placed := s.bookingService.PlaceHandledEvents(cargo, events)
+
+	return trackingdomain.NewCargoTimeline(cargo, placed, time.Now()), nil
+}
@@ -0,0 +1,134 @@
+package trackingapplication
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go_hex/internal/support/auth"
+	"go_hex/internal/tracking/trackingdomain"
+	"log/slog"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Mock implementations
+type MockCargoBookingService struct {
+	mock.Mock
+}
+
+func (m *MockCargoBookingService) GetBookedCargo(ctx context.Context, trackingId string) (trackingdomain.BookedCargo, error) {
+	args := m.Called(ctx, trackingId)
+	return args.Get(0).(trackingdomain.BookedCargo), args.Error(1)
+}
+
+func (m *MockCargoBookingService) PlaceHandledEvents(cargo trackingdomain.BookedCargo, events []trackingdomain.HandledEvent) []trackingdomain.TimelineEvent {
+	args := m.Called(cargo, events)
+	return args.Get(0).([]trackingdomain.TimelineEvent)
+}
+
+type MockHandlingHistoryService struct {
+	mock.Mock
+}
+
+func (m *MockHandlingHistoryService) GetHandlingHistory(ctx context.Context, trackingId string) ([]trackingdomain.HandledEvent, error) {
+	args := m.Called(ctx, trackingId)
+	events, _ := args.Get(0).([]trackingdomain.HandledEvent)
+	return events, args.Error(1)
+}
+
+// contextWithRoles returns a context authenticated as a caller with the given roles
+func contextWithRoles(t *testing.T, roles ...string) context.Context {
+	claims, err := auth.NewClaims("test-user", "testuser", "test@example.com", roles, nil)
+	require.NoError(t, err)
+	return context.WithValue(context.Background(), auth.ClaimsContextKey, claims)
+}
+
+func TestCargoTimelineService_GetCargoTimeline(t *testing.T) {
+	departure := time.Now().Add(-time.Hour)
+	cargo := trackingdomain.BookedCargo{
+		TrackingId:  "TEST123",
+		Origin:      "USNYC",
+		Destination: "DEHAM",
+		Legs: []trackingdomain.PlannedLeg{
+			{VoyageNumber: "V001", LoadLocation: "USNYC", UnloadLocation: "DEHAM", LoadTime: departure, UnloadTime: departure.Add(48 * time.Hour)},
+		},
+	}
+
+	t.Run("should combine booking and handling history", func(t *testing.T) {
+		// Setup
+		bookingService := &MockCargoBookingService{}
+		handlingHistory := &MockHandlingHistoryService{}
+		received := trackingdomain.HandledEvent{EventId: "event-1", Type: trackingdomain.HandlingTypeReceive, Location: "USNYC", CompletionTime: departure.Add(-time.Hour)}
+		loaded := trackingdomain.HandledEvent{EventId: "event-2", Type: trackingdomain.HandlingTypeLoad, Location: "USNYC", VoyageNumber: "V001", CompletionTime: departure}
+		bookingService.On("GetBookedCargo", mock.Anything, "TEST123").Return(cargo, nil)
+		handlingHistory.On("GetHandlingHistory", mock.Anything, "TEST123").Return([]trackingdomain.HandledEvent{loaded, received}, nil)
+		bookingService.On("PlaceHandledEvents", cargo, []trackingdomain.HandledEvent{received, loaded}).Return([]trackingdomain.TimelineEvent{
+			{HandledEvent: received, LegIndex: -1, Expected: true},
+			{HandledEvent: loaded, LegIndex: 0, Expected: true},
+		})
+		service := NewCargoTimelineService(bookingService, handlingHistory, slog.Default())
+
+		// Execute
+		timeline, err := service.GetCargoTimeline(contextWithRoles(t, "user"), "TEST123")
+
+		// Verify
+		require.NoError(t, err)
+		assert.Equal(t, cargo, timeline.Cargo)
+		require.Len(t, timeline.Events, 2)
+		assert.Equal(t, 0, timeline.Events[1].LegIndex)
+		assert.Equal(t, trackingdomain.LegStatusOnboard, timeline.Legs[0].Status)
+		assert.True(t, timeline.IsOnSchedule())
+		bookingService.AssertExpectations(t)
+		handlingHistory.AssertExpectations(t)
+	})
+
+	t.Run("should fail for cargo that is not booked", func(t *testing.T) {
+		// Setup
+		bookingService := &MockCargoBookingService{}
+		handlingHistory := &MockHandlingHistoryService{}
+		bookingService.On("GetBookedCargo", mock.Anything, "UNKNOWN").Return(trackingdomain.BookedCargo{}, trackingdomain.NewCargoNotFoundError("UNKNOWN"))
+		service := NewCargoTimelineService(bookingService, handlingHistory, slog.Default())
+
+		// Execute
+		_, err := service.GetCargoTimeline(contextWithRoles(t, "user"), "UNKNOWN")
+
+		// Verify
+		var notFound trackingdomain.CargoNotFoundError
+		assert.ErrorAs(t, err, &notFound)
+		handlingHistory.AssertNotCalled(t, "GetHandlingHistory", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should fail when handling history cannot be read", func(t *testing.T) {
+		// Setup
+		bookingService := &MockCargoBookingService{}
+		handlingHistory := &MockHandlingHistoryService{}
+		bookingService.On("GetBookedCargo", mock.Anything, "TEST123").Return(cargo, nil)
+		handlingHistory.On("GetHandlingHistory", mock.Anything, "TEST123").Return(nil, errors.New("database unavailable"))
+		service := NewCargoTimelineService(bookingService, handlingHistory, slog.Default())
+
+		// Execute
+		_, err := service.GetCargoTimeline(contextWithRoles(t, "user"), "TEST123")
+
+		// Verify
+		assert.ErrorContains(t, err, "database unavailable")
+	})
+
+	t.Run("should require the permission to track cargo", func(t *testing.T) {
+		// Setup
+		bookingService := &MockCargoBookingService{}
+		handlingHistory := &MockHandlingHistoryService{}
+		service := NewCargoTimelineService(bookingService, handlingHistory, slog.Default())
+
+		// Execute
+		_, err := service.GetCargoTimeline(contextWithRoles(t), "TEST123")
+
+		// Verify
+		var authErr auth.AuthorizationError
+		assert.ErrorAs(t, err, &authErr)
+		bookingService.AssertNotCalled(t, "GetBookedCargo", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,22 @@
+package trackingapplication
+
+import (
+	"go_hex/internal/support/auth"
+)
+
+// RequireTrackingPermission checks if the user may follow cargo on its timeline: track the cargo and view its handling
+func RequireTrackingPermission(claims *auth.Claims) error {
+	if claims == nil {
+		return auth.NewAuthenticationError("no authentication context found")
+	}
+
+	if claims.BookingClaims == nil || !claims.BookingClaims.HasPermission(auth.PermissionTrackCargo) {
+		return auth.NewAuthorizationError("insufficient permissions for cargo tracking")
+	}
+
+	if claims.HandlingClaims == nil || !claims.HandlingClaims.HasPermission(auth.PermissionViewHandling) {
+		return auth.NewAuthorizationError("insufficient permissions for cargo tracking")
+	}
+
+	return nil
+}
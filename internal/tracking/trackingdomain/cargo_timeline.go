@@ -0,0 +1,259 @@
+package trackingdomain
+
+import (
+	"sort"
+	"time"
+)
+
+// Handling event types as reported by the handling context
+const (
+	HandlingTypeReceive = "RECEIVE"
+	HandlingTypeLoad    = "LOAD"
+	HandlingTypeUnload  = "UNLOAD"
+	HandlingTypeClaim   = "CLAIM"
+	HandlingTypeCustoms = "CUSTOMS"
+)
+
+// BookedCargo is what the tracking context knows about a booking and the route assigned to it
+type BookedCargo struct {
+	TrackingId      string
+	Origin          string // UN/LOCODE
+	Destination     string // UN/LOCODE
+	ArrivalDeadline time.Time
+	RoutingStatus   string
+	TransportStatus string
+
+	// BookedAt is when the cargo was booked and RoutedAt when its itinerary was assigned; zero where not known
+	BookedAt time.Time
+	RoutedAt time.Time
+
+	// Legs is the assigned itinerary in travel order; empty while the cargo is not routed
+	Legs []PlannedLeg
+
+	// EstimatedTimeOfArrival is the booking context's estimate from the handling progress; nil when it has none
+	EstimatedTimeOfArrival *time.Time
+}
+
+// IsRouted reports whether an itinerary has been assigned to the cargo
+func (c BookedCargo) IsRouted() bool {
+	return len(c.Legs) > 0
+}
+
+// ArrivalSlack returns how long before its arrival deadline the cargo is estimated to arrive;
+// negative when it is estimated to be late, and false without an estimate
+func (c BookedCargo) ArrivalSlack() (time.Duration, bool) {
+	if c.EstimatedTimeOfArrival == nil {
+		return 0, false
+	}
+	return c.ArrivalDeadline.Sub(*c.EstimatedTimeOfArrival), true
+}
+
+// PlannedLeg is one leg of the itinerary assigned to a cargo
+type PlannedLeg struct {
+	VoyageNumber   string
+	LoadLocation   string // UN/LOCODE
+	UnloadLocation string // UN/LOCODE
+	LoadTime       time.Time
+	UnloadTime     time.Time
+}
+
+// Milestone types of the booking steps placed on the timeline
+const (
+	MilestoneBooked = "BOOKED"
+	MilestoneRouted = "ROUTED"
+)
+
+// Milestone is a step of the booking itself on the timeline, such as the booking or the assignment of the itinerary
+type Milestone struct {
+	Type string
+	Time time.Time
+}
+
+// HandledEvent is a handling event registered for a cargo
+type HandledEvent struct {
+	EventId          string
+	Type             string
+	Location         string // UN/LOCODE
+	VoyageNumber     string
+	CompletionTime   time.Time
+	RegistrationTime time.Time
+}
+
+// LegStatus tells how far the cargo has travelled along a leg
+type LegStatus string
+
+const (
+	LegStatusPending   LegStatus = "PENDING"
+	LegStatusOnboard   LegStatus = "ONBOARD"
+	LegStatusCompleted LegStatus = "COMPLETED"
+)
+
+// LegProgress compares the planned times of a leg with the handling that actually happened on it
+type LegProgress struct {
+	PlannedLeg
+	Status LegStatus
+
+	// ActualLoadTime and ActualUnloadTime are the completion times of the latest load and unload on this leg; nil until handled
+	ActualLoadTime   *time.Time
+	ActualUnloadTime *time.Time
+}
+
+// TimelineEvent is a handling event placed against the itinerary
+type TimelineEvent struct {
+	HandledEvent
+
+	// LegIndex is the itinerary leg the event loaded or unloaded the cargo for; -1 for any other event
+	LegIndex int
+
+	// Expected reports whether the itinerary plans this handling; always false for cargo that is not routed
+	Expected bool
+}
+
+// Position is where a cargo is: in port at Location, or aboard VoyageNumber after being loaded at Location
+type Position struct {
+	Location     string
+	VoyageNumber string
+}
+
+// IsAboard reports whether the position is on a carrier rather than in port
+func (p Position) IsAboard() bool {
+	return p.VoyageNumber != ""
+}
+
+// CargoTimeline is the tracking view of a cargo: its booking, the progress along the assigned itinerary,
+// every handling event, and where the cargo is compared to where the itinerary says it should be
+type CargoTimeline struct {
+	Cargo BookedCargo
+
+	// Legs follows the itinerary; empty while the cargo is not routed
+	Legs []LegProgress
+
+	// Milestones are the booking and the assignment of the current itinerary in time order; a step is left
+	// out while it has not happened or when its time is not known
+	Milestones []Milestone
+
+	// Events are ordered by completion time, registration time and event ID
+	Events []TimelineEvent
+
+	// Expected is where the itinerary places the cargo at AsOf; nil while the cargo is not routed
+	Expected *Position
+
+	// Actual is where the most recent handling left the cargo; nil until the cargo is handled
+	Actual *Position
+
+	AsOf time.Time
+}
+
+// SortHandledEvents orders handling events as they happened: by completion time, then registration time, then event ID
+func SortHandledEvents(events []HandledEvent) {
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].handledBefore(events[j])
+	})
+}
+
+// handledBefore reports whether the event comes before other in the order SortHandledEvents establishes
+func (e HandledEvent) handledBefore(other HandledEvent) bool {
+	if result := e.CompletionTime.Compare(other.CompletionTime); result != 0 {
+		return result < 0
+	}
+	if result := e.RegistrationTime.Compare(other.RegistrationTime); result != 0 {
+		return result < 0
+	}
+	return e.EventId < other.EventId
+}
+
+// NewCargoTimeline builds the timeline of a cargo from its booking and its handling events, placed on its itinerary
+// by the booking context, as of the given time
+func NewCargoTimeline(cargo BookedCargo, events []TimelineEvent, asOf time.Time) CargoTimeline {
+	ordered := make([]TimelineEvent, len(events))
+	copy(ordered, events)
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].handledBefore(ordered[j].HandledEvent)
+	})
+
+	timeline := CargoTimeline{
+		Cargo:      cargo,
+		Legs:       make([]LegProgress, len(cargo.Legs)),
+		Milestones: cargo.milestones(),
+		Events:     make([]TimelineEvent, len(ordered)),
+		AsOf:       asOf,
+	}
+
+	for i, leg := range cargo.Legs {
+		timeline.Legs[i] = LegProgress{PlannedLeg: leg, Status: LegStatusPending}
+	}
+
+	for i, event := range ordered {
+		timeline.Events[i] = event
+		if event.LegIndex >= 0 && event.LegIndex < len(timeline.Legs) {
+			timeline.Legs[event.LegIndex].record(event.HandledEvent)
+		}
+	}
+
+	if cargo.IsRouted() {
+		expected := cargo.expectedPosition(asOf)
+		timeline.Expected = &expected
+	}
+
+	if len(ordered) > 0 {
+		last := ordered[len(ordered)-1]
+		actual := Position{Location: last.Location}
+		if last.Type == HandlingTypeLoad {
+			actual.VoyageNumber = last.VoyageNumber
+		}
+		timeline.Actual = &actual
+	}
+
+	return timeline
+}
+
+// IsOnSchedule reports whether the cargo is where its itinerary places it; false while not routed or not handled
+func (t CargoTimeline) IsOnSchedule() bool {
+	return t.Expected != nil && t.Actual != nil && *t.Expected == *t.Actual
+}
+
+// milestones returns the booking steps with a known time, in time order
+func (c BookedCargo) milestones() []Milestone {
+	milestones := make([]Milestone, 0, 2)
+	if !c.BookedAt.IsZero() {
+		milestones = append(milestones, Milestone{Type: MilestoneBooked, Time: c.BookedAt})
+	}
+	if c.IsRouted() && !c.RoutedAt.IsZero() {
+		milestones = append(milestones, Milestone{Type: MilestoneRouted, Time: c.RoutedAt})
+	}
+	sort.SliceStable(milestones, func(i, j int) bool {
+		return milestones[i].Time.Before(milestones[j].Time)
+	})
+	return milestones
+}
+
+// expectedPosition places the cargo on the itinerary by the planned load and unload times
+func (c BookedCargo) expectedPosition(at time.Time) Position {
+	for _, leg := range c.Legs {
+		if at.Before(leg.LoadTime) {
+			return Position{Location: leg.LoadLocation}
+		}
+		if at.Before(leg.UnloadTime) {
+			return Position{Location: leg.LoadLocation, VoyageNumber: leg.VoyageNumber}
+		}
+	}
+	return Position{Location: c.Legs[len(c.Legs)-1].UnloadLocation}
+}
+
+// record applies a load or unload on this leg, keeping the latest of each
+func (l *LegProgress) record(event HandledEvent) {
+	completed := event.CompletionTime
+	switch event.Type {
+	case HandlingTypeLoad:
+		l.ActualLoadTime = &completed
+	case HandlingTypeUnload:
+		l.ActualUnloadTime = &completed
+	}
+
+	switch {
+	case l.ActualUnloadTime != nil && (l.ActualLoadTime == nil || !l.ActualUnloadTime.Before(*l.ActualLoadTime)):
+		l.Status = LegStatusCompleted
+	case l.ActualLoadTime != nil:
+		l.Status = LegStatusOnboard
+	}
+}
@@ -0,0 +1,166 @@
+package trackingdomain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCargoTimeline(t *testing.T) {
+	departure := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	cargo := BookedCargo{
+		TrackingId:      "TEST123",
+		Origin:          "USNYC",
+		Destination:     "SESTO",
+		ArrivalDeadline: departure.Add(14 * 24 * time.Hour),
+		RoutingStatus:   "ROUTED",
+		TransportStatus: "ONBOARD_CARRIER",
+		Legs: []PlannedLeg{
+			{VoyageNumber: "V001", LoadLocation: "USNYC", UnloadLocation: "DEHAM", LoadTime: departure, UnloadTime: departure.Add(7 * 24 * time.Hour)},
+			{VoyageNumber: "V002", LoadLocation: "DEHAM", UnloadLocation: "SESTO", LoadTime: departure.Add(8 * 24 * time.Hour), UnloadTime: departure.Add(10 * 24 * time.Hour)},
+		},
+	}
+	event := func(eventType, location, voyage string, completed time.Time) HandledEvent {
+		return HandledEvent{EventId: eventType + "-" + location, Type: eventType, Location: location, VoyageNumber: voyage,
+			CompletionTime: completed, RegistrationTime: completed.Add(time.Minute)}
+	}
+	placed := func(event HandledEvent, legIndex int, expected bool) TimelineEvent {
+		return TimelineEvent{HandledEvent: event, LegIndex: legIndex, Expected: expected}
+	}
+
+	t.Run("should record handling events on the legs they were placed on", func(t *testing.T) {
+		// Setup
+		received := event(HandlingTypeReceive, "USNYC", "", departure.Add(-time.Hour))
+		loaded := event(HandlingTypeLoad, "USNYC", "V001", departure.Add(30*time.Minute))
+		unloaded := event(HandlingTypeUnload, "DEHAM", "V001", departure.Add(7*24*time.Hour+2*time.Hour))
+		reloaded := event(HandlingTypeLoad, "DEHAM", "V002", departure.Add(8*24*time.Hour))
+
+		// Execute
+		timeline := NewCargoTimeline(cargo, []TimelineEvent{
+			placed(reloaded, 1, true), placed(received, -1, true), placed(unloaded, 0, true), placed(loaded, 0, true),
+		}, departure.Add(9*24*time.Hour))
+
+		// Verify
+		require.Len(t, timeline.Events, 4)
+		assert.Equal(t, []HandledEvent{received, loaded, unloaded, reloaded}, []HandledEvent{
+			timeline.Events[0].HandledEvent, timeline.Events[1].HandledEvent, timeline.Events[2].HandledEvent, timeline.Events[3].HandledEvent,
+		})
+		assert.Equal(t, []int{-1, 0, 0, 1}, []int{
+			timeline.Events[0].LegIndex, timeline.Events[1].LegIndex, timeline.Events[2].LegIndex, timeline.Events[3].LegIndex,
+		})
+		for _, timelineEvent := range timeline.Events {
+			assert.True(t, timelineEvent.Expected, timelineEvent.EventId)
+		}
+
+		require.Len(t, timeline.Legs, 2)
+		assert.Equal(t, LegStatusCompleted, timeline.Legs[0].Status)
+		assert.Equal(t, loaded.CompletionTime, *timeline.Legs[0].ActualLoadTime)
+		assert.Equal(t, unloaded.CompletionTime, *timeline.Legs[0].ActualUnloadTime)
+		assert.Equal(t, LegStatusOnboard, timeline.Legs[1].Status)
+		assert.Nil(t, timeline.Legs[1].ActualUnloadTime)
+
+		assert.Equal(t, &Position{Location: "DEHAM", VoyageNumber: "V002"}, timeline.Expected)
+		assert.Equal(t, &Position{Location: "DEHAM", VoyageNumber: "V002"}, timeline.Actual)
+		assert.True(t, timeline.IsOnSchedule())
+	})
+
+	t.Run("should keep handling the itinerary does not plan off its legs", func(t *testing.T) {
+		// Setup
+		received := event(HandlingTypeReceive, "USNYC", "", departure.Add(-time.Hour))
+		wrongVoyage := event(HandlingTypeLoad, "USNYC", "V999", departure.Add(time.Hour))
+
+		// Execute
+		timeline := NewCargoTimeline(cargo, []TimelineEvent{placed(received, -1, true), placed(wrongVoyage, -1, false)}, departure.Add(2*time.Hour))
+
+		// Verify
+		assert.True(t, timeline.Events[0].Expected)
+		assert.False(t, timeline.Events[1].Expected)
+		assert.Equal(t, -1, timeline.Events[1].LegIndex)
+		assert.Equal(t, LegStatusPending, timeline.Legs[0].Status)
+		assert.Equal(t, &Position{Location: "USNYC", VoyageNumber: "V001"}, timeline.Expected)
+		assert.Equal(t, &Position{Location: "USNYC", VoyageNumber: "V999"}, timeline.Actual)
+		assert.False(t, timeline.IsOnSchedule())
+	})
+
+	t.Run("should order events handled and registered at the same time by event ID", func(t *testing.T) {
+		// Setup
+		first := event(HandlingTypeCustoms, "USNYC", "", departure.Add(-time.Hour))
+		second := first
+		first.EventId, second.EventId = "event-a", "event-b"
+
+		// Execute
+		timeline := NewCargoTimeline(cargo, []TimelineEvent{placed(second, -1, true), placed(first, -1, true)}, departure)
+
+		// Verify
+		require.Len(t, timeline.Events, 2)
+		assert.Equal(t, "event-a", timeline.Events[0].EventId)
+		assert.Equal(t, "event-b", timeline.Events[1].EventId)
+	})
+
+	t.Run("should place cargo by the itinerary schedule", func(t *testing.T) {
+		tests := map[string]struct {
+			at       time.Time
+			expected Position
+		}{
+			"before departure":      {departure.Add(-time.Hour), Position{Location: "USNYC"}},
+			"aboard first voyage":   {departure.Add(time.Hour), Position{Location: "USNYC", VoyageNumber: "V001"}},
+			"between legs":          {departure.Add(7*24*time.Hour + time.Hour), Position{Location: "DEHAM"}},
+			"after final unloading": {departure.Add(11 * 24 * time.Hour), Position{Location: "SESTO"}},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				timeline := NewCargoTimeline(cargo, nil, test.at)
+
+				assert.Equal(t, &test.expected, timeline.Expected)
+				assert.Nil(t, timeline.Actual)
+			})
+		}
+	})
+
+	t.Run("should have no expected position for cargo that is not routed", func(t *testing.T) {
+		// Setup
+		unrouted := cargo
+		unrouted.Legs = nil
+		received := event(HandlingTypeReceive, "USNYC", "", departure)
+
+		// Execute
+		timeline := NewCargoTimeline(unrouted, []TimelineEvent{placed(received, -1, false)}, departure.Add(time.Hour))
+
+		// Verify
+		assert.Empty(t, timeline.Legs)
+		assert.Nil(t, timeline.Expected)
+		assert.Equal(t, &Position{Location: "USNYC"}, timeline.Actual)
+		assert.False(t, timeline.Events[0].Expected)
+	})
+
+	t.Run("should place the booking and the route assignment on the timeline", func(t *testing.T) {
+		// Setup
+		booked := cargo
+		booked.BookedAt = departure.Add(-3 * 24 * time.Hour)
+		booked.RoutedAt = departure.Add(-2 * 24 * time.Hour)
+
+		// Execute
+		timeline := NewCargoTimeline(booked, nil, departure)
+
+		// Verify
+		assert.Equal(t, []Milestone{
+			{Type: MilestoneBooked, Time: booked.BookedAt},
+			{Type: MilestoneRouted, Time: booked.RoutedAt},
+		}, timeline.Milestones)
+	})
+
+	t.Run("should leave out booking steps that have not happened or have no known time", func(t *testing.T) {
+		// Setup
+		unrouted := cargo
+		unrouted.Legs = nil
+		unrouted.RoutedAt = departure
+
+		// Execute
+		timeline := NewCargoTimeline(unrouted, nil, departure)
+
+		// Verify
+		assert.Empty(t, timeline.Milestones)
+	})
+}
@@ -0,0 +1,21 @@
+package trackingdomain
+
+import (
+	"fmt"
+
+	"go_hex/internal/support/errors"
+)
+
+// CargoNotFoundError reports that the booking context knows no cargo with the given tracking ID
+type CargoNotFoundError struct {
+	errors.BaseError
+	TrackingId string
+}
+
+// NewCargoNotFoundError creates a new cargo not found error
+func NewCargoNotFoundError(trackingId string) CargoNotFoundError {
+	return CargoNotFoundError{
+		BaseError:  errors.NewBaseError(fmt.Sprintf("cargo with tracking ID %s not found", trackingId), nil),
+		TrackingId: trackingId,
+	}
+}
@@ -0,0 +1,74 @@
+package trackingmock
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"go_hex/internal/support/auth"
+	"go_hex/internal/tracking/ports/trackingprimary"
+	"go_hex/internal/tracking/ports/trackingsecondary"
+	"go_hex/internal/tracking/trackingapplication"
+	"go_hex/internal/tracking/trackingdomain"
+)
+
+// MockTrackingApplication embeds the real application service but provides access to the timelines of test data.
+// Tracking keeps no data of its own, so it reads the cargo and handling the other mock applications populated.
+type MockTrackingApplication struct {
+	*trackingapplication.CargoTimelineService
+	logger *slog.Logger
+}
+
+// NewMockTrackingApplication creates a mock tracking application with embedded real application service
+func NewMockTrackingApplication(
+	bookingService trackingsecondary.CargoBookingService,
+	handlingHistory trackingsecondary.HandlingHistoryService,
+	logger *slog.Logger,
+) *MockTrackingApplication {
+	return &MockTrackingApplication{
+		CargoTimelineService: trackingapplication.NewCargoTimelineService(bookingService, handlingHistory, logger),
+		logger:               logger,
+	}
+}
+
+// CollectTestTimelines builds the timelines of test cargo through the application layer
+func (m *MockTrackingApplication) CollectTestTimelines(ctx context.Context, trackingIds []string) ([]trackingdomain.CargoTimeline, error) {
+	m.logger.Info("Collecting test cargo timelines through tracking application", "cargos", len(trackingIds))
+
+	// Create authenticated context for internal operations
+	testCtx := m.createTestContext(ctx)
+
+	timelines := make([]trackingdomain.CargoTimeline, 0, len(trackingIds))
+	for _, trackingId := range trackingIds {
+		timeline, err := m.GetCargoTimeline(testCtx, trackingId)
+		if err != nil {
+			m.logger.Error("Failed to build test cargo timeline", "error", err, "trackingId", trackingId)
+			return nil, fmt.Errorf("failed to build timeline of cargo %s: %w", trackingId, err)
+		}
+
+		m.logger.Info("Collected test cargo timeline",
+			"trackingId", trackingId,
+			"legs", len(timeline.Legs),
+			"events", len(timeline.Events))
+		timelines = append(timelines, timeline)
+	}
+
+	return timelines, nil
+}
+
+// createTestContext creates an authenticated context for test operations
+func (m *MockTrackingApplication) createTestContext(ctx context.Context) context.Context {
+	// Create test claims with admin permissions
+	claims, _ := auth.NewClaims(
+		"test-user",
+		"test-system",
+		"test@example.com",
+		[]string{string(auth.RoleAdmin)},
+		map[string]string{"test": "true"},
+	)
+
+	return context.WithValue(ctx, auth.ClaimsContextKey, claims)
+}
+
+// Ensure MockTrackingApplication implements the primary port
+var _ trackingprimary.CargoTimelineService = (*MockTrackingApplication)(nil)
@@ -8,11 +8,13 @@ import (
 	"time"
 
 	"go_hex/internal/adapters/driven/event_bus"
+	"go_hex/internal/adapters/driven/in_memory_handling_quarantine"
 	"go_hex/internal/adapters/integration"
 
 	"go_hex/internal/booking/bookingapplication"
 	"go_hex/internal/handling/handlingapplication"
 	"go_hex/internal/handling/handlingdomain"
+	"go_hex/internal/support/outbox"
 
 	"go_hex/test/testdata"
 )
@@ -47,18 +49,28 @@ func TestCargoShippingSystemIntegrationWithGeneratedData(t *testing.T) {
 	// Create adapter for Booking->Routing integration (synchronous, customer-supplier)
 	routingAdapter := integration.NewRoutingServiceAdapter(testEnv.RoutingService)
 
+	// Create adapter for Booking->Handling history queries (synchronous, ACL)
+	handlingHistoryAdapter := integration.NewHandlingHistoryAdapter(
+		handlingapplication.NewHandlingEventQueryService(testEnv.HandlingEventRepo, logger),
+	)
+
 	// Create Booking context application service
 	bookingService := bookingapplication.NewBookingApplicationService(
 		testEnv.CargoRepo,
 		routingAdapter, // Synchronous integration with routing
-		eventBus,       // Event publisher
+		integration.NewVoyageScheduleAdapter(testEnv.RoutingService), // Synchronous read of current voyage schedules
+		handlingHistoryAdapter, // Synchronous integration with handling
 		logger,
 	)
 
 	// Create Handling context application services
 	handlingReportService := handlingapplication.NewHandlingReportService(
 		testEnv.HandlingEventRepo,
-		eventBus, // Event publisher for handling events
+		in_memory_handling_quarantine.NewInMemoryHandlingQuarantine(),
+		integration.NewCargoBookingAdapter(bookingService),
+		integration.NewShippingNetworkAdapter(testEnv.RoutingService),
+		handlingapplication.SequencePolicyStrict,
+		handlingdomain.DefaultTimeWindowPolicy(),
 		logger,
 	)
 
@@ -71,6 +83,9 @@ func TestCargoShippingSystemIntegrationWithGeneratedData(t *testing.T) {
 		handlingToBookingHandler.HandleCargoWasHandled,
 	)
 
+	// Relay the recorded handling events to the event bus while the scenarios run
+	startRelay(t, testEnv.Outbox, eventBus, logger)
+
 	// Create scenario executor
 	executor := testdata.NewScenarioExecutor(testEnv)
 
@@ -110,18 +125,28 @@ func TestSpecificCargoScenario(t *testing.T) {
 	// Create adapter for Booking->Routing integration
 	routingAdapter := integration.NewRoutingServiceAdapter(testEnv.RoutingService)
 
+	// Create adapter for Booking->Handling history queries
+	handlingHistoryAdapter := integration.NewHandlingHistoryAdapter(
+		handlingapplication.NewHandlingEventQueryService(testEnv.HandlingEventRepo, logger),
+	)
+
 	// Create Booking context application service
 	bookingService := bookingapplication.NewBookingApplicationService(
 		testEnv.CargoRepo,
 		routingAdapter,
-		eventBus,
+		integration.NewVoyageScheduleAdapter(testEnv.RoutingService),
+		handlingHistoryAdapter,
 		logger,
 	)
 
 	// Create Handling context application services
 	handlingReportService := handlingapplication.NewHandlingReportService(
 		testEnv.HandlingEventRepo,
-		eventBus,
+		in_memory_handling_quarantine.NewInMemoryHandlingQuarantine(),
+		integration.NewCargoBookingAdapter(bookingService),
+		integration.NewShippingNetworkAdapter(testEnv.RoutingService),
+		handlingapplication.SequencePolicyStrict,
+		handlingdomain.DefaultTimeWindowPolicy(),
 		logger,
 	)
 
@@ -132,6 +157,9 @@ func TestSpecificCargoScenario(t *testing.T) {
 		handlingToBookingHandler.HandleCargoWasHandled,
 	)
 
+	// Relay the recorded handling events to the event bus while the scenarios run
+	startRelay(t, testEnv.Outbox, eventBus, logger)
+
 	// Get the first generated scenario
 	scenarios := testEnv.GetTestScenarios()
 	if len(scenarios) == 0 {
@@ -175,17 +203,25 @@ func TestStressWithMultipleDataSets(t *testing.T) {
 			// Create minimal setup for this test
 			eventBus := event_bus.NewInMemoryEventBus(logger)
 			routingAdapter := integration.NewRoutingServiceAdapter(testEnv.RoutingService)
+			handlingHistoryAdapter := integration.NewHandlingHistoryAdapter(
+				handlingapplication.NewHandlingEventQueryService(testEnv.HandlingEventRepo, logger),
+			)
 
 			bookingService := bookingapplication.NewBookingApplicationService(
 				testEnv.CargoRepo,
 				routingAdapter,
-				eventBus,
+				integration.NewVoyageScheduleAdapter(testEnv.RoutingService),
+				handlingHistoryAdapter,
 				logger,
 			)
 
 			handlingReportService := handlingapplication.NewHandlingReportService(
 				testEnv.HandlingEventRepo,
-				eventBus,
+				in_memory_handling_quarantine.NewInMemoryHandlingQuarantine(),
+				integration.NewCargoBookingAdapter(bookingService),
+				integration.NewShippingNetworkAdapter(testEnv.RoutingService),
+				handlingapplication.SequencePolicyStrict,
+				handlingdomain.DefaultTimeWindowPolicy(),
 				logger,
 			)
 
@@ -195,6 +231,9 @@ func TestStressWithMultipleDataSets(t *testing.T) {
 				handlingToBookingHandler.HandleCargoWasHandled,
 			)
 
+			// Relay the recorded handling events to the event bus while the scenarios run
+			startRelay(t, testEnv.Outbox, eventBus, logger)
+
 			// Run scenarios for this iteration
 			executor := testdata.NewScenarioExecutor(testEnv)
 			err = executor.ExecuteAllScenarios(ctx, bookingService, handlingReportService)
@@ -207,3 +246,12 @@ func TestStressWithMultipleDataSets(t *testing.T) {
 		})
 	}
 }
+
+// startRelay relays the events recorded in eventOutbox to eventBus until the test ends, polling often enough for
+// the scenarios' short pauses between handling reports
+func startRelay(t *testing.T, eventOutbox outbox.Store, eventBus *event_bus.InMemoryEventBus, logger *slog.Logger) {
+	config := outbox.DefaultRelayConfig()
+	config.PollInterval = 20 * time.Millisecond
+	relay := outbox.NewRelay(eventOutbox, eventBus, newEventRegistry(), config, logger)
+	go relay.Run(t.Context())
+}
@@ -2,6 +2,7 @@ package integration
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"os"
 	"testing"
@@ -9,17 +10,23 @@ import (
 
 	"go_hex/internal/adapters/driven/event_bus"
 	"go_hex/internal/adapters/driven/in_memory_cargo_repo"
+	"go_hex/internal/adapters/driven/in_memory_handling_quarantine"
 	"go_hex/internal/adapters/driven/in_memory_handling_repo"
 	"go_hex/internal/adapters/driven/in_memory_location_repo"
+	"go_hex/internal/adapters/driven/in_memory_outbox"
 	"go_hex/internal/adapters/driven/in_memory_voyage_repo"
 	"go_hex/internal/adapters/integration"
 	"go_hex/internal/support/auth"
+	"go_hex/internal/support/outbox"
 
 	"go_hex/internal/booking/bookingapplication"
 	"go_hex/internal/booking/bookingdomain"
+	"go_hex/internal/booking/ports/bookingprimary"
 	"go_hex/internal/handling/handlingapplication"
 	"go_hex/internal/handling/handlingdomain"
+	"go_hex/internal/routing/ports/routingsecondary"
 	"go_hex/internal/routing/routingapplication"
+	"go_hex/internal/routing/routingdomain"
 )
 
 // createAuthenticatedContext creates a context with admin authentication for testing
@@ -42,37 +49,54 @@ func TestCargoShippingSystemIntegration(t *testing.T) {
 	// Create event bus for inter-context communication
 	eventBus := event_bus.NewInMemoryEventBus(logger)
 
-	// Create repositories
-	cargoRepo := in_memory_cargo_repo.NewInMemoryCargoRepository()
-	voyageRepo := in_memory_voyage_repo.NewInMemoryVoyageRepository()
+	// Create repositories, recording their domain events in a shared outbox
+	eventOutbox := in_memory_outbox.NewInMemoryOutbox()
+	cargoRepo := in_memory_cargo_repo.NewInMemoryCargoRepository(eventOutbox)
+	voyageRepo := in_memory_voyage_repo.NewInMemoryVoyageRepository(cargoRepo)
 	locationRepo := in_memory_location_repo.NewInMemoryLocationRepository()
-	handlingEventRepo := in_memory_handling_repo.NewInMemoryHandlingEventRepository()
+	handlingEventRepo := in_memory_handling_repo.NewInMemoryHandlingEventRepository(eventOutbox)
+
+	// Seed the shipping network the handling reports refer to
+	voyage := seedShippingNetwork(t, locationRepo, voyageRepo)
 
 	// Create Routing context application service
 	routingService := routingapplication.NewRoutingApplicationService(
 		voyageRepo,
 		locationRepo,
+		routingapplication.DefaultMaxLegs,
 		logger,
 	)
 
 	// Create adapter for Booking->Routing integration (synchronous, customer-supplier)
 	routingAdapter := integration.NewRoutingServiceAdapter(routingService)
 
+	// Create adapter for Booking->Handling history queries (synchronous, ACL)
+	handlingQueryService := handlingapplication.NewHandlingEventQueryService(handlingEventRepo, logger)
+	handlingHistoryAdapter := integration.NewHandlingHistoryAdapter(handlingQueryService)
+
 	// Create Booking context application service
 	bookingService := bookingapplication.NewBookingApplicationService(
 		cargoRepo,
 		routingAdapter, // Synchronous integration with routing
-		eventBus,       // Event publisher
+		integration.NewVoyageScheduleAdapter(routingService), // Synchronous read of current voyage schedules
+		handlingHistoryAdapter,                               // Synchronous integration with handling
 		logger,
 	)
 
 	// Create Handling context application services
 	handlingReportService := handlingapplication.NewHandlingReportService(
 		handlingEventRepo,
-		eventBus, // Event publisher for handling events
+		in_memory_handling_quarantine.NewInMemoryHandlingQuarantine(),
+		integration.NewCargoBookingAdapter(bookingService),    // Synchronous check of handled cargo
+		integration.NewShippingNetworkAdapter(routingService), // Synchronous check of locations and voyages
+		handlingapplication.SequencePolicyStrict,
+		handlingdomain.DefaultTimeWindowPolicy(),
 		logger,
 	)
 
+	// Relay the recorded events to the event bus
+	relay := outbox.NewRelay(eventOutbox, eventBus, newEventRegistry(), outbox.DefaultRelayConfig(), logger)
+
 	// Set up event-driven integration: Handling->Booking (asynchronous, ACL)
 	handlingToBookingHandler := integration.NewHandlingToBookingEventHandler(bookingService, logger)
 
@@ -95,7 +119,7 @@ func TestCargoShippingSystemIntegration(t *testing.T) {
 
 	// Test 2: Request route candidates (Booking->Routing synchronous integration)
 	t.Log("Test 2: Requesting route candidates")
-	candidates, err := bookingService.RequestRouteCandidates(ctx, cargo.GetTrackingId())
+	candidates, err := bookingService.RequestRouteCandidates(ctx, cargo.GetTrackingId(), bookingdomain.RankingCriteria{})
 	if err != nil {
 		t.Fatalf("Failed to get route candidates: %v", err)
 	}
@@ -104,7 +128,7 @@ func TestCargoShippingSystemIntegration(t *testing.T) {
 	if len(candidates) > 0 {
 		// Test 3: Assign the first route candidate
 		t.Log("Test 3: Assigning route to cargo")
-		err = bookingService.AssignRouteToCargo(ctx, cargo.GetTrackingId(), candidates[0])
+		err = bookingService.AssignRouteToCargo(ctx, cargo.GetTrackingId(), candidates[0], bookingprimary.AnyVersion)
 		if err != nil {
 			t.Fatalf("Failed to assign route: %v", err)
 		}
@@ -121,24 +145,34 @@ func TestCargoShippingSystemIntegration(t *testing.T) {
 		t.Log("Cargo itinerary confirmed")
 	}
 
-	// Test 4: Submit handling report (Handling->Booking asynchronous integration)
-	t.Log("Test 4: Submitting handling report")
-	handlingReport := handlingdomain.HandlingReport{
-		TrackingId:     cargo.GetTrackingId().String(),
-		EventType:      string(handlingdomain.HandlingEventTypeLoad),
-		Location:       "SESTO",
-		VoyageNumber:   "V001",
-		CompletionTime: time.Now().Format(time.RFC3339),
+	// Test 4: Submit handling reports (Handling->Booking asynchronous integration)
+	t.Log("Test 4: Submitting handling reports")
+	handlingReports := []handlingdomain.HandlingReport{
+		{
+			TrackingId:     cargo.GetTrackingId().String(),
+			EventType:      string(handlingdomain.HandlingEventTypeReceive),
+			Location:       "SESTO",
+			CompletionTime: time.Now().Add(-time.Hour).Format(time.RFC3339),
+		},
+		{
+			TrackingId:     cargo.GetTrackingId().String(),
+			EventType:      string(handlingdomain.HandlingEventTypeLoad),
+			Location:       "SESTO",
+			VoyageNumber:   voyage.GetVoyageNumber().String(),
+			CompletionTime: time.Now().Format(time.RFC3339),
+		},
 	}
 
-	err = handlingReportService.SubmitHandlingReport(ctx, handlingReport)
-	if err != nil {
-		t.Fatalf("Failed to submit handling report: %v", err)
+	for _, handlingReport := range handlingReports {
+		_, err = handlingReportService.SubmitHandlingReport(ctx, handlingReport)
+		if err != nil {
+			t.Fatalf("Failed to submit %s handling report: %v", handlingReport.EventType, err)
+		}
 	}
-	t.Log("Handling report submitted successfully")
+	t.Log("Handling reports submitted successfully")
 
-	// Small delay to allow event processing
-	time.Sleep(100 * time.Millisecond)
+	// Deliver the recorded handling events
+	relay.DispatchPending()
 
 	// Test 5: Verify cargo delivery status was updated via event integration
 	t.Log("Test 5: Verifying cargo delivery status update")
@@ -156,3 +190,101 @@ func TestCargoShippingSystemIntegration(t *testing.T) {
 
 	t.Log("Integration test completed successfully!")
 }
+
+func TestHandlingReportForCancelledCargoIsRejected(t *testing.T) {
+	// Set up logger
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+	// Create repositories
+	eventOutbox := in_memory_outbox.NewInMemoryOutbox()
+	cargoRepo := in_memory_cargo_repo.NewInMemoryCargoRepository(eventOutbox)
+	voyageRepo := in_memory_voyage_repo.NewInMemoryVoyageRepository(cargoRepo)
+	locationRepo := in_memory_location_repo.NewInMemoryLocationRepository()
+	handlingEventRepo := in_memory_handling_repo.NewInMemoryHandlingEventRepository(eventOutbox)
+	seedShippingNetwork(t, locationRepo, voyageRepo)
+
+	// Create the application services
+	routingService := routingapplication.NewRoutingApplicationService(voyageRepo, locationRepo, routingapplication.DefaultMaxLegs, logger)
+	bookingService := bookingapplication.NewBookingApplicationService(
+		cargoRepo,
+		integration.NewRoutingServiceAdapter(routingService),
+		integration.NewVoyageScheduleAdapter(routingService),
+		integration.NewHandlingHistoryAdapter(handlingapplication.NewHandlingEventQueryService(handlingEventRepo, logger)),
+		logger,
+	)
+	handlingReportService := handlingapplication.NewHandlingReportService(
+		handlingEventRepo,
+		in_memory_handling_quarantine.NewInMemoryHandlingQuarantine(),
+		integration.NewCargoBookingAdapter(bookingService),
+		integration.NewShippingNetworkAdapter(routingService),
+		handlingapplication.SequencePolicyStrict,
+		handlingdomain.DefaultTimeWindowPolicy(),
+		logger,
+	)
+
+	ctx := createAuthenticatedContext()
+
+	// Book and cancel a cargo
+	futureDeadline := time.Now().Add(30 * 24 * time.Hour).Format(time.RFC3339)
+	cargo, err := bookingService.BookNewCargo(ctx, "SESTO", "NLRTM", futureDeadline)
+	if err != nil {
+		t.Fatalf("Failed to book cargo: %v", err)
+	}
+	if err := bookingService.CancelCargo(ctx, cargo.GetTrackingId(), "customer withdrew", bookingprimary.AnyVersion); err != nil {
+		t.Fatalf("Failed to cancel cargo: %v", err)
+	}
+
+	// Report the cancelled cargo as received
+	_, err = handlingReportService.SubmitHandlingReport(ctx, handlingdomain.HandlingReport{
+		TrackingId:     cargo.GetTrackingId().String(),
+		EventType:      string(handlingdomain.HandlingEventTypeReceive),
+		Location:       "SESTO",
+		CompletionTime: time.Now().Add(-time.Hour).Format(time.RFC3339),
+	})
+
+	// Verify the report is rejected as referring to cargo that is not booked
+	var referenceErr handlingdomain.UnknownReferenceError
+	if !errors.As(err, &referenceErr) {
+		t.Fatalf("Expected unknown reference error for cancelled cargo, got %v", err)
+	}
+}
+
+// newEventRegistry registers the domain events the tests subscribe to
+func newEventRegistry() *outbox.Registry {
+	registry := outbox.NewRegistry()
+	outbox.Register[handlingdomain.HandlingEventRegisteredEvent](registry)
+	return registry
+}
+
+// seedShippingNetwork stores the SESTO and NLRTM locations and a voyage sailing between them
+func seedShippingNetwork(t *testing.T, locationRepo routingsecondary.LocationRepository, voyageRepo routingsecondary.VoyageRepository) routingdomain.Voyage {
+	for _, code := range []string{"SESTO", "NLRTM"} {
+		location, err := routingdomain.NewLocation(code, code, code[:2])
+		if err != nil {
+			t.Fatalf("Failed to create location %s: %v", code, err)
+		}
+		if err := locationRepo.Store(location); err != nil {
+			t.Fatalf("Failed to store location %s: %v", code, err)
+		}
+	}
+
+	departure := time.Now().Add(48 * time.Hour)
+	movement, err := routingdomain.NewCarrierMovement(
+		routingdomain.UnLocode{Code: "SESTO"},
+		routingdomain.UnLocode{Code: "NLRTM"},
+		departure,
+		departure.Add(72*time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create carrier movement: %v", err)
+	}
+	voyage, err := routingdomain.NewVoyage([]routingdomain.CarrierMovement{movement})
+	if err != nil {
+		t.Fatalf("Failed to create voyage: %v", err)
+	}
+	if err := voyageRepo.Store(voyage); err != nil {
+		t.Fatalf("Failed to store voyage: %v", err)
+	}
+
+	return voyage
+}
@@ -7,17 +7,22 @@ import (
 	"time"
 
 	"go_hex/internal/adapters/driven/in_memory_cargo_repo"
+	"go_hex/internal/adapters/driven/in_memory_handling_quarantine"
 	"go_hex/internal/adapters/driven/in_memory_handling_repo"
 	"go_hex/internal/adapters/driven/in_memory_location_repo"
+	"go_hex/internal/adapters/driven/in_memory_outbox"
 	"go_hex/internal/adapters/driven/in_memory_voyage_repo"
-	"go_hex/internal/adapters/driven/stdout_event_publisher"
 	"go_hex/internal/adapters/integration"
 	"go_hex/internal/booking/bookingdomain"
 	"go_hex/internal/booking/bookingmock"
+	"go_hex/internal/handling/handlingapplication"
 	"go_hex/internal/handling/handlingdomain"
 	"go_hex/internal/handling/handlingmock"
+	"go_hex/internal/routing/routingapplication"
 	"go_hex/internal/routing/routingdomain"
 	"go_hex/internal/routing/routingmock"
+	"go_hex/internal/tracking/trackingdomain"
+	"go_hex/internal/tracking/trackingmock"
 )
 
 // MockTestEnvironment provides a complete test environment using mock applications
@@ -27,6 +32,7 @@ type MockTestEnvironment struct {
 	BookingApp  *bookingmock.MockBookingApplication
 	RoutingApp  *routingmock.MockRoutingApplication
 	HandlingApp *handlingmock.MockHandlingApplication
+	TrackingApp *trackingmock.MockTrackingApplication
 
 	// Repositories (clean, no mock data)
 	CargoRepo         *in_memory_cargo_repo.InMemoryCargoRepository
@@ -49,24 +55,39 @@ func NewMockTestEnvironment(seed int64, logger *slog.Logger) (*MockTestEnvironme
 	logger.Info("Creating mock test environment", "seed", seed)
 
 	// Create clean repositories (no mock data)
-	cargoRepo := in_memory_cargo_repo.NewInMemoryCargoRepository().(*in_memory_cargo_repo.InMemoryCargoRepository)
-	voyageRepo := in_memory_voyage_repo.NewInMemoryVoyageRepository().(*in_memory_voyage_repo.InMemoryVoyageRepository)
+	eventOutbox := in_memory_outbox.NewInMemoryOutbox()
+	cargoRepo := in_memory_cargo_repo.NewInMemoryCargoRepository(eventOutbox).(*in_memory_cargo_repo.InMemoryCargoRepository)
+	voyageRepo := in_memory_voyage_repo.NewInMemoryVoyageRepository(cargoRepo).(*in_memory_voyage_repo.InMemoryVoyageRepository)
 	locationRepo := in_memory_location_repo.NewInMemoryLocationRepository().(*in_memory_location_repo.InMemoryLocationRepository)
-	handlingEventRepo := in_memory_handling_repo.NewInMemoryHandlingEventRepository().(*in_memory_handling_repo.InMemoryHandlingEventRepository)
-
-	// Create event publisher
-	eventPublisher := stdout_event_publisher.NewStdoutEventPublisher()
+	handlingEventRepo := in_memory_handling_repo.NewInMemoryHandlingEventRepository(eventOutbox).(*in_memory_handling_repo.InMemoryHandlingEventRepository)
 
 	// Create mock applications with embedded real applications
-	routingApp := routingmock.NewMockRoutingApplication(voyageRepo, locationRepo, logger, seed)
+	routingApp := routingmock.NewMockRoutingApplication(voyageRepo, locationRepo, routingapplication.DefaultMaxLegs, logger, seed)
 	routingServiceAdapter := integration.NewRoutingServiceAdapter(routingApp.RoutingApplicationService)
-	bookingApp := bookingmock.NewMockBookingApplication(cargoRepo, routingServiceAdapter, eventPublisher, logger, seed)
-	handlingApp := handlingmock.NewMockHandlingApplication(handlingEventRepo, eventPublisher, logger, seed)
+	handlingQueryService := handlingapplication.NewHandlingEventQueryService(handlingEventRepo, logger)
+	handlingHistoryAdapter := integration.NewHandlingHistoryAdapter(handlingQueryService)
+	bookingApp := bookingmock.NewMockBookingApplication(cargoRepo, routingServiceAdapter, integration.NewVoyageScheduleAdapter(routingApp), handlingHistoryAdapter, logger, seed)
+	handlingApp := handlingmock.NewMockHandlingApplication(
+		handlingEventRepo,
+		in_memory_handling_quarantine.NewInMemoryHandlingQuarantine(),
+		integration.NewCargoBookingAdapter(bookingApp),
+		integration.NewShippingNetworkAdapter(routingApp),
+		handlingapplication.SequencePolicyStrict,
+		handlingdomain.DefaultTimeWindowPolicy(),
+		logger,
+		seed,
+	)
+	trackingApp := trackingmock.NewMockTrackingApplication(
+		integration.NewTrackingBookingAdapter(bookingApp),
+		integration.NewTrackingHandlingAdapter(handlingQueryService),
+		logger,
+	)
 
 	return &MockTestEnvironment{
 		BookingApp:        bookingApp,
 		RoutingApp:        routingApp,
 		HandlingApp:       handlingApp,
+		TrackingApp:       trackingApp,
 		CargoRepo:         cargoRepo,
 		VoyageRepo:        voyageRepo,
 		LocationRepo:      locationRepo,
@@ -112,18 +133,34 @@ func (env *MockTestEnvironment) PopulateTestData(ctx context.Context) error {
 		trackingIds[i] = cargo.GetTrackingId().String()
 	}
 
-	handlingScenarios := env.HandlingApp.GenerateHandlingScenarios(trackingIds, locationCodes)
+	voyageRoutes := make([]handlingmock.TestVoyageRoute, len(voyages))
+	for i, voyage := range voyages {
+		portCalls := []string{voyage.GetDepartureLocation().String()}
+		for _, movement := range voyage.GetSchedule().Movements {
+			portCalls = append(portCalls, movement.ArrivalLocation.String())
+		}
+		voyageRoutes[i] = handlingmock.TestVoyageRoute{VoyageNumber: voyage.GetVoyageNumber().String(), PortCalls: portCalls}
+	}
+
+	handlingScenarios := env.HandlingApp.GenerateHandlingScenarios(trackingIds, voyageRoutes)
 	handlingEvents, err := env.HandlingApp.PopulateTestHandlingEvents(ctx, handlingScenarios)
 	if err != nil {
 		return fmt.Errorf("failed to populate handling events: %w", err)
 	}
 
+	// Step 5: Read the resulting cargo timelines through tracking application
+	timelines, err := env.TrackingApp.CollectTestTimelines(ctx, trackingIds)
+	if err != nil {
+		return fmt.Errorf("failed to collect cargo timelines: %w", err)
+	}
+
 	// Store the generated data for reference
 	env.TestData = TestDataSnapshot{
 		Locations:      locations,
 		Voyages:        voyages,
 		Cargos:         cargos,
 		HandlingEvents: handlingEvents,
+		Timelines:      timelines,
 		Seed:           env.Seed,
 		GeneratedAt:    time.Now(),
 	}
@@ -207,6 +244,7 @@ type TestDataSnapshot struct {
 	Voyages        []routingdomain.Voyage
 	Cargos         []bookingdomain.Cargo
 	HandlingEvents []handlingdomain.HandlingEvent
+	Timelines      []trackingdomain.CargoTimeline
 	Seed           int64
 	GeneratedAt    time.Time
 }
@@ -9,6 +9,7 @@ import (
 	"go_hex/internal/adapters/driven/in_memory_cargo_repo"
 	"go_hex/internal/adapters/driven/in_memory_handling_repo"
 	"go_hex/internal/adapters/driven/in_memory_location_repo"
+	"go_hex/internal/adapters/driven/in_memory_outbox"
 	"go_hex/internal/adapters/driven/in_memory_voyage_repo"
 	"go_hex/internal/booking/bookingapplication"
 	"go_hex/internal/handling/handlingapplication"
@@ -23,6 +24,9 @@ type TestEnvironment struct {
 	LocationRepo      *in_memory_location_repo.InMemoryLocationRepository
 	HandlingEventRepo *in_memory_handling_repo.InMemoryHandlingEventRepository
 
+	// Outbox the repositories record their domain events in
+	Outbox *in_memory_outbox.InMemoryOutbox
+
 	// Application Services
 	BookingService        *bookingapplication.BookingApplicationService
 	RoutingService        *routingapplication.RoutingApplicationService
@@ -40,15 +44,17 @@ func NewTestEnvironment(seed int64, logger *slog.Logger) (*TestEnvironment, erro
 	testData := generator.GenerateCompleteTestDataSet()
 
 	// Create repositories (they auto-seed with default data)
-	cargoRepo := in_memory_cargo_repo.NewInMemoryCargoRepository().(*in_memory_cargo_repo.InMemoryCargoRepository)
-	voyageRepo := in_memory_voyage_repo.NewInMemoryVoyageRepository().(*in_memory_voyage_repo.InMemoryVoyageRepository)
+	eventOutbox := in_memory_outbox.NewInMemoryOutbox()
+	cargoRepo := in_memory_cargo_repo.NewInMemoryCargoRepository(eventOutbox).(*in_memory_cargo_repo.InMemoryCargoRepository)
+	voyageRepo := in_memory_voyage_repo.NewInMemoryVoyageRepository(cargoRepo).(*in_memory_voyage_repo.InMemoryVoyageRepository)
 	locationRepo := in_memory_location_repo.NewInMemoryLocationRepository().(*in_memory_location_repo.InMemoryLocationRepository)
-	handlingEventRepo := in_memory_handling_repo.NewInMemoryHandlingEventRepository().(*in_memory_handling_repo.InMemoryHandlingEventRepository)
+	handlingEventRepo := in_memory_handling_repo.NewInMemoryHandlingEventRepository(eventOutbox).(*in_memory_handling_repo.InMemoryHandlingEventRepository)
 
 	// Create application services (this would normally be done in main.go)
 	routingService := routingapplication.NewRoutingApplicationService(
 		voyageRepo,
 		locationRepo,
+		routingapplication.DefaultMaxLegs,
 		logger,
 	)
 
@@ -57,6 +63,7 @@ func NewTestEnvironment(seed int64, logger *slog.Logger) (*TestEnvironment, erro
 		VoyageRepo:        voyageRepo,
 		LocationRepo:      locationRepo,
 		HandlingEventRepo: handlingEventRepo,
+		Outbox:            eventOutbox,
 		RoutingService:    routingService,
 		TestData:          testData,
 		Logger:            logger,
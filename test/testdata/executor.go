@@ -43,7 +43,7 @@ func (se *ScenarioExecutor) ExecuteCargoScenario(ctx context.Context, scenario C
 	se.logger.Info("Cargo booked successfully", "tracking_id", cargo.GetTrackingId().String())
 
 	// Step 2: Request route candidates
-	candidates, err := bookingService.RequestRouteCandidates(ctx, cargo.GetTrackingId())
+	candidates, err := bookingService.RequestRouteCandidates(ctx, cargo.GetTrackingId(), bookingdomain.RankingCriteria{})
 	if err != nil {
 		return fmt.Errorf("failed to get route candidates: %w", err)
 	}
@@ -51,7 +51,7 @@ func (se *ScenarioExecutor) ExecuteCargoScenario(ctx context.Context, scenario C
 
 	// Step 3: Assign route if candidates are available
 	if len(candidates) > 0 {
-		err = bookingService.AssignRouteToCargo(ctx, cargo.GetTrackingId(), candidates[0])
+		err = bookingService.AssignRouteToCargo(ctx, cargo.GetTrackingId(), candidates[0], bookingprimary.AnyVersion)
 		if err != nil {
 			return fmt.Errorf("failed to assign route: %w", err)
 		}
@@ -89,7 +89,7 @@ func (se *ScenarioExecutor) ExecuteCargoScenario(ctx context.Context, scenario C
 			CompletionTime: eventData.CompletionTime.Format(time.RFC3339),
 		}
 
-		err = handlingService.SubmitHandlingReport(ctx, handlingReport)
+		_, err = handlingService.SubmitHandlingReport(ctx, handlingReport)
 		if err != nil {
 			return fmt.Errorf("failed to submit handling report %d: %w", i, err)
 		}
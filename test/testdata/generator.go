@@ -277,9 +277,16 @@ func (g *TestDataGenerator) generateHandlingEvents(origin, destination string, v
 		Delay:          0,
 	})
 
-	// Add LOAD event
-	if len(voyages) > 0 {
-		selectedVoyage := voyages[g.random.Intn(len(voyages))]
+	// Add LOAD event on a voyage departing from the origin, so the handling context accepts it
+	var departing []routingdomain.Voyage
+	for _, voyage := range voyages {
+		if voyage.CanCarryCargoFrom(routingdomain.UnLocode{Code: origin}) {
+			departing = append(departing, voyage)
+		}
+	}
+
+	if len(departing) > 0 {
+		selectedVoyage := departing[g.random.Intn(len(departing))]
 
 		currentTime = currentTime.Add(time.Duration(2+g.random.Intn(4)) * time.Hour)
 		events = append(events, HandlingEventData{
@@ -290,33 +297,34 @@ func (g *TestDataGenerator) generateHandlingEvents(origin, destination string, v
 			Delay:          500 * time.Millisecond, // Small delay between events
 		})
 
-		// Add UNLOAD event if voyage has movements
-		schedule := selectedVoyage.GetSchedule()
-		if len(schedule.Movements) > 0 {
-			// Pick a random movement for unloading
-			movement := schedule.Movements[g.random.Intn(len(schedule.Movements))]
-			unloadLocation := movement.ArrivalLocation.String()
+		// Pick a random later arrival of the voyage for unloading
+		movements := selectedVoyage.GetSchedule().Movements
+		loadIdx := 0
+		for movements[loadIdx].DepartureLocation.String() != origin {
+			loadIdx++
+		}
+		movement := movements[loadIdx+g.random.Intn(len(movements)-loadIdx)]
+		unloadLocation := movement.ArrivalLocation.String()
 
-			currentTime = currentTime.Add(time.Duration(4+g.random.Intn(8)) * time.Hour) // Use relative time instead of movement time
+		currentTime = currentTime.Add(time.Duration(4+g.random.Intn(8)) * time.Hour) // Use relative time instead of movement time
+		events = append(events, HandlingEventData{
+			EventType:      handlingdomain.HandlingEventTypeUnload,
+			Location:       unloadLocation,
+			VoyageNumber:   selectedVoyage.GetVoyageNumber().String(),
+			CompletionTime: currentTime,
+			Delay:          1 * time.Second,
+		})
+
+		// Add CLAIM event if unloaded at destination
+		if unloadLocation == destination {
+			currentTime = currentTime.Add(time.Duration(1+g.random.Intn(6)) * time.Hour)
 			events = append(events, HandlingEventData{
-				EventType:      handlingdomain.HandlingEventTypeUnload,
-				Location:       unloadLocation,
-				VoyageNumber:   selectedVoyage.GetVoyageNumber().String(),
+				EventType:      handlingdomain.HandlingEventTypeClaim,
+				Location:       destination,
+				VoyageNumber:   "",
 				CompletionTime: currentTime,
-				Delay:          1 * time.Second,
+				Delay:          1500 * time.Millisecond,
 			})
-
-			// Add CLAIM event if unloaded at destination
-			if unloadLocation == destination {
-				currentTime = currentTime.Add(time.Duration(1+g.random.Intn(6)) * time.Hour)
-				events = append(events, HandlingEventData{
-					EventType:      handlingdomain.HandlingEventTypeClaim,
-					Location:       destination,
-					VoyageNumber:   "",
-					CompletionTime: currentTime,
-					Delay:          1500 * time.Millisecond,
-				})
-			}
 		}
 	}
 